@@ -118,6 +118,13 @@ type DataPlaneMetricsExtensionStatus struct {
 	//
 	// +kube:validation:Optional
 	ControlPlaneRef *NamespacedRef `json:"controlPlaneRef,omitempty"`
+
+	// Conditions describe the current conditions of this DataPlaneMetricsExtension.
+	//
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=8
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // ServiceSelector holds the service selector specification.
@@ -137,3 +144,62 @@ type ServiceSelectorEntry struct {
 	// +kubebuilder:validation:Required
 	Name string `json:"name"`
 }
+
+// The following are DataPlaneMetricsExtension specific types for
+// https://kubernetes.io/docs/reference/generated/kubernetes-api/v1.30/#condition-v1-meta fields
+
+// DataPlaneMetricsExtensionConditionType is the type for Conditions in a DataPlaneMetricsExtension's
+// Status.Conditions array.
+type DataPlaneMetricsExtensionConditionType string
+
+// DataPlaneMetricsExtensionConditionReason is a reason for the DataPlaneMetricsExtension condition's last transition.
+type DataPlaneMetricsExtensionConditionReason string
+
+const (
+	// This condition indicates whether the DataPlaneMetricsExtension has been
+	// accepted as an extension of a ControlPlane and its metrics configuration
+	// has been programmed for the selected Services.
+	//
+	// Possible reasons for this condition to be "True" are:
+	//
+	// * "Accepted"
+	//
+	// Possible reasons for this condition to be "False" are:
+	//
+	// * "NotReferenced"
+	// * "RefNotPermitted"
+	// * "MultipleControlPlanes"
+	//
+	DataPlaneMetricsExtensionConditionTypeAccepted DataPlaneMetricsExtensionConditionType = "Accepted"
+
+	// DataPlaneMetricsExtensionReasonAccepted indicates that the extension is referenced
+	// by exactly one ControlPlane and that the Prometheus plugin has been configured
+	// for the selected Services.
+	DataPlaneMetricsExtensionReasonAccepted DataPlaneMetricsExtensionConditionReason = "Accepted"
+
+	// DataPlaneMetricsExtensionReasonNotReferenced is used with the "Accepted" condition type
+	// when no ControlPlane references the extension in its spec.extensions.
+	DataPlaneMetricsExtensionReasonNotReferenced DataPlaneMetricsExtensionConditionReason = "NotReferenced"
+
+	// DataPlaneMetricsExtensionReasonRefNotPermitted is used with the "Accepted" condition type
+	// when the extension is referenced by a ControlPlane from a different namespace.
+	DataPlaneMetricsExtensionReasonRefNotPermitted DataPlaneMetricsExtensionConditionReason = "RefNotPermitted"
+
+	// DataPlaneMetricsExtensionReasonMultipleControlPlanes is used with the "Accepted" condition type
+	// when the extension is referenced by more than one ControlPlane.
+	DataPlaneMetricsExtensionReasonMultipleControlPlanes DataPlaneMetricsExtensionConditionReason = "MultipleControlPlanes"
+)
+
+// -----------------------------------------------------------------------------
+// DataPlaneMetricsExtension - ConditionsAware Implementation
+// -----------------------------------------------------------------------------
+
+// GetConditions returns the status conditions.
+func (e *DataPlaneMetricsExtension) GetConditions() []metav1.Condition {
+	return e.Status.Conditions
+}
+
+// SetConditions sets the status conditions.
+func (e *DataPlaneMetricsExtension) SetConditions(conditions []metav1.Condition) {
+	e.Status.Conditions = conditions
+}
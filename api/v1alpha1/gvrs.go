@@ -10,3 +10,21 @@ func AIGatewayGVR() schema.GroupVersionResource {
 		Resource: "aigateways",
 	}
 }
+
+// KongPluginInstallationGVR returns current package KongPluginInstallation GVR.
+func KongPluginInstallationGVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    SchemeGroupVersion.Group,
+		Version:  SchemeGroupVersion.Version,
+		Resource: "kongplugininstallations",
+	}
+}
+
+// DataPlaneMetricsExtensionGVR returns current package DataPlaneMetricsExtension GVR.
+func DataPlaneMetricsExtensionGVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    SchemeGroupVersion.Group,
+		Version:  SchemeGroupVersion.Version,
+		Resource: "dataplanemetricsextensions",
+	}
+}
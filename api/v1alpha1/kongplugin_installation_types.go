@@ -31,7 +31,7 @@ func init() {
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:shortName=kpi,categories=kong;all
 //+kubebuilder:subresource:status
-//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Accepted",type=string,JSONPath=`.status.conditions[?(@.type=="Accepted")].status`
 
 // KongPluginInstallation allows using a custom Kong Plugin distributed as a container image available in a registry.
 // Such a plugin can be associated with GatewayConfiguration or DataPlane to be available for particular Kong Gateway
@@ -64,6 +64,8 @@ type KongPluginInstallationSpec struct {
 	// ImagePullSecretRef is a reference to a Kubernetes Secret containing credentials necessary to pull the OCI image
 	// in Image. It must follow the format in https://kubernetes.io/docs/tasks/configure-pod-container/pull-image-private-registry.
 	// It is optional. If the image is public, omit this field.
+	// A Secret in a different namespace than the KongPluginInstallation's one can be referenced
+	// only when a ReferenceGrant in the Secret's namespace allows it.
 	//
 	//+optional
 	ImagePullSecretRef *corev1.SecretReference `json:"imagePullSecretRef,omitempty"`
@@ -77,6 +79,12 @@ type KongPluginInstallationStatus struct {
 	//+listMapKey=type
 	//+kubebuilder:validation:MaxItems=8
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// UnderlyingConfigMapName is the name of the ConfigMap that contains the plugin's content.
+	// It is set when the plugin is successfully fetched and unpacked.
+	//
+	//+optional
+	UnderlyingConfigMapName string `json:"underlyingConfigMapName,omitempty"`
 }
 
 // The following are KongPluginInstallation specific types for
@@ -131,3 +139,17 @@ const (
 	// fetching and unpacking the image is in progress.
 	KongPluginInstallationReasonPending KongPluginInstallationConditionReason = "Pending"
 )
+
+// -----------------------------------------------------------------------------
+// KongPluginInstallation - ConditionsAware Implementation
+// -----------------------------------------------------------------------------
+
+// GetConditions returns the status conditions.
+func (c *KongPluginInstallation) GetConditions() []metav1.Condition {
+	return c.Status.Conditions
+}
+
+// SetConditions sets the status conditions.
+func (c *KongPluginInstallation) SetConditions(conditions []metav1.Condition) {
+	c.Status.Conditions = conditions
+}
@@ -99,6 +99,13 @@ type ControlPlaneOptions struct {
 	//
 	// +optional
 	Extensions []v1alpha1.ExtensionRef `json:"extensions,omitempty"`
+
+	// ClusterCertificateOptions configures how the client certificate which the ControlPlane uses to
+	// authenticate against the DataPlane's admin API is issued. When not set, the certificate is issued
+	// by the operator's cluster CA.
+	//
+	// +optional
+	ClusterCertificateOptions *ClusterCertificateOptions `json:"clusterCertificate,omitempty"`
 }
 
 // ControlPlaneDeploymentOptions is a shared type used on objects to indicate that their
@@ -109,8 +116,9 @@ type ControlPlaneOptions struct {
 type ControlPlaneDeploymentOptions struct {
 	// Replicas describes the number of desired pods.
 	// This is a pointer to distinguish between explicit zero and not specified.
-	// This only affects the DataPlane deployments for now, for more details on
-	// ControlPlane scaling please see https://github.com/Kong/gateway-operator/issues/736.
+	// When more than 1 replica is requested, the ControlPlane's pods use leader
+	// election: only the leader pushes configuration to the DataPlane while the
+	// others stand by to take over.
 	//
 	// +optional
 	// +kubebuilder:default=1
@@ -132,8 +140,89 @@ type ControlPlaneStatus struct {
 	// +kubebuilder:validation:MaxItems=8
 	// +kubebuilder:default={{type: "Scheduled", status: "Unknown", reason:"NotReconciled", message:"Waiting for controller", lastTransitionTime: "1970-01-01T00:00:00Z"}}
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ReadyReplicas is the number of ready ControlPlane pods, including both
+	// the leader and the standby pods.
+	//
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// StandbyReplicas is the number of ready ControlPlane pods which are not
+	// the leader and are waiting to take over the leader election Lease.
+	//
+	// +optional
+	StandbyReplicas int32 `json:"standbyReplicas,omitempty"`
+
+	// Leader is the name of the ControlPlane pod currently holding the leader
+	// election Lease.
+	//
+	// +optional
+	Leader string `json:"leader,omitempty"`
+
+	// Replicas is the number of desired ControlPlane pods.
+	//
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Version is the version of the ControlPlane's controller, parsed from the
+	// image of its Deployment.
+	//
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// DataPlane describes the DataPlane which the ControlPlane configures.
+	//
+	// +optional
+	DataPlane *ControlPlaneDataPlaneStatus `json:"dataPlane,omitempty"`
+
+	// ConfigurationSync describes the last configuration push to the DataPlane
+	// as reported by the metrics of the ControlPlane's leader pod.
+	//
+	// +optional
+	ConfigurationSync *ControlPlaneConfigurationSyncStatus `json:"configurationSync,omitempty"`
+}
+
+// ControlPlaneDataPlaneStatus describes the DataPlane which a ControlPlane configures.
+type ControlPlaneDataPlaneStatus struct {
+	// Name is the name of the DataPlane.
+	Name string `json:"name"`
+
+	// AdminEndpoints are the addresses of the DataPlane's ready Admin API
+	// endpoints to which the ControlPlane pushes configuration.
+	//
+	// +optional
+	AdminEndpoints []string `json:"adminEndpoints,omitempty"`
 }
 
+// ControlPlaneConfigurationSyncStatus describes the last configuration push
+// from a ControlPlane to its DataPlane.
+type ControlPlaneConfigurationSyncStatus struct {
+	// Result is the result of the last configuration push.
+	//
+	// +kubebuilder:validation:Enum=Succeeded;Failed
+	Result ControlPlaneConfigurationSyncResult `json:"result"`
+
+	// LastSuccessfulPushTime is the time of the last successful configuration
+	// push as reported by the ingress controller.
+	//
+	// +optional
+	LastSuccessfulPushTime *metav1.Time `json:"lastSuccessfulPushTime,omitempty"`
+}
+
+// ControlPlaneConfigurationSyncResult is the result of a ControlPlane's
+// configuration push.
+type ControlPlaneConfigurationSyncResult string
+
+const (
+	// ControlPlaneConfigurationSyncSucceeded indicates that the configuration
+	// was successfully pushed to the DataPlane.
+	ControlPlaneConfigurationSyncSucceeded ControlPlaneConfigurationSyncResult = "Succeeded"
+
+	// ControlPlaneConfigurationSyncFailed indicates that the DataPlane rejected
+	// the configuration or couldn't be reached.
+	ControlPlaneConfigurationSyncFailed ControlPlaneConfigurationSyncResult = "Failed"
+)
+
 // GetConditions returns the ControlPlane Status Conditions
 func (c *ControlPlane) GetConditions() []metav1.Condition {
 	return c.Status.Conditions
@@ -68,6 +68,76 @@ type DataPlaneOptions struct {
 
 	// +optional
 	Resources DataPlaneResources `json:"resources"`
+
+	// PluginsToInstall is a list of KongPluginInstallation resources that
+	// will be installed and available in the DataPlane. The namespace of each
+	// KongPluginInstallation defaults to the namespace of the DataPlane and
+	// must be the same as the namespace of the DataPlane.
+	//
+	// +optional
+	PluginsToInstall []NamespacedName `json:"pluginsToInstall,omitempty"`
+
+	// Konnect configures the DataPlane to run in hybrid mode, connected to
+	// a Konnect control plane. The operator generates the DataPlane's client
+	// certificate, registers it with the control plane and configures the
+	// DataPlane with the control plane's cluster endpoints. The certificate is
+	// deregistered when the DataPlane is deleted. The registration result is
+	// reported in the KonnectCertificateRegistered condition.
+	// Requires the Konnect controllers to be enabled, the DataPlane is rejected
+	// otherwise.
+	//
+	// +optional
+	Konnect *DataPlaneKonnectOptions `json:"konnect,omitempty"`
+
+	// Database configures the DataPlane to use a database instead of running
+	// in DB-less mode. The operator runs the database migrations using Jobs
+	// before the DataPlane's pods are rolled out with a new image and finishes
+	// them once all the DataPlane's pods run it.
+	//
+	// +optional
+	Database *DataPlaneDatabaseOptions `json:"database,omitempty"`
+}
+
+// DataPlaneDatabaseOptions defines the database used by the DataPlane.
+type DataPlaneDatabaseOptions struct {
+	// Postgres configures the DataPlane to use a PostgreSQL database.
+	//
+	// +optional
+	Postgres *DataPlanePostgresOptions `json:"postgres,omitempty"`
+}
+
+// DataPlanePostgresOptions defines the PostgreSQL database used by the DataPlane.
+type DataPlanePostgresOptions struct {
+	// SecretName is the name of the Secret, in the DataPlane's namespace,
+	// holding the connection details of the PostgreSQL database. The Secret
+	// has to contain the "host", "user" and "password" keys and can contain
+	// the optional "port", "database" and "ssl" keys.
+	//
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
+}
+
+// DataPlaneKonnectOptions defines the Konnect control plane the DataPlane
+// connects to.
+type DataPlaneKonnectOptions struct {
+	// ControlPlaneID is the ID of the Konnect control plane the DataPlane
+	// connects to.
+	//
+	// +kubebuilder:validation:MinLength=1
+	ControlPlaneID string `json:"controlPlaneID"`
+
+	// APIAuthConfigurationRef is the reference to the KonnectAPIAuthConfiguration,
+	// in the DataPlane's namespace, used to register the DataPlane's certificate
+	// with the control plane.
+	APIAuthConfigurationRef KonnectAPIAuthConfigurationRef `json:"authRef"`
+}
+
+// KonnectAPIAuthConfigurationRef is a reference to a KonnectAPIAuthConfiguration.
+type KonnectAPIAuthConfigurationRef struct {
+	// Name is the name of the KonnectAPIAuthConfiguration resource.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
 }
 
 // DataPlaneResources defines the resources that will be created and managed
@@ -155,6 +225,12 @@ type DataPlaneNetworkOptions struct {
 	//
 	// +optional
 	KonnectCertificateOptions *KonnectCertificateOptions `json:"konnectCertificate,omitempty"`
+
+	// ClusterCertificateOptions configures how the certificate securing the DataPlane's admin API is issued.
+	// When not set, the certificate is issued by the operator's cluster CA.
+	//
+	// +optional
+	ClusterCertificateOptions *ClusterCertificateOptions `json:"clusterCertificate,omitempty"`
 }
 
 // DataPlaneServices contains Services related DataPlane configuration, shared with the GatewayConfiguration.
@@ -168,14 +244,89 @@ type DataPlaneServices struct {
 	//
 	// +optional
 	Ingress *DataPlaneServiceOptions `json:"ingress,omitempty"`
+
+	// PrivateIngress is an additional Kubernetes Service that will be used to
+	// expose ingress traffic for the DataPlane on a private network, e.g. using
+	// an internal cloud provider LoadBalancer configured through its annotations,
+	// next to the Service configured with Ingress.
+	// Load balancer addresses of this Service are reported with the
+	// PrivateLoadBalancer source type in the DataPlane's status.
+	//
+	// +optional
+	PrivateIngress *DataPlaneServiceOptions `json:"privateIngress,omitempty"`
+
+	// AdminAPI is the Kubernetes Service that exposes the DataPlane's Admin API
+	// to the ControlPlane. Here you can inject additional annotations and labels
+	// on the Service, change its type or expose additional ports (for instance,
+	// the status port for external health checkers).
+	//
+	// +optional
+	AdminAPI *DataPlaneAdminAPIServiceOptions `json:"adminAPI,omitempty"`
+}
+
+// DataPlaneAdminAPIServiceOptions contains the DataPlane's Admin API Service
+// related configuration.
+type DataPlaneAdminAPIServiceOptions struct {
+	// Ports defines the list of ports that are exposed by the service next to
+	// the Admin API port. The ports field allows defining the name, port,
+	// targetPort and protocol of the underlying service ports. The targetPort
+	// defaults to the port and the protocol defaults to TCP.
+	//
+	// +optional
+	Ports []DataPlaneServicePort `json:"ports,omitempty"`
+
+	// Type determines how the Service is exposed.
+	// Defaults to `ClusterIP`.
+	//
+	// Valid options are `ClusterIP`, `NodePort` and `LoadBalancer`.
+	//
+	// `ClusterIP` Services are headless, i.e. no cluster-internal IP address
+	// is allocated for them and their DNS name resolves to the DataPlane pods.
+	//
+	// `NodePort` and `LoadBalancer` Services get a cluster-internal IP address
+	// allocated. Changing the type from or to `ClusterIP` recreates the Service.
+	//
+	// More info: https://kubernetes.io/docs/concepts/services-networking/service/#publishing-services-service-types
+	//
+	// +optional
+	// +kubebuilder:default=ClusterIP
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	Type corev1.ServiceType `json:"type,omitempty"`
+
+	// Annotations is an unstructured key value map stored with a resource that may be
+	// set by external tools to store and retrieve arbitrary metadata. They are not
+	// queryable and should be preserved when modifying objects.
+	//
+	// More info: http://kubernetes.io/docs/user-guide/annotations
+	//
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Labels are additional labels set on the Service. Labels used by the
+	// operator to manage the Service take precedence over the ones set here.
+	//
+	// More info: http://kubernetes.io/docs/user-guide/labels
+	//
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// ExternalTrafficPolicy describes how nodes distribute service traffic they
+	// receive on one of the Service's "externally-facing" addresses (NodePorts,
+	// ExternalIPs, and LoadBalancer IPs). It is only applied to `NodePort` and
+	// `LoadBalancer` Services.
+	//
+	// More info: https://kubernetes.io/docs/tasks/access-application-cluster/create-external-load-balancer/#preserving-the-client-source-ip
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=Cluster;Local
+	ExternalTrafficPolicy corev1.ServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
 }
 
 // DataPlaneServiceOptions contains Services related DataPlane configuration.
 type DataPlaneServiceOptions struct {
 	// Ports defines the list of ports that are exposed by the service.
-	// The ports field allows defining the name, port and targetPort of
-	// the underlying service ports, while the protocol is defaulted to TCP,
-	// as it is the only protocol currently supported.
+	// The ports field allows defining the name, port, targetPort and protocol
+	// of the underlying service ports. The protocol defaults to TCP.
 	Ports []DataPlaneServicePort `json:"ports,omitempty"`
 
 	// ServiceOptions is the struct containing service options shared with
@@ -206,6 +357,13 @@ type DataPlaneServicePort struct {
 	// More info: https://kubernetes.io/docs/concepts/services-networking/service/#defining-a-service
 	// +optional
 	TargetPort intstr.IntOrString `json:"targetPort,omitempty"`
+
+	// Protocol is the IP protocol of this port. Supports "TCP" and "UDP".
+	// Defaults to TCP.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=TCP;UDP
+	Protocol corev1.Protocol `json:"protocol,omitempty"`
 }
 
 // ServiceOptions is used to includes options to customize the ingress service,
@@ -301,6 +459,28 @@ type DataPlaneStatus struct {
 	//
 	// +optional
 	RolloutStatus *DataPlaneRolloutStatus `json:"rollout,omitempty"`
+
+	// DatabaseStatus contains information about the migrations of the
+	// DataPlane's database. It is set only if a database was configured
+	// in the spec.
+	//
+	// +optional
+	DatabaseStatus *DataPlaneDatabaseStatus `json:"database,omitempty"`
+}
+
+// DataPlaneDatabaseStatus describes the state of the DataPlane's database migrations.
+type DataPlaneDatabaseStatus struct {
+	// MigratedImage is the DataPlane image whose migrations have been run
+	// on the database with kong migrations bootstrap or kong migrations up.
+	//
+	// +optional
+	MigratedImage string `json:"migratedImage,omitempty"`
+
+	// FinishedImage is the DataPlane image whose migrations have been finished
+	// with kong migrations finish, once all the DataPlane's pods ran it.
+	//
+	// +optional
+	FinishedImage string `json:"finishedImage,omitempty"`
 }
 
 // DataPlaneRolloutStatus describes the DataPlane rollout status.
@@ -312,6 +492,36 @@ type DataPlaneRolloutStatus struct {
 	// Deployment contains the information about the preview deployment.
 	Deployment *DataPlaneRolloutStatusDeployment `json:"deployment,omitempty"`
 
+	// Canary contains the information about the progress of a Canary rollout.
+	//
+	// +optional
+	Canary *DataPlaneRolloutStatusCanary `json:"canary,omitempty"`
+
+	// Analysis contains the information about the progress of the promotion
+	// analysis of the preview pods.
+	//
+	// +optional
+	Analysis *DataPlaneRolloutStatusAnalysis `json:"analysis,omitempty"`
+
+	// StartedAt is the time at which the rollout of the generation from
+	// ObservedGeneration was initiated.
+	//
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// ObservedGeneration is the DataPlane generation for which the rollout
+	// was initiated.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// History contains the revisions which were promoted, ordered from
+	// the oldest to the newest one.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=32
+	History []DataPlaneRolloutRevision `json:"history,omitempty"`
+
 	// Conditions contains the status conditions about the rollout.
 	//
 	// +listType=map
@@ -361,6 +571,66 @@ type DataPlaneRolloutStatusDeployment struct {
 	Selector string `json:"selector,omitempty"`
 }
 
+// DataPlaneRolloutStatusCanary is a rollout status field which contains
+// the progress of a Canary rollout.
+type DataPlaneRolloutStatusCanary struct {
+	// Step is the index of the current step of the Canary rollout.
+	// It's equal to the number of steps once all of them are completed.
+	//
+	// +kubebuilder:validation:Minimum=0
+	Step int32 `json:"step"`
+
+	// Weight is the weight of the current step of the Canary rollout.
+	Weight int32 `json:"weight"`
+
+	// StepReachedAt is the time at which the preview and live Deployments
+	// reached the replicas required by the current step.
+	// It's not set while they are being scaled.
+	//
+	// +optional
+	StepReachedAt *metav1.Time `json:"stepReachedAt,omitempty"`
+
+	// ObservedGeneration is the DataPlane generation being rolled out.
+	ObservedGeneration int64 `json:"observedGeneration"`
+}
+
+// DataPlaneRolloutStatusAnalysis is a rollout status field which contains
+// the progress of the promotion analysis.
+type DataPlaneRolloutStatusAnalysis struct {
+	// StartedAt is the time at which the analysis started.
+	StartedAt metav1.Time `json:"startedAt"`
+
+	// LastMeasuredAt is the time of the last measurement.
+	//
+	// +optional
+	LastMeasuredAt *metav1.Time `json:"lastMeasuredAt,omitempty"`
+
+	// Measurements is the number of measurements which passed.
+	//
+	// +kubebuilder:validation:Minimum=0
+	Measurements int32 `json:"measurements"`
+
+	// ObservedGeneration is the DataPlane generation being analyzed.
+	ObservedGeneration int64 `json:"observedGeneration"`
+}
+
+// DataPlaneRolloutRevision is an entry of the DataPlane's rollout history.
+// Only the proxy image is recorded as rolling back restores only the image.
+type DataPlaneRolloutRevision struct {
+	// Revision is the number of the revision.
+	//
+	// +kubebuilder:validation:Minimum=1
+	Revision int64 `json:"revision"`
+
+	// Image is the image of the revision's proxy container.
+	//
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// PromotedAt is the time at which the revision was promoted.
+	PromotedAt metav1.Time `json:"promotedAt"`
+}
+
 // RolloutStatusService is a struct which contains status information about
 // services that are exposed as part of the rollout.
 type RolloutStatusService struct {
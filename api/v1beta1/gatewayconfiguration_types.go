@@ -69,6 +69,13 @@ type GatewayConfigDataPlaneNetworkOptions struct {
 	// the topology of various forms of traffic (including ingress, etc.) to
 	// and from the DataPlane.
 	Services *GatewayConfigDataPlaneServices `json:"services,omitempty"`
+
+	// ClusterCertificateOptions configures how the certificate securing the DataPlane's admin API is issued.
+	// When not set, the certificate is issued by the operator's cluster CA. It has to reference the same
+	// issuer as controlPlaneOptions.clusterCertificate.
+	//
+	// +optional
+	ClusterCertificateOptions *ClusterCertificateOptions `json:"clusterCertificate,omitempty"`
 }
 
 // GatewayConfigDataPlaneServices contains Services related DataPlane configuration.
@@ -82,6 +89,15 @@ type GatewayConfigDataPlaneServices struct {
 	//
 	// +optional
 	Ingress *GatewayConfigServiceOptions `json:"ingress,omitempty"`
+
+	// PrivateIngress is an additional Kubernetes Service that will be used to
+	// expose ingress traffic for the DataPlane on a private network, e.g. using
+	// an internal cloud provider LoadBalancer configured through its annotations,
+	// next to the Service configured with Ingress. It exposes the same ports,
+	// based on the Gateway's listeners.
+	//
+	// +optional
+	PrivateIngress *GatewayConfigServiceOptions `json:"privateIngress,omitempty"`
 }
 
 // GatewayConfigServiceOptions is used to includes options to customize the ingress service,
@@ -99,6 +115,36 @@ type GatewayConfigurationStatus struct {
 	// +listMapKey=type
 	// +kubebuilder:validation:MaxItems=8
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// GatewayClasses are the names of the GatewayClasses which reference
+	// the GatewayConfiguration through their parametersRef.
+	//
+	// +optional
+	// +listType=set
+	GatewayClasses []string `json:"gatewayClasses,omitempty"`
+
+	// Gateways are the Gateways which use the GatewayConfiguration, either
+	// through their GatewayClass or through their spec.infrastructure.parametersRef.
+	//
+	// +optional
+	Gateways []GatewayConfigurationGatewayReference `json:"gateways,omitempty"`
+}
+
+// GatewayConfigurationGatewayReference identifies a Gateway which uses
+// a GatewayConfiguration.
+type GatewayConfigurationGatewayReference struct {
+	// Namespace is the namespace of the Gateway.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the Gateway.
+	Name string `json:"name"`
+
+	// TargetKind is the kind of the resource through which the Gateway
+	// references the GatewayConfiguration. When both the Gateway and its
+	// GatewayClass reference the GatewayConfiguration, Gateway is reported.
+	//
+	// +kubebuilder:validation:Enum=Gateway;GatewayClass
+	TargetKind GatewayConfigurationTargetKind `json:"targetKind"`
 }
 
 //+kubebuilder:object:root=true
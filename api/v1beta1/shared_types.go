@@ -3,6 +3,7 @@ package v1beta1
 import (
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // DeploymentOptions is a shared type used on objects to indicate that their
@@ -81,14 +82,38 @@ type HorizontalScaling struct {
 type Rollout struct {
 	// Strategy contains the deployment strategy for rollout.
 	Strategy RolloutStrategy `json:"strategy"`
+
+	// Timeout is the maximum duration the preview Deployment has to become
+	// ready in after the rollout is initiated. When it's exceeded, the rollout
+	// is marked as failed and the preview resources are removed. The rollout
+	// is not retried until the spec changes.
+	// When it's not set, the rollout waits for the preview Deployment indefinitely.
+	//
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// HistoryLimit is the number of revisions kept in the rollout history.
+	//
+	// +optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=32
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
 }
 
 // RolloutStrategy holds the rollout strategy options.
+//
+// +kubebuilder:validation:XValidation:message="Only one of blueGreen and canary can be set.",rule="!(has(self.blueGreen) && has(self.canary))"
 type RolloutStrategy struct {
 	// BlueGreen holds the options specific for Blue Green Deployments.
 	//
 	// +optional
 	BlueGreen *BlueGreenStrategy `json:"blueGreen,omitempty"`
+
+	// Canary holds the options specific for Canary Deployments.
+	//
+	// +optional
+	Canary *CanaryStrategy `json:"canary,omitempty"`
 }
 
 // BlueGreenStrategy defines the Blue Green deployment strategy.
@@ -102,10 +127,96 @@ type BlueGreenStrategy struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default={"plan":{"deployment":"ScaleDownOnPromotionScaleUpOnRollout"}}
 	Resources RolloutResources `json:"resources,omitempty"`
+
+	// PreviewRouting configures the live DataPlane to forward requests matching
+	// the configured header or cookie to the preview pods. It allows testing the
+	// preview version on the production hostname before it gets promoted,
+	// without exposing the preview ingress Service.
+	//
+	// The live pods forward the requests once they were rolled out with this
+	// option set, i.e. it takes effect for the rollouts following the one that
+	// enabled it. When no rollout is in progress, matching requests are handled
+	// by the live pods. Requests are forwarded over TLS when they were received
+	// over TLS. It cannot be used with KONG_NGINX_HTTP_INCLUDE or
+	// KONG_NGINX_PROXY_INCLUDE set on the proxy container.
+	//
+	// +optional
+	PreviewRouting *PreviewRouting `json:"previewRouting,omitempty"`
+}
+
+// PreviewRouting defines which requests received by the live DataPlane are
+// forwarded to the preview pods.
+//
+// +kubebuilder:validation:XValidation:message="Exactly one of header or cookie has to be set",rule="has(self.header) != has(self.cookie)"
+type PreviewRouting struct {
+	// Header matches requests carrying the header with the given name and value.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:message="Header name can only contain alphanumeric characters and dashes",rule="self.name.matches('^[A-Za-z0-9-]+$')"
+	Header *PreviewRoutingMatch `json:"header,omitempty"`
+
+	// Cookie matches requests carrying the cookie with the given name and value.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:message="Cookie name can only contain alphanumeric characters and underscores",rule="self.name.matches('^[A-Za-z0-9_]+$')"
+	Cookie *PreviewRoutingMatch `json:"cookie,omitempty"`
+}
+
+// PreviewRoutingMatch defines the name and the exact value of a header or
+// a cookie.
+type PreviewRoutingMatch struct {
+	// Name is the name of the header or cookie.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=64
+	Name string `json:"name"`
+
+	// Value is the value the header or cookie has to be equal to.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9._~-]+$`
+	Value string `json:"value"`
+}
+
+// CanaryStrategy defines the Canary deployment strategy.
+// The traffic is shifted gradually from the live to the preview (canary) Deployment
+// by scaling the replicas of both Deployments which run behind the shared ingress
+// Service according to the weight of the current step.
+// After the last step the preview resources are promoted.
+type CanaryStrategy struct {
+	// Steps defines the steps of the rollout, with weights in increasing order.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Steps []CanaryStep `json:"steps"`
+}
+
+// CanaryStep is a single step of a Canary rollout.
+type CanaryStep struct {
+	// Weight is the percentage of the replicas which run the preview version
+	// during this step. The number of preview replicas is rounded to the nearest
+	// integer, keeping at least one preview and one live replica.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+
+	// Pause defines how long the rollout stays on this step once its replicas
+	// are ready before it moves to the next step.
+	// When it's not set, the rollout waits for a manual approval which can be given
+	// by annotating the DataPlane object with
+	// `"gateway-operator.konghq.com/promote-when-ready": "true"`.
+	//
+	// +optional
+	Pause *metav1.Duration `json:"pause,omitempty"`
 }
 
 // Promotion is a type that contains fields that define how the operator handles
 // promotion of resources during a blue/green rollout.
+//
+// +kubebuilder:validation:XValidation:message="Analysis can only be set when using AutomaticPromotion strategy.",rule="!has(self.analysis) || self.strategy == 'AutomaticPromotion'"
+// +kubebuilder:validation:XValidation:message="Analysis has to be set when using AutomaticPromotion strategy.",rule="self.strategy != 'AutomaticPromotion' || has(self.analysis)"
 type Promotion struct {
 	// Strategy indicates how you want the operator to handle the promotion of
 	// the preview (green) resources (Deployments and Services) after all workflows
@@ -115,6 +226,55 @@ type Promotion struct {
 	// +kubebuilder:validation:Enum=AutomaticPromotion;BreakBeforePromotion
 	// +kubebuilder:default=BreakBeforePromotion
 	Strategy PromotionStrategy `json:"strategy"`
+
+	// Analysis defines how the preview resources are analyzed before they are
+	// automatically promoted. It is required when using the AutomaticPromotion
+	// strategy.
+	//
+	// +optional
+	Analysis *PromotionAnalysis `json:"analysis,omitempty"`
+}
+
+// PromotionAnalysis defines the analysis of the preview pods which has to pass
+// before the preview resources are automatically promoted.
+// During the analysis the operator periodically probes the readiness status
+// endpoint of every preview pod and scrapes its Prometheus metrics.
+// The analysis fails as soon as a pod is not ready or any of the configured
+// thresholds is exceeded.
+//
+// The error rate and latency thresholds require the Prometheus plugin to be
+// enabled with status code and latency metrics in the DataPlane's configuration.
+type PromotionAnalysis struct {
+	// Window is the duration of the analysis. The preview resources are
+	// promoted when all the measurements within the window pass.
+	//
+	// +optional
+	// +kubebuilder:default="5m"
+	Window metav1.Duration `json:"window,omitempty"`
+
+	// Interval is the interval between the measurements.
+	//
+	// +optional
+	// +kubebuilder:default="30s"
+	Interval metav1.Duration `json:"interval,omitempty"`
+
+	// MaxErrorRate is the maximum percentage of requests which are allowed
+	// to be answered with a 5xx status code by the preview pods during the analysis.
+	// It requires status_code_metrics to be enabled in Kong's prometheus plugin.
+	// Measurements are inconclusive until the preview pods proxy requests.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MaxErrorRate *int32 `json:"maxErrorRate,omitempty"`
+
+	// MaxAverageLatency is the maximum average latency of requests proxied
+	// by the preview pods during the analysis.
+	// It requires latency_metrics to be enabled in Kong's prometheus plugin.
+	// Measurements are inconclusive until the preview pods proxy requests.
+	//
+	// +optional
+	MaxAverageLatency *metav1.Duration `json:"maxAverageLatency,omitempty"`
 }
 
 // PromotionStrategy is the type of promotion strategy consts.
@@ -126,11 +286,15 @@ type Promotion struct {
 //     The user must indicate manually when they want the promotion to continue.
 //     That can be done by annotating the `DataPlane` object with
 //     `"gateway-operator.konghq.com/promote-when-ready": "true"`.
+//   - `AutomaticPromotion` is a promotion strategy which will ensure all new
+//     resources are ready and promote them once the preview pods pass
+//     the configured analysis.
 type PromotionStrategy string
 
 const (
 	// AutomaticPromotion indicates that once all workflows and tests have completed successfully,
 	// the new resources should be promoted and replace the previous resources.
+	// The promotion only happens after the preview pods pass the configured analysis.
 	AutomaticPromotion PromotionStrategy = "AutomaticPromotion"
 
 	// BreakBeforePromotion is the same as AutomaticPromotion but with an added breakpoint
@@ -171,6 +335,10 @@ type RolloutResourcePlan struct {
 //     the Deployment to 0 when the rollout is not initiated by a spec change
 //     and then to scale it up when the rollout is initiated (the owner resource
 //     like a DataPlane is patched or updated).
+//   - `DeleteOnPromotionRecreateOnRollout` is a rollout resource plan for
+//     Deployment which makes the operator delete the preview Deployment and
+//     the preview Services when the rollout is not initiated by a spec change
+//     and then to re-create them when the rollout is initiated.
 type RolloutResourcePlanDeployment string
 
 const (
@@ -181,9 +349,9 @@ const (
 	// like a DataPlane is patched or updated).
 	RolloutResourcePlanDeploymentScaleDownOnPromotionScaleUpOnRollout RolloutResourcePlanDeployment = "ScaleDownOnPromotionScaleUpOnRollout"
 	// RolloutResourcePlanDeploymentDeleteOnPromotionRecreateOnRollout which makes the operator delete the
-	// Deployment the rollout is not initiated by a spec change and then to
-	// re-create it when the rollout is initiated (the owner resource like
-	// a DataPlane is patched or updated)
+	// preview Deployment and the preview Services when the rollout is not initiated
+	// by a spec change and then to re-create them when the rollout is initiated
+	// (the owner resource like a DataPlane is patched or updated).
 	RolloutResourcePlanDeploymentDeleteOnPromotionRecreateOnRollout RolloutResourcePlanDeployment = "DeleteOnPromotionRecreateOnRollout"
 )
 
@@ -213,6 +381,15 @@ const (
 	// DataPlanePromoteWhenReadyAnnotationTrue is the annotation value that needs to be set to the DataPlane's
 	// DataPlanePromoteWhenReadyAnnotationKey annotation to signal that the new resources should be promoted.
 	DataPlanePromoteWhenReadyAnnotationTrue = "true"
+
+	// DataPlaneRollbackToRevisionAnnotationKey is the annotation key which can be used
+	// to annotate a DataPlane object to roll it back to a revision from its rollout
+	// history. Its value has to be the number of the revision.
+	// Once the operator detects the annotation, it sets the image of the DataPlane's
+	// proxy container to the revision's image, which initiates a new rollout,
+	// and removes the annotation. Other changes made to the pod template since
+	// the revision was promoted are kept.
+	DataPlaneRollbackToRevisionAnnotationKey = "gateway-operator.konghq.com/rollback-to-revision"
 )
 
 // KonnectCertificateOptions indicates how the operator should manage the certificates that managed entities will use
@@ -224,6 +401,40 @@ type KonnectCertificateOptions struct {
 	Issuer NamespacedName `json:"issuer"`
 }
 
+// ClusterCertificateOptions indicates how the operator should manage the certificates that the ControlPlane and the
+// DataPlane use to secure their communication (the DataPlane's admin API mTLS).
+type ClusterCertificateOptions struct {
+	// Issuer is the cert-manager Issuer or ClusterIssuer the operator will use to request the certificate instead
+	// of signing it with the operator's cluster CA.
+	// The ControlPlane and the DataPlane it manages have to reference the same issuer, which has to populate
+	// the ca.crt key of the certificate Secret (e.g. a CA Issuer).
+	Issuer CertManagerIssuerReference `json:"issuer"`
+}
+
+// CertManagerIssuerReference references a cert-manager Issuer or ClusterIssuer.
+type CertManagerIssuerReference struct {
+	// Kind is the kind of the referenced issuer. An Issuer has to be in the same namespace as the owner
+	// of the certificate.
+	//
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	Kind CertManagerIssuerKind `json:"kind"`
+
+	// Name is the name of the referenced issuer.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// CertManagerIssuerKind is the kind of a cert-manager issuer.
+type CertManagerIssuerKind string
+
+const (
+	// CertManagerIssuerKindIssuer is the kind of a namespaced cert-manager Issuer.
+	CertManagerIssuerKindIssuer CertManagerIssuerKind = "Issuer"
+	// CertManagerIssuerKindClusterIssuer is the kind of a cluster scoped cert-manager ClusterIssuer.
+	CertManagerIssuerKindClusterIssuer CertManagerIssuerKind = "ClusterIssuer"
+)
+
 // NamespacedName is a resource identified by name and optional namespace.
 type NamespacedName struct {
 	// +optional
@@ -54,8 +54,13 @@ func (in *Address) DeepCopy() *Address {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BlueGreenStrategy) DeepCopyInto(out *BlueGreenStrategy) {
 	*out = *in
-	out.Promotion = in.Promotion
+	in.Promotion.DeepCopyInto(&out.Promotion)
 	out.Resources = in.Resources
+	if in.PreviewRouting != nil {
+		in, out := &in.PreviewRouting, &out.PreviewRouting
+		*out = new(PreviewRouting)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlueGreenStrategy.
@@ -68,6 +73,79 @@ func (in *BlueGreenStrategy) DeepCopy() *BlueGreenStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryStep) DeepCopyInto(out *CanaryStep) {
+	*out = *in
+	if in.Pause != nil {
+		in, out := &in.Pause, &out.Pause
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryStep.
+func (in *CanaryStep) DeepCopy() *CanaryStep {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryStrategy) DeepCopyInto(out *CanaryStrategy) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]CanaryStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryStrategy.
+func (in *CanaryStrategy) DeepCopy() *CanaryStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuerReference) DeepCopyInto(out *CertManagerIssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuerReference.
+func (in *CertManagerIssuerReference) DeepCopy() *CertManagerIssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCertificateOptions) DeepCopyInto(out *ClusterCertificateOptions) {
+	*out = *in
+	out.Issuer = in.Issuer
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCertificateOptions.
+func (in *ClusterCertificateOptions) DeepCopy() *ClusterCertificateOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCertificateOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControlPlane) DeepCopyInto(out *ControlPlane) {
 	*out = *in
@@ -95,6 +173,45 @@ func (in *ControlPlane) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneConfigurationSyncStatus) DeepCopyInto(out *ControlPlaneConfigurationSyncStatus) {
+	*out = *in
+	if in.LastSuccessfulPushTime != nil {
+		in, out := &in.LastSuccessfulPushTime, &out.LastSuccessfulPushTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneConfigurationSyncStatus.
+func (in *ControlPlaneConfigurationSyncStatus) DeepCopy() *ControlPlaneConfigurationSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneConfigurationSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneDataPlaneStatus) DeepCopyInto(out *ControlPlaneDataPlaneStatus) {
+	*out = *in
+	if in.AdminEndpoints != nil {
+		in, out := &in.AdminEndpoints, &out.AdminEndpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneDataPlaneStatus.
+func (in *ControlPlaneDataPlaneStatus) DeepCopy() *ControlPlaneDataPlaneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneDataPlaneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControlPlaneDeploymentOptions) DeepCopyInto(out *ControlPlaneDeploymentOptions) {
 	*out = *in
@@ -168,6 +285,11 @@ func (in *ControlPlaneOptions) DeepCopyInto(out *ControlPlaneOptions) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ClusterCertificateOptions != nil {
+		in, out := &in.ClusterCertificateOptions, &out.ClusterCertificateOptions
+		*out = new(ClusterCertificateOptions)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneOptions.
@@ -216,6 +338,16 @@ func (in *ControlPlaneStatus) DeepCopyInto(out *ControlPlaneStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DataPlane != nil {
+		in, out := &in.DataPlane, &out.DataPlane
+		*out = new(ControlPlaneDataPlaneStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigurationSync != nil {
+		in, out := &in.ConfigurationSync, &out.ConfigurationSync
+		*out = new(ControlPlaneConfigurationSyncStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneStatus.
@@ -255,6 +387,75 @@ func (in *DataPlane) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataPlaneAdminAPIServiceOptions) DeepCopyInto(out *DataPlaneAdminAPIServiceOptions) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]DataPlaneServicePort, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataPlaneAdminAPIServiceOptions.
+func (in *DataPlaneAdminAPIServiceOptions) DeepCopy() *DataPlaneAdminAPIServiceOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DataPlaneAdminAPIServiceOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataPlaneDatabaseOptions) DeepCopyInto(out *DataPlaneDatabaseOptions) {
+	*out = *in
+	if in.Postgres != nil {
+		in, out := &in.Postgres, &out.Postgres
+		*out = new(DataPlanePostgresOptions)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataPlaneDatabaseOptions.
+func (in *DataPlaneDatabaseOptions) DeepCopy() *DataPlaneDatabaseOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DataPlaneDatabaseOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataPlaneDatabaseStatus) DeepCopyInto(out *DataPlaneDatabaseStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataPlaneDatabaseStatus.
+func (in *DataPlaneDatabaseStatus) DeepCopy() *DataPlaneDatabaseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataPlaneDatabaseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataPlaneDeploymentOptions) DeepCopyInto(out *DataPlaneDeploymentOptions) {
 	*out = *in
@@ -276,6 +477,22 @@ func (in *DataPlaneDeploymentOptions) DeepCopy() *DataPlaneDeploymentOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataPlaneKonnectOptions) DeepCopyInto(out *DataPlaneKonnectOptions) {
+	*out = *in
+	out.APIAuthConfigurationRef = in.APIAuthConfigurationRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataPlaneKonnectOptions.
+func (in *DataPlaneKonnectOptions) DeepCopy() *DataPlaneKonnectOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DataPlaneKonnectOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataPlaneList) DeepCopyInto(out *DataPlaneList) {
 	*out = *in
@@ -321,6 +538,11 @@ func (in *DataPlaneNetworkOptions) DeepCopyInto(out *DataPlaneNetworkOptions) {
 		*out = new(KonnectCertificateOptions)
 		**out = **in
 	}
+	if in.ClusterCertificateOptions != nil {
+		in, out := &in.ClusterCertificateOptions, &out.ClusterCertificateOptions
+		*out = new(ClusterCertificateOptions)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataPlaneNetworkOptions.
@@ -339,6 +561,21 @@ func (in *DataPlaneOptions) DeepCopyInto(out *DataPlaneOptions) {
 	in.Deployment.DeepCopyInto(&out.Deployment)
 	in.Network.DeepCopyInto(&out.Network)
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.PluginsToInstall != nil {
+		in, out := &in.PluginsToInstall, &out.PluginsToInstall
+		*out = make([]NamespacedName, len(*in))
+		copy(*out, *in)
+	}
+	if in.Konnect != nil {
+		in, out := &in.Konnect, &out.Konnect
+		*out = new(DataPlaneKonnectOptions)
+		**out = **in
+	}
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(DataPlaneDatabaseOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataPlaneOptions.
@@ -351,6 +588,21 @@ func (in *DataPlaneOptions) DeepCopy() *DataPlaneOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataPlanePostgresOptions) DeepCopyInto(out *DataPlanePostgresOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataPlanePostgresOptions.
+func (in *DataPlanePostgresOptions) DeepCopy() *DataPlanePostgresOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DataPlanePostgresOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataPlaneResources) DeepCopyInto(out *DataPlaneResources) {
 	*out = *in
@@ -371,6 +623,22 @@ func (in *DataPlaneResources) DeepCopy() *DataPlaneResources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataPlaneRolloutRevision) DeepCopyInto(out *DataPlaneRolloutRevision) {
+	*out = *in
+	in.PromotedAt.DeepCopyInto(&out.PromotedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataPlaneRolloutRevision.
+func (in *DataPlaneRolloutRevision) DeepCopy() *DataPlaneRolloutRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(DataPlaneRolloutRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataPlaneRolloutStatus) DeepCopyInto(out *DataPlaneRolloutStatus) {
 	*out = *in
@@ -384,6 +652,27 @@ func (in *DataPlaneRolloutStatus) DeepCopyInto(out *DataPlaneRolloutStatus) {
 		*out = new(DataPlaneRolloutStatusDeployment)
 		**out = **in
 	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(DataPlaneRolloutStatusCanary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Analysis != nil {
+		in, out := &in.Analysis, &out.Analysis
+		*out = new(DataPlaneRolloutStatusAnalysis)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]DataPlaneRolloutRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -403,6 +692,45 @@ func (in *DataPlaneRolloutStatus) DeepCopy() *DataPlaneRolloutStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataPlaneRolloutStatusAnalysis) DeepCopyInto(out *DataPlaneRolloutStatusAnalysis) {
+	*out = *in
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+	if in.LastMeasuredAt != nil {
+		in, out := &in.LastMeasuredAt, &out.LastMeasuredAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataPlaneRolloutStatusAnalysis.
+func (in *DataPlaneRolloutStatusAnalysis) DeepCopy() *DataPlaneRolloutStatusAnalysis {
+	if in == nil {
+		return nil
+	}
+	out := new(DataPlaneRolloutStatusAnalysis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataPlaneRolloutStatusCanary) DeepCopyInto(out *DataPlaneRolloutStatusCanary) {
+	*out = *in
+	if in.StepReachedAt != nil {
+		in, out := &in.StepReachedAt, &out.StepReachedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataPlaneRolloutStatusCanary.
+func (in *DataPlaneRolloutStatusCanary) DeepCopy() *DataPlaneRolloutStatusCanary {
+	if in == nil {
+		return nil
+	}
+	out := new(DataPlaneRolloutStatusCanary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataPlaneRolloutStatusDeployment) DeepCopyInto(out *DataPlaneRolloutStatusDeployment) {
 	*out = *in
@@ -488,6 +816,16 @@ func (in *DataPlaneServices) DeepCopyInto(out *DataPlaneServices) {
 		*out = new(DataPlaneServiceOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PrivateIngress != nil {
+		in, out := &in.PrivateIngress, &out.PrivateIngress
+		*out = new(DataPlaneServiceOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdminAPI != nil {
+		in, out := &in.AdminAPI, &out.AdminAPI
+		*out = new(DataPlaneAdminAPIServiceOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataPlaneServices.
@@ -538,6 +876,11 @@ func (in *DataPlaneStatus) DeepCopyInto(out *DataPlaneStatus) {
 		*out = new(DataPlaneRolloutStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DatabaseStatus != nil {
+		in, out := &in.DatabaseStatus, &out.DatabaseStatus
+		*out = new(DataPlaneDatabaseStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataPlaneStatus.
@@ -588,6 +931,11 @@ func (in *GatewayConfigDataPlaneNetworkOptions) DeepCopyInto(out *GatewayConfigD
 		*out = new(GatewayConfigDataPlaneServices)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ClusterCertificateOptions != nil {
+		in, out := &in.ClusterCertificateOptions, &out.ClusterCertificateOptions
+		*out = new(ClusterCertificateOptions)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayConfigDataPlaneNetworkOptions.
@@ -625,6 +973,11 @@ func (in *GatewayConfigDataPlaneServices) DeepCopyInto(out *GatewayConfigDataPla
 		*out = new(GatewayConfigServiceOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PrivateIngress != nil {
+		in, out := &in.PrivateIngress, &out.PrivateIngress
+		*out = new(GatewayConfigServiceOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayConfigDataPlaneServices.
@@ -680,6 +1033,21 @@ func (in *GatewayConfiguration) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayConfigurationGatewayReference) DeepCopyInto(out *GatewayConfigurationGatewayReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayConfigurationGatewayReference.
+func (in *GatewayConfigurationGatewayReference) DeepCopy() *GatewayConfigurationGatewayReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayConfigurationGatewayReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GatewayConfigurationList) DeepCopyInto(out *GatewayConfigurationList) {
 	*out = *in
@@ -747,6 +1115,16 @@ func (in *GatewayConfigurationStatus) DeepCopyInto(out *GatewayConfigurationStat
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.GatewayClasses != nil {
+		in, out := &in.GatewayClasses, &out.GatewayClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Gateways != nil {
+		in, out := &in.Gateways, &out.Gateways
+		*out = make([]GatewayConfigurationGatewayReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayConfigurationStatus.
@@ -791,6 +1169,21 @@ func (in *HorizontalScaling) DeepCopy() *HorizontalScaling {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectAPIAuthConfigurationRef) DeepCopyInto(out *KonnectAPIAuthConfigurationRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectAPIAuthConfigurationRef.
+func (in *KonnectAPIAuthConfigurationRef) DeepCopy() *KonnectAPIAuthConfigurationRef {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectAPIAuthConfigurationRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KonnectCertificateOptions) DeepCopyInto(out *KonnectCertificateOptions) {
 	*out = *in
@@ -868,9 +1261,54 @@ func (in *PodDisruptionBudgetSpec) DeepCopy() *PodDisruptionBudgetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreviewRouting) DeepCopyInto(out *PreviewRouting) {
+	*out = *in
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = new(PreviewRoutingMatch)
+		**out = **in
+	}
+	if in.Cookie != nil {
+		in, out := &in.Cookie, &out.Cookie
+		*out = new(PreviewRoutingMatch)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviewRouting.
+func (in *PreviewRouting) DeepCopy() *PreviewRouting {
+	if in == nil {
+		return nil
+	}
+	out := new(PreviewRouting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreviewRoutingMatch) DeepCopyInto(out *PreviewRoutingMatch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviewRoutingMatch.
+func (in *PreviewRoutingMatch) DeepCopy() *PreviewRoutingMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(PreviewRoutingMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Promotion) DeepCopyInto(out *Promotion) {
 	*out = *in
+	if in.Analysis != nil {
+		in, out := &in.Analysis, &out.Analysis
+		*out = new(PromotionAnalysis)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Promotion.
@@ -883,10 +1321,47 @@ func (in *Promotion) DeepCopy() *Promotion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromotionAnalysis) DeepCopyInto(out *PromotionAnalysis) {
+	*out = *in
+	out.Window = in.Window
+	out.Interval = in.Interval
+	if in.MaxErrorRate != nil {
+		in, out := &in.MaxErrorRate, &out.MaxErrorRate
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxAverageLatency != nil {
+		in, out := &in.MaxAverageLatency, &out.MaxAverageLatency
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromotionAnalysis.
+func (in *PromotionAnalysis) DeepCopy() *PromotionAnalysis {
+	if in == nil {
+		return nil
+	}
+	out := new(PromotionAnalysis)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rollout) DeepCopyInto(out *Rollout) {
 	*out = *in
 	in.Strategy.DeepCopyInto(&out.Strategy)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rollout.
@@ -958,7 +1433,12 @@ func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
 	if in.BlueGreen != nil {
 		in, out := &in.BlueGreen, &out.BlueGreen
 		*out = new(BlueGreenStrategy)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryStrategy)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
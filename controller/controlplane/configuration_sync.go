@@ -0,0 +1,233 @@
+package controlplane
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/pkg/log"
+	"github.com/kong/gateway-operator/pkg/consts"
+)
+
+// -----------------------------------------------------------------------------
+// Reconciler - Configuration sync status
+// -----------------------------------------------------------------------------
+
+const (
+	// configurationPushCountMetric is the name of the ingress controller's
+	// metric which counts the configuration pushes by their result.
+	configurationPushCountMetric = "ingress_controller_configuration_push_count"
+	// configurationPushLastSuccessfulMetric is the name of the ingress controller's
+	// metric holding the Unix time of the last successful configuration push.
+	configurationPushLastSuccessfulMetric = "ingress_controller_configuration_push_last_successful"
+
+	// configurationSyncScrapeTimeout is the timeout of a single request sent
+	// to the ControlPlane's leader pod to scrape its metrics.
+	configurationSyncScrapeTimeout = 5 * time.Second
+	// configurationSyncPollInterval is the interval at which the metrics of
+	// the ControlPlane's leader pod are scraped until its configuration is
+	// pushed successfully.
+	configurationSyncPollInterval = 30 * time.Second
+)
+
+// ensureConfigurationSyncStatus scrapes the metrics of the ControlPlane's
+// leader pod and updates the ControlPlane's status with the result of the last
+// configuration push observed since the previous scrape.
+// Scraping failures are logged and leave the status untouched because the
+// leader pod might not be reachable from the operator.
+// It returns true when the configuration sync has converged, i.e. the last
+// observed push succeeded, or when it isn't reported, and false when the
+// metrics have to be scraped again.
+func (r *Reconciler) ensureConfigurationSyncStatus(
+	ctx context.Context,
+	logger logr.Logger,
+	cp *operatorv1beta1.ControlPlane,
+	leader *corev1.Pod,
+) bool {
+	if r.metricsScraper == nil {
+		return true
+	}
+	if leader == nil || leader.Status.PodIP == "" {
+		return false
+	}
+
+	m, err := r.metricsScraper.ConfigurationPushMetrics(ctx, leader)
+	if err != nil {
+		log.Debug(logger, "failed scraping metrics of ControlPlane leader pod", cp, "pod", leader.Name, "error", err)
+		return false
+	}
+	delta, first := r.configurationPushObservations.delta(cp.UID, leader.Name, m)
+
+	status := cp.Status.ConfigurationSync
+	if status == nil {
+		status = &operatorv1beta1.ControlPlaneConfigurationSyncStatus{}
+	}
+	switch {
+	// The order of the pushes counted before the first scrape of a leader pod
+	// is unknown, so they only determine the result when it isn't set yet.
+	case first && status.Result != "":
+	case first && delta.Successful > 0:
+		status.Result = operatorv1beta1.ControlPlaneConfigurationSyncSucceeded
+	// A single sync pushes the configuration to each of the DataPlane's pods,
+	// so any failure since the previous scrape marks the sync as failed.
+	case delta.Failed > 0:
+		status.Result = operatorv1beta1.ControlPlaneConfigurationSyncFailed
+	case delta.Successful > 0:
+		status.Result = operatorv1beta1.ControlPlaneConfigurationSyncSucceeded
+	}
+	// The ingress controller exposes only the time of the last successful push.
+	if !m.LastSuccessful.IsZero() {
+		status.LastSuccessfulPushTime = &metav1.Time{Time: m.LastSuccessful}
+	}
+	if status.Result == "" {
+		// no configuration push was observed yet.
+		return false
+	}
+	cp.Status.ConfigurationSync = status
+	return status.Result == operatorv1beta1.ControlPlaneConfigurationSyncSucceeded
+}
+
+// configurationPushMetrics contains the ingress controller's configuration push metrics.
+type configurationPushMetrics struct {
+	Successful     float64
+	Failed         float64
+	LastSuccessful time.Time
+}
+
+// controlPlaneMetricsScraper scrapes the metrics of the ControlPlane's pods.
+type controlPlaneMetricsScraper interface {
+	// ConfigurationPushMetrics returns the pod's configuration push metrics.
+	ConfigurationPushMetrics(ctx context.Context, pod *corev1.Pod) (configurationPushMetrics, error)
+}
+
+// httpControlPlaneMetricsScraper is a controlPlaneMetricsScraper which uses
+// the ingress controller's metrics endpoint.
+type httpControlPlaneMetricsScraper struct {
+	client *http.Client
+}
+
+func newHTTPControlPlaneMetricsScraper() *httpControlPlaneMetricsScraper {
+	return &httpControlPlaneMetricsScraper{
+		client: &http.Client{
+			Timeout: configurationSyncScrapeTimeout,
+		},
+	}
+}
+
+// ConfigurationPushMetrics implements controlPlaneMetricsScraper.
+func (s *httpControlPlaneMetricsScraper) ConfigurationPushMetrics(ctx context.Context, pod *corev1.Pod) (configurationPushMetrics, error) {
+	url := fmt.Sprintf("http://%s/metrics", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(consts.ControlPlaneMetricsPort)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return configurationPushMetrics{}, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return configurationPushMetrics{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return configurationPushMetrics{}, fmt.Errorf("metrics endpoint responded with %d", resp.StatusCode)
+	}
+	return parseConfigurationPushMetrics(resp.Body)
+}
+
+// parseConfigurationPushMetrics parses the ingress controller's metrics
+// in the Prometheus text format.
+func parseConfigurationPushMetrics(r io.Reader) (configurationPushMetrics, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return configurationPushMetrics{}, fmt.Errorf("failed parsing metrics: %w", err)
+	}
+
+	var m configurationPushMetrics
+	if f, ok := families[configurationPushCountMetric]; ok {
+		for _, metric := range f.GetMetric() {
+			v := metric.GetCounter().GetValue()
+			for _, l := range metric.GetLabel() {
+				if l.GetName() != "success" {
+					continue
+				}
+				if l.GetValue() == "true" {
+					m.Successful += v
+				} else {
+					m.Failed += v
+				}
+			}
+		}
+	}
+	if f, ok := families[configurationPushLastSuccessfulMetric]; ok {
+		var last float64
+		for _, metric := range f.GetMetric() {
+			last = max(last, metric.GetGauge().GetValue())
+		}
+		if last > 0 {
+			m.LastSuccessful = time.Unix(int64(last), 0)
+		}
+	}
+	return m, nil
+}
+
+// configurationPushObservations stores the configuration push metrics of the
+// ControlPlanes' leader pods observed during the previous scrape so that the
+// pushes which happened in between can be determined.
+type configurationPushObservations struct {
+	lock         sync.Mutex
+	observations map[types.UID]configurationPushObservation
+}
+
+type configurationPushObservation struct {
+	pod     string
+	metrics configurationPushMetrics
+}
+
+func newConfigurationPushObservations() *configurationPushObservations {
+	return &configurationPushObservations{
+		observations: make(map[types.UID]configurationPushObservation),
+	}
+}
+
+// delta returns the difference between the provided metrics of the ControlPlane's
+// leader pod and the ones observed during the previous scrape, and records them
+// for the next one. When the pod is scraped for the first time, the provided
+// metrics are returned as they are and first is true. When its counters were
+// reset, e.g. because the container was restarted, the provided metrics are
+// returned as they are too.
+func (o *configurationPushObservations) delta(uid types.UID, pod string, m configurationPushMetrics) (d configurationPushMetrics, first bool) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	prev, ok := o.observations[uid]
+	o.observations[uid] = configurationPushObservation{pod: pod, metrics: m}
+	if !ok || prev.pod != pod {
+		return m, true
+	}
+	if m.Successful < prev.metrics.Successful || m.Failed < prev.metrics.Failed {
+		return m, false
+	}
+	return configurationPushMetrics{
+		Successful:     m.Successful - prev.metrics.Successful,
+		Failed:         m.Failed - prev.metrics.Failed,
+		LastSuccessful: m.LastSuccessful,
+	}, false
+}
+
+// reset removes the observations recorded for the ControlPlane with the provided UID.
+func (o *configurationPushObservations) reset(uid types.UID) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	delete(o.observations, uid)
+}
@@ -0,0 +1,158 @@
+package controlplane
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+)
+
+func TestParseConfigurationPushMetrics(t *testing.T) {
+	const metrics = `# HELP ingress_controller_configuration_push_count Count of successful/failed configuration pushes to Kong.
+# TYPE ingress_controller_configuration_push_count counter
+ingress_controller_configuration_push_count{dataplane="https://10.244.0.10:8444",protocol="db-less",success="true"} 5
+ingress_controller_configuration_push_count{dataplane="https://10.244.0.11:8444",protocol="db-less",success="true"} 4
+ingress_controller_configuration_push_count{dataplane="https://10.244.0.11:8444",failure_reason="other",protocol="db-less",success="false"} 1
+# HELP ingress_controller_configuration_push_last_successful Time of last successful configuration push.
+# TYPE ingress_controller_configuration_push_last_successful gauge
+ingress_controller_configuration_push_last_successful{dataplane="https://10.244.0.10:8444"} 1.7e+09
+ingress_controller_configuration_push_last_successful{dataplane="https://10.244.0.11:8444"} 1.6e+09
+# HELP go_goroutines Number of goroutines that currently exist.
+# TYPE go_goroutines gauge
+go_goroutines 42
+`
+
+	m, err := parseConfigurationPushMetrics(strings.NewReader(metrics))
+	require.NoError(t, err)
+	require.Equal(t, configurationPushMetrics{
+		Successful:     9,
+		Failed:         1,
+		LastSuccessful: time.Unix(1700000000, 0),
+	}, m)
+
+	t.Log("verifying that missing metrics are not an error")
+	m, err = parseConfigurationPushMetrics(strings.NewReader("go_goroutines 42\n"))
+	require.NoError(t, err)
+	require.Equal(t, configurationPushMetrics{}, m)
+
+	t.Log("verifying that malformed metrics are an error")
+	_, err = parseConfigurationPushMetrics(strings.NewReader("ingress_controller_configuration_push_count{ 1\n"))
+	require.Error(t, err)
+}
+
+func TestConfigurationPushObservationsDelta(t *testing.T) {
+	o := newConfigurationPushObservations()
+
+	t.Log("verifying that the first observation is returned as it is")
+	d, first := o.delta("uid", "cp-1", configurationPushMetrics{Successful: 3, Failed: 1})
+	require.True(t, first)
+	require.Equal(t, configurationPushMetrics{Successful: 3, Failed: 1}, d)
+
+	t.Log("verifying that the following observations return the difference")
+	d, first = o.delta("uid", "cp-1", configurationPushMetrics{Successful: 5, Failed: 1})
+	require.False(t, first)
+	require.Equal(t, configurationPushMetrics{Successful: 2}, d)
+
+	t.Log("verifying that reset counters are returned as they are")
+	d, first = o.delta("uid", "cp-1", configurationPushMetrics{Successful: 1})
+	require.False(t, first)
+	require.Equal(t, configurationPushMetrics{Successful: 1}, d)
+
+	t.Log("verifying that a new leader pod is observed for the first time")
+	d, first = o.delta("uid", "cp-2", configurationPushMetrics{Successful: 7})
+	require.True(t, first)
+	require.Equal(t, configurationPushMetrics{Successful: 7}, d)
+
+	t.Log("verifying that reset observations are observed for the first time")
+	o.reset("uid")
+	_, first = o.delta("uid", "cp-2", configurationPushMetrics{Successful: 7})
+	require.True(t, first)
+}
+
+type fakeControlPlaneMetricsScraper struct {
+	metrics configurationPushMetrics
+	err     error
+}
+
+func (s *fakeControlPlaneMetricsScraper) ConfigurationPushMetrics(context.Context, *corev1.Pod) (configurationPushMetrics, error) {
+	return s.metrics, s.err
+}
+
+func TestEnsureConfigurationSyncStatus(t *testing.T) {
+	ctx := context.Background()
+	leader := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cp-1",
+		},
+		Status: corev1.PodStatus{
+			PodIP: "10.244.0.5",
+		},
+	}
+	lastSuccessful := time.Unix(1700000000, 0)
+	scraper := &fakeControlPlaneMetricsScraper{}
+	r := &Reconciler{
+		metricsScraper:                scraper,
+		configurationPushObservations: newConfigurationPushObservations(),
+	}
+	cp := &operatorv1beta1.ControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cp",
+			UID:  "uid",
+		},
+	}
+
+	t.Log("verifying that the status is not set before any push is observed")
+	require.False(t, r.ensureConfigurationSyncStatus(ctx, logr.Discard(), cp, leader))
+	require.Nil(t, cp.Status.ConfigurationSync)
+
+	t.Log("verifying that a successful push is reported with the time from the metrics")
+	scraper.metrics = configurationPushMetrics{Successful: 1, LastSuccessful: lastSuccessful}
+	require.True(t, r.ensureConfigurationSyncStatus(ctx, logr.Discard(), cp, leader))
+	require.NotNil(t, cp.Status.ConfigurationSync)
+	require.Equal(t, operatorv1beta1.ControlPlaneConfigurationSyncSucceeded, cp.Status.ConfigurationSync.Result)
+	require.Equal(t, lastSuccessful, cp.Status.ConfigurationSync.LastSuccessfulPushTime.Time)
+
+	t.Log("verifying that a failed push is reported")
+	scraper.metrics = configurationPushMetrics{Successful: 1, Failed: 1, LastSuccessful: lastSuccessful}
+	require.False(t, r.ensureConfigurationSyncStatus(ctx, logr.Discard(), cp, leader))
+	require.Equal(t, operatorv1beta1.ControlPlaneConfigurationSyncFailed, cp.Status.ConfigurationSync.Result)
+	require.Equal(t, lastSuccessful, cp.Status.ConfigurationSync.LastSuccessfulPushTime.Time)
+
+	t.Log("verifying that the status is unchanged when no push happened since the previous scrape")
+	require.False(t, r.ensureConfigurationSyncStatus(ctx, logr.Discard(), cp, leader))
+	require.Equal(t, operatorv1beta1.ControlPlaneConfigurationSyncFailed, cp.Status.ConfigurationSync.Result)
+
+	t.Log("verifying that the status is unchanged when scraping fails")
+	scraper.err = errors.New("connection refused")
+	require.False(t, r.ensureConfigurationSyncStatus(ctx, logr.Discard(), cp, leader))
+	require.Equal(t, operatorv1beta1.ControlPlaneConfigurationSyncFailed, cp.Status.ConfigurationSync.Result)
+
+	t.Log("verifying that the pushes counted by a new leader before its first scrape don't override the result")
+	scraper.err = nil
+	scraper.metrics = configurationPushMetrics{Successful: 10}
+	newLeader := leader.DeepCopy()
+	newLeader.Name = "cp-2"
+	r.ensureConfigurationSyncStatus(ctx, logr.Discard(), cp, newLeader)
+	require.Equal(t, operatorv1beta1.ControlPlaneConfigurationSyncFailed, cp.Status.ConfigurationSync.Result)
+
+	t.Log("verifying that following pushes of the new leader are reported")
+	scraper.metrics = configurationPushMetrics{Successful: 11}
+	require.True(t, r.ensureConfigurationSyncStatus(ctx, logr.Discard(), cp, newLeader))
+	require.Equal(t, operatorv1beta1.ControlPlaneConfigurationSyncSucceeded, cp.Status.ConfigurationSync.Result)
+
+	t.Log("verifying that the status is unchanged without a leader")
+	scraper.metrics = configurationPushMetrics{Successful: 11, Failed: 5}
+	r.ensureConfigurationSyncStatus(ctx, logr.Discard(), cp, nil)
+	require.Equal(t, operatorv1beta1.ControlPlaneConfigurationSyncSucceeded, cp.Status.ConfigurationSync.Result)
+
+	t.Log("verifying that the sync is considered converged when it isn't reported")
+	require.True(t, (&Reconciler{}).ensureConfigurationSyncStatus(ctx, logr.Discard(), cp, leader))
+}
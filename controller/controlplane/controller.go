@@ -11,10 +11,13 @@ import (
 	admregv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,7 +31,9 @@ import (
 	"github.com/kong/gateway-operator/controller/pkg/controlplane"
 	"github.com/kong/gateway-operator/controller/pkg/log"
 	"github.com/kong/gateway-operator/controller/pkg/op"
+	"github.com/kong/gateway-operator/controller/pkg/secrets"
 	operatorerrors "github.com/kong/gateway-operator/internal/errors"
+	controlplanevalidation "github.com/kong/gateway-operator/internal/validation/controlplane"
 	"github.com/kong/gateway-operator/internal/versions"
 	"github.com/kong/gateway-operator/pkg/consts"
 	gatewayutils "github.com/kong/gateway-operator/pkg/utils/gateway"
@@ -39,15 +44,38 @@ import (
 type Reconciler struct {
 	client.Client
 	Scheme                   *runtime.Scheme
+	eventRecorder            record.EventRecorder
 	ClusterCASecretName      string
 	ClusterCASecretNamespace string
-	DevelopmentMode          bool
+	// ClusterCertificateRenewalWindow is the period before the expiration of
+	// the ControlPlane's certificates during which they get renewed.
+	ClusterCertificateRenewalWindow time.Duration
+	DevelopmentMode                 bool
+
+	// metricsScraper scrapes the configuration push metrics of the ControlPlane's
+	// leader pod. When nil, the configuration sync status is not reported.
+	metricsScraper                controlPlaneMetricsScraper
+	configurationPushObservations *configurationPushObservations
+	// apiReader reads the ControlPlanes' leader election Leases. When nil,
+	// the Client is used.
+	apiReader client.Reader
 }
 
 const requeueWithoutBackoff = time.Millisecond * 200
 
+// leaderElectionPollInterval is the interval at which the ControlPlane's leader
+// election Lease is read while its ready pods have no leader.
+const leaderElectionPollInterval = 5 * time.Second
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.eventRecorder = mgr.GetEventRecorderFor("controlplane")
+	r.metricsScraper = newHTTPControlPlaneMetricsScraper()
+	r.configurationPushObservations = newConfigurationPushObservations()
+	// Leader election Leases are read directly from the API server instead of
+	// being watched, not to cache all the Leases in the cluster.
+	r.apiReader = mgr.GetAPIReader()
+
 	// for owned objects we need to check if updates to the objects resulted in the
 	// removal of an OwnerReference to the parent object, and if so we need to
 	// enqueue the parent object so that reconciliation can create a replacement.
@@ -75,6 +103,11 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&appsv1.Deployment{}).
 		// watch for changes in Services created by the controlplane controller
 		Owns(&corev1.Service{}).
+		// watch for changes in Roles and RoleBindings created by the controlplane controller
+		Owns(&rbacv1.Role{}).
+		Owns(&rbacv1.RoleBinding{}).
+		// watch for changes in PodDisruptionBudgets created by the controlplane controller
+		Owns(&policyv1.PodDisruptionBudget{}).
 		// watch for changes in ValidatingWebhookConfigurations created by the controlplane controller.
 		// Since the ValidatingWebhookConfigurations are cluster-wide but controlplanes are namespaced,
 		// we need to manually detect the owner by means of the UID
@@ -100,6 +133,11 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&rbacv1.ClusterRoleBinding{},
 			handler.EnqueueRequestsFromMapFunc(r.getControlPlaneForClusterRoleBinding),
 			builder.WithPredicates(clusterRoleBindingOwnerPredicate)).
+		// watch for changes in the cluster CA Secret which issues ControlPlane certificates
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.getControlPlanesForClusterCASecret),
+			builder.WithPredicates(predicate.NewPredicateFuncs(r.isClusterCASecret))).
 		Watches(
 			&operatorv1beta1.DataPlane{},
 			handler.EnqueueRequestsFromMapFunc(r.getControlPlanesFromDataPlane)).
@@ -120,6 +158,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	cp := new(operatorv1beta1.ControlPlane)
 	if err := r.Client.Get(ctx, req.NamespacedName, cp); err != nil {
 		if k8serrors.IsNotFound(err) {
+			secrets.DeleteCertificateExpirationMetrics(&operatorv1beta1.ControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Namespace: req.Namespace, Name: req.Name},
+			})
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, err
@@ -202,6 +243,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 
 		// cleanup completed
+		if r.configurationPushObservations != nil {
+			r.configurationPushObservations.reset(cp.UID)
+		}
 		log.Debug(logger, "resource cleanup completed, controlplane deleted", cp)
 		return ctrl.Result{}, nil
 	}
@@ -265,7 +309,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 
 	log.Trace(logger, "validating ControlPlane configuration", cp)
-	if err := validateControlPlane(cp, r.DevelopmentMode); err != nil {
+	if err := validateControlPlane(cp, dataplane, r.DevelopmentMode); err != nil {
 		return ctrl.Result{}, err
 	}
 
@@ -319,6 +363,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	} else {
 		log.Debug(logger, "DataPlane not set, deployment for ControlPlane will remain dormant", cp)
 	}
+	if err := r.ensureDataPlaneObservedStatus(ctx, cp, dataplane, dataplaneAdminServiceName); err != nil {
+		return ctrl.Result{}, err
+	}
 
 	log.Trace(logger, "ensuring ServiceAccount for ControlPlane deployment exists", cp)
 	createdOrUpdated, controlplaneServiceAccount, err := r.ensureServiceAccount(ctx, cp)
@@ -350,8 +397,32 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, nil // requeue will be triggered by the creation or update of the owned object
 	}
 
+	log.Trace(logger, "ensuring leader election Role for ControlPlane deployment exists", cp)
+	createdOrUpdated, controlplaneRole, err := r.ensureRole(ctx, cp)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if createdOrUpdated {
+		log.Debug(logger, "role updated", cp)
+		return ctrl.Result{}, nil // requeue will be triggered by the creation or update of the owned object
+	}
+
+	log.Trace(logger, "ensuring leader election RoleBinding for ControlPlane deployment exists", cp)
+	createdOrUpdated, _, err = r.ensureRoleBinding(ctx, cp, controlplaneServiceAccount.Name, controlplaneRole.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if createdOrUpdated {
+		log.Debug(logger, "roleBinding updated", cp)
+		return ctrl.Result{}, nil // requeue will be triggered by the creation or update of the owned object
+	}
+
 	log.Trace(logger, "creating mTLS certificate", cp)
 	res, adminCertificate, err := r.ensureAdminMTLSCertificateSecret(ctx, cp)
+	if errors.Is(err, secrets.ErrCertificateNotReady) {
+		log.Debug(logger, "waiting for mTLS certificate to be issued", cp)
+		return ctrl.Result{RequeueAfter: secrets.CertificateNotReadyRequeueAfter}, nil
+	}
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -361,9 +432,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 
 	deploymentParams := ensureDeploymentParams{
-		ControlPlane:            cp,
-		ServiceAccountName:      controlplaneServiceAccount.Name,
-		AdminMTLSCertSecretName: adminCertificate.Name,
+		ControlPlane:        cp,
+		ServiceAccountName:  controlplaneServiceAccount.Name,
+		AdminMTLSCertSecret: adminCertificate,
 	}
 
 	admissionWebhookCertificateSecretName, res, err := r.ensureWebhookResources(ctx, logger, cp)
@@ -395,7 +466,24 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 		return ctrl.Result{}, nil // requeue will be triggered by the creation or update of the owned object
 	}
+
+	log.Trace(logger, "ensuring PodDisruptionBudget for ControlPlane deployment", cp)
+	res, err = r.ensurePodDisruptionBudget(ctx, logger, cp)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if res != op.Noop {
+		log.Debug(logger, "podDisruptionBudget updated", cp)
+		return ctrl.Result{}, nil // requeue will be triggered by the creation, update or deletion of the owned object
+	}
+
 	log.Trace(logger, "checking readiness of ControlPlane deployments", cp)
+	leader, err := r.ensureReplicasStatus(ctx, cp, controlplaneDeployment)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	ensureVersionStatus(cp, controlplaneDeployment)
+	configurationSynced := r.ensureConfigurationSyncStatus(ctx, logger, cp, leader)
 
 	if controlplaneDeployment.Status.Replicas == 0 || controlplaneDeployment.Status.AvailableReplicas < controlplaneDeployment.Status.Replicas {
 		log.Trace(logger, "deployment for ControlPlane not ready yet", controlplaneDeployment)
@@ -431,20 +519,44 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 
 	log.Debug(logger, "reconciliation complete for ControlPlane resource", cp)
+	if leader == nil && cp.Status.ReadyReplicas > 0 {
+		// Leases aren't watched so poll the leader election Lease until a leader
+		// is elected. Later leader changes follow changes of the pods' readiness,
+		// which trigger the reconciliation through the owned Deployment.
+		return ctrl.Result{RequeueAfter: leaderElectionPollInterval}, nil
+	}
+	if !configurationSynced {
+		// KIC doesn't notify about configuration pushes, so poll its metrics
+		// until the configuration is pushed successfully.
+		return ctrl.Result{RequeueAfter: configurationSyncPollInterval}, nil
+	}
+	if cp.Spec.ClusterCertificateOptions == nil {
+		return secrets.RequeueForCertificateRenewal(ctrl.Result{}, adminCertificate, r.ClusterCertificateRenewalWindow), nil
+	}
 	return ctrl.Result{}, nil
 }
 
-// validateControlPlane validates the control plane.
-func validateControlPlane(controlPlane *operatorv1beta1.ControlPlane, devMode bool) error {
+// validateControlPlane validates the control plane and, when it's set, its
+// compatibility with the DataPlane it manages.
+func validateControlPlane(controlPlane *operatorv1beta1.ControlPlane, dataplane *operatorv1beta1.DataPlane, devMode bool) error {
 	versionValidationOptions := make([]versions.VersionValidationOption, 0)
 	if !devMode {
 		versionValidationOptions = append(versionValidationOptions, versions.IsControlPlaneImageVersionSupported)
 	}
-	_, err := controlplane.GenerateImage(&controlPlane.Spec.ControlPlaneOptions, versionValidationOptions...)
-	return err
+	if _, err := controlplane.GenerateImage(&controlPlane.Spec.ControlPlaneOptions, versionValidationOptions...); err != nil {
+		return err
+	}
+	if dataplane == nil {
+		return nil
+	}
+	return controlplanevalidation.ValidateClusterCertificateIssuer(
+		controlPlane.Spec.ClusterCertificateOptions,
+		dataplane.Spec.Network.ClusterCertificateOptions,
+	)
 }
 
 // patchStatus Patches the resource status only when there are changes in the Conditions
+// or in the observed fields of the status.
 func (r *Reconciler) patchStatus(ctx context.Context, logger logr.Logger, updated *operatorv1beta1.ControlPlane) (ctrl.Result, error) {
 	current := &operatorv1beta1.ControlPlane{}
 
@@ -453,7 +565,7 @@ func (r *Reconciler) patchStatus(ctx context.Context, logger logr.Logger, update
 		return ctrl.Result{}, err
 	}
 
-	if k8sutils.NeedsUpdate(current, updated) {
+	if k8sutils.NeedsUpdate(current, updated) || observedStatusChanged(current.Status, updated.Status) {
 		log.Debug(logger, "patching ControlPlane status", updated, "status", updated.Status)
 		if err := r.Client.Status().Patch(ctx, updated, client.MergeFrom(current)); err != nil {
 			if k8serrors.IsConflict(err) {
@@ -468,6 +580,13 @@ func (r *Reconciler) patchStatus(ctx context.Context, logger logr.Logger, update
 	return ctrl.Result{}, nil
 }
 
+// observedStatusChanged returns true when the fields of the ControlPlane's status
+// other than the Conditions differ.
+func observedStatusChanged(current, updated operatorv1beta1.ControlPlaneStatus) bool {
+	current.Conditions, updated.Conditions = nil, nil
+	return !equality.Semantic.DeepEqual(current, updated)
+}
+
 func (r *Reconciler) ensureWebhookResources(
 	ctx context.Context, logger logr.Logger, cp *operatorv1beta1.ControlPlane,
 ) (string, op.Result, error) {
@@ -4,6 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
@@ -11,7 +15,9 @@ import (
 	admregv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	certificatesv1 "k8s.io/api/certificates/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -139,7 +145,7 @@ func setControlPlaneEnvOnDataPlaneChange(
 type ensureDeploymentParams struct {
 	ControlPlane                   *operatorv1beta1.ControlPlane
 	ServiceAccountName             string
-	AdminMTLSCertSecretName        string
+	AdminMTLSCertSecret            *corev1.Secret
 	AdmissionWebhookCertSecretName string
 }
 
@@ -185,12 +191,14 @@ func (r *Reconciler) ensureDeployment(
 		ControlPlane:                   params.ControlPlane,
 		ControlPlaneImage:              controlplaneImage,
 		ServiceAccountName:             params.ServiceAccountName,
-		AdminMTLSCertSecretName:        params.AdminMTLSCertSecretName,
+		AdminMTLSCertSecretName:        params.AdminMTLSCertSecret.Name,
 		AdmissionWebhookCertSecretName: params.AdmissionWebhookCertSecretName,
 	})
 	if err != nil {
 		return op.Noop, nil, err
 	}
+	// roll out the ControlPlane's pods when its admin API client certificate gets renewed
+	secrets.CertificateRenewalDeploymentOpt(params.AdminMTLSCertSecret)(generatedDeployment)
 
 	if count == 1 {
 		var updated bool
@@ -467,6 +475,317 @@ func (r *Reconciler) ensureClusterRoleBinding(
 	return true, generated, r.Client.Create(ctx, generated)
 }
 
+// ensureRole ensures that a Role granting the ControlPlane's pods access
+// to their leader election Lease exists in the ControlPlane's namespace.
+func (r *Reconciler) ensureRole(
+	ctx context.Context,
+	cp *operatorv1beta1.ControlPlane,
+) (createdOrUpdated bool, role *rbacv1.Role, err error) {
+	roles, err := k8sutils.ListRolesForOwner(
+		ctx,
+		r.Client,
+		cp.Namespace,
+		cp.UID,
+		client.MatchingLabels{
+			consts.GatewayOperatorManagedByLabel: consts.ControlPlaneManagedLabelValue,
+		},
+	)
+	if err != nil {
+		return false, nil, err
+	}
+
+	count := len(roles)
+	if count > 1 {
+		if err := k8sreduce.ReduceRoles(ctx, r.Client, roles); err != nil {
+			return false, nil, err
+		}
+		return false, nil, errors.New("number of roles reduced")
+	}
+
+	generated := k8sresources.GenerateNewRoleForControlPlane(cp.Namespace, cp.Name)
+	k8sutils.SetOwnerForObject(generated, cp)
+
+	if count == 1 {
+		var (
+			updated  bool
+			existing = &roles[0]
+			old      = existing.DeepCopy()
+		)
+
+		updated, existing.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existing.ObjectMeta, generated.ObjectMeta)
+		if updated || !cmp.Equal(existing.Rules, generated.Rules) {
+			existing.Rules = generated.Rules
+			if err := r.Client.Patch(ctx, existing, client.MergeFrom(old)); err != nil {
+				return false, existing, fmt.Errorf("failed patching ControlPlane's Role %s: %w", existing.Name, err)
+			}
+			return true, existing, nil
+		}
+		return false, existing, nil
+	}
+
+	return true, generated, r.Client.Create(ctx, generated)
+}
+
+// ensureRoleBinding ensures that a RoleBinding binding the ControlPlane's
+// leader election Role to its ServiceAccount exists.
+func (r *Reconciler) ensureRoleBinding(
+	ctx context.Context,
+	cp *operatorv1beta1.ControlPlane,
+	serviceAccountName string,
+	roleName string,
+) (createdOrUpdated bool, rb *rbacv1.RoleBinding, err error) {
+	logger := log.GetLogger(ctx, "controlplane.ensureRoleBinding", r.DevelopmentMode)
+
+	roleBindings, err := k8sutils.ListRoleBindingsForOwner(
+		ctx,
+		r.Client,
+		cp.Namespace,
+		cp.UID,
+		client.MatchingLabels{
+			consts.GatewayOperatorManagedByLabel: consts.ControlPlaneManagedLabelValue,
+		},
+	)
+	if err != nil {
+		return false, nil, err
+	}
+
+	count := len(roleBindings)
+	if count > 1 {
+		if err := k8sreduce.ReduceRoleBindings(ctx, r.Client, roleBindings); err != nil {
+			return false, nil, err
+		}
+		return false, nil, errors.New("number of roleBindings reduced")
+	}
+
+	generated := k8sresources.GenerateNewRoleBindingForControlPlane(cp.Namespace, cp.Name, serviceAccountName, roleName)
+	k8sutils.SetOwnerForObject(generated, cp)
+
+	if count == 1 {
+		existing := &roleBindings[0]
+		// Delete and re-create RoleBinding if the Role it refers to changed because RoleRef is immutable.
+		if existing.RoleRef != generated.RoleRef {
+			log.Debug(logger, "Role name changed, delete and re-create a RoleBinding",
+				existing,
+				"old_role", existing.RoleRef.Name,
+				"new_role", roleName,
+			)
+			if err := r.Client.Delete(ctx, existing); err != nil {
+				return false, nil, err
+			}
+			return false, nil, errors.New("name of Role changed, out of date RoleBinding deleted")
+		}
+
+		var (
+			old     = existing.DeepCopy()
+			updated bool
+		)
+		updated, existing.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existing.ObjectMeta, generated.ObjectMeta)
+		if updated || !cmp.Equal(existing.Subjects, generated.Subjects) {
+			existing.Subjects = generated.Subjects
+			if err := r.Client.Patch(ctx, existing, client.MergeFrom(old)); err != nil {
+				return false, existing, fmt.Errorf("failed patching ControlPlane's RoleBinding %s: %w", existing.Name, err)
+			}
+			return true, existing, nil
+		}
+		return false, existing, nil
+	}
+
+	return true, generated, r.Client.Create(ctx, generated)
+}
+
+// ensurePodDisruptionBudget ensures that a PodDisruptionBudget exists for
+// ControlPlanes running more than 1 replica. The PodDisruptionBudget is deleted
+// when the ControlPlane is scaled down to a single replica or its DataPlane is unset.
+func (r *Reconciler) ensurePodDisruptionBudget(
+	ctx context.Context,
+	logger logr.Logger,
+	cp *operatorv1beta1.ControlPlane,
+) (op.Result, error) {
+	pdbs, err := k8sutils.ListPodDisruptionBudgetsForOwner(
+		ctx,
+		r.Client,
+		cp.Namespace,
+		cp.UID,
+		client.MatchingLabels{
+			consts.GatewayOperatorManagedByLabel: consts.ControlPlaneManagedLabelValue,
+		},
+	)
+	if err != nil {
+		return op.Noop, fmt.Errorf("failed listing PodDisruptionBudgets for ControlPlane %s/%s: %w", cp.Namespace, cp.Name, err)
+	}
+
+	dataplaneIsSet := cp.Spec.DataPlane != nil && *cp.Spec.DataPlane != ""
+	replicas := cp.Spec.Deployment.Replicas
+	if !dataplaneIsSet || replicas == nil || *replicas < 2 {
+		if err := k8sreduce.ReducePodDisruptionBudgets(ctx, r.Client, pdbs, k8sreduce.FilterNone); err != nil {
+			return op.Noop, fmt.Errorf("failed reducing PodDisruptionBudgets for ControlPlane %s/%s: %w", cp.Namespace, cp.Name, err)
+		}
+		if len(pdbs) > 0 {
+			return op.Deleted, nil
+		}
+		return op.Noop, nil
+	}
+
+	if len(pdbs) > 1 {
+		if err := k8sreduce.ReducePodDisruptionBudgets(ctx, r.Client, pdbs, k8sreduce.FilterPodDisruptionBudgets); err != nil {
+			return op.Noop, fmt.Errorf("failed reducing PodDisruptionBudgets for ControlPlane %s/%s: %w", cp.Namespace, cp.Name, err)
+		}
+		return op.Deleted, nil
+	}
+
+	generated := k8sresources.GeneratePodDisruptionBudgetForControlPlane(cp)
+
+	if len(pdbs) == 1 {
+		var updated bool
+		existing := &pdbs[0]
+		old := existing.DeepCopy()
+
+		updated, existing.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existing.ObjectMeta, generated.ObjectMeta)
+		if !cmp.Equal(existing.Spec, generated.Spec) {
+			existing.Spec = generated.Spec
+			updated = true
+		}
+
+		res, _, err := patch.ApplyPatchIfNonEmpty(ctx, r.Client, logger, existing, old, cp, updated)
+		return res, err
+	}
+
+	if err := r.Client.Create(ctx, generated); err != nil {
+		return op.Noop, fmt.Errorf("failed creating PodDisruptionBudget for ControlPlane %s: %w", cp.Name, err)
+	}
+
+	return op.Created, nil
+}
+
+// ensureReplicasStatus sets the desired, ready, standby and leader pods in the
+// ControlPlane's status and returns the leader pod when it's ready. The leader
+// is read from the holder of the ControlPlane's leader election Lease.
+func (r *Reconciler) ensureReplicasStatus(
+	ctx context.Context,
+	cp *operatorv1beta1.ControlPlane,
+	deployment *appsv1.Deployment,
+) (*corev1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing ControlPlane Deployment %s selector: %w", deployment.Name, err)
+	}
+	var pods corev1.PodList
+	if err := r.Client.List(ctx, &pods,
+		client.InNamespace(deployment.Namespace),
+		client.MatchingLabelsSelector{Selector: selector},
+	); err != nil {
+		return nil, fmt.Errorf("failed listing ControlPlane pods: %w", err)
+	}
+
+	var (
+		lease  coordinationv1.Lease
+		holder string
+	)
+	var reader client.Reader = r.Client
+	if r.apiReader != nil {
+		reader = r.apiReader
+	}
+	switch err := reader.Get(ctx, controlplane.LeaderElectionLease(cp), &lease); {
+	case k8serrors.IsNotFound(err):
+	case err != nil:
+		return nil, fmt.Errorf("failed getting ControlPlane leader election Lease: %w", err)
+	case lease.Spec.HolderIdentity != nil:
+		// The holder identity has the form <pod name>_<unique ID>.
+		holder, _, _ = strings.Cut(*lease.Spec.HolderIdentity, "_")
+	}
+
+	var (
+		ready  int32
+		leader *corev1.Pod
+	)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !pod.DeletionTimestamp.IsZero() || !k8sutils.IsPodReady(pod) {
+			continue
+		}
+		ready++
+		if pod.Name == holder {
+			leader = pod
+		}
+	}
+
+	cp.Status.Replicas = lo.FromPtr(deployment.Spec.Replicas)
+	cp.Status.ReadyReplicas = ready
+	cp.Status.StandbyReplicas = ready
+	cp.Status.Leader = ""
+	if leader != nil {
+		cp.Status.StandbyReplicas--
+		cp.Status.Leader = holder
+	}
+	return leader, nil
+}
+
+// ensureVersionStatus sets the version of the ingress controller run by the
+// ControlPlane's Deployment in the ControlPlane's status. The version is left
+// empty when it can't be determined from the controller container's image.
+func ensureVersionStatus(cp *operatorv1beta1.ControlPlane, deployment *appsv1.Deployment) {
+	cp.Status.Version = ""
+	container := k8sutils.GetPodContainerByName(&deployment.Spec.Template.Spec, consts.ControlPlaneControllerContainerName)
+	if container == nil {
+		return
+	}
+	v, err := versions.FromImage(container.Image)
+	if err != nil {
+		return
+	}
+	cp.Status.Version = v.String()
+}
+
+// ensureDataPlaneObservedStatus sets the name of the ControlPlane's DataPlane and
+// the endpoints of its ready Admin API pods in the ControlPlane's status.
+// The endpoints are read from the EndpointSlices of the DataPlane's admin Service.
+func (r *Reconciler) ensureDataPlaneObservedStatus(
+	ctx context.Context,
+	cp *operatorv1beta1.ControlPlane,
+	dataplane *operatorv1beta1.DataPlane,
+	dataplaneAdminServiceName string,
+) error {
+	if dataplane == nil {
+		cp.Status.DataPlane = nil
+		return nil
+	}
+
+	var endpointSlices discoveryv1.EndpointSliceList
+	if err := r.Client.List(ctx, &endpointSlices,
+		client.InNamespace(dataplane.Namespace),
+		client.MatchingLabels{discoveryv1.LabelServiceName: dataplaneAdminServiceName},
+	); err != nil {
+		return fmt.Errorf("failed listing DataPlane admin Service EndpointSlices: %w", err)
+	}
+
+	var endpoints []string
+	for _, es := range endpointSlices.Items {
+		port, ok := lo.Find(es.Ports, func(p discoveryv1.EndpointPort) bool {
+			return lo.FromPtr(p.Name) == consts.DataPlaneAdminServicePortName && p.Port != nil
+		})
+		if !ok {
+			continue
+		}
+		for _, ep := range es.Endpoints {
+			if ep.Conditions.Ready != nil && !*ep.Conditions.Ready {
+				continue
+			}
+			for _, addr := range ep.Addresses {
+				endpoints = append(endpoints,
+					"https://"+net.JoinHostPort(addr, strconv.Itoa(int(*port.Port))),
+				)
+			}
+		}
+	}
+	sort.Strings(endpoints)
+
+	cp.Status.DataPlane = &operatorv1beta1.ControlPlaneDataPlaneStatus{
+		Name:           dataplane.Name,
+		AdminEndpoints: endpoints,
+	}
+	return nil
+}
+
 // ensureAdminMTLSCertificateSecret ensures that a Secret is created with the certificate for mTLS communication between the
 // ControlPlane and the DataPlane.
 func (r *Reconciler) ensureAdminMTLSCertificateSecret(
@@ -487,9 +806,20 @@ func (r *Reconciler) ensureAdminMTLSCertificateSecret(
 	}
 	// this subject is arbitrary. data planes only care that client certificates are signed by the trusted CA, and will
 	// accept a certificate with any subject
+	subject := fmt.Sprintf("%s.%s", cp.Name, cp.Namespace)
+	if certOpts := cp.Spec.ClusterCertificateOptions; certOpts != nil {
+		return secrets.EnsureCertManagerCertificate(ctx,
+			cp,
+			subject,
+			certOpts.Issuer,
+			usages,
+			r.Client,
+			matchingLabels,
+		)
+	}
 	return secrets.EnsureCertificate(ctx,
 		cp,
-		fmt.Sprintf("%s.%s", cp.Name, cp.Namespace),
+		subject,
 		k8stypes.NamespacedName{
 			Namespace: r.ClusterCASecretNamespace,
 			Name:      r.ClusterCASecretName,
@@ -497,9 +827,18 @@ func (r *Reconciler) ensureAdminMTLSCertificateSecret(
 		usages,
 		r.Client,
 		matchingLabels,
+		r.certificateOpts()...,
 	)
 }
 
+// certificateOpts returns the options used to ensure the ControlPlane's certificates.
+func (r *Reconciler) certificateOpts() []secrets.CertificateOpt {
+	return []secrets.CertificateOpt{
+		secrets.WithRenewalWindow(r.ClusterCertificateRenewalWindow),
+		secrets.WithEventRecorder(r.eventRecorder),
+	}
+}
+
 // ensureAdmissionWebhookCertificateSecret ensures that a Secret is created with the serving certificate for the
 // ControlPlane's admission webhook.
 func (r *Reconciler) ensureAdmissionWebhookCertificateSecret(
@@ -548,6 +887,7 @@ func (r *Reconciler) ensureAdmissionWebhookCertificateSecret(
 		usages,
 		r.Client,
 		matchingLabels,
+		r.certificateOpts()...,
 	)
 }
 
@@ -4,12 +4,19 @@ import (
 	"context"
 	"testing"
 
+	"github.com/go-logr/logr"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 	admregv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
@@ -202,3 +209,300 @@ func Test_ensureValidatingWebhookConfiguration(t *testing.T) {
 		})
 	}
 }
+
+func Test_ensurePodDisruptionBudget(t *testing.T) {
+	ctx := context.Background()
+	cp := &operatorv1beta1.ControlPlane{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "gateway-operator.konghq.com/v1beta1",
+			Kind:       "ControlPlane",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cp",
+			Namespace: "default",
+			UID:       types.UID("1234"),
+		},
+		Spec: operatorv1beta1.ControlPlaneSpec{
+			ControlPlaneOptions: operatorv1beta1.ControlPlaneOptions{
+				DataPlane: lo.ToPtr("dp"),
+				Deployment: operatorv1beta1.ControlPlaneDeploymentOptions{
+					Replicas: lo.ToPtr(int32(1)),
+				},
+			},
+		},
+	}
+	r := &Reconciler{
+		Client: fakectrlruntimeclient.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(cp).
+			Build(),
+	}
+	listPDBs := func() []policyv1.PodDisruptionBudget {
+		t.Helper()
+		var pdbs policyv1.PodDisruptionBudgetList
+		require.NoError(t, r.Client.List(ctx, &pdbs))
+		return pdbs.Items
+	}
+
+	t.Log("single replica ControlPlanes don't get a PodDisruptionBudget")
+	res, err := r.ensurePodDisruptionBudget(ctx, logr.Discard(), cp)
+	require.NoError(t, err)
+	require.Equal(t, op.Noop, res)
+	require.Empty(t, listPDBs())
+
+	t.Log("multi replica ControlPlanes get a PodDisruptionBudget")
+	cp.Spec.Deployment.Replicas = lo.ToPtr(int32(3))
+	res, err = r.ensurePodDisruptionBudget(ctx, logr.Discard(), cp)
+	require.NoError(t, err)
+	require.Equal(t, op.Created, res)
+	pdbs := listPDBs()
+	require.Len(t, pdbs, 1)
+	require.Equal(t, map[string]string{"app": "cp"}, pdbs[0].Spec.Selector.MatchLabels)
+	require.Equal(t, 1, pdbs[0].Spec.MaxUnavailable.IntValue())
+
+	res, err = r.ensurePodDisruptionBudget(ctx, logr.Discard(), cp)
+	require.NoError(t, err)
+	require.Equal(t, op.Noop, res)
+
+	t.Log("PodDisruptionBudget is deleted when the DataPlane is unset")
+	cp.Spec.DataPlane = nil
+	res, err = r.ensurePodDisruptionBudget(ctx, logr.Discard(), cp)
+	require.NoError(t, err)
+	require.Equal(t, op.Deleted, res)
+	require.Empty(t, listPDBs())
+}
+
+func Test_ensureReplicasStatus(t *testing.T) {
+	ctx := context.Background()
+	cp := &operatorv1beta1.ControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cp",
+			Namespace: "default",
+		},
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "controlplane-cp",
+			Namespace: "default",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: lo.ToPtr(int32(3)),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "cp"},
+			},
+		},
+	}
+	newPod := func(name string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{"app": "cp"},
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: ready},
+				},
+			},
+		}
+	}
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cp.konghq.com",
+			Namespace: "default",
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity: lo.ToPtr("cp-1_2b9f1b4e-6e3a-4c8c-9f0e-2d1d0b7a6c5d"),
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		objects []client.Object
+		status  operatorv1beta1.ControlPlaneStatus
+		leader  string
+	}{
+		{
+			name: "no pods",
+			status: operatorv1beta1.ControlPlaneStatus{
+				Replicas: 3,
+			},
+		},
+		{
+			name: "ready pods without a leader",
+			objects: []client.Object{
+				newPod("cp-1", corev1.ConditionTrue),
+				newPod("cp-2", corev1.ConditionTrue),
+			},
+			status: operatorv1beta1.ControlPlaneStatus{
+				Replicas:        3,
+				ReadyReplicas:   2,
+				StandbyReplicas: 2,
+			},
+		},
+		{
+			name: "leader and standby pods",
+			objects: []client.Object{
+				newPod("cp-1", corev1.ConditionTrue),
+				newPod("cp-2", corev1.ConditionTrue),
+				newPod("cp-3", corev1.ConditionFalse),
+				lease,
+			},
+			status: operatorv1beta1.ControlPlaneStatus{
+				Replicas:        3,
+				ReadyReplicas:   2,
+				StandbyReplicas: 1,
+				Leader:          "cp-1",
+			},
+			leader: "cp-1",
+		},
+		{
+			name: "leader pod not ready",
+			objects: []client.Object{
+				newPod("cp-1", corev1.ConditionFalse),
+				newPod("cp-2", corev1.ConditionTrue),
+				lease,
+			},
+			status: operatorv1beta1.ControlPlaneStatus{
+				Replicas:        3,
+				ReadyReplicas:   1,
+				StandbyReplicas: 1,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			r := &Reconciler{
+				Client: fakectrlruntimeclient.NewClientBuilder().
+					WithScheme(scheme.Scheme).
+					WithObjects(tc.objects...).
+					Build(),
+			}
+			cp := cp.DeepCopy()
+			leader, err := r.ensureReplicasStatus(ctx, cp, deployment)
+			require.NoError(t, err)
+			require.Equal(t, tc.status, cp.Status)
+			if tc.leader == "" {
+				require.Nil(t, leader)
+			} else {
+				require.NotNil(t, leader)
+				require.Equal(t, tc.leader, leader.Name)
+			}
+		})
+	}
+}
+
+func Test_ensureDataPlaneObservedStatus(t *testing.T) {
+	ctx := context.Background()
+	dataplane := &operatorv1beta1.DataPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dp",
+			Namespace: "default",
+		},
+	}
+	newEndpointSlice := func(name, service string, ready bool, addresses ...string) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints: []discoveryv1.Endpoint{
+				{
+					Addresses:  addresses,
+					Conditions: discoveryv1.EndpointConditions{Ready: lo.ToPtr(ready)},
+				},
+			},
+			Ports: []discoveryv1.EndpointPort{
+				{
+					Name: lo.ToPtr(consts.DataPlaneAdminServicePortName),
+					Port: lo.ToPtr(int32(consts.DataPlaneAdminAPIPort)),
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		dataplane *operatorv1beta1.DataPlane
+		objects   []client.Object
+		status    *operatorv1beta1.ControlPlaneDataPlaneStatus
+	}{
+		{
+			name: "no dataplane",
+		},
+		{
+			name:      "dataplane without endpoints",
+			dataplane: dataplane,
+			status: &operatorv1beta1.ControlPlaneDataPlaneStatus{
+				Name: "dp",
+			},
+		},
+		{
+			name:      "dataplane with ready and not ready endpoints",
+			dataplane: dataplane,
+			objects: []client.Object{
+				newEndpointSlice("dp-admin-1", "dp-admin", true, "10.0.0.2"),
+				newEndpointSlice("dp-admin-2", "dp-admin", true, "10.0.0.1"),
+				newEndpointSlice("dp-admin-3", "dp-admin", false, "10.0.0.3"),
+				newEndpointSlice("dp-proxy-1", "dp-proxy", true, "10.0.0.4"),
+			},
+			status: &operatorv1beta1.ControlPlaneDataPlaneStatus{
+				Name: "dp",
+				AdminEndpoints: []string{
+					"https://10.0.0.1:8444",
+					"https://10.0.0.2:8444",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			r := &Reconciler{
+				Client: fakectrlruntimeclient.NewClientBuilder().
+					WithScheme(scheme.Scheme).
+					WithObjects(tc.objects...).
+					Build(),
+			}
+			cp := &operatorv1beta1.ControlPlane{
+				Status: operatorv1beta1.ControlPlaneStatus{
+					DataPlane: &operatorv1beta1.ControlPlaneDataPlaneStatus{Name: "stale"},
+				},
+			}
+			require.NoError(t, r.ensureDataPlaneObservedStatus(ctx, cp, tc.dataplane, "dp-admin"))
+			require.Equal(t, tc.status, cp.Status.DataPlane)
+		})
+	}
+}
+
+func Test_ensureVersionStatus(t *testing.T) {
+	newDeployment := func(image string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  consts.ControlPlaneControllerContainerName,
+								Image: image,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	cp := &operatorv1beta1.ControlPlane{}
+	ensureVersionStatus(cp, newDeployment("kong/kubernetes-ingress-controller:3.2.0"))
+	require.Equal(t, "3.2.0", cp.Status.Version)
+
+	t.Log("verifying that the version is cleared when it can't be determined from the image")
+	ensureVersionStatus(cp, newDeployment("kong/kubernetes-ingress-controller"))
+	require.Empty(t, cp.Status.Version)
+}
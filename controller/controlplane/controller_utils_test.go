@@ -266,6 +266,10 @@ func TestSetControlPlaneDefaults(t *testing.T) {
 											Name:  "CONTROLLER_ANONYMOUS_REPORTS",
 											Value: "true",
 										},
+										{
+											Name:  "CONTROLLER_ELECTION_NAMESPACE",
+											Value: "test-ns",
+										},
 									},
 								},
 							},
@@ -434,6 +438,10 @@ func TestSetControlPlaneDefaults(t *testing.T) {
 											Name:  "CONTROLLER_ADMISSION_WEBHOOK_LISTEN",
 											Value: consts.ControlPlaneAdmissionWebhookEnvVarValue,
 										},
+										{
+											Name:  "CONTROLLER_ELECTION_NAMESPACE",
+											Value: "test-ns",
+										},
 									},
 								},
 							},
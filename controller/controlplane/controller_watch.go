@@ -56,6 +56,10 @@ func (r *Reconciler) clusterRoleBindingHasControlPlaneOwner(obj client.Object) b
 	return r.ClusterScopedObjHasControlPlaneOwner(ctx, clusterRoleBinding)
 }
 
+func (r *Reconciler) isClusterCASecret(obj client.Object) bool {
+	return obj.GetNamespace() == r.ClusterCASecretNamespace && obj.GetName() == r.ClusterCASecretName
+}
+
 func (r *Reconciler) validatingWebhookConfigurationHasControlPlaneOwner(obj client.Object) bool {
 	ctx := context.Background()
 
@@ -219,6 +223,25 @@ func (r *Reconciler) getControlPlanesFromDataPlaneDeployment(ctx context.Context
 	return r.getControlPlanesFromDataPlane(ctx, dataPlane)
 }
 
+func (r *Reconciler) getControlPlanesForClusterCASecret(ctx context.Context, _ client.Object) (recs []reconcile.Request) {
+	controlPlaneList := &operatorv1beta1.ControlPlaneList{}
+	if err := r.Client.List(ctx, controlPlaneList); err != nil {
+		log.FromContext(ctx).Error(err, "failed to map ControlPlanes on cluster CA Secret")
+		return nil
+	}
+
+	recs = make([]reconcile.Request, 0, len(controlPlaneList.Items))
+	for _, cp := range controlPlaneList.Items {
+		recs = append(recs, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: cp.Namespace,
+				Name:      cp.Name,
+			},
+		})
+	}
+	return recs
+}
+
 func (r *Reconciler) getControlPlanesFromDataPlane(ctx context.Context, obj client.Object) (recs []reconcile.Request) {
 	dataplane, ok := obj.(*operatorv1beta1.DataPlane)
 	if !ok {
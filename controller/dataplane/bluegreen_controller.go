@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
@@ -16,6 +17,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -26,6 +28,7 @@ import (
 	"github.com/kong/gateway-operator/controller/pkg/dataplane"
 	"github.com/kong/gateway-operator/controller/pkg/log"
 	"github.com/kong/gateway-operator/controller/pkg/op"
+	"github.com/kong/gateway-operator/controller/pkg/secrets"
 	"github.com/kong/gateway-operator/pkg/consts"
 	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
 	k8sresources "github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
@@ -53,6 +56,9 @@ type BlueGreenReconciler struct {
 	// certificate data which will be used when generating certificates for DataPlane's
 	// Deployment.
 	ClusterCASecretNamespace string
+	// ClusterCertificateRenewalWindow is the period before the expiration of
+	// the DataPlane's certificates during which they get renewed.
+	ClusterCertificateRenewalWindow time.Duration
 
 	// DevelopmentMode indicates if the controller should run in development mode,
 	// which causes it to e.g. perform less validations.
@@ -64,6 +70,14 @@ type BlueGreenReconciler struct {
 	ContextInjector ctxinjector.CtxInjector
 
 	DefaultImage string
+
+	eventRecorder record.EventRecorder
+
+	// promotionAnalysisProber probes the preview pods during the promotion analysis.
+	promotionAnalysisProber previewPodProber
+	// promotionAnalysisBaselines stores the baseline metrics of the preview pods
+	// during the promotion analysis.
+	promotionAnalysisBaselines *promotionAnalysisBaselines
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -73,7 +87,14 @@ func (r *BlueGreenReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return fmt.Errorf("incorrect delegate controller type: %T", r.DataPlaneController)
 	}
 	delegate.eventRecorder = mgr.GetEventRecorderFor("dataplane")
-	return DataPlaneWatchBuilder(mgr).
+	r.eventRecorder = delegate.eventRecorder
+	r.promotionAnalysisProber = newHTTPPreviewPodProber()
+	r.promotionAnalysisBaselines = newPromotionAnalysisBaselines()
+	clusterCASecretNN := types.NamespacedName{
+		Namespace: r.ClusterCASecretNamespace,
+		Name:      r.ClusterCASecretName,
+	}
+	return DataPlaneWatchBuilder(mgr, clusterCASecretNN, delegate.KongPluginInstallationControllerEnabled).
 		Complete(r)
 }
 
@@ -88,6 +109,9 @@ func (r *BlueGreenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	var dataplane operatorv1beta1.DataPlane
 	if err := r.Client.Get(ctx, req.NamespacedName, &dataplane); err != nil {
 		if k8serrors.IsNotFound(err) {
+			secrets.DeleteCertificateExpirationMetrics(&operatorv1beta1.DataPlane{
+				ObjectMeta: metav1.ObjectMeta{Namespace: req.Namespace, Name: req.Name},
+			})
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, err
@@ -95,15 +119,32 @@ func (r *BlueGreenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	logger := log.GetLogger(ctx, "dataplaneBlueGreen", r.DevelopmentMode)
 
-	// Blue Green rollout strategy is not enabled, delegate to DataPlane controller.
-	if dataplane.Spec.Deployment.Rollout == nil || dataplane.Spec.Deployment.Rollout.Strategy.BlueGreen == nil {
+	// Neither Blue Green nor Canary rollout strategy is enabled, delegate to DataPlane controller.
+	if rollout := dataplane.Spec.Deployment.Rollout; rollout == nil ||
+		(rollout.Strategy.BlueGreen == nil && rollout.Strategy.Canary == nil) {
 		if err := r.prunePreviewSubresources(ctx, &dataplane); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed pruning preview DataPlane subresources: %w", err)
 		}
-		log.Trace(logger, "no Rollout with BlueGreen strategy specified, delegating to DataPlaneReconciler", req)
+		if err := r.ensurePreviewRoutingResources(ctx, logger, &dataplane); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed ensuring preview routing DataPlane subresources: %w", err)
+		}
+		log.Trace(logger, "no Rollout with BlueGreen or Canary strategy specified, delegating to DataPlaneReconciler", req)
 		return r.DataPlaneController.Reconcile(ctx, req)
 	}
 
+	// Preview routing resources have to be in place before the Deployments,
+	// live and preview, are built.
+	if err := r.ensurePreviewRoutingResources(ctx, logger, &dataplane); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed ensuring preview routing DataPlane subresources: %w", err)
+	}
+
+	// Rolling back updates the DataPlane's spec which triggers reconciliation.
+	if rolledBack, err := r.ensureRollback(ctx, logger, &dataplane); err != nil {
+		return ctrl.Result{}, err
+	} else if rolledBack {
+		return ctrl.Result{}, nil
+	}
+
 	if shouldDelegateToDataPlaneController(&dataplane, logger) {
 		return r.DataPlaneController.Reconcile(ctx, req)
 	}
@@ -137,6 +178,39 @@ func (r *BlueGreenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}
 
+	if err := r.ensureRolloutStartedStatus(ctx, logger, &dataplane); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The database has to be migrated for the preview image before the preview
+	// Deployment is rolled out with it. The migrations are finished once
+	// the promoted image runs in all the DataPlane's pods.
+	migrationsRes, migrated, err := ensureDataPlaneDatabaseMigrations(ctx, r.Client, logger, &dataplane, r.DefaultImage, r.DevelopmentMode)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !migrated {
+		log.Debug(logger, "waiting for DataPlane database migrations before deploying preview resources", dataplane)
+		return migrationsRes, r.ensureRolledOutCondition(ctx, logger, &dataplane, metav1.ConditionFalse,
+			consts.DataPlaneConditionReasonRolloutProgressing, "Waiting for database migrations")
+	}
+
+	// The rollout which timed out is not retried until the DataPlane's spec changes.
+	if isRolloutTimedOut(&dataplane) {
+		log.Trace(logger, "rollout timed out for the current generation, not retrying", dataplane)
+		return migrationsRes, r.teardownPreviewSubresources(ctx, logger, &dataplane)
+	}
+
+	// With the DeleteOnPromotionRecreateOnRollout resource plan the preview
+	// resources are removed once the current generation has been rolled out
+	// and recreated when the DataPlane's spec changes.
+	if shouldDeletePreviewSubresources(&dataplane) {
+		if err := r.teardownPreviewSubresources(ctx, logger, &dataplane); err != nil {
+			return ctrl.Result{}, err
+		}
+		return migrationsRes, r.ensureRolledOutCondition(ctx, logger, &dataplane, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutWaitingForChange, "")
+	}
+
 	// DataPlane is ready and we can proceed with deploying preview resources.
 
 	// Ensure "preview" Admin API service.
@@ -164,7 +238,13 @@ func (r *BlueGreenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			Namespace: dataplaneAdminService.Namespace,
 			Name:      dataplaneAdminService.Name,
 		},
+		secrets.WithRenewalWindow(r.ClusterCertificateRenewalWindow),
+		secrets.WithEventRecorder(r.eventRecorder),
 	)
+	if errors.Is(err, secrets.ErrCertificateNotReady) {
+		log.Debug(logger, "waiting for mTLS certificate to be issued", dataplane)
+		return ctrl.Result{RequeueAfter: secrets.CertificateNotReadyRequeueAfter}, nil
+	}
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -191,13 +271,26 @@ func (r *BlueGreenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	// Ensure "preview" Deployment.
 	deployment, res, err := r.ensureDeploymentForDataPlane(ctx, logger, &dataplane, certSecret)
-	if err != nil {
+	if kpiErr := (kongPluginInstallationNotReadyError{}); errors.As(err, &kpiErr) {
+		// The DataPlane is enqueued again when the KongPluginInstallation's status changes.
+		log.Debug(logger, "waiting for KongPluginInstallation to become ready", dataplane,
+			"kongplugininstallation", kpiErr.kongPluginInstallation.String())
+		return ctrl.Result{}, r.ensureRolledOutCondition(ctx, logger, &dataplane, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutProgressing, kpiErr.Error())
+	} else if err != nil {
 		cErr := r.ensureRolledOutCondition(ctx, logger, &dataplane, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutFailed, "failed to ensure preview Deployment")
 		return ctrl.Result{}, fmt.Errorf("failed to ensure Deployment for DataPlane: %w", errors.Join(cErr, err))
 	} else if res == op.Created || res == op.Updated {
 		return ctrl.Result{}, nil // dataplane deployment creation/update will trigger reconciliation
 	} else if replicas := deployment.Spec.Replicas; replicas != nil && *replicas == 0 {
-		return ctrl.Result{}, r.ensureRolledOutCondition(ctx, logger, &dataplane, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutWaitingForChange, "")
+		return migrationsRes, r.ensureRolledOutCondition(ctx, logger, &dataplane, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutWaitingForChange, "")
+	}
+
+	// Ensure the PodDisruptionBudget covers the "preview" pods as well.
+	res, _, err = ensurePodDisruptionBudgetForDataPlane(ctx, r.Client, logger, &dataplane)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed ensuring PodDisruptionBudget for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	} else if res != op.Noop {
+		return ctrl.Result{}, nil // PodDisruptionBudget creation/update will trigger reconciliation
 	}
 
 	// TODO: check if the preview service is available.
@@ -205,10 +298,32 @@ func (r *BlueGreenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		deployment.Status.AvailableReplicas != deployment.Status.Replicas ||
 		deployment.Status.ReadyReplicas != deployment.Status.Replicas {
 		log.Trace(logger, "preview deployment for DataPlane not ready yet", dataplane)
+		remaining, ok := rolloutTimeoutRemaining(&dataplane)
+		if ok && remaining <= 0 {
+			log.Info(logger, "rollout timed out, removing preview resources", dataplane)
+			if err := r.ensureRolledOutCondition(ctx, logger, &dataplane, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutTimedOut, consts.DataPlaneConditionMessageRolledOutRolloutTimedOut); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, r.teardownPreviewSubresources(ctx, logger, &dataplane)
+		}
 		err := r.ensureRolledOutCondition(ctx, logger, &dataplane, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutProgressing, consts.DataPlaneConditionMessageRolledOutPreviewDeploymentNotYetReady)
+		if ok {
+			return ctrl.Result{RequeueAfter: remaining}, err
+		}
 		return ctrl.Result{}, err
 	}
 
+	// Progress the Canary rollout through its steps before the promotion.
+	if dataplane.Spec.Deployment.Rollout.Strategy.Canary != nil {
+		done, res, err := r.ensureCanaryStep(ctx, logger, &dataplane, deployment)
+		if err != nil {
+			cErr := r.ensureRolledOutCondition(ctx, logger, &dataplane, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutFailed, "failed to progress Canary rollout")
+			return ctrl.Result{}, fmt.Errorf("failed progressing Canary rollout for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, errors.Join(cErr, err))
+		} else if !done {
+			return res, nil
+		}
+	}
+
 	// TODO: Perform promotion condition checks to verify we can proceed
 	// Ref: https://github.com/Kong/gateway-operator/issues/170
 
@@ -222,6 +337,18 @@ func (r *BlueGreenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
+	// AutomaticPromotion is gated by the analysis of the preview pods.
+	if bg := dataplane.Spec.Deployment.Rollout.Strategy.BlueGreen; bg != nil &&
+		bg.Promotion.Strategy == operatorv1beta1.AutomaticPromotion && bg.Promotion.Analysis != nil {
+		passed, res, err := r.ensurePromotionAnalysis(ctx, logger, &dataplane)
+		if err != nil {
+			cErr := r.ensureRolledOutCondition(ctx, logger, &dataplane, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutFailed, "failed to analyze preview pods")
+			return ctrl.Result{}, fmt.Errorf("failed analyzing preview pods of DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, errors.Join(cErr, err))
+		} else if !passed {
+			return res, nil
+		}
+	}
+
 	// If we've failed to promote previously, don't set the RolledOut reason to
 	// PromotionInProgress as the error can reoccur and the status can start flapping.
 	c, ok = k8sutils.GetCondition(consts.DataPlaneConditionTypeRolledOut, dataplane.Status.RolloutStatus)
@@ -245,10 +372,14 @@ func (r *BlueGreenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		"app":                        dataplane.Name,
 		consts.OperatorLabelSelector: dataplane.Status.RolloutStatus.Deployment.Selector,
 	}
-	for _, serviceType := range []consts.ServiceType{
+	liveServiceTypes := []consts.ServiceType{
 		consts.DataPlaneIngressServiceLabelValue,
 		consts.DataPlaneAdminServiceLabelValue,
-	} {
+	}
+	if dataplane.Spec.Network.Services != nil && dataplane.Spec.Network.Services.PrivateIngress != nil {
+		liveServiceTypes = append(liveServiceTypes, consts.DataPlanePrivateIngressServiceLabelValue)
+	}
+	for _, serviceType := range liveServiceTypes {
 		if ok, err := r.waitForLiveServiceSelectorsPropagation(ctx,
 			&dataplane,
 			serviceType,
@@ -264,6 +395,13 @@ func (r *BlueGreenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	{
 		// Promotion is effectively done (live services point to the preview).
 
+		// Record the promoted revision in the rollout history before the preview
+		// deployment becomes indistinguishable from the previous live one.
+		history, err := r.rolloutHistoryForPromotion(ctx, &dataplane, deployment)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
 		// Let's label the preview deployment as live so that it's easily retrievable.
 		previewDeploymentSelector := dataplane.Status.RolloutStatus.Deployment.Selector
 		if updated, err := r.ensurePreviewDeploymentLabeledLive(ctx, logger, &dataplane, previewDeploymentSelector); err != nil {
@@ -277,6 +415,9 @@ func (r *BlueGreenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		// reconciliation to create new preview.
 		old := dataplane.DeepCopy()
 		dataplane.Status.RolloutStatus.Deployment.Selector = ""
+		dataplane.Status.RolloutStatus.Canary = nil
+		dataplane.Status.RolloutStatus.Analysis = nil
+		dataplane.Status.RolloutStatus.History = history
 		if err := r.Client.Status().Patch(ctx, &dataplane, client.MergeFrom(old)); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed updating DataPlane's RolloutStatus: %w", err)
 		}
@@ -298,7 +439,10 @@ func (r *BlueGreenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 
 	log.Debug(logger, "BlueGreen reconciliation complete for DataPlane resource", dataplane)
-	return ctrl.Result{}, nil
+	if dataplane.Spec.Network.ClusterCertificateOptions == nil {
+		return secrets.RequeueForCertificateRenewal(migrationsRes, certSecret, r.ClusterCertificateRenewalWindow), nil
+	}
+	return migrationsRes, nil
 }
 
 // ensureDataPlaneLiveReadyStatus ensures that the DataPlane has the Ready status
@@ -339,6 +483,9 @@ func shouldDelegateToDataPlaneController(
 	// - any other reason for rollout status condition "RolledOut" should not trigger
 	//   the delegation because that either means that we're waiting for the promotion,
 	//   we're in the process of promotion or the promotion failed.
+	//   A rollout which timed out isn't delegated either as the live resources
+	//   would be updated with the spec that failed to roll out. The live resources
+	//   changed by a Canary rollout are restored when the preview ones are torn down.
 	cReady, okReady := k8sutils.GetCondition(consts.ReadyType, dataplane)
 	cRolledOut, okRolledOut := k8sutils.GetCondition(consts.DataPlaneConditionTypeRolledOut, dataplane.Status.RolloutStatus)
 	if okReady && okRolledOut &&
@@ -369,12 +516,37 @@ func shouldDelegateToDataPlaneController(
 
 // prunePreviewSubresources is used to prune DataPlane's preview subresources
 // when they are not necessary anymore, e.g. when rollout strategy is unset.
+// Contrary to the DeleteOnPromotionRecreateOnRollout resource plan, which only
+// deletes the preview subresources, it also removes the DataPlane's rollout status.
 func (r *BlueGreenReconciler) prunePreviewSubresources(
 	ctx context.Context,
 	dataplane *operatorv1beta1.DataPlane,
 ) error {
 	logger := log.GetLogger(ctx, "dataplaneBlueGreen", r.DevelopmentMode)
 
+	if err := r.deletePreviewSubresources(ctx, logger, dataplane); err != nil {
+		return err
+	}
+
+	if dataplane.Status.RolloutStatus != nil {
+		old := dataplane.DeepCopy()
+		dataplane.Status.RolloutStatus = nil
+		if err := r.Client.Status().Patch(ctx, dataplane, client.MergeFrom(old)); err != nil {
+			return fmt.Errorf("failed patching DataPlane %s/%s to remove rollout status: %w",
+				dataplane.Namespace, dataplane.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// deletePreviewSubresources deletes DataPlane's preview Deployments, Services
+// and the Services' certificate Secrets.
+func (r *BlueGreenReconciler) deletePreviewSubresources(
+	ctx context.Context,
+	logger logr.Logger,
+	dataplane *operatorv1beta1.DataPlane,
+) error {
 	deployments, err := k8sutils.ListDeploymentsForOwner(
 		ctx,
 		r.Client,
@@ -436,18 +608,31 @@ func (r *BlueGreenReconciler) prunePreviewSubresources(
 		}
 	}
 
-	if dataplane.Status.RolloutStatus != nil {
-		old := dataplane.DeepCopy()
-		dataplane.Status.RolloutStatus = nil
-		if err := r.Client.Status().Patch(ctx, dataplane, client.MergeFrom(old)); err != nil {
-			return fmt.Errorf("failed patching DataPlane %s/%s to remove rollout status: %w",
-				dataplane.Namespace, dataplane.Name, err)
-		}
-	}
-
 	return nil
 }
 
+// shouldDeletePreviewSubresources returns true when the DataPlane uses the Blue
+// Green rollout strategy with the DeleteOnPromotionRecreateOnRollout resource
+// plan and its current generation has already been rolled out, meaning that
+// there's nothing to preview until its spec changes.
+func shouldDeletePreviewSubresources(dataplane *operatorv1beta1.DataPlane) bool {
+	blueGreen := dataplane.Spec.Deployment.Rollout.Strategy.BlueGreen
+	if blueGreen == nil ||
+		blueGreen.Resources.Plan.Deployment != operatorv1beta1.RolloutResourcePlanDeploymentDeleteOnPromotionRecreateOnRollout {
+		return false
+	}
+	return isCurrentGenerationRolledOut(dataplane)
+}
+
+// isCurrentGenerationRolledOut returns true when the DataPlane's Ready and
+// RolledOut conditions were observed for the same generation, i.e. when the
+// live resources already run the DataPlane's current spec.
+func isCurrentGenerationRolledOut(dataplane *operatorv1beta1.DataPlane) bool {
+	cReady, okReady := k8sutils.GetCondition(consts.ReadyType, dataplane)
+	cRolledOut, okRolledOut := k8sutils.GetCondition(consts.DataPlaneConditionTypeRolledOut, dataplane.Status.RolloutStatus)
+	return okReady && okRolledOut && cReady.ObservedGeneration == cRolledOut.ObservedGeneration
+}
+
 func removeObjectSliceWithDataPlaneOwnedFinalizer[
 	T DataPlaneOwnedResource, PT DataPlaneOwnedResourcePointer[T, PT],
 ](
@@ -487,22 +672,19 @@ func (r *BlueGreenReconciler) ensureDeploymentForDataPlane(
 ) (*appsv1.Deployment, op.Result, error) {
 	deploymentOpts := []k8sresources.DeploymentOpt{
 		labelSelectorFromDataPlaneRolloutStatusSelectorDeploymentOpt(dataplane),
+		secrets.CertificateRenewalDeploymentOpt(certSecret),
 	}
 
-	// If we're running the exact same Generation as "live" version is then:
-	// - the rollout resource plan is set to ScaleDownOnPromotionScaleUpOnRollout
-	//   then  scale down the Deployment to 0 replicas.
-	cReady, okReady := k8sutils.GetCondition(consts.ReadyType, dataplane)
-	cRolledOut, okRolledOut := k8sutils.GetCondition(consts.DataPlaneConditionTypeRolledOut, dataplane.Status.RolloutStatus)
-	if okReady && okRolledOut && cReady.ObservedGeneration == cRolledOut.ObservedGeneration {
-		dPlan := dataplane.Spec.Deployment.Rollout.Strategy.BlueGreen.Resources.Plan.Deployment
-		if dPlan == operatorv1beta1.RolloutResourcePlanDeploymentScaleDownOnPromotionScaleUpOnRollout {
-			deploymentOpts = append(deploymentOpts, func(d *appsv1.Deployment) {
-				d.Spec.Replicas = lo.ToPtr(int32(0))
-			})
-		}
-		// TODO: implemented DeleteOnPromotionRecreateOnRollout
-		// Ref: https://github.com/Kong/gateway-operator/issues/163
+	// If we're running the exact same Generation as "live" version is then
+	// scale down the Deployment to 0 replicas. Blue Green rollouts with
+	// the DeleteOnPromotionRecreateOnRollout resource plan don't get here
+	// as their preview Deployment is deleted instead.
+	if isCurrentGenerationRolledOut(dataplane) {
+		deploymentOpts = append(deploymentOpts, func(d *appsv1.Deployment) {
+			d.Spec.Replicas = lo.ToPtr(int32(0))
+		})
+	} else if dataplane.Spec.Deployment.Rollout.Strategy.Canary != nil {
+		deploymentOpts = append(deploymentOpts, canaryPreviewReplicasDeploymentOpt(dataplane))
 	}
 	deploymentLabels := client.MatchingLabels{
 		consts.DataPlaneDeploymentStateLabel: consts.DataPlaneStateLabelValuePreview,
@@ -727,6 +909,10 @@ func rolloutStatusChanged(old, updated *operatorv1beta1.DataPlane) bool {
 // canProceedWithPromotion verifies whether a DataPlane preview resources can be promoted. It assumes that all the
 // preview resources are ready.
 func canProceedWithPromotion(dataplane operatorv1beta1.DataPlane) (bool, error) {
+	// Canary rollouts are promoted once all their steps are completed.
+	if dataplane.Spec.Deployment.Rollout.Strategy.Canary != nil {
+		return true, nil
+	}
 	promotionStrategy := dataplane.Spec.Deployment.Rollout.Strategy.BlueGreen.Promotion.Strategy
 	switch promotionStrategy {
 	case operatorv1beta1.BreakBeforePromotion:
@@ -735,7 +921,8 @@ func canProceedWithPromotion(dataplane operatorv1beta1.DataPlane) (bool, error)
 		return dataplane.Annotations[operatorv1beta1.DataPlanePromoteWhenReadyAnnotationKey] ==
 			operatorv1beta1.DataPlanePromoteWhenReadyAnnotationTrue, nil
 	case operatorv1beta1.AutomaticPromotion:
-		// If the promotion strategy is AutomaticPromotion, we can proceed with promotion straight away.
+		// If the promotion strategy is AutomaticPromotion, we can proceed with promotion
+		// once the preview pods pass the analysis.
 		return true, nil
 	default:
 		return false, fmt.Errorf("unknown promotion strategy %q", promotionStrategy)
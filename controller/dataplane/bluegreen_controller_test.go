@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
@@ -608,3 +609,122 @@ func TestEnsurePreviewIngressService(t *testing.T) {
 		})
 	}
 }
+
+func TestShouldDeletePreviewSubresources(t *testing.T) {
+	withConditions := func(dp *operatorv1beta1.DataPlane, readyGeneration, rolledOutGeneration int64) *operatorv1beta1.DataPlane {
+		dp.Status.Conditions = []metav1.Condition{
+			k8sutils.NewConditionWithGeneration(consts.ReadyType, metav1.ConditionTrue, consts.ResourceReadyReason, "", readyGeneration),
+		}
+		dp.Status.RolloutStatus = &operatorv1beta1.DataPlaneRolloutStatus{
+			Conditions: []metav1.Condition{
+				k8sutils.NewConditionWithGeneration(consts.DataPlaneConditionTypeRolledOut, metav1.ConditionTrue, consts.DataPlaneConditionReasonRolloutPromotionDone, "", rolledOutGeneration),
+			},
+		}
+		return dp
+	}
+
+	testCases := []struct {
+		name      string
+		dataplane *operatorv1beta1.DataPlane
+		expected  bool
+	}{
+		{
+			name: "DeleteOnPromotionRecreateOnRollout plan, current generation rolled out",
+			dataplane: withConditions(builder.NewDataPlaneBuilder().
+				WithDeploymentResourcePlan(operatorv1beta1.RolloutResourcePlanDeploymentDeleteOnPromotionRecreateOnRollout).
+				Build(), 2, 2),
+			expected: true,
+		},
+		{
+			name: "DeleteOnPromotionRecreateOnRollout plan, rollout in progress",
+			dataplane: withConditions(builder.NewDataPlaneBuilder().
+				WithDeploymentResourcePlan(operatorv1beta1.RolloutResourcePlanDeploymentDeleteOnPromotionRecreateOnRollout).
+				Build(), 2, 3),
+			expected: false,
+		},
+		{
+			name: "DeleteOnPromotionRecreateOnRollout plan, no conditions",
+			dataplane: builder.NewDataPlaneBuilder().
+				WithDeploymentResourcePlan(operatorv1beta1.RolloutResourcePlanDeploymentDeleteOnPromotionRecreateOnRollout).
+				Build(),
+			expected: false,
+		},
+		{
+			name: "ScaleDownOnPromotionScaleUpOnRollout plan, current generation rolled out",
+			dataplane: withConditions(builder.NewDataPlaneBuilder().
+				WithDeploymentResourcePlan(operatorv1beta1.RolloutResourcePlanDeploymentScaleDownOnPromotionScaleUpOnRollout).
+				Build(), 2, 2),
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, shouldDeletePreviewSubresources(tc.dataplane))
+		})
+	}
+}
+
+func TestDeletePreviewSubresources(t *testing.T) {
+	ctx := context.Background()
+
+	dp := builder.NewDataPlaneBuilder().
+		WithObjectMeta(metav1.ObjectMeta{
+			Name:      "dp",
+			Namespace: "default",
+			UID:       types.UID(uuid.NewString()),
+		}).
+		WithDeploymentResourcePlan(operatorv1beta1.RolloutResourcePlanDeploymentDeleteOnPromotionRecreateOnRollout).
+		Build()
+	dp.TypeMeta = metav1.TypeMeta{
+		APIVersion: operatorv1beta1.SchemeGroupVersion.String(),
+		Kind:       "DataPlane",
+	}
+
+	ownedObject := func(obj client.Object, name string, labels map[string]string) client.Object {
+		obj.SetName(name)
+		obj.SetNamespace(dp.Namespace)
+		obj.SetLabels(labels)
+		obj.SetFinalizers([]string{consts.DataPlaneOwnedWaitForOwnerFinalizer})
+		k8sutils.SetOwnerForObject(obj, dp)
+		return obj
+	}
+	previewDeployment := ownedObject(&appsv1.Deployment{}, "dp-preview", map[string]string{
+		"app":                                dp.Name,
+		consts.DataPlaneDeploymentStateLabel: consts.DataPlaneStateLabelValuePreview,
+	})
+	liveDeployment := ownedObject(&appsv1.Deployment{}, "dp-live", map[string]string{
+		"app":                                dp.Name,
+		consts.DataPlaneDeploymentStateLabel: consts.DataPlaneStateLabelValueLive,
+	})
+	previewService := ownedObject(&corev1.Service{}, "dp-admin-preview", map[string]string{
+		"app":                             dp.Name,
+		consts.DataPlaneServiceStateLabel: consts.DataPlaneStateLabelValuePreview,
+	})
+	liveService := ownedObject(&corev1.Service{}, "dp-admin-live", map[string]string{
+		"app":                             dp.Name,
+		consts.DataPlaneServiceStateLabel: consts.DataPlaneStateLabelValueLive,
+	})
+	previewServiceSecret := ownedObject(&corev1.Secret{}, "dp-admin-preview-cert", map[string]string{
+		consts.ServiceSecretLabel: previewService.GetName(),
+	})
+
+	fakeClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(dp, previewDeployment, liveDeployment, previewService, liveService, previewServiceSecret).
+		Build()
+	r := BlueGreenReconciler{
+		Client: fakeClient,
+	}
+
+	require.NoError(t, r.deletePreviewSubresources(ctx, logr.Discard(), dp))
+
+	for _, obj := range []client.Object{previewDeployment, previewService, previewServiceSecret} {
+		err := fakeClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		require.True(t, k8serrors.IsNotFound(err), "preview object %s should be deleted", obj.GetName())
+	}
+	for _, obj := range []client.Object{liveDeployment, liveService} {
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(obj), obj), "live object %s should be kept", obj.GetName())
+	}
+}
@@ -0,0 +1,292 @@
+package dataplane
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/pkg/log"
+	"github.com/kong/gateway-operator/pkg/consts"
+)
+
+// -----------------------------------------------------------------------------
+// DataPlaneBlueGreenReconciler - Canary rollout
+// -----------------------------------------------------------------------------
+
+// ensureCanaryStep progresses the Canary rollout of the DataPlane through the
+// steps configured in its Canary rollout strategy.
+// For each step the live and preview Deployments are scaled according to the
+// step's weight and the live ingress Service selects the pods of both of them.
+// Once the Deployments are ready, the rollout stays on the step for the configured
+// pause or until it's approved with the promote-when-ready annotation.
+// It returns true when all the steps are completed and the preview resources
+// can be promoted.
+func (r *BlueGreenReconciler) ensureCanaryStep(
+	ctx context.Context,
+	logger logr.Logger,
+	dataplane *operatorv1beta1.DataPlane,
+	previewDeployment *appsv1.Deployment,
+) (done bool, res ctrl.Result, err error) {
+	steps := dataplane.Spec.Deployment.Rollout.Strategy.Canary.Steps
+
+	status := dataplane.Status.RolloutStatus.Canary
+	if status == nil || status.ObservedGeneration != dataplane.Generation {
+		old := dataplane.DeepCopy()
+		dataplane.Status.RolloutStatus.Canary = &operatorv1beta1.DataPlaneRolloutStatusCanary{
+			Step:               0,
+			Weight:             canaryWeight(dataplane, 0),
+			ObservedGeneration: dataplane.Generation,
+		}
+		if _, err := r.patchRolloutStatus(ctx, logger, old, dataplane); err != nil {
+			return false, ctrl.Result{}, fmt.Errorf("failed initializing Canary rollout status: %w", err)
+		}
+		return false, ctrl.Result{}, nil
+	}
+	if int(status.Step) >= len(steps) {
+		return true, ctrl.Result{}, nil
+	}
+	step := steps[status.Step]
+
+	previewReplicas, liveReplicas := canaryReplicas(dataplaneReplicas(dataplane), step.Weight)
+	liveReady, err := r.ensureLiveDeploymentsReplicas(ctx, dataplane, liveReplicas)
+	if err != nil {
+		return false, ctrl.Result{}, err
+	}
+	if updated, err := r.ensureLiveIngressServiceSelectsCanary(ctx, dataplane); err != nil {
+		return false, ctrl.Result{}, err
+	} else if updated {
+		log.Debug(logger, "live ingress Service selects preview pods", dataplane)
+		return false, ctrl.Result{}, nil
+	}
+	if !liveReady || !deploymentHasReadyReplicas(previewDeployment, previewReplicas) {
+		message := fmt.Sprintf("Canary step %d (weight %d%%): scaling Deployments", status.Step, step.Weight)
+		return false, ctrl.Result{}, r.ensureRolledOutCondition(ctx, logger, dataplane, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutProgressing, message)
+	}
+
+	if status.StepReachedAt == nil {
+		old := dataplane.DeepCopy()
+		dataplane.Status.RolloutStatus.Canary.StepReachedAt = lo.ToPtr(metav1.Now())
+		if _, err := r.patchRolloutStatus(ctx, logger, old, dataplane); err != nil {
+			return false, ctrl.Result{}, fmt.Errorf("failed updating Canary rollout status: %w", err)
+		}
+		return false, ctrl.Result{}, nil
+	}
+
+	if step.Pause != nil {
+		if remaining := time.Until(status.StepReachedAt.Add(step.Pause.Duration)); remaining > 0 {
+			message := fmt.Sprintf("Canary step %d (weight %d%%): paused", status.Step, step.Weight)
+			if err := r.ensureRolledOutCondition(ctx, logger, dataplane, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutProgressing, message); err != nil {
+				return false, ctrl.Result{}, err
+			}
+			return false, ctrl.Result{RequeueAfter: remaining}, nil
+		}
+	} else {
+		if dataplane.Annotations[operatorv1beta1.DataPlanePromoteWhenReadyAnnotationKey] != operatorv1beta1.DataPlanePromoteWhenReadyAnnotationTrue {
+			message := fmt.Sprintf("Canary step %d (weight %d%%): awaiting approval", status.Step, step.Weight)
+			return false, ctrl.Result{}, r.ensureRolledOutCondition(ctx, logger, dataplane, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutAwaitingPromotion, message)
+		}
+		if err := r.resetPromoteWhenReadyAnnotation(ctx, dataplane); err != nil {
+			return false, ctrl.Result{}, err
+		}
+	}
+
+	// Move on to the next step. Once the last step is completed, the step
+	// is set to the number of steps which indicates that the preview resources
+	// can be promoted.
+	old := dataplane.DeepCopy()
+	next := status.Step + 1
+	dataplane.Status.RolloutStatus.Canary.Step = next
+	dataplane.Status.RolloutStatus.Canary.Weight = canaryWeight(dataplane, next)
+	dataplane.Status.RolloutStatus.Canary.StepReachedAt = nil
+	if _, err := r.patchRolloutStatus(ctx, logger, old, dataplane); err != nil {
+		return false, ctrl.Result{}, fmt.Errorf("failed updating Canary rollout status: %w", err)
+	}
+	log.Debug(logger, "Canary step completed", dataplane, "step", status.Step)
+	return false, ctrl.Result{}, nil
+}
+
+// ensureLiveDeploymentsReplicas scales the live Deployments of the DataPlane
+// to the provided number of replicas. It returns true if they are scaled and ready.
+func (r *BlueGreenReconciler) ensureLiveDeploymentsReplicas(
+	ctx context.Context,
+	dataplane *operatorv1beta1.DataPlane,
+	replicas int32,
+) (bool, error) {
+	deployments, err := listDataPlaneLiveDeployments(ctx, r.Client, dataplane)
+	if err != nil {
+		return false, fmt.Errorf("failed listing live Deployments for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+
+	ready := true
+	for _, d := range deployments {
+		d := d
+		if lo.FromPtr(d.Spec.Replicas) != replicas {
+			old := d.DeepCopy()
+			d.Spec.Replicas = lo.ToPtr(replicas)
+			if err := r.Client.Patch(ctx, &d, client.MergeFrom(old)); err != nil {
+				return false, fmt.Errorf("failed scaling live Deployment %s/%s: %w", d.Namespace, d.Name, err)
+			}
+			ready = false
+			continue
+		}
+		if !deploymentHasReadyReplicas(&d, replicas) {
+			ready = false
+		}
+	}
+	return ready, nil
+}
+
+// ensureLiveIngressServiceSelectsCanary ensures that the live ingress Services,
+// including the private one, select the pods of both the live and the preview
+// Deployments so that the traffic is split between them according to their replicas.
+func (r *BlueGreenReconciler) ensureLiveIngressServiceSelectsCanary(
+	ctx context.Context,
+	dataplane *operatorv1beta1.DataPlane,
+) (updated bool, err error) {
+	services, err := listDataPlaneLiveServices(ctx, r.Client, dataplane)
+	if err != nil {
+		return false, fmt.Errorf("failed listing live ingress Services for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+	privateServices, err := listDataPlaneLivePrivateServices(ctx, r.Client, dataplane)
+	if err != nil {
+		return false, fmt.Errorf("failed listing live private ingress Services for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+	services = append(services, privateServices...)
+
+	for _, svc := range services {
+		svc := svc
+		if _, ok := svc.Spec.Selector[consts.OperatorLabelSelector]; !ok {
+			continue
+		}
+		old := svc.DeepCopy()
+		delete(svc.Spec.Selector, consts.OperatorLabelSelector)
+		if err := r.Client.Patch(ctx, &svc, client.MergeFrom(old)); err != nil {
+			return false, fmt.Errorf("failed updating selector of live ingress Service %s/%s: %w", svc.Namespace, svc.Name, err)
+		}
+		updated = true
+	}
+	return updated, nil
+}
+
+// restoreLiveResourcesAfterCanary reverts the changes made to the live resources
+// by an unfinished Canary rollout, e.g. when it timed out: the live Deployments
+// are scaled back to the DataPlane's replicas, the live ingress Services select
+// only the live pods again and the Canary rollout status is removed.
+func (r *BlueGreenReconciler) restoreLiveResourcesAfterCanary(
+	ctx context.Context,
+	logger logr.Logger,
+	dataplane *operatorv1beta1.DataPlane,
+) error {
+	if dataplane.Status.RolloutStatus == nil || dataplane.Status.RolloutStatus.Canary == nil {
+		return nil
+	}
+
+	if _, err := r.ensureLiveDeploymentsReplicas(ctx, dataplane, dataplaneReplicas(dataplane)); err != nil {
+		return err
+	}
+	if err := r.ensureLiveIngressServiceSelectsLive(ctx, dataplane); err != nil {
+		return err
+	}
+
+	old := dataplane.DeepCopy()
+	dataplane.Status.RolloutStatus.Canary = nil
+	if _, err := r.patchRolloutStatus(ctx, logger, old, dataplane); err != nil {
+		return fmt.Errorf("failed removing Canary rollout status: %w", err)
+	}
+	log.Debug(logger, "live resources restored after unfinished Canary rollout", dataplane)
+	return nil
+}
+
+// ensureLiveIngressServiceSelectsLive ensures that the live ingress Services,
+// including the private one, select only the pods of the live Deployments.
+// It reverts the changes made by ensureLiveIngressServiceSelectsCanary.
+func (r *BlueGreenReconciler) ensureLiveIngressServiceSelectsLive(
+	ctx context.Context,
+	dataplane *operatorv1beta1.DataPlane,
+) error {
+	if dataplane.Status.Selector == "" {
+		return nil
+	}
+
+	services, err := listDataPlaneLiveServices(ctx, r.Client, dataplane)
+	if err != nil {
+		return fmt.Errorf("failed listing live ingress Services for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+	privateServices, err := listDataPlaneLivePrivateServices(ctx, r.Client, dataplane)
+	if err != nil {
+		return fmt.Errorf("failed listing live private ingress Services for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+	services = append(services, privateServices...)
+
+	for _, svc := range services {
+		svc := svc
+		if svc.Spec.Selector[consts.OperatorLabelSelector] == dataplane.Status.Selector {
+			continue
+		}
+		old := svc.DeepCopy()
+		if svc.Spec.Selector == nil {
+			svc.Spec.Selector = map[string]string{}
+		}
+		svc.Spec.Selector[consts.OperatorLabelSelector] = dataplane.Status.Selector
+		if err := r.Client.Patch(ctx, &svc, client.MergeFrom(old)); err != nil {
+			return fmt.Errorf("failed updating selector of live ingress Service %s/%s: %w", svc.Namespace, svc.Name, err)
+		}
+	}
+	return nil
+}
+
+// canaryPreviewReplicasDeploymentOpt returns a DeploymentOpt which sets the
+// replicas of the preview Deployment according to the current step of the
+// DataPlane's Canary rollout.
+func canaryPreviewReplicasDeploymentOpt(dataplane *operatorv1beta1.DataPlane) func(*appsv1.Deployment) {
+	return func(d *appsv1.Deployment) {
+		var step int32
+		if s := dataplane.Status.RolloutStatus; s != nil && s.Canary != nil && s.Canary.ObservedGeneration == dataplane.Generation {
+			step = s.Canary.Step
+		}
+		previewReplicas, _ := canaryReplicas(dataplaneReplicas(dataplane), canaryWeight(dataplane, step))
+		d.Spec.Replicas = lo.ToPtr(previewReplicas)
+	}
+}
+
+// canaryWeight returns the weight of the provided step of the DataPlane's Canary
+// rollout. The weight of the last step is returned for the steps past the last one.
+func canaryWeight(dataplane *operatorv1beta1.DataPlane, step int32) int32 {
+	steps := dataplane.Spec.Deployment.Rollout.Strategy.Canary.Steps
+	if len(steps) == 0 {
+		return 100
+	}
+	return steps[min(int(step), len(steps)-1)].Weight
+}
+
+// canaryReplicas returns the number of preview and live replicas for the provided
+// total number of replicas and the weight of a Canary step. There's always at
+// least one preview and one live replica.
+func canaryReplicas(total, weight int32) (preview int32, live int32) {
+	preview = max(int32(math.Round(float64(total)*float64(weight)/100)), 1)
+	live = max(total-preview, 1)
+	return preview, live
+}
+
+// dataplaneReplicas returns the number of replicas configured for the DataPlane.
+func dataplaneReplicas(dataplane *operatorv1beta1.DataPlane) int32 {
+	return lo.FromPtrOr(dataplane.Spec.Deployment.Replicas, 1)
+}
+
+// deploymentHasReadyReplicas returns true if the Deployment has been scaled
+// to the provided number of replicas and all of them are available.
+func deploymentHasReadyReplicas(d *appsv1.Deployment, replicas int32) bool {
+	return lo.FromPtr(d.Spec.Replicas) == replicas &&
+		d.Status.ObservedGeneration >= d.Generation &&
+		d.Status.Replicas == replicas &&
+		d.Status.AvailableReplicas == replicas
+}
@@ -0,0 +1,356 @@
+package dataplane
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+func TestCanaryReplicas(t *testing.T) {
+	testCases := []struct {
+		total, weight   int32
+		expectedPreview int32
+		expectedLive    int32
+	}{
+		{total: 10, weight: 10, expectedPreview: 1, expectedLive: 9},
+		{total: 10, weight: 50, expectedPreview: 5, expectedLive: 5},
+		{total: 4, weight: 30, expectedPreview: 1, expectedLive: 3},
+		{total: 4, weight: 40, expectedPreview: 2, expectedLive: 2},
+		{total: 1, weight: 10, expectedPreview: 1, expectedLive: 1},
+		{total: 3, weight: 100, expectedPreview: 3, expectedLive: 1},
+	}
+
+	for _, tc := range testCases {
+		preview, live := canaryReplicas(tc.total, tc.weight)
+		require.Equal(t, tc.expectedPreview, preview, "total %d, weight %d", tc.total, tc.weight)
+		require.Equal(t, tc.expectedLive, live, "total %d, weight %d", tc.total, tc.weight)
+	}
+}
+
+func TestEnsureCanaryStep(t *testing.T) {
+	ctx := context.Background()
+	logger := logr.Discard()
+
+	dp := &operatorv1beta1.DataPlane{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: operatorv1beta1.SchemeGroupVersion.String(),
+			Kind:       "DataPlane",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "dp",
+			Namespace:  "default",
+			UID:        types.UID("1234"),
+			Generation: 2,
+		},
+		Spec: operatorv1beta1.DataPlaneSpec{
+			DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+				Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+					DeploymentOptions: operatorv1beta1.DeploymentOptions{
+						Replicas: lo.ToPtr(int32(4)),
+					},
+					Rollout: &operatorv1beta1.Rollout{
+						Strategy: operatorv1beta1.RolloutStrategy{
+							Canary: &operatorv1beta1.CanaryStrategy{
+								Steps: []operatorv1beta1.CanaryStep{
+									{Weight: 25, Pause: &metav1.Duration{Duration: time.Hour}},
+									{Weight: 50},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Status: operatorv1beta1.DataPlaneStatus{
+			Selector: "live-selector",
+			RolloutStatus: &operatorv1beta1.DataPlaneRolloutStatus{
+				Deployment: &operatorv1beta1.DataPlaneRolloutStatusDeployment{
+					Selector: "preview-selector",
+				},
+			},
+		},
+	}
+	liveDeployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dp-live",
+			Namespace: "default",
+			Labels: map[string]string{
+				"app":                                dp.Name,
+				consts.DataPlaneDeploymentStateLabel: consts.DataPlaneStateLabelValueLive,
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: lo.ToPtr(int32(4)),
+		},
+	}
+	k8sutils.SetOwnerForObject(liveDeployment, dp)
+	liveIngressService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dp-ingress",
+			Namespace: "default",
+			Labels: map[string]string{
+				"app":                             dp.Name,
+				consts.DataPlaneServiceStateLabel: consts.DataPlaneStateLabelValueLive,
+				consts.DataPlaneServiceTypeLabel:  string(consts.DataPlaneIngressServiceLabelValue),
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{
+				"app":                        dp.Name,
+				consts.OperatorLabelSelector: "live-selector",
+			},
+		},
+	}
+	k8sutils.SetOwnerForObject(liveIngressService, dp)
+	previewDeployment := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: lo.ToPtr(int32(1)),
+		},
+	}
+
+	fakeClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(dp, liveDeployment, liveIngressService).
+		WithStatusSubresource(dp, liveDeployment).
+		Build()
+	r := BlueGreenReconciler{
+		Client: fakeClient,
+	}
+
+	ensure := func() (bool, time.Duration) {
+		t.Helper()
+		done, res, err := r.ensureCanaryStep(ctx, logger, dp, previewDeployment)
+		require.NoError(t, err)
+		return done, res.RequeueAfter
+	}
+	setLiveDeploymentReady := func(replicas int32) {
+		t.Helper()
+		d := &appsv1.Deployment{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(liveDeployment), d))
+		require.Equal(t, replicas, *d.Spec.Replicas)
+		d.Status = appsv1.DeploymentStatus{
+			Replicas:          replicas,
+			AvailableReplicas: replicas,
+		}
+		require.NoError(t, fakeClient.Status().Update(ctx, d))
+	}
+	setPreviewDeploymentReady := func(replicas int32) {
+		previewDeployment.Spec.Replicas = lo.ToPtr(replicas)
+		previewDeployment.Status = appsv1.DeploymentStatus{
+			Replicas:          replicas,
+			AvailableReplicas: replicas,
+		}
+	}
+	requireRolledOutCondition := func(reason consts.ConditionReason) {
+		t.Helper()
+		c, ok := k8sutils.GetCondition(consts.DataPlaneConditionTypeRolledOut, dp.Status.RolloutStatus)
+		require.True(t, ok)
+		require.Equal(t, string(reason), c.Reason)
+	}
+
+	t.Log("Canary rollout status is initialized with the first step")
+	done, _ := ensure()
+	require.False(t, done)
+	require.Equal(t, &operatorv1beta1.DataPlaneRolloutStatusCanary{
+		Step:               0,
+		Weight:             25,
+		ObservedGeneration: 2,
+	}, dp.Status.RolloutStatus.Canary)
+
+	t.Log("live Deployment is scaled down and live ingress Service selects preview pods")
+	done, _ = ensure()
+	require.False(t, done)
+	svc := &corev1.Service{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(liveIngressService), svc))
+	require.Equal(t, map[string]string{"app": dp.Name}, svc.Spec.Selector)
+
+	t.Log("step is not reached until the Deployments are ready")
+	done, _ = ensure()
+	require.False(t, done)
+	requireRolledOutCondition(consts.DataPlaneConditionReasonRolloutProgressing)
+	require.Nil(t, dp.Status.RolloutStatus.Canary.StepReachedAt)
+	setLiveDeploymentReady(3)
+	setPreviewDeploymentReady(1)
+	done, _ = ensure()
+	require.False(t, done)
+	require.NotNil(t, dp.Status.RolloutStatus.Canary.StepReachedAt)
+
+	t.Log("step is paused")
+	done, requeueAfter := ensure()
+	require.False(t, done)
+	require.Greater(t, requeueAfter, 59*time.Minute)
+
+	t.Log("rollout moves on to the next step when the pause is over")
+	dp.Status.RolloutStatus.Canary.StepReachedAt = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+	done, _ = ensure()
+	require.False(t, done)
+	require.Equal(t, int32(1), dp.Status.RolloutStatus.Canary.Step)
+	require.Equal(t, int32(50), dp.Status.RolloutStatus.Canary.Weight)
+	require.Nil(t, dp.Status.RolloutStatus.Canary.StepReachedAt)
+
+	t.Log("step without pause awaits approval")
+	done, _ = ensure()
+	require.False(t, done)
+	setLiveDeploymentReady(2)
+	setPreviewDeploymentReady(2)
+	done, _ = ensure()
+	require.False(t, done)
+	done, _ = ensure()
+	require.False(t, done)
+	requireRolledOutCondition(consts.DataPlaneConditionReasonRolloutAwaitingPromotion)
+
+	t.Log("approving the last step completes the Canary steps")
+	old := dp.DeepCopy()
+	dp.Annotations = map[string]string{
+		operatorv1beta1.DataPlanePromoteWhenReadyAnnotationKey: operatorv1beta1.DataPlanePromoteWhenReadyAnnotationTrue,
+	}
+	require.NoError(t, fakeClient.Patch(ctx, dp, client.MergeFrom(old)))
+	done, _ = ensure()
+	require.False(t, done)
+	require.Equal(t, int32(2), dp.Status.RolloutStatus.Canary.Step)
+	require.NotContains(t, dp.Annotations, operatorv1beta1.DataPlanePromoteWhenReadyAnnotationKey)
+	done, _ = ensure()
+	require.True(t, done)
+}
+
+func TestTeardownPreviewSubresourcesRestoresLiveResourcesAfterCanary(t *testing.T) {
+	ctx := context.Background()
+	logger := logr.Discard()
+
+	dp := &operatorv1beta1.DataPlane{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: operatorv1beta1.SchemeGroupVersion.String(),
+			Kind:       "DataPlane",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "dp",
+			Namespace:  "default",
+			UID:        types.UID("1234"),
+			Generation: 2,
+		},
+		Spec: operatorv1beta1.DataPlaneSpec{
+			DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+				Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+					DeploymentOptions: operatorv1beta1.DeploymentOptions{
+						Replicas: lo.ToPtr(int32(4)),
+					},
+					Rollout: &operatorv1beta1.Rollout{
+						Strategy: operatorv1beta1.RolloutStrategy{
+							Canary: &operatorv1beta1.CanaryStrategy{
+								Steps: []operatorv1beta1.CanaryStep{
+									{Weight: 25},
+								},
+							},
+						},
+						Timeout: &metav1.Duration{Duration: time.Minute},
+					},
+				},
+			},
+		},
+		Status: operatorv1beta1.DataPlaneStatus{
+			Selector: "live-selector",
+			RolloutStatus: &operatorv1beta1.DataPlaneRolloutStatus{
+				Deployment: &operatorv1beta1.DataPlaneRolloutStatusDeployment{
+					Selector: "preview-selector",
+				},
+				Canary: &operatorv1beta1.DataPlaneRolloutStatusCanary{
+					Step:               0,
+					Weight:             25,
+					ObservedGeneration: 2,
+				},
+			},
+		},
+	}
+	k8sutils.SetCondition(
+		k8sutils.NewConditionWithGeneration(consts.DataPlaneConditionTypeRolledOut, metav1.ConditionFalse,
+			consts.DataPlaneConditionReasonRolloutTimedOut, consts.DataPlaneConditionMessageRolledOutRolloutTimedOut, 2),
+		dp.Status.RolloutStatus,
+	)
+	liveDeployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dp-live",
+			Namespace: "default",
+			Labels: map[string]string{
+				"app":                                dp.Name,
+				consts.DataPlaneDeploymentStateLabel: consts.DataPlaneStateLabelValueLive,
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: lo.ToPtr(int32(3)),
+		},
+	}
+	k8sutils.SetOwnerForObject(liveDeployment, dp)
+	liveService := func(name string, serviceType consts.ServiceType) *corev1.Service {
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					"app":                             dp.Name,
+					consts.DataPlaneServiceStateLabel: consts.DataPlaneStateLabelValueLive,
+					consts.DataPlaneServiceTypeLabel:  string(serviceType),
+				},
+			},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{
+					"app": dp.Name,
+				},
+			},
+		}
+		k8sutils.SetOwnerForObject(svc, dp)
+		return svc
+	}
+	liveIngressService := liveService("dp-ingress", consts.DataPlaneIngressServiceLabelValue)
+	livePrivateIngressService := liveService("dp-private-ingress", consts.DataPlanePrivateIngressServiceLabelValue)
+
+	fakeClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(dp, liveDeployment, liveIngressService, livePrivateIngressService).
+		WithStatusSubresource(dp, liveDeployment).
+		Build()
+	r := BlueGreenReconciler{
+		Client: fakeClient,
+	}
+
+	t.Log("tearing down the preview resources of a timed out Canary rollout")
+	require.NoError(t, r.teardownPreviewSubresources(ctx, logger, dp))
+
+	t.Log("live Deployment is scaled back to the DataPlane's replicas")
+	d := &appsv1.Deployment{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(liveDeployment), d))
+	require.Equal(t, int32(4), *d.Spec.Replicas)
+
+	t.Log("live ingress Services select only the live pods again")
+	for _, s := range []*corev1.Service{liveIngressService, livePrivateIngressService} {
+		svc := &corev1.Service{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(s), svc))
+		require.Equal(t, map[string]string{
+			"app":                        dp.Name,
+			consts.OperatorLabelSelector: "live-selector",
+		}, svc.Spec.Selector, "Service %s", s.Name)
+	}
+
+	t.Log("Canary rollout status is removed")
+	require.Nil(t, dp.Status.RolloutStatus.Canary)
+	stored := &operatorv1beta1.DataPlane{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(dp), stored))
+	require.Nil(t, stored.Status.RolloutStatus.Canary)
+	require.True(t, isRolloutTimedOut(stored))
+}
@@ -2,12 +2,16 @@ package dataplane
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -18,6 +22,7 @@ import (
 	"github.com/kong/gateway-operator/controller/pkg/ctxinjector"
 	"github.com/kong/gateway-operator/controller/pkg/log"
 	"github.com/kong/gateway-operator/controller/pkg/op"
+	"github.com/kong/gateway-operator/controller/pkg/secrets"
 	"github.com/kong/gateway-operator/pkg/consts"
 	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
 	k8sresources "github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
@@ -38,18 +43,31 @@ type Reconciler struct {
 	eventRecorder            record.EventRecorder
 	ClusterCASecretName      string
 	ClusterCASecretNamespace string
-	DevelopmentMode          bool
-	Validator                dataPlaneValidator
-	Callbacks                DataPlaneCallbacks
-	ContextInjector          ctxinjector.CtxInjector
-	DefaultImage             string
+	// ClusterCertificateRenewalWindow is the period before the expiration of
+	// the DataPlane's certificates during which they get renewed.
+	ClusterCertificateRenewalWindow time.Duration
+	DevelopmentMode                 bool
+	Validator                       dataPlaneValidator
+	Callbacks                       DataPlaneCallbacks
+	ContextInjector                 ctxinjector.CtxInjector
+	DefaultImage                    string
+	// KongPluginInstallationControllerEnabled indicates whether KongPluginInstallations
+	// are reconciled and hence whether DataPlanes can install them and should watch them.
+	KongPluginInstallationControllerEnabled bool
+	// KonnectControllersEnabled indicates whether the Konnect controllers are
+	// enabled and hence whether DataPlanes can connect to Konnect.
+	KonnectControllersEnabled bool
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.eventRecorder = mgr.GetEventRecorderFor("dataplane")
 
-	return DataPlaneWatchBuilder(mgr).
+	clusterCASecretNN := types.NamespacedName{
+		Namespace: r.ClusterCASecretNamespace,
+		Name:      r.ClusterCASecretName,
+	}
+	return DataPlaneWatchBuilder(mgr, clusterCASecretNN, r.KongPluginInstallationControllerEnabled).
 		Complete(r)
 }
 
@@ -67,6 +85,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	dataplane := new(operatorv1beta1.DataPlane)
 	if err := r.Client.Get(ctx, req.NamespacedName, dataplane); err != nil {
 		if k8serrors.IsNotFound(err) {
+			secrets.DeleteCertificateExpirationMetrics(&operatorv1beta1.DataPlane{
+				ObjectMeta: metav1.ObjectMeta{Namespace: req.Namespace, Name: req.Name},
+			})
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, err
@@ -85,7 +106,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 
 	log.Trace(logger, "validating DataPlane configuration", dataplane)
-	err := r.Validator.Validate(dataplane)
+	err := r.validate(dataplane)
+	if err == nil {
+		err = r.validateKonnectOptions(dataplane)
+	}
 	if err != nil {
 		log.Info(logger, "failed to validate dataplane: "+err.Error(), dataplane)
 		r.eventRecorder.Event(dataplane, "Warning", "ValidationFailed", err.Error())
@@ -132,6 +156,23 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, nil
 	}
 
+	log.Trace(logger, "exposing DataPlane deployment via private service", dataplane)
+	serviceRes, dataplanePrivateIngressService, err := ensurePrivateIngressServiceForDataPlane(
+		ctx,
+		log.GetLogger(ctx, "dataplane_private_ingress_service", r.DevelopmentMode),
+		r.Client,
+		dataplane,
+		additionalServiceLabels,
+		k8sresources.LabelSelectorFromDataPlaneStatusSelectorServiceOpt(dataplane),
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if serviceRes != op.Noop {
+		log.Debug(logger, "DataPlane private ingress service modified", dataplane, "reason", serviceRes)
+		return ctrl.Result{}, nil
+	}
+
 	dataplaneServiceChanged, err := r.ensureDataPlaneServiceStatus(ctx, logger, dataplane, dataplaneIngressService.Name)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -151,7 +192,13 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 			Namespace: dataplaneAdminService.Namespace,
 			Name:      dataplaneAdminService.Name,
 		},
+		secrets.WithRenewalWindow(r.ClusterCertificateRenewalWindow),
+		secrets.WithEventRecorder(r.eventRecorder),
 	)
+	if errors.Is(err, secrets.ErrCertificateNotReady) {
+		log.Debug(logger, "waiting for mTLS certificate to be issued", dataplane)
+		return ctrl.Result{RequeueAfter: secrets.CertificateNotReadyRequeueAfter}, nil
+	}
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -164,20 +211,38 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	if dataplaneIngressService.Spec.ClusterIP == "" {
 		return ctrl.Result{}, nil // no need to requeue, the update will trigger.
 	}
+	ingressServices := []*corev1.Service{dataplaneIngressService}
+	if dataplanePrivateIngressService != nil {
+		if dataplanePrivateIngressService.Spec.ClusterIP == "" {
+			return ctrl.Result{}, nil // no need to requeue, the update will trigger.
+		}
+		ingressServices = append(ingressServices, dataplanePrivateIngressService)
+	}
 
 	log.Trace(logger, "ensuring DataPlane has service addesses in status", dataplaneIngressService)
-	if updated, err := r.ensureDataPlaneAddressesStatus(ctx, logger, dataplane, dataplaneIngressService); err != nil {
+	if updated, err := r.ensureDataPlaneAddressesStatus(ctx, logger, dataplane, ingressServices...); err != nil {
 		return ctrl.Result{}, err
 	} else if updated {
 		log.Debug(logger, "dataplane status.Addresses updated", dataplane)
 		return ctrl.Result{}, nil // no need to requeue, the update will trigger.
 	}
 
+	log.Trace(logger, "ensuring DataPlane database migrations", dataplane)
+	migrationsRes, migrated, err := ensureDataPlaneDatabaseMigrations(ctx, r.Client, logger, dataplane, r.DefaultImage, r.DevelopmentMode)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !migrated {
+		log.Debug(logger, "waiting for DataPlane database migrations", dataplane)
+		return migrationsRes, nil // migrations Job status update will trigger reconciliation
+	}
+
 	deploymentLabels := client.MatchingLabels{
 		consts.DataPlaneDeploymentStateLabel: consts.DataPlaneStateLabelValueLive,
 	}
 	deploymentOpts := []k8sresources.DeploymentOpt{
 		labelSelectorFromDataPlaneStatusSelectorDeploymentOpt(dataplane),
+		secrets.CertificateRenewalDeploymentOpt(certSecret),
 	}
 	deploymentBuilder := NewDeploymentBuilder(logger.WithName("deployment_builder"), r.Client).
 		WithBeforeCallbacks(r.Callbacks.BeforeDeployment).
@@ -188,6 +253,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		WithAdditionalLabels(deploymentLabels)
 
 	deployment, res, err := deploymentBuilder.BuildAndDeploy(ctx, dataplane, r.DevelopmentMode)
+	if kpiErr := (kongPluginInstallationNotReadyError{}); errors.As(err, &kpiErr) {
+		// No need to requeue: the DataPlane is enqueued again when the
+		// KongPluginInstallation's status changes.
+		log.Debug(logger, "waiting for KongPluginInstallation to become ready", dataplane,
+			"kongplugininstallation", kpiErr.kongPluginInstallation.String())
+		return ctrl.Result{}, r.ensureDataPlaneIsMarkedNotReady(ctx, logger, dataplane,
+			DataPlaneConditionReasonKongPluginInstallationNotReady, kpiErr.Error())
+	}
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("could not build Deployment for DataPlane %s/%s: %w",
 			dataplane.Namespace, dataplane.Name, err)
@@ -204,6 +277,15 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, nil
 	}
 
+	res, _, err = ensurePodDisruptionBudgetForDataPlane(ctx, r.Client, logger, dataplane)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("could not ensure PodDisruptionBudget for DataPlane %s/%s: %w",
+			dataplane.Namespace, dataplane.Name, err)
+	}
+	if res != op.Noop {
+		return ctrl.Result{}, nil
+	}
+
 	if res, err := ensureDataPlaneReadyStatus(ctx, r.Client, logger, dataplane, dataplane.Generation); err != nil {
 		return ctrl.Result{}, err
 	} else if res.Requeue {
@@ -211,7 +293,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 
 	log.Debug(logger, "reconciliation complete for DataPlane resource", dataplane)
-	return ctrl.Result{}, nil
+	if dataplane.Spec.Network.ClusterCertificateOptions == nil {
+		return secrets.RequeueForCertificateRenewal(migrationsRes, certSecret, r.ClusterCertificateRenewalWindow), nil
+	}
+	return migrationsRes, nil
 }
 
 func (r *Reconciler) initSelectorInStatus(ctx context.Context, logger logr.Logger, dataplane *operatorv1beta1.DataPlane) error {
@@ -10,4 +10,8 @@ const (
 	// DataPlaneConditionValidationFailed is a reason which indicates validation of
 	// a dataplane is failed.
 	DataPlaneConditionValidationFailed consts.ConditionReason = "ValidationFailed"
+
+	// DataPlaneConditionReasonKongPluginInstallationNotReady is a reason which indicates
+	// a KongPluginInstallation referenced by a dataplane is not ready yet.
+	DataPlaneConditionReasonKongPluginInstallationNotReady consts.ConditionReason = "KongPluginInstallationNotReady"
 )
@@ -59,11 +59,15 @@ func (r *Reconciler) ensureDataPlaneAddressesStatus(
 	ctx context.Context,
 	log logr.Logger,
 	dataplane *operatorv1beta1.DataPlane,
-	dataplaneService *corev1.Service,
+	dataplaneServices ...*corev1.Service,
 ) (bool, error) {
-	addresses, err := address.AddressesFromService(dataplaneService)
-	if err != nil {
-		return false, fmt.Errorf("failed getting addresses for service %s: %w", dataplaneService, err)
+	addresses := make([]operatorv1beta1.Address, 0)
+	for _, dataplaneService := range dataplaneServices {
+		serviceAddresses, err := address.AddressesFromService(dataplaneService)
+		if err != nil {
+			return false, fmt.Errorf("failed getting addresses for service %s: %w", dataplaneService, err)
+		}
+		addresses = append(addresses, serviceAddresses...)
 	}
 
 	// Compare the lengths prior to cmp.Equal() because cmp.Equal() will return
@@ -129,16 +133,16 @@ func (r *Reconciler) ensureDataPlaneIsMarkedNotReady(
 	return nil
 }
 
-// ensureDataPlaneIngressServiceAnnotationsUpdated updates annotations of existing ingress service
+// ensureDataPlaneServiceAnnotationsUpdated updates annotations of existing service
 // owned by the `DataPlane`. It first removes outdated annotations and then update annotations
-// in current spec of `DataPlane`.
-func ensureDataPlaneIngressServiceAnnotationsUpdated(
-	dataplane *operatorv1beta1.DataPlane, existingAnnotations map[string]string, generatedAnnotations map[string]string,
+// in current spec of `DataPlane`, provided as specAnnotations.
+func ensureDataPlaneServiceAnnotationsUpdated(
+	specAnnotations map[string]string, existingAnnotations map[string]string, generatedAnnotations map[string]string,
 ) (bool, map[string]string, error) {
 	// Remove annotations applied from previous version of DataPlane but removed in the current version.
 	// Should be done before updating new annotations, because the updating process will overwrite the annotation
 	// to save last applied annotations.
-	outdatedAnnotations, err := extractOutdatedDataPlaneIngressServiceAnnotations(dataplane, existingAnnotations)
+	outdatedAnnotations, err := extractOutdatedDataPlaneServiceAnnotations(specAnnotations, existingAnnotations)
 	if err != nil {
 		return true, existingAnnotations, fmt.Errorf("failed to extract outdated annotations: %w", err)
 	}
@@ -203,7 +207,8 @@ func patchDataPlaneStatus(ctx context.Context, cl client.Client, logger logr.Log
 		addressesChanged(current, updated) ||
 		readinessChanged(current, updated) ||
 		current.Status.Service != updated.Status.Service ||
-		current.Status.Selector != updated.Status.Selector {
+		current.Status.Selector != updated.Status.Selector ||
+		!cmp.Equal(current.Status.DatabaseStatus, updated.Status.DatabaseStatus) {
 
 		log.Debug(logger, "patching DataPlane status", updated, "status", updated.Status)
 		return true, cl.Status().Patch(ctx, updated, client.MergeFrom(current))
@@ -57,7 +57,18 @@ func generateDataPlaneImage(dataplane *operatorv1beta1.DataPlane, defaultImage s
 // -----------------------------------------------------------------------------
 
 func addAnnotationsForDataPlaneIngressService(obj client.Object, dataplane operatorv1beta1.DataPlane) {
-	specAnnotations := extractDataPlaneIngressServiceAnnotations(&dataplane)
+	addAnnotationsForDataPlaneService(obj, extractDataPlaneIngressServiceAnnotations(&dataplane))
+}
+
+// addAnnotationsForDataPlaneAdminAPIService sets the Admin API Service annotations
+// from the DataPlane spec on the provided object.
+func addAnnotationsForDataPlaneAdminAPIService(obj client.Object, dataplane operatorv1beta1.DataPlane) {
+	addAnnotationsForDataPlaneService(obj, extractDataPlaneAdminAPIServiceAnnotations(&dataplane))
+}
+
+// addAnnotationsForDataPlaneService sets the provided annotations specified in the
+// DataPlane spec on the object and records them as its last applied annotations.
+func addAnnotationsForDataPlaneService(obj client.Object, specAnnotations map[string]string) {
 	if specAnnotations == nil {
 		return
 	}
@@ -86,10 +97,20 @@ func extractDataPlaneIngressServiceAnnotations(dataplane *operatorv1beta1.DataPl
 	return anns
 }
 
-// extractOutdatedDataPlaneIngressServiceAnnotations returns the last applied annotations
-// of ingress service from `DataPlane` spec but disappeared in current `DataPlane` spec.
-func extractOutdatedDataPlaneIngressServiceAnnotations(
-	dataplane *operatorv1beta1.DataPlane, existingAnnotations map[string]string,
+func extractDataPlaneAdminAPIServiceAnnotations(dataplane *operatorv1beta1.DataPlane) map[string]string {
+	if dataplane.Spec.DataPlaneOptions.Network.Services == nil ||
+		dataplane.Spec.DataPlaneOptions.Network.Services.AdminAPI == nil {
+		return nil
+	}
+
+	return dataplane.Spec.DataPlaneOptions.Network.Services.AdminAPI.Annotations
+}
+
+// extractOutdatedDataPlaneServiceAnnotations returns the last applied annotations
+// of a service from `DataPlane` spec but disappeared in current `DataPlane` spec,
+// which are provided as currentSpecifiedAnnotations.
+func extractOutdatedDataPlaneServiceAnnotations(
+	currentSpecifiedAnnotations map[string]string, existingAnnotations map[string]string,
 ) (map[string]string, error) {
 	if existingAnnotations == nil {
 		return nil, nil
@@ -107,7 +128,6 @@ func extractOutdatedDataPlaneIngressServiceAnnotations(
 	// the annotation is outdated and should be removed.
 	// So we remove the annotations present in current spec in last applied annotations,
 	// the remaining annotations are outdated and should be removed.
-	currentSpecifiedAnnotations := extractDataPlaneIngressServiceAnnotations(dataplane)
 	for k := range currentSpecifiedAnnotations {
 		delete(outdatedAnnotations, k)
 	}
@@ -226,9 +246,16 @@ func ensureDataPlaneReadyStatus(
 		return ctrl.Result{Requeue: true}, nil
 	}
 
-	ingressService := services[0]
-	if !dataPlaneIngressServiceIsReady(&ingressService) {
-		log.Debug(logger, "Ingress Service for DataPlane not ready yet", dataplane)
+	privateServices, err := listDataPlaneLivePrivateServices(ctx, cl, dataplane)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed listing private ingress services for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+
+	for _, ingressService := range append(services, privateServices...) {
+		if dataPlaneIngressServiceIsReady(&ingressService) {
+			continue
+		}
+		log.Debug(logger, "Ingress Service for DataPlane not ready yet", dataplane, "service", ingressService.Name)
 
 		// Set Ready to false for dataplane as the Service is not ready yet.
 		k8sutils.SetCondition(
@@ -292,6 +319,23 @@ func listDataPlaneLiveServices(
 	)
 }
 
+func listDataPlaneLivePrivateServices(
+	ctx context.Context,
+	cl client.Client,
+	dataplane *operatorv1beta1.DataPlane,
+) ([]corev1.Service, error) {
+	return k8sutils.ListServicesForOwner(ctx,
+		cl,
+		dataplane.Namespace,
+		dataplane.UID,
+		client.MatchingLabels{
+			"app":                             dataplane.Name,
+			consts.DataPlaneServiceStateLabel: consts.DataPlaneStateLabelValueLive,
+			consts.DataPlaneServiceTypeLabel:  string(consts.DataPlanePrivateIngressServiceLabelValue),
+		},
+	)
+}
+
 func isDeploymentReady(deploymentStatus appsv1.DeploymentStatus) (metav1.ConditionStatus, bool) {
 	// We check if the Deployment is not Ready.
 	// This is the case when status has replicas set to 0 or status.availableReplicas
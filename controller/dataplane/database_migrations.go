@@ -0,0 +1,391 @@
+package dataplane
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/pkg/log"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+	k8sresources "github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
+)
+
+// -----------------------------------------------------------------------------
+// DataPlane - Database Migrations
+// -----------------------------------------------------------------------------
+
+// dataPlaneMigrationsPodsRequeueAfter is the period after which a DataPlane
+// waiting for its pods to run the migrated image, so that the migrations can be
+// finished, is reconciled again. Pods are not watched by the DataPlane
+// controllers and the terminating pods don't trigger any Deployment changes.
+const dataPlaneMigrationsPodsRequeueAfter = 10 * time.Second
+
+// dataPlaneMigrationsJobRetryBackoff is the period after which a failed
+// database migrations Job of a DataPlane is recreated to retry the migrations.
+const dataPlaneMigrationsJobRetryBackoff = 5 * time.Minute
+
+// migrationsJobState is the state of a DataPlane's database migrations Job.
+type migrationsJobState int
+
+const (
+	migrationsJobRunning migrationsJobState = iota
+	migrationsJobFailed
+	migrationsJobComplete
+)
+
+// ensureDataPlaneDatabaseMigrations ensures the database of the DataPlane
+// is migrated for the DataPlane's image. The migrations are run with Jobs
+// in the following order:
+//
+//   - kong migrations finish for the previously migrated image, once all
+//     the DataPlane's pods run it,
+//   - kong migrations bootstrap and kong migrations up for the DataPlane's image,
+//     before the DataPlane's pods are rolled out with it.
+//
+// It returns true when the DataPlane's pods can be rolled out with the image.
+// The progress is reflected in the DataPlane's DatabaseMigrated condition.
+func ensureDataPlaneDatabaseMigrations(
+	ctx context.Context,
+	cl client.Client,
+	logger logr.Logger,
+	dataplane *operatorv1beta1.DataPlane,
+	defaultImage string,
+	developmentMode bool,
+) (ctrl.Result, bool, error) {
+	if dataplane.Spec.Database == nil || dataplane.Spec.Database.Postgres == nil {
+		return ctrl.Result{}, true, nil
+	}
+
+	image, err := generateDataPlaneImageForMode(developmentMode, dataplane, defaultImage)
+	if err != nil {
+		return ctrl.Result{}, false, fmt.Errorf("could not determine image of DataPlane %s/%s: %w",
+			dataplane.Namespace, dataplane.Name, err)
+	}
+
+	status := lo.FromPtr(dataplane.Status.DatabaseStatus)
+	jobs, err := listDataPlaneMigrationsJobs(ctx, cl, dataplane)
+	if err != nil {
+		return ctrl.Result{}, false, err
+	}
+	jobs, err = reduceDataPlaneMigrationsJobs(ctx, cl, status, image, jobs)
+	if err != nil {
+		return ctrl.Result{}, false, err
+	}
+
+	// The previously migrated image has pending migrations which have to be
+	// finished before the migrations of a new image can be run. The database
+	// stays compatible with the previously finished image, e.g. on rollback.
+	if status.MigratedImage != "" && status.FinishedImage != status.MigratedImage {
+		canRollout := image == status.MigratedImage || image == status.FinishedImage
+
+		podsRunImage, err := dataPlanePodsRunImage(ctx, cl, dataplane, status.MigratedImage)
+		if err != nil {
+			return ctrl.Result{}, false, err
+		}
+		if !podsRunImage {
+			msg := fmt.Sprintf("migrations of image %s are finished once all DataPlane pods run it", status.MigratedImage)
+			if !canRollout {
+				msg = fmt.Sprintf("waiting for all DataPlane pods to run image %s to finish its migrations before migrating to image %s",
+					status.MigratedImage, image)
+			}
+			err := ensureDataPlaneDatabaseStatus(ctx, cl, logger, dataplane, status,
+				metav1.ConditionFalse, consts.DataPlaneConditionReasonMigrationsPending, msg)
+			return ctrl.Result{RequeueAfter: dataPlaneMigrationsPodsRequeueAfter}, canRollout, err
+		}
+
+		state, job, err := ensureDataPlaneMigrationsJob(ctx, cl, logger, dataplane, jobs,
+			consts.DataPlaneMigrationsJobPhaseFinishLabelValue, status.MigratedImage)
+		if err != nil {
+			return ctrl.Result{}, false, err
+		}
+		switch state {
+		case migrationsJobRunning:
+			err := ensureDataPlaneDatabaseStatus(ctx, cl, logger, dataplane, status,
+				metav1.ConditionFalse, consts.DataPlaneConditionReasonMigrationsRunning,
+				fmt.Sprintf("running kong migrations finish for image %s", status.MigratedImage))
+			return ctrl.Result{}, canRollout, err
+		case migrationsJobFailed:
+			err := ensureDataPlaneDatabaseStatus(ctx, cl, logger, dataplane, status,
+				metav1.ConditionFalse, consts.DataPlaneConditionReasonMigrationsFailed,
+				fmt.Sprintf("kong migrations finish failed for image %s in Job %s: %s",
+					status.MigratedImage, job.Name, jobFailure(job)))
+			return ctrl.Result{RequeueAfter: migrationsJobRetryAfter(job)}, canRollout, err
+		case migrationsJobComplete:
+			status.FinishedImage = status.MigratedImage
+		}
+	}
+
+	if status.MigratedImage != image && status.FinishedImage != image {
+		state, job, err := ensureDataPlaneMigrationsJob(ctx, cl, logger, dataplane, jobs,
+			consts.DataPlaneMigrationsJobPhaseUpLabelValue, image)
+		if err != nil {
+			return ctrl.Result{}, false, err
+		}
+		switch state {
+		case migrationsJobRunning:
+			err := ensureDataPlaneDatabaseStatus(ctx, cl, logger, dataplane, status,
+				metav1.ConditionFalse, consts.DataPlaneConditionReasonMigrationsRunning,
+				fmt.Sprintf("running kong migrations up for image %s", image))
+			return ctrl.Result{}, false, err
+		case migrationsJobFailed:
+			err := ensureDataPlaneDatabaseStatus(ctx, cl, logger, dataplane, status,
+				metav1.ConditionFalse, consts.DataPlaneConditionReasonMigrationsFailed,
+				fmt.Sprintf("kong migrations up failed for image %s in Job %s: %s",
+					image, job.Name, jobFailure(job)))
+			return ctrl.Result{RequeueAfter: migrationsJobRetryAfter(job)}, false, err
+		case migrationsJobComplete:
+			status.MigratedImage = image
+		}
+	}
+
+	if status.FinishedImage != status.MigratedImage {
+		// The migrations are finished by the next reconciliations, once the
+		// DataPlane's pods are rolled out with the migrated image.
+		err := ensureDataPlaneDatabaseStatus(ctx, cl, logger, dataplane, status,
+			metav1.ConditionFalse, consts.DataPlaneConditionReasonMigrationsPending,
+			fmt.Sprintf("migrations of image %s are finished once all DataPlane pods run it", status.MigratedImage))
+		return ctrl.Result{}, true, err
+	}
+
+	err = ensureDataPlaneDatabaseStatus(ctx, cl, logger, dataplane, status,
+		metav1.ConditionTrue, consts.DataPlaneConditionReasonMigrationsComplete, "")
+	return ctrl.Result{}, true, err
+}
+
+// ensureDataPlaneMigrationsJob ensures a Job running the migrations phase
+// for the provided image exists and returns its state along with the Job.
+// A Job which hasn't completed is recreated when the DataPlane's spec it's
+// generated from changes. A failed Job is recreated once
+// dataPlaneMigrationsJobRetryBackoff has passed since its failure.
+func ensureDataPlaneMigrationsJob(
+	ctx context.Context,
+	cl client.Client,
+	logger logr.Logger,
+	dataplane *operatorv1beta1.DataPlane,
+	jobs []batchv1.Job,
+	phase string,
+	image string,
+) (migrationsJobState, *batchv1.Job, error) {
+	generated := k8sresources.GenerateNewMigrationsJobForDataPlane(dataplane, image, phase)
+
+	job, ok := lo.Find(jobs, func(job batchv1.Job) bool {
+		return job.DeletionTimestamp.IsZero() && isDataPlaneMigrationsJobFor(&job, phase, image)
+	})
+	if ok {
+		checksum := consts.DataPlaneMigrationsJobChecksumAnnotation
+		switch {
+		case isJobConditionTrue(&job, batchv1.JobComplete):
+			return migrationsJobComplete, &job, nil
+		case job.Annotations[checksum] != generated.Annotations[checksum]:
+			log.Debug(logger, "database migrations Job outdated, recreating it", dataplane, "phase", phase, "image", image, "job", job.Name)
+		case isJobConditionTrue(&job, batchv1.JobFailed):
+			if migrationsJobRetryAfter(&job) > 0 {
+				return migrationsJobFailed, &job, nil
+			}
+			log.Debug(logger, "database migrations Job failed, retrying", dataplane, "phase", phase, "image", image, "job", job.Name)
+		default:
+			return migrationsJobRunning, &job, nil
+		}
+		err := cl.Delete(ctx, &job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return migrationsJobRunning, nil, fmt.Errorf("failed deleting migrations Job %s/%s: %w", job.Namespace, job.Name, err)
+		}
+	}
+
+	if err := cl.Create(ctx, generated); err != nil {
+		return migrationsJobRunning, nil, fmt.Errorf("failed creating %s migrations Job for DataPlane %s/%s: %w",
+			phase, dataplane.Namespace, dataplane.Name, err)
+	}
+	log.Debug(logger, "database migrations Job created", dataplane, "phase", phase, "image", image, "job", generated.Name)
+	return migrationsJobRunning, generated, nil
+}
+
+// migrationsJobRetryAfter returns the period after which the failed migrations
+// Job is retried, i.e. 0 when its retry backoff has already expired.
+func migrationsJobRetryAfter(job *batchv1.Job) time.Duration {
+	c, ok := lo.Find(job.Status.Conditions, func(c batchv1.JobCondition) bool {
+		return c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue
+	})
+	if !ok {
+		return 0
+	}
+	return max(time.Until(c.LastTransitionTime.Add(dataPlaneMigrationsJobRetryBackoff)), 0)
+}
+
+// jobFailure returns the failure reported by the Job's Failed condition.
+func jobFailure(job *batchv1.Job) string {
+	c, ok := lo.Find(job.Status.Conditions, func(c batchv1.JobCondition) bool {
+		return c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue
+	})
+	switch {
+	case !ok || c.Reason == "" && c.Message == "":
+		return "unknown failure"
+	case c.Message == "":
+		return c.Reason
+	default:
+		return fmt.Sprintf("%s: %s", c.Reason, c.Message)
+	}
+}
+
+// reduceDataPlaneMigrationsJobs deletes the DataPlane's migrations Jobs which
+// are not needed anymore, i.e. the ones whose completion has already been
+// recorded in the DataPlane's status and the ones for images which are not
+// going to be migrated. It returns the remaining Jobs.
+func reduceDataPlaneMigrationsJobs(
+	ctx context.Context,
+	cl client.Client,
+	status operatorv1beta1.DataPlaneDatabaseStatus,
+	image string,
+	jobs []batchv1.Job,
+) ([]batchv1.Job, error) {
+	needed := func(job *batchv1.Job) bool {
+		if status.MigratedImage != status.FinishedImage &&
+			isDataPlaneMigrationsJobFor(job, consts.DataPlaneMigrationsJobPhaseFinishLabelValue, status.MigratedImage) {
+			return true
+		}
+		return status.MigratedImage != image && status.FinishedImage != image &&
+			isDataPlaneMigrationsJobFor(job, consts.DataPlaneMigrationsJobPhaseUpLabelValue, image)
+	}
+
+	remaining := make([]batchv1.Job, 0, len(jobs))
+	for _, job := range jobs {
+		if needed(&job) {
+			remaining = append(remaining, job)
+			continue
+		}
+		err := cl.Delete(ctx, &job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed deleting migrations Job %s/%s: %w", job.Namespace, job.Name, err)
+		}
+	}
+	return remaining, nil
+}
+
+// listDataPlaneMigrationsJobs lists the database migrations Jobs owned by the DataPlane.
+func listDataPlaneMigrationsJobs(
+	ctx context.Context,
+	cl client.Client,
+	dataplane *operatorv1beta1.DataPlane,
+) ([]batchv1.Job, error) {
+	var jobList batchv1.JobList
+	if err := cl.List(ctx, &jobList,
+		client.InNamespace(dataplane.Namespace),
+		k8sresources.GetManagedLabelForOwner(dataplane),
+		client.HasLabels{consts.DataPlaneMigrationsJobPhaseLabel},
+	); err != nil {
+		return nil, fmt.Errorf("failed listing migrations Jobs for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+
+	jobs := lo.Filter(jobList.Items, func(job batchv1.Job, _ int) bool {
+		return k8sutils.IsOwnedByRefUID(&job, dataplane.UID)
+	})
+	// Prefer the most recent Jobs, e.g. when a failed Job has been retried
+	// and the deleted one is still being garbage collected.
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[j].CreationTimestamp.Before(&jobs[i].CreationTimestamp)
+	})
+	return jobs, nil
+}
+
+// dataPlanePodsRunImage returns true when all the DataPlane's running pods,
+// live and preview ones, run the provided image.
+func dataPlanePodsRunImage(
+	ctx context.Context,
+	cl client.Client,
+	dataplane *operatorv1beta1.DataPlane,
+	image string,
+) (bool, error) {
+	var pods corev1.PodList
+	if err := cl.List(ctx, &pods,
+		client.InNamespace(dataplane.Namespace),
+		client.MatchingLabels{"app": dataplane.Name},
+	); err != nil {
+		return false, fmt.Errorf("failed listing pods for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		container := k8sutils.GetPodContainerByName(&pod.Spec, consts.DataPlaneProxyContainerName)
+		if container == nil || container.Image != image {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ensureDataPlaneDatabaseStatus sets the DataPlane's database status and its
+// DatabaseMigrated condition and patches the DataPlane's status if needed.
+func ensureDataPlaneDatabaseStatus(
+	ctx context.Context,
+	cl client.Client,
+	logger logr.Logger,
+	dataplane *operatorv1beta1.DataPlane,
+	status operatorv1beta1.DataPlaneDatabaseStatus,
+	conditionStatus metav1.ConditionStatus,
+	reason consts.ConditionReason,
+	message string,
+) error {
+	dataplane.Status.DatabaseStatus = &status
+	k8sutils.SetCondition(
+		k8sutils.NewConditionWithGeneration(
+			consts.DataPlaneConditionTypeDatabaseMigrated,
+			conditionStatus,
+			reason,
+			message,
+			dataplane.Generation,
+		),
+		dataplane,
+	)
+	if _, err := patchDataPlaneStatus(ctx, cl, logger, dataplane); err != nil {
+		return fmt.Errorf("failed patching database status for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+	return nil
+}
+
+// isDataPlaneMigrationsJobFor returns true when the Job runs the migrations
+// phase for the provided image.
+func isDataPlaneMigrationsJobFor(job *batchv1.Job, phase string, image string) bool {
+	return job.Labels[consts.DataPlaneMigrationsJobPhaseLabel] == phase &&
+		job.Annotations[consts.DataPlaneMigrationsJobImageAnnotation] == image
+}
+
+// isJobConditionTrue returns true when the Job has the condition of the provided type set to true.
+func isJobConditionTrue(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	return lo.ContainsBy(job.Status.Conditions, func(c batchv1.JobCondition) bool {
+		return c.Type == conditionType && c.Status == corev1.ConditionTrue
+	})
+}
+
+// withDatabaseConfiguration configures the DataPlane's proxy container to use
+// the DataPlane's Postgres database.
+func withDatabaseConfiguration(
+	deployment *k8sresources.Deployment,
+	dataplane *operatorv1beta1.DataPlane,
+) *k8sresources.Deployment {
+	if dataplane.Spec.Database == nil || dataplane.Spec.Database.Postgres == nil {
+		return deployment
+	}
+	container := k8sutils.GetPodContainerByName(&deployment.Spec.Template.Spec, consts.DataPlaneProxyContainerName)
+	if container == nil {
+		return deployment
+	}
+
+	for _, env := range k8sresources.GenerateDataPlanePostgresEnvVars(dataplane.Spec.Database.Postgres.SecretName) {
+		deployment.WithEnvVar(env, consts.DataPlaneProxyContainerName)
+	}
+	sort.Sort(k8sutils.SortableEnvVars(container.Env))
+	return deployment
+}
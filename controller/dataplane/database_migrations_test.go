@@ -0,0 +1,271 @@
+package dataplane
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+	k8sresources "github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
+)
+
+func TestEnsureDataPlaneDatabaseMigrations(t *testing.T) {
+	const (
+		image1 = "kong/kong-gateway:3.7"
+		image2 = "kong/kong-gateway:3.8"
+		image3 = "kong/kong-gateway:3.9"
+	)
+	ctx := context.Background()
+	logger := logr.Discard()
+	dp := &operatorv1beta1.DataPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dp",
+			Namespace: "default",
+			UID:       types.UID("1234"),
+		},
+		Spec: operatorv1beta1.DataPlaneSpec{
+			DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+				Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+					DeploymentOptions: operatorv1beta1.DeploymentOptions{
+						PodTemplateSpec: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{Name: consts.DataPlaneProxyContainerName},
+								},
+							},
+						},
+					},
+				},
+				Database: &operatorv1beta1.DataPlaneDatabaseOptions{
+					Postgres: &operatorv1beta1.DataPlanePostgresOptions{
+						SecretName: "postgres",
+					},
+				},
+			},
+		},
+	}
+	fakeClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(dp).
+		WithStatusSubresource(dp).
+		Build()
+
+	ensureWithResult := func(image string) (ctrl.Result, bool) {
+		t.Helper()
+		dp.Spec.Deployment.PodTemplateSpec.Spec.Containers[0].Image = image
+		res, migrated, err := ensureDataPlaneDatabaseMigrations(ctx, fakeClient, logger, dp, consts.DefaultDataPlaneImage, true)
+		require.NoError(t, err)
+		return res, migrated
+	}
+	ensure := func(image string) bool {
+		t.Helper()
+		_, migrated := ensureWithResult(image)
+		return migrated
+	}
+	listJobs := func() []batchv1.Job {
+		t.Helper()
+		jobs, err := listDataPlaneMigrationsJobs(ctx, fakeClient, dp)
+		require.NoError(t, err)
+		return jobs
+	}
+	setJobsConditionAt := func(conditionType batchv1.JobConditionType, at time.Time) {
+		t.Helper()
+		for _, job := range listJobs() {
+			job.Status.Conditions = []batchv1.JobCondition{
+				{
+					Type:               conditionType,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(at),
+				},
+			}
+			if conditionType == batchv1.JobFailed {
+				job.Status.Conditions[0].Reason = "BackoffLimitExceeded"
+				job.Status.Conditions[0].Message = "Job has reached the specified backoff limit"
+			}
+			require.NoError(t, fakeClient.Status().Update(ctx, &job))
+		}
+	}
+	setJobsCondition := func(conditionType batchv1.JobConditionType) {
+		t.Helper()
+		setJobsConditionAt(conditionType, time.Now())
+	}
+	setProxyEnv := func(env []corev1.EnvVar) {
+		t.Helper()
+		dp.Spec.Deployment.PodTemplateSpec.Spec.Containers[0].Env = env
+		require.NoError(t, fakeClient.Update(ctx, dp))
+	}
+	requireJob := func(phase string, image string) string {
+		t.Helper()
+		jobs := listJobs()
+		require.Len(t, jobs, 1)
+		require.True(t, isDataPlaneMigrationsJobFor(&jobs[0], phase, image))
+		return jobs[0].Name
+	}
+	requireCondition := func(status metav1.ConditionStatus, reason consts.ConditionReason) metav1.Condition {
+		t.Helper()
+		c, ok := k8sutils.GetCondition(consts.DataPlaneConditionTypeDatabaseMigrated, dp)
+		require.True(t, ok)
+		require.Equal(t, status, c.Status)
+		require.Equal(t, string(reason), c.Reason)
+		return c
+	}
+	runPods := func(image string) {
+		t.Helper()
+		var pods corev1.PodList
+		require.NoError(t, fakeClient.List(ctx, &pods))
+		for _, pod := range pods.Items {
+			require.NoError(t, fakeClient.Delete(ctx, &pod))
+		}
+		require.NoError(t, fakeClient.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "dp-",
+				Namespace:    dp.Namespace,
+				Labels:       map[string]string{"app": dp.Name},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					k8sresources.GenerateDataPlaneContainer(image),
+				},
+			},
+		}))
+	}
+
+	t.Log("database is bootstrapped before the DataPlane is deployed")
+	require.False(t, ensure(image1))
+	requireJob(consts.DataPlaneMigrationsJobPhaseUpLabelValue, image1)
+	requireCondition(metav1.ConditionFalse, consts.DataPlaneConditionReasonMigrationsRunning)
+	require.False(t, ensure(image1), "the Job is not created again")
+	require.Len(t, listJobs(), 1)
+
+	t.Log("DataPlane is deployed once the database is bootstrapped")
+	setJobsCondition(batchv1.JobComplete)
+	require.True(t, ensure(image1))
+	require.Equal(t, image1, dp.Status.DatabaseStatus.MigratedImage)
+	requireCondition(metav1.ConditionFalse, consts.DataPlaneConditionReasonMigrationsPending)
+
+	t.Log("migrations are finished once all the DataPlane's pods run the image")
+	runPods(image1)
+	require.True(t, ensure(image1))
+	requireJob(consts.DataPlaneMigrationsJobPhaseFinishLabelValue, image1)
+	setJobsCondition(batchv1.JobComplete)
+	require.True(t, ensure(image1))
+	require.Equal(t, &operatorv1beta1.DataPlaneDatabaseStatus{
+		MigratedImage: image1,
+		FinishedImage: image1,
+	}, dp.Status.DatabaseStatus)
+	requireCondition(metav1.ConditionTrue, consts.DataPlaneConditionReasonMigrationsComplete)
+	require.True(t, ensure(image1))
+	require.Empty(t, listJobs(), "completed Jobs are deleted")
+
+	t.Log("migrations of a new image are run before it's rolled out")
+	require.False(t, ensure(image2))
+	requireJob(consts.DataPlaneMigrationsJobPhaseUpLabelValue, image2)
+	setJobsCondition(batchv1.JobComplete)
+	require.True(t, ensure(image2))
+	require.Equal(t, image2, dp.Status.DatabaseStatus.MigratedImage)
+	requireCondition(metav1.ConditionFalse, consts.DataPlaneConditionReasonMigrationsPending)
+
+	t.Log("DataPlane can be rolled back while the migrations are not finished")
+	require.True(t, ensure(image1))
+
+	t.Log("migrations of another image wait for the pending migrations to be finished")
+	require.False(t, ensure(image3))
+	requireCondition(metav1.ConditionFalse, consts.DataPlaneConditionReasonMigrationsPending)
+	require.Empty(t, listJobs())
+	runPods(image2)
+	require.False(t, ensure(image3))
+	requireJob(consts.DataPlaneMigrationsJobPhaseFinishLabelValue, image2)
+	setJobsCondition(batchv1.JobComplete)
+	require.False(t, ensure(image3))
+	require.Equal(t, image2, dp.Status.DatabaseStatus.FinishedImage)
+	require.False(t, ensure(image3), "completed finish Job is deleted")
+	requireJob(consts.DataPlaneMigrationsJobPhaseUpLabelValue, image3)
+
+	t.Log("running migrations are recreated when the DataPlane's spec changes")
+	runningJob := requireJob(consts.DataPlaneMigrationsJobPhaseUpLabelValue, image3)
+	setProxyEnv([]corev1.EnvVar{{Name: "KONG_LOG_LEVEL", Value: "debug"}})
+	require.False(t, ensure(image3))
+	recreatedJob := requireJob(consts.DataPlaneMigrationsJobPhaseUpLabelValue, image3)
+	require.NotEqual(t, runningJob, recreatedJob)
+	requireCondition(metav1.ConditionFalse, consts.DataPlaneConditionReasonMigrationsRunning)
+
+	t.Log("failed migrations are reported with the Job's failure and not retried before the backoff expires")
+	setJobsCondition(batchv1.JobFailed)
+	res, migrated := ensureWithResult(image3)
+	require.False(t, migrated)
+	require.Greater(t, res.RequeueAfter, time.Duration(0))
+	require.LessOrEqual(t, res.RequeueAfter, dataPlaneMigrationsJobRetryBackoff)
+	c := requireCondition(metav1.ConditionFalse, consts.DataPlaneConditionReasonMigrationsFailed)
+	require.Contains(t, c.Message, recreatedJob)
+	require.Contains(t, c.Message, "BackoffLimitExceeded: Job has reached the specified backoff limit")
+	require.Equal(t, recreatedJob, requireJob(consts.DataPlaneMigrationsJobPhaseUpLabelValue, image3))
+
+	t.Log("failed migrations are retried once the backoff expires")
+	setJobsConditionAt(batchv1.JobFailed, time.Now().Add(-dataPlaneMigrationsJobRetryBackoff))
+	require.False(t, ensure(image3))
+	require.NotEqual(t, recreatedJob, requireJob(consts.DataPlaneMigrationsJobPhaseUpLabelValue, image3))
+	requireCondition(metav1.ConditionFalse, consts.DataPlaneConditionReasonMigrationsRunning)
+
+	t.Log("failed migrations are retried as soon as the DataPlane's spec changes")
+	setJobsCondition(batchv1.JobFailed)
+	failedJob := requireJob(consts.DataPlaneMigrationsJobPhaseUpLabelValue, image3)
+	require.False(t, ensure(image3))
+	require.Equal(t, failedJob, requireJob(consts.DataPlaneMigrationsJobPhaseUpLabelValue, image3))
+	setProxyEnv(nil)
+	require.False(t, ensure(image3))
+	require.NotEqual(t, failedJob, requireJob(consts.DataPlaneMigrationsJobPhaseUpLabelValue, image3))
+	requireCondition(metav1.ConditionFalse, consts.DataPlaneConditionReasonMigrationsRunning)
+}
+
+func TestWithDatabaseConfiguration(t *testing.T) {
+	newDeployment := func() *k8sresources.Deployment {
+		return &k8sresources.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: consts.DataPlaneProxyContainerName,
+								Env: []corev1.EnvVar{
+									{Name: consts.EnvVarKongDatabase, Value: "off"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	dp := &operatorv1beta1.DataPlane{}
+	require.Equal(t, newDeployment(), withDatabaseConfiguration(newDeployment(), dp))
+
+	dp.Spec.Database = &operatorv1beta1.DataPlaneDatabaseOptions{
+		Postgres: &operatorv1beta1.DataPlanePostgresOptions{
+			SecretName: "postgres",
+		},
+	}
+	deployment := withDatabaseConfiguration(newDeployment(), dp)
+	container := k8sutils.GetPodContainerByName(&deployment.Spec.Template.Spec, consts.DataPlaneProxyContainerName)
+	require.Equal(t, "postgres", k8sutils.EnvValueByName(container.Env, consts.EnvVarKongDatabase))
+	for _, env := range container.Env {
+		if env.Name == "KONG_PG_HOST" {
+			require.Equal(t, "postgres", env.ValueFrom.SecretKeyRef.Name)
+			require.Equal(t, consts.DataPlanePostgresSecretHostKey, env.ValueFrom.SecretKeyRef.Key)
+		}
+	}
+	require.Len(t, container.Env, 7)
+}
@@ -0,0 +1,132 @@
+package dataplane
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"path/filepath"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+	k8sresources "github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
+)
+
+// -----------------------------------------------------------------------------
+// DataPlane Deployment - Konnect
+// -----------------------------------------------------------------------------
+
+// validateKonnectOptions rejects the DataPlane's Konnect configuration when the
+// Konnect controllers are disabled, since nothing would ever register its
+// certificate with the Konnect control plane.
+func (r *Reconciler) validateKonnectOptions(dataplane *operatorv1beta1.DataPlane) error {
+	if dataplane.Spec.Konnect != nil && !r.KonnectControllersEnabled {
+		return errors.New("konnect can't be used when the Konnect controllers are disabled")
+	}
+	return nil
+}
+
+// konnectCertificateSecretForDataPlane returns the Secret holding the client
+// certificate the DataPlane uses to connect to its Konnect control plane.
+// It returns nil when the DataPlane is not configured to connect to Konnect or
+// the certificate hasn't been registered with the control plane yet, which is
+// done by the Konnect DataPlane controller.
+func konnectCertificateSecretForDataPlane(
+	ctx context.Context,
+	cl client.Client,
+	dataplane *operatorv1beta1.DataPlane,
+) (*corev1.Secret, error) {
+	konnectOpts := dataplane.Spec.Konnect
+	if konnectOpts == nil {
+		return nil, nil
+	}
+
+	matchingLabels := k8sresources.GetManagedLabelForOwner(dataplane)
+	matchingLabels[consts.CertPurposeLabel] = consts.DataPlaneKonnectCertificatePurposeLabelValue
+	secrets, err := k8sutils.ListSecretsForOwner(ctx, cl, dataplane.UID, client.InNamespace(dataplane.Namespace), matchingLabels)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing Konnect certificate Secrets for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+	for i := range secrets {
+		annotations := secrets[i].Annotations
+		if annotations[consts.DataPlaneKonnectControlPlaneIDAnnotation] == konnectOpts.ControlPlaneID &&
+			annotations[consts.DataPlaneKonnectCertificateIDAnnotation] != "" &&
+			annotations[consts.DataPlaneKonnectControlPlaneEndpointAnnotation] != "" &&
+			annotations[consts.DataPlaneKonnectTelemetryEndpointAnnotation] != "" {
+			return &secrets[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// withKonnectConfiguration mounts the DataPlane's Konnect client certificate
+// in the DataPlane's proxy container and configures it to connect to the Konnect
+// control plane's cluster endpoints in hybrid mode.
+func withKonnectConfiguration(deployment *k8sresources.Deployment, secret *corev1.Secret) *k8sresources.Deployment {
+	if secret == nil {
+		return deployment
+	}
+	container := k8sutils.GetPodContainerByName(&deployment.Spec.Template.Spec, consts.DataPlaneProxyContainerName)
+	if container == nil {
+		return deployment
+	}
+
+	const volumeName = "konnect-certificate"
+	deployment.WithVolume(corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secret.Name,
+			},
+		},
+	}).WithVolumeMount(corev1.VolumeMount{
+		Name:      volumeName,
+		MountPath: consts.DataPlaneKonnectCertificateMountPath,
+		ReadOnly:  true,
+	}, consts.DataPlaneProxyContainerName)
+
+	var (
+		cpAddress, cpServerName = konnectEndpointAddress(secret.Annotations[consts.DataPlaneKonnectControlPlaneEndpointAnnotation])
+		tpAddress, tpServerName = konnectEndpointAddress(secret.Annotations[consts.DataPlaneKonnectTelemetryEndpointAnnotation])
+	)
+	for _, env := range []corev1.EnvVar{
+		{Name: "KONG_ROLE", Value: "data_plane"},
+		{Name: consts.EnvVarKongDatabase, Value: "off"},
+		{Name: "KONG_KONNECT_MODE", Value: "on"},
+		{Name: "KONG_VITALS", Value: "off"},
+		{Name: "KONG_CLUSTER_MTLS", Value: "pki"},
+		{Name: "KONG_CLUSTER_CONTROL_PLANE", Value: cpAddress},
+		{Name: "KONG_CLUSTER_SERVER_NAME", Value: cpServerName},
+		{Name: "KONG_CLUSTER_TELEMETRY_ENDPOINT", Value: tpAddress},
+		{Name: "KONG_CLUSTER_TELEMETRY_SERVER_NAME", Value: tpServerName},
+		{Name: consts.ClusterCertEnvKey, Value: filepath.Join(consts.DataPlaneKonnectCertificateMountPath, consts.TLSCRT)},
+		{Name: consts.ClusterCertKeyEnvKey, Value: filepath.Join(consts.DataPlaneKonnectCertificateMountPath, consts.TLSKey)},
+		{Name: "KONG_LUA_SSL_TRUSTED_CERTIFICATE", Value: "system"},
+	} {
+		deployment.WithEnvVar(env, consts.DataPlaneProxyContainerName)
+	}
+	sort.Sort(k8sutils.SortableEnvVars(container.Env))
+
+	return deployment
+}
+
+// konnectEndpointAddress returns the host:port address and the server name of
+// the provided Konnect endpoint, e.g. https://1234abcd.us.cp0.konghq.com.
+func konnectEndpointAddress(endpoint string) (address string, serverName string) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		// Endpoint without a scheme, use it as the host.
+		u = &url.URL{Host: endpoint}
+	}
+	port := u.Port()
+	if port == "" {
+		port = "443"
+	}
+	return net.JoinHostPort(u.Hostname(), port), u.Hostname()
+}
@@ -0,0 +1,135 @@
+package dataplane
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+	k8sresources "github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
+)
+
+func TestValidateKonnectOptions(t *testing.T) {
+	dataplane := &operatorv1beta1.DataPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "dp",
+		},
+	}
+
+	t.Log("DataPlanes without konnect are accepted regardless of the controllers")
+	r := Reconciler{}
+	require.NoError(t, r.validateKonnectOptions(dataplane))
+
+	t.Log("konnect is rejected when the Konnect controllers are disabled")
+	dataplane.Spec.Konnect = &operatorv1beta1.DataPlaneKonnectOptions{ControlPlaneID: "cp-1"}
+	require.ErrorContains(t, r.validateKonnectOptions(dataplane), "Konnect controllers are disabled")
+
+	t.Log("konnect is accepted when the Konnect controllers are enabled")
+	r.KonnectControllersEnabled = true
+	require.NoError(t, r.validateKonnectOptions(dataplane))
+}
+
+func TestKonnectCertificateSecretForDataPlane(t *testing.T) {
+	ctx := context.Background()
+	dp := &operatorv1beta1.DataPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dp",
+			Namespace: "default",
+			UID:       types.UID("1234"),
+		},
+		Spec: operatorv1beta1.DataPlaneSpec{
+			DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+				Konnect: &operatorv1beta1.DataPlaneKonnectOptions{
+					ControlPlaneID: "cp-1",
+				},
+			},
+		},
+	}
+	secret := k8sresources.GenerateNewTLSSecret(dp,
+		k8sresources.SecretWithLabel(consts.CertPurposeLabel, consts.DataPlaneKonnectCertificatePurposeLabelValue),
+	)
+	secret.Name = "konnect-certificate"
+	secret.Annotations = map[string]string{
+		consts.DataPlaneKonnectControlPlaneIDAnnotation: "cp-1",
+	}
+	fakeClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(dp, secret).
+		Build()
+
+	t.Log("Secret is not used until the certificate is registered")
+	s, err := konnectCertificateSecretForDataPlane(ctx, fakeClient, dp)
+	require.NoError(t, err)
+	require.Nil(t, s)
+
+	secret.Annotations[consts.DataPlaneKonnectCertificateIDAnnotation] = "cert-1"
+	secret.Annotations[consts.DataPlaneKonnectControlPlaneEndpointAnnotation] = "https://cp.konghq.tech"
+	secret.Annotations[consts.DataPlaneKonnectTelemetryEndpointAnnotation] = "https://tp.konghq.tech"
+	require.NoError(t, fakeClient.Update(ctx, secret))
+	s, err = konnectCertificateSecretForDataPlane(ctx, fakeClient, dp)
+	require.NoError(t, err)
+	require.NotNil(t, s)
+	require.Equal(t, "konnect-certificate", s.Name)
+
+	t.Log("Secret is not used when it's registered with another control plane")
+	dp.Spec.Konnect.ControlPlaneID = "cp-2"
+	s, err = konnectCertificateSecretForDataPlane(ctx, fakeClient, dp)
+	require.NoError(t, err)
+	require.Nil(t, s)
+}
+
+func TestWithKonnectConfiguration(t *testing.T) {
+	newDeployment := func() *k8sresources.Deployment {
+		return &k8sresources.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: consts.DataPlaneProxyContainerName},
+						},
+					},
+				},
+			},
+		}
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "konnect-certificate",
+			Annotations: map[string]string{
+				consts.DataPlaneKonnectControlPlaneEndpointAnnotation: "https://1234abcd.us.cp0.konghq.com",
+				consts.DataPlaneKonnectTelemetryEndpointAnnotation:    "https://1234abcd.us.tp0.konghq.com:8443",
+			},
+		},
+	}
+
+	require.Equal(t, newDeployment(), withKonnectConfiguration(newDeployment(), nil))
+
+	deployment := withKonnectConfiguration(newDeployment(), secret)
+	podSpec := &deployment.Spec.Template.Spec
+	require.Len(t, podSpec.Volumes, 1)
+	require.Equal(t, "konnect-certificate", podSpec.Volumes[0].Secret.SecretName)
+	container := k8sutils.GetPodContainerByName(podSpec, consts.DataPlaneProxyContainerName)
+	for name, value := range map[string]string{
+		"KONG_ROLE":                          "data_plane",
+		"KONG_CLUSTER_MTLS":                  "pki",
+		"KONG_CLUSTER_CONTROL_PLANE":         "1234abcd.us.cp0.konghq.com:443",
+		"KONG_CLUSTER_SERVER_NAME":           "1234abcd.us.cp0.konghq.com",
+		"KONG_CLUSTER_TELEMETRY_ENDPOINT":    "1234abcd.us.tp0.konghq.com:8443",
+		"KONG_CLUSTER_TELEMETRY_SERVER_NAME": "1234abcd.us.tp0.konghq.com",
+		"KONG_CLUSTER_CERT":                  "/etc/kong/konnect-certificate/tls.crt",
+		"KONG_CLUSTER_CERT_KEY":              "/etc/kong/konnect-certificate/tls.key",
+	} {
+		require.Equal(t, value, k8sutils.EnvValueByName(container.Env, name), name)
+	}
+}
@@ -143,6 +143,30 @@ func (d *DeploymentBuilder) BuildAndDeploy(
 	// apply default envvars and restore the hacked-out ones
 	desiredDeployment = applyEnvForDataPlane(existingEnvVars, desiredDeployment)
 
+	// install custom plugins configured with KongPluginInstallations
+	plugins, err := customPluginsForDataPlane(ctx, d.client, dataplane)
+	if err != nil {
+		return nil, op.Noop, err
+	}
+	desiredDeployment = withCustomPlugins(desiredDeployment, plugins)
+
+	// forward requests matching the preview routing to the preview pods
+	previewRoutingConfigMap, err := previewRoutingConfigMapForDataPlane(ctx, d.client, dataplane)
+	if err != nil {
+		return nil, op.Noop, err
+	}
+	desiredDeployment = withPreviewRouting(desiredDeployment, previewRoutingConfigMap)
+
+	// connect to the Konnect control plane once the certificate is registered with it
+	konnectCertificateSecret, err := konnectCertificateSecretForDataPlane(ctx, d.client, dataplane)
+	if err != nil {
+		return nil, op.Noop, err
+	}
+	desiredDeployment = withKonnectConfiguration(desiredDeployment, konnectCertificateSecret)
+
+	// use the database configured for the DataPlane
+	desiredDeployment = withDatabaseConfiguration(desiredDeployment, dataplane)
+
 	// push the complete Deployment to Kubernetes
 	res, deployment, err := reconcileDataPlaneDeployment(ctx, d.client, d.logger,
 		dataplane, existingDeployment, desiredDeployment.Unwrap())
@@ -165,11 +189,7 @@ func generateDataPlaneDeployment(
 		opts = append(opts, matchingLabelsToDeploymentOpt(additionalDeploymentLabels))
 	}
 
-	versionValidationOptions := make([]versions.VersionValidationOption, 0)
-	if !developmentMode {
-		versionValidationOptions = append(versionValidationOptions, versions.IsDataPlaneImageVersionSupported)
-	}
-	dataplaneImage, err := generateDataPlaneImage(dataplane, defaultImage, versionValidationOptions...)
+	dataplaneImage, err := generateDataPlaneImageForMode(developmentMode, dataplane, defaultImage)
 	if err != nil {
 		return nil, err
 	}
@@ -181,6 +201,20 @@ func generateDataPlaneDeployment(
 	return generatedDeployment, nil
 }
 
+// generateDataPlaneImageForMode determines the image of the DataPlane. The image's
+// version is validated unless the operator runs in development mode.
+func generateDataPlaneImageForMode(
+	developmentMode bool,
+	dataplane *operatorv1beta1.DataPlane,
+	defaultImage string,
+) (string, error) {
+	versionValidationOptions := make([]versions.VersionValidationOption, 0)
+	if !developmentMode {
+		versionValidationOptions = append(versionValidationOptions, versions.IsDataPlaneImageVersionSupported)
+	}
+	return generateDataPlaneImage(dataplane, defaultImage, versionValidationOptions...)
+}
+
 // applyDeploymentUserPatchesForDataPlane applies user PodTemplateSpec patches and fills in defaults
 // for any previously unset environment variables.
 func applyDeploymentUserPatchesForDataPlane(
@@ -0,0 +1,177 @@
+package dataplane
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/kong/gateway-operator/api/v1alpha1"
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+	k8sresources "github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
+)
+
+// customPlugin is a custom Kong plugin installed in a DataPlane with a KongPluginInstallation.
+type customPlugin struct {
+	// Name is the name of the plugin, it's the name of the KongPluginInstallation.
+	Name string
+	// ConfigMapName is the name of the ConfigMap holding the plugin's files.
+	ConfigMapName string
+	// Data is the content of the ConfigMap holding the plugin's files.
+	Data map[string]string
+}
+
+// kongPluginInstallationNotReadyError is returned by customPluginsForDataPlane when a
+// KongPluginInstallation used by the DataPlane is not accepted yet.
+type kongPluginInstallationNotReadyError struct {
+	kongPluginInstallation types.NamespacedName
+}
+
+func (e kongPluginInstallationNotReadyError) Error() string {
+	return fmt.Sprintf("KongPluginInstallation %s is not ready yet", e.kongPluginInstallation)
+}
+
+// validate validates the DataPlane with the configured validator and rejects
+// plugins to install when the KongPluginInstallation controller is disabled,
+// since nothing would ever make the referenced KongPluginInstallations ready.
+func (r *Reconciler) validate(dataplane *operatorv1beta1.DataPlane) error {
+	if err := r.Validator.Validate(dataplane); err != nil {
+		return err
+	}
+	if len(dataplane.Spec.PluginsToInstall) > 0 && !r.KongPluginInstallationControllerEnabled {
+		return errors.New("pluginsToInstall can't be used when the KongPluginInstallation controller is disabled")
+	}
+	return nil
+}
+
+// customPluginsForDataPlane returns custom plugins that have to be installed in the DataPlane, based on
+// the KongPluginInstallations listed in its spec. It returns a kongPluginInstallationNotReadyError when
+// any of them is not ready yet.
+func customPluginsForDataPlane(
+	ctx context.Context,
+	cl client.Client,
+	dataplane *operatorv1beta1.DataPlane,
+) ([]customPlugin, error) {
+	plugins := make([]customPlugin, 0, len(dataplane.Spec.PluginsToInstall))
+	for _, ref := range dataplane.Spec.PluginsToInstall {
+		nn := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+		if nn.Namespace == "" {
+			nn.Namespace = dataplane.Namespace
+		}
+
+		var kpi operatorv1alpha1.KongPluginInstallation
+		if err := cl.Get(ctx, nn, &kpi); err != nil {
+			return nil, fmt.Errorf("failed to get KongPluginInstallation %s: %w", nn, err)
+		}
+		c, ok := k8sutils.GetCondition(consts.ConditionType(operatorv1alpha1.KongPluginInstallationConditionStatusAccepted), &kpi)
+		if !ok || c.Status != metav1.ConditionTrue || c.ObservedGeneration != kpi.Generation ||
+			kpi.Status.UnderlyingConfigMapName == "" {
+			return nil, kongPluginInstallationNotReadyError{kongPluginInstallation: nn}
+		}
+
+		var cm corev1.ConfigMap
+		cmNN := types.NamespacedName{Namespace: kpi.Namespace, Name: kpi.Status.UnderlyingConfigMapName}
+		if err := cl.Get(ctx, cmNN, &cm); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil, fmt.Errorf("ConfigMap %s of KongPluginInstallation %s not found", cmNN, nn)
+			}
+			return nil, fmt.Errorf("failed to get ConfigMap %s of KongPluginInstallation %s: %w", cmNN, nn, err)
+		}
+
+		plugins = append(plugins, customPlugin{
+			Name:          kpi.Name,
+			ConfigMapName: cm.Name,
+			Data:          cm.Data,
+		})
+	}
+	return plugins, nil
+}
+
+// withCustomPlugins mounts the custom plugins' files in the DataPlane's proxy container, enables the plugins
+// with the KONG_PLUGINS environment variable (on top of what's already configured) and makes them loadable
+// through KONG_LUA_PACKAGE_PATH. It has to be run after all the environment variables are set, since it
+// extends their values.
+func withCustomPlugins(deployment *k8sresources.Deployment, plugins []customPlugin) *k8sresources.Deployment {
+	if len(plugins) == 0 {
+		return deployment
+	}
+	container := k8sutils.GetPodContainerByName(&deployment.Spec.Template.Spec, consts.DataPlaneProxyContainerName)
+	if container == nil {
+		return deployment
+	}
+
+	names := make([]string, 0, len(plugins))
+	for i, plugin := range plugins {
+		volumeName := fmt.Sprintf("custom-plugin-%d", i)
+		deployment.WithVolume(corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: plugin.ConfigMapName},
+				},
+			},
+		}).WithVolumeMount(corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: filepath.Join(consts.DataPlanePluginsMountPath, "kong", "plugins", plugin.Name),
+			ReadOnly:  true,
+		}, consts.DataPlaneProxyContainerName)
+		names = append(names, plugin.Name)
+	}
+
+	kongPlugins := k8sutils.EnvValueByName(container.Env, "KONG_PLUGINS")
+	if kongPlugins == "" {
+		kongPlugins = "bundled"
+	}
+	kongPlugins = strings.Join(append([]string{kongPlugins}, names...), ",")
+
+	luaPackagePath := filepath.Join(consts.DataPlanePluginsMountPath, "?.lua") + ";"
+	if existing := k8sutils.EnvValueByName(container.Env, "KONG_LUA_PACKAGE_PATH"); existing != "" {
+		luaPackagePath += existing
+	} else {
+		// The trailing ";;" appends the default Lua package path.
+		luaPackagePath += ";"
+	}
+
+	deployment.
+		WithEnvVar(corev1.EnvVar{Name: "KONG_PLUGINS", Value: kongPlugins}, consts.DataPlaneProxyContainerName).
+		WithEnvVar(corev1.EnvVar{Name: "KONG_LUA_PACKAGE_PATH", Value: luaPackagePath}, consts.DataPlaneProxyContainerName)
+	sort.Sort(k8sutils.SortableEnvVars(container.Env))
+
+	if deployment.Spec.Template.Annotations == nil {
+		deployment.Spec.Template.Annotations = make(map[string]string)
+	}
+	deployment.Spec.Template.Annotations[consts.DataPlanePluginsChecksumAnnotation] = customPluginsChecksum(plugins)
+
+	return deployment
+}
+
+// customPluginsChecksum returns a checksum of the content of the custom plugins. Setting it on the pod
+// template ensures that pods are rolled out when any of the plugins changes, since Kong doesn't reload
+// plugins' code on its own.
+func customPluginsChecksum(plugins []customPlugin) string {
+	h := sha256.New()
+	for _, plugin := range plugins {
+		fmt.Fprintf(h, "%s\x00", plugin.Name)
+		files := make([]string, 0, len(plugin.Data))
+		for f := range plugin.Data {
+			files = append(files, f)
+		}
+		sort.Strings(files)
+		for _, f := range files {
+			fmt.Fprintf(h, "%s\x00%s\x00", f, plugin.Data[f])
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
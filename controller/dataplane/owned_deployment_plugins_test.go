@@ -0,0 +1,332 @@
+package dataplane
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1alpha1 "github.com/kong/gateway-operator/api/v1alpha1"
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	dpv "github.com/kong/gateway-operator/internal/validation/dataplane"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+	"github.com/kong/gateway-operator/test/helpers"
+)
+
+func TestDeploymentBuilderWithCustomPlugins(t *testing.T) {
+	kpi := func(name string, ready bool) *operatorv1alpha1.KongPluginInstallation {
+		kpi := &operatorv1alpha1.KongPluginInstallation{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  "default",
+				Name:       name,
+				Generation: 1,
+			},
+		}
+		if ready {
+			kpi.Status = operatorv1alpha1.KongPluginInstallationStatus{
+				UnderlyingConfigMapName: name + "-cm",
+				Conditions: []metav1.Condition{
+					{
+						Type:               string(operatorv1alpha1.KongPluginInstallationConditionStatusAccepted),
+						Status:             metav1.ConditionTrue,
+						Reason:             string(operatorv1alpha1.KongPluginInstallationReasonReady),
+						ObservedGeneration: 1,
+					},
+				},
+			}
+		}
+		return kpi
+	}
+	configMap := func(name, handler string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+			},
+			Data: map[string]string{
+				"handler.lua": handler,
+				"schema.lua":  "return {}",
+			},
+		}
+	}
+	dataPlane := func(env []corev1.EnvVar, plugins ...string) *operatorv1beta1.DataPlane {
+		dp := &operatorv1beta1.DataPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test",
+			},
+		}
+		for _, p := range plugins {
+			dp.Spec.PluginsToInstall = append(dp.Spec.PluginsToInstall, operatorv1beta1.NamespacedName{Name: p})
+		}
+		if len(env) > 0 {
+			dp.Spec.Deployment.PodTemplateSpec = &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: consts.DataPlaneProxyContainerName,
+							Env:  env,
+						},
+					},
+				},
+			}
+		}
+		return dp
+	}
+
+	testCases := []struct {
+		name                   string
+		dataPlane              *operatorv1beta1.DataPlane
+		objects                []client.Object
+		expectedErr            string
+		expectNotReadyErr      bool
+		expectedKongPlugins    string
+		expectedLuaPackagePath string
+		expectedMounts         map[string]string
+	}{
+		{
+			name:      "plugins are mounted and enabled",
+			dataPlane: dataPlane(nil, "myheader", "other"),
+			objects: []client.Object{
+				kpi("myheader", true), configMap("myheader-cm", "return {}"),
+				kpi("other", true), configMap("other-cm", "return {}"),
+			},
+			expectedKongPlugins:    "bundled,myheader,other",
+			expectedLuaPackagePath: "/opt/?.lua;;",
+			expectedMounts: map[string]string{
+				"/opt/kong/plugins/myheader": "myheader-cm",
+				"/opt/kong/plugins/other":    "other-cm",
+			},
+		},
+		{
+			name: "plugins are appended to user provided configuration",
+			dataPlane: dataPlane([]corev1.EnvVar{
+				{Name: "KONG_PLUGINS", Value: "bundled,custom"},
+				{Name: "KONG_LUA_PACKAGE_PATH", Value: "/custom/?.lua;;"},
+			}, "myheader"),
+			objects: []client.Object{
+				kpi("myheader", true), configMap("myheader-cm", "return {}"),
+			},
+			expectedKongPlugins:    "bundled,custom,myheader",
+			expectedLuaPackagePath: "/opt/?.lua;/custom/?.lua;;",
+			expectedMounts: map[string]string{
+				"/opt/kong/plugins/myheader": "myheader-cm",
+			},
+		},
+		{
+			name:        "KongPluginInstallation does not exist",
+			dataPlane:   dataPlane(nil, "myheader"),
+			expectedErr: "failed to get KongPluginInstallation default/myheader",
+		},
+		{
+			name:      "KongPluginInstallation is not ready",
+			dataPlane: dataPlane(nil, "myheader"),
+			objects: []client.Object{
+				kpi("myheader", false),
+			},
+			expectedErr:       "KongPluginInstallation default/myheader is not ready yet",
+			expectNotReadyErr: true,
+		},
+		{
+			name:      "ConfigMap of KongPluginInstallation does not exist",
+			dataPlane: dataPlane(nil, "myheader"),
+			objects: []client.Object{
+				kpi("myheader", true),
+			},
+			expectedErr: "ConfigMap default/myheader-cm of KongPluginInstallation default/myheader not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fakeClient := fakectrlruntimeclient.
+				NewClientBuilder().
+				WithObjects(append(tc.objects, tc.dataPlane)...).
+				WithScheme(scheme.Scheme).
+				Build()
+
+			deployment, _, err := NewDeploymentBuilder(logr.Discard(), fakeClient).
+				WithClusterCertificate("certificate").
+				BuildAndDeploy(ctx, tc.dataPlane, true)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				if tc.expectNotReadyErr {
+					require.ErrorAs(t, err, &kongPluginInstallationNotReadyError{})
+				}
+				return
+			}
+			require.NoError(t, err)
+
+			container := k8sutils.GetPodContainerByName(&deployment.Spec.Template.Spec, consts.DataPlaneProxyContainerName)
+			require.NotNil(t, container)
+			require.Equal(t, tc.expectedKongPlugins, k8sutils.EnvValueByName(container.Env, "KONG_PLUGINS"))
+			require.Equal(t, tc.expectedLuaPackagePath, k8sutils.EnvValueByName(container.Env, "KONG_LUA_PACKAGE_PATH"))
+
+			mounts := make(map[string]string)
+			for _, vm := range container.VolumeMounts {
+				for _, v := range deployment.Spec.Template.Spec.Volumes {
+					if v.Name == vm.Name && v.ConfigMap != nil {
+						mounts[vm.MountPath] = v.ConfigMap.Name
+					}
+				}
+			}
+			require.Equal(t, tc.expectedMounts, mounts)
+			checksum := deployment.Spec.Template.Annotations[consts.DataPlanePluginsChecksumAnnotation]
+			require.NotEmpty(t, checksum)
+
+			t.Log("changing the content of a plugin should change the checksum to trigger a rollout")
+			var cm corev1.ConfigMap
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "myheader-cm"}, &cm))
+			cm.Data["handler.lua"] = "return { VERSION = \"2.0\" }"
+			require.NoError(t, fakeClient.Update(ctx, &cm))
+			deployment, _, err = NewDeploymentBuilder(logr.Discard(), fakeClient).
+				WithClusterCertificate("certificate").
+				BuildAndDeploy(ctx, tc.dataPlane, true)
+			require.NoError(t, err)
+			require.NotNil(t, deployment)
+			require.NotEqual(t, checksum, deployment.Spec.Template.Annotations[consts.DataPlanePluginsChecksumAnnotation])
+		})
+	}
+}
+
+func TestReconcilerValidatePluginsToInstall(t *testing.T) {
+	dataplane := &operatorv1beta1.DataPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "dp",
+		},
+		Spec: operatorv1beta1.DataPlaneSpec{
+			DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+				Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+					DeploymentOptions: operatorv1beta1.DeploymentOptions{
+						PodTemplateSpec: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:  consts.DataPlaneProxyContainerName,
+										Image: consts.DefaultDataPlaneImage,
+									},
+								},
+							},
+						},
+					},
+				},
+				PluginsToInstall: []operatorv1beta1.NamespacedName{{Name: "myheader"}},
+			},
+		},
+	}
+
+	t.Log("pluginsToInstall is rejected when the KongPluginInstallation controller is disabled")
+	r := Reconciler{Validator: dpv.NewValidator(nil)}
+	require.ErrorContains(t, r.validate(dataplane), "KongPluginInstallation controller is disabled")
+
+	t.Log("pluginsToInstall is accepted when the KongPluginInstallation controller is enabled")
+	r.KongPluginInstallationControllerEnabled = true
+	require.NoError(t, r.validate(dataplane))
+
+	t.Log("DataPlanes without pluginsToInstall are accepted regardless of the controller")
+	r.KongPluginInstallationControllerEnabled = false
+	dataplane.Spec.PluginsToInstall = nil
+	require.NoError(t, r.validate(dataplane))
+}
+
+func TestReconcilerMarksDataPlaneNotReadyForNotReadyKongPluginInstallation(t *testing.T) {
+	ca := helpers.CreateCA(t)
+	mtlsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "mtls-secret",
+		},
+		Data: map[string][]byte{
+			"tls.crt": ca.CertPEM.Bytes(),
+			"tls.key": ca.KeyPEM.Bytes(),
+		},
+	}
+	kpi := &operatorv1alpha1.KongPluginInstallation{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "default",
+			Name:       "myheader",
+			Generation: 1,
+		},
+	}
+	dp := &operatorv1beta1.DataPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "dp",
+			UID:       types.UID(uuid.NewString()),
+		},
+		Spec: operatorv1beta1.DataPlaneSpec{
+			DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+				Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+					DeploymentOptions: operatorv1beta1.DeploymentOptions{
+						PodTemplateSpec: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:  consts.DataPlaneProxyContainerName,
+										Image: consts.DefaultDataPlaneImage,
+									},
+								},
+							},
+						},
+					},
+				},
+				PluginsToInstall: []operatorv1beta1.NamespacedName{{Name: "myheader"}},
+			},
+		},
+	}
+
+	fakeClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(dp, kpi, mtlsSecret).
+		WithStatusSubresource(dp, &corev1.Service{}).
+		Build()
+	r := Reconciler{
+		Client:                                  fakeClient,
+		ClusterCASecretName:                     mtlsSecret.Name,
+		ClusterCASecretNamespace:                mtlsSecret.Namespace,
+		Validator:                               dpv.NewValidator(fakeClient),
+		KongPluginInstallationControllerEnabled: true,
+	}
+
+	t.Log("reconciling until the DataPlane's Deployment would be built")
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(dp)}
+	for i := 0; i < 10; i++ {
+		res, err := r.Reconcile(ctx, req)
+		require.NoError(t, err, "a not ready KongPluginInstallation shouldn't fail the reconciliation")
+		require.Zero(t, res.RequeueAfter)
+
+		// The fake client doesn't allocate addresses for the DataPlane's Services.
+		var services corev1.ServiceList
+		require.NoError(t, fakeClient.List(ctx, &services, client.InNamespace(dp.Namespace)))
+		for i := range services.Items {
+			if svc := &services.Items[i]; svc.Spec.ClusterIP == "" {
+				svc.Spec.ClusterIP = "10.0.0.1"
+				require.NoError(t, fakeClient.Update(ctx, svc))
+				svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "1.2.3.4"}}
+				require.NoError(t, fakeClient.Status().Update(ctx, svc))
+			}
+		}
+	}
+
+	t.Log("the DataPlane should be marked not ready because of the KongPluginInstallation")
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, dp))
+	c, ok := k8sutils.GetCondition(consts.ReadyType, dp)
+	require.True(t, ok, "DataPlane should have a Ready condition set")
+	require.Equal(t, metav1.ConditionFalse, c.Status)
+	require.Equal(t, string(DataPlaneConditionReasonKongPluginInstallationNotReady), c.Reason)
+	require.Equal(t, "KongPluginInstallation default/myheader is not ready yet", c.Message)
+}
@@ -11,6 +11,7 @@ import (
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	certificatesv1 "k8s.io/api/certificates/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
@@ -29,25 +30,40 @@ import (
 )
 
 // ensureDataPlaneCertificate ensures that a certificate exists for the given dataplane.
-// Said certificate is used to secure the Admin API.
+// Said certificate is used to secure the Admin API. It's issued by the cluster CA
+// or, when configured, requested from cert-manager in which case
+// secrets.ErrCertificateNotReady is returned until it's issued.
 func ensureDataPlaneCertificate(
 	ctx context.Context,
 	cl client.Client,
 	dataplane *operatorv1beta1.DataPlane,
 	clusterCASecretNN types.NamespacedName,
 	adminServiceNN types.NamespacedName,
+	opts ...secrets.CertificateOpt,
 ) (op.Result, *corev1.Secret, error) {
 	usages := []certificatesv1.KeyUsage{
 		certificatesv1.UsageKeyEncipherment,
 		certificatesv1.UsageDigitalSignature, certificatesv1.UsageServerAuth,
 	}
+	subject := fmt.Sprintf("*.%s.%s.svc", adminServiceNN.Name, adminServiceNN.Namespace)
+	if certOpts := dataplane.Spec.Network.ClusterCertificateOptions; certOpts != nil {
+		return secrets.EnsureCertManagerCertificate(ctx,
+			dataplane,
+			subject,
+			certOpts.Issuer,
+			usages,
+			cl,
+			secrets.GetManagedLabelForServiceSecret(adminServiceNN),
+		)
+	}
 	return secrets.EnsureCertificate(ctx,
 		dataplane,
-		fmt.Sprintf("*.%s.%s.svc", adminServiceNN.Name, adminServiceNN.Namespace),
+		subject,
 		clusterCASecretNN,
 		usages,
 		cl,
 		secrets.GetManagedLabelForServiceSecret(adminServiceNN),
+		opts...,
 	)
 }
 
@@ -113,6 +129,70 @@ func ensureHPAForDataPlane(
 	return op.Created, nil, nil
 }
 
+// ensurePodDisruptionBudgetForDataPlane ensures that the PodDisruptionBudget
+// configured in the DataPlane's spec.resources.podDisruptionBudget exists and is
+// up to date. The PodDisruptionBudget is deleted when it's not configured.
+func ensurePodDisruptionBudgetForDataPlane(
+	ctx context.Context,
+	cl client.Client,
+	log logr.Logger,
+	dataplane *operatorv1beta1.DataPlane,
+) (res op.Result, pdb *policyv1.PodDisruptionBudget, err error) {
+	matchingLabels := k8sresources.GetManagedLabelForOwner(dataplane)
+	pdbs, err := k8sutils.ListPodDisruptionBudgetsForOwner(
+		ctx,
+		cl,
+		dataplane.Namespace,
+		dataplane.UID,
+		matchingLabels,
+	)
+	if err != nil {
+		return op.Noop, nil, fmt.Errorf("failed listing PodDisruptionBudgets for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+
+	if dataplane.Spec.Resources.PodDisruptionBudget == nil {
+		if err := k8sreduce.ReducePodDisruptionBudgets(ctx, cl, pdbs, k8sreduce.FilterNone); err != nil {
+			return op.Noop, nil, fmt.Errorf("failed reducing PodDisruptionBudgets for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+		}
+		return op.Noop, nil, nil
+	}
+
+	if len(pdbs) > 1 {
+		if err := k8sreduce.ReducePodDisruptionBudgets(ctx, cl, pdbs, k8sreduce.FilterPodDisruptionBudgets); err != nil {
+			return op.Noop, nil, fmt.Errorf("failed reducing PodDisruptionBudgets for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+		}
+		return op.Noop, nil, nil
+	}
+
+	generatedPDB, err := k8sresources.GeneratePodDisruptionBudgetForDataPlane(dataplane)
+	if err != nil {
+		return op.Noop, nil, err
+	}
+
+	if len(pdbs) == 1 {
+		var updated bool
+		existingPDB := &pdbs[0]
+		oldExistingPDB := existingPDB.DeepCopy()
+
+		// ensure that object metadata is up to date
+		updated, existingPDB.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existingPDB.ObjectMeta, generatedPDB.ObjectMeta)
+
+		// ensure that the disruption budget and the selector are up to date
+		if !cmp.Equal(existingPDB.Spec, generatedPDB.Spec) {
+			existingPDB.Spec = generatedPDB.Spec
+			updated = true
+		}
+
+		return patch.ApplyPatchIfNonEmpty(ctx, cl, log, existingPDB, oldExistingPDB, dataplane, updated)
+	}
+
+	if err = cl.Create(ctx, generatedPDB); err != nil {
+		return op.Noop, nil, fmt.Errorf("failed creating PodDisruptionBudget for DataPlane %s: %w", dataplane.Name, err)
+	}
+
+	return op.Created, generatedPDB, nil
+}
+
 func matchingLabelsToServiceOpt(ml client.MatchingLabels) k8sresources.ServiceOpt {
 	return func(s *corev1.Service) {
 		if s.Labels == nil {
@@ -208,20 +288,62 @@ func ensureAdminServiceForDataPlane(
 	if err != nil {
 		return op.Noop, nil, err
 	}
+	addAnnotationsForDataPlaneAdminAPIService(generatedService, *dataPlane)
+
+	// Headless Services can't be turned into Services with a cluster IP and vice versa,
+	// hence the existing Service is recreated when that changes.
+	if count == 1 && (services[0].Spec.ClusterIP == corev1.ClusterIPNone) != (generatedService.Spec.ClusterIP == corev1.ClusterIPNone) {
+		if err := dataplane.OwnedObjectPreDeleteHook(ctx, cl, &services[0]); err != nil {
+			return op.Noop, nil, err
+		}
+		if err := client.IgnoreNotFound(cl.Delete(ctx, &services[0])); err != nil {
+			return op.Noop, nil, fmt.Errorf("failed deleting DataPlane Service %s: %w", services[0].Name, err)
+		}
+		count = 0
+	}
 
 	if count == 1 {
-		var updated bool
+		var (
+			updated       bool
+			annotationErr error
+		)
 		existingService := &services[0]
-		updated, existingService.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existingService.ObjectMeta, generatedService.ObjectMeta)
+		updated, existingService.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existingService.ObjectMeta, generatedService.ObjectMeta,
+			// enforce all the annotations provided through the dataplane API
+			func(existingMeta metav1.ObjectMeta, generatedMeta metav1.ObjectMeta) (bool, metav1.ObjectMeta) {
+				metaToUpdate, updatedAnnotations, err := ensureDataPlaneServiceAnnotationsUpdated(
+					extractDataPlaneAdminAPIServiceAnnotations(dataPlane), existingMeta.Annotations, generatedMeta.Annotations,
+				)
+				if err != nil {
+					annotationErr = err
+					return false, existingMeta
+				}
+				existingMeta.Annotations = updatedAnnotations
+				return metaToUpdate, existingMeta
+			})
+		if annotationErr != nil {
+			return op.Noop, existingService, fmt.Errorf("failed updating annotations of DataPlane Service %s: %w", existingService.Name, annotationErr)
+		}
 
 		if existingService.Spec.Type != generatedService.Spec.Type {
 			existingService.Spec.Type = generatedService.Spec.Type
 			updated = true
 		}
+		if existingService.Spec.ExternalTrafficPolicy != generatedService.Spec.ExternalTrafficPolicy {
+			existingService.Spec.ExternalTrafficPolicy = generatedService.Spec.ExternalTrafficPolicy
+			updated = true
+		}
 		if !cmp.Equal(existingService.Spec.Selector, generatedService.Spec.Selector) {
 			existingService.Spec.Selector = generatedService.Spec.Selector
 			updated = true
 		}
+		if !cmp.Equal(generatedService.Spec.Ports, existingService.Spec.Ports, cmp.FilterPath(func(p cmp.Path) bool {
+			// NodePort is assigned by the K8S controlplane components.
+			return p.Last().String() == ".NodePort"
+		}, cmp.Ignore())) {
+			existingService.Spec.Ports = generatedService.Spec.Ports
+			updated = true
+		}
 		if !cmp.Equal(existingService.Labels, generatedService.Labels) {
 			existingService.Labels = generatedService.Labels
 			updated = true
@@ -252,6 +374,62 @@ func ensureIngressServiceForDataPlane(
 	dataPlane *operatorv1beta1.DataPlane,
 	additionalServiceLabels client.MatchingLabels,
 	opts ...k8sresources.ServiceOpt,
+) (op.Result, *corev1.Service, error) {
+	return ensureIngressServiceOfTypeForDataPlane(ctx, logger, cl, dataPlane,
+		consts.DataPlaneIngressServiceLabelValue, additionalServiceLabels, opts...)
+}
+
+// ensurePrivateIngressServiceForDataPlane ensures private ingress service with
+// metadata and spec generated from the dataplane's private ingress options.
+// When the dataplane doesn't have the private ingress configured, the existing
+// private ingress services are deleted.
+func ensurePrivateIngressServiceForDataPlane(
+	ctx context.Context,
+	logger logr.Logger,
+	cl client.Client,
+	dataPlane *operatorv1beta1.DataPlane,
+	additionalServiceLabels client.MatchingLabels,
+	opts ...k8sresources.ServiceOpt,
+) (op.Result, *corev1.Service, error) {
+	if dataPlane.Spec.Network.Services == nil || dataPlane.Spec.Network.Services.PrivateIngress == nil {
+		matchingLabels := k8sresources.GetManagedLabelForOwner(dataPlane)
+		matchingLabels[consts.DataPlaneServiceTypeLabel] = string(consts.DataPlanePrivateIngressServiceLabelValue)
+		for k, v := range additionalServiceLabels {
+			matchingLabels[k] = v
+		}
+		services, err := k8sutils.ListServicesForOwner(ctx, cl, dataPlane.Namespace, dataPlane.UID, matchingLabels)
+		if err != nil {
+			return op.Noop, nil, fmt.Errorf("failed listing Services for DataPlane %s/%s: %w", dataPlane.Namespace, dataPlane.Name, err)
+		}
+		if len(services) == 0 {
+			return op.Noop, nil, nil
+		}
+		if err := removeObjectSliceWithDataPlaneOwnedFinalizer(ctx, cl, services); err != nil {
+			return op.Noop, nil, fmt.Errorf("failed deleting private ingress Services for DataPlane %s/%s: %w", dataPlane.Namespace, dataPlane.Name, err)
+		}
+		return op.Deleted, nil, nil
+	}
+
+	// The private ingress service is generated the same way as the ingress service,
+	// hence the dataplane's ingress options are replaced with the private ones.
+	privateDataPlane := dataPlane.DeepCopy()
+	privateDataPlane.Spec.Network.Services.Ingress = privateDataPlane.Spec.Network.Services.PrivateIngress
+	opts = append(opts,
+		k8sresources.ServicePortsFromDataPlaneIngressOpt(privateDataPlane),
+		k8sresources.PrivateIngressServiceOpt(privateDataPlane),
+	)
+	return ensureIngressServiceOfTypeForDataPlane(ctx, logger, cl, privateDataPlane,
+		consts.DataPlanePrivateIngressServiceLabelValue, additionalServiceLabels, opts...)
+}
+
+func ensureIngressServiceOfTypeForDataPlane(
+	ctx context.Context,
+	logger logr.Logger,
+	cl client.Client,
+	dataPlane *operatorv1beta1.DataPlane,
+	serviceType consts.ServiceType,
+	additionalServiceLabels client.MatchingLabels,
+	opts ...k8sresources.ServiceOpt,
 ) (op.Result, *corev1.Service, error) {
 	// TODO: https://github.com/Kong/gateway-operator/issues/156.
 	// Use only new labels after several minor version of soak time.
@@ -262,7 +440,7 @@ func ensureIngressServiceForDataPlane(
 
 	// Get the Services for the DataPlane using new labels.
 	matchingLabels := k8sresources.GetManagedLabelForOwner(dataPlane)
-	matchingLabels[consts.DataPlaneServiceTypeLabel] = string(consts.DataPlaneIngressServiceLabelValue)
+	matchingLabels[consts.DataPlaneServiceTypeLabel] = string(serviceType)
 	for k, v := range additionalServiceLabels {
 		matchingLabels[k] = v
 	}
@@ -279,36 +457,39 @@ func ensureIngressServiceForDataPlane(
 	}
 
 	// Get the Services for the DataPlane using legacy labels.
-	reqLegacyLabels, err := k8sresources.GetManagedLabelRequirementsForOwnerLegacy(dataPlane)
-	if err != nil {
-		return op.Noop, nil, err
-	}
-	reqLegacyServiceType, err := labels.NewRequirement(
-		consts.DataPlaneServiceTypeLabelLegacy, selection.Equals, []string{string(consts.DataPlaneProxyServiceLabelValueLegacy)},
-	)
-	if err != nil {
-		return op.Noop, nil, err
-	}
-	servicesLegacy, err := k8sutils.ListServicesForOwner(
-		ctx,
-		cl,
-		dataPlane.Namespace,
-		dataPlane.UID,
-		&client.ListOptions{
-			LabelSelector: labels.NewSelector().Add(*reqLegacyServiceType).Add(reqLegacyLabels...),
-		},
-	)
-	if err != nil {
-		return op.Noop, nil, fmt.Errorf("failed listing Services for DataPlane %s/%s: %w", dataPlane.Namespace, dataPlane.Name, err)
+	// Private ingress services have never used them.
+	if serviceType == consts.DataPlaneIngressServiceLabelValue {
+		reqLegacyLabels, err := k8sresources.GetManagedLabelRequirementsForOwnerLegacy(dataPlane)
+		if err != nil {
+			return op.Noop, nil, err
+		}
+		reqLegacyServiceType, err := labels.NewRequirement(
+			consts.DataPlaneServiceTypeLabelLegacy, selection.Equals, []string{string(consts.DataPlaneProxyServiceLabelValueLegacy)},
+		)
+		if err != nil {
+			return op.Noop, nil, err
+		}
+		servicesLegacy, err := k8sutils.ListServicesForOwner(
+			ctx,
+			cl,
+			dataPlane.Namespace,
+			dataPlane.UID,
+			&client.ListOptions{
+				LabelSelector: labels.NewSelector().Add(*reqLegacyServiceType).Add(reqLegacyLabels...),
+			},
+		)
+		if err != nil {
+			return op.Noop, nil, fmt.Errorf("failed listing Services for DataPlane %s/%s: %w", dataPlane.Namespace, dataPlane.Name, err)
+		}
+		services = append(services, servicesLegacy...)
 	}
-	services = append(services, servicesLegacy...)
 
 	count := len(services)
 	if count > 1 {
 		if err := k8sreduce.ReduceServices(ctx, cl, services, dataplane.OwnedObjectPreDeleteHook); err != nil {
 			return op.Noop, nil, err
 		}
-		return op.Noop, nil, errors.New("number of DataPlane ingress services reduced")
+		return op.Noop, nil, fmt.Errorf("number of DataPlane %s services reduced", serviceType)
 	}
 
 	if len(additionalServiceLabels) > 0 {
@@ -328,8 +509,8 @@ func ensureIngressServiceForDataPlane(
 		updated, existingService.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existingService.ObjectMeta, generatedService.ObjectMeta,
 			// enforce all the annotations provided through the dataplane API
 			func(existingMeta metav1.ObjectMeta, generatedMeta metav1.ObjectMeta) (bool, metav1.ObjectMeta) {
-				metaToUpdate, updatedAnnotations, err := ensureDataPlaneIngressServiceAnnotationsUpdated(
-					dataPlane, existingMeta.Annotations, generatedMeta.Annotations,
+				metaToUpdate, updatedAnnotations, err := ensureDataPlaneServiceAnnotationsUpdated(
+					extractDataPlaneIngressServiceAnnotations(dataPlane), existingMeta.Annotations, generatedMeta.Annotations,
 				)
 				if err != nil {
 					logger.Error(err, "failed to update annotations of existing ingress service for dataplane",
@@ -5,9 +5,12 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -192,3 +195,229 @@ func TestEnsureIngressServiceForDataPlane(t *testing.T) {
 		})
 	}
 }
+
+func TestEnsurePrivateIngressServiceForDataPlane(t *testing.T) {
+	ctx := context.Background()
+	dp := builder.NewDataPlaneBuilder().WithObjectMeta(metav1.ObjectMeta{
+		Namespace: "default",
+		Name:      "dp-1",
+	}).WithIngressServiceType(corev1.ServiceTypeLoadBalancer).Build()
+	dp.Spec.Network.Services.PrivateIngress = &operatorv1beta1.DataPlaneServiceOptions{
+		Ports: []operatorv1beta1.DataPlaneServicePort{
+			{Port: 8080, TargetPort: intstr.FromInt(consts.DataPlaneProxyPort)},
+		},
+		ServiceOptions: operatorv1beta1.ServiceOptions{
+			Type:                  corev1.ServiceTypeLoadBalancer,
+			ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyLocal,
+			Annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-scheme": "internal",
+			},
+		},
+	}
+	fakeClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(dp).
+		Build()
+
+	res, ingressSvc, err := ensureIngressServiceForDataPlane(ctx, logr.Discard(), fakeClient, dp, nil,
+		k8sresources.ServicePortsFromDataPlaneIngressOpt(dp),
+	)
+	require.NoError(t, err)
+	require.Equal(t, op.Created, res)
+
+	t.Log("private ingress Service is created with the private ingress options")
+	res, svc, err := ensurePrivateIngressServiceForDataPlane(ctx, logr.Discard(), fakeClient, dp, nil)
+	require.NoError(t, err)
+	require.Equal(t, op.Created, res)
+	require.Equal(t, "dataplane-private-ingress-dp-1-", svc.GenerateName)
+	require.Equal(t, string(consts.DataPlanePrivateIngressServiceLabelValue), svc.Labels[consts.DataPlaneServiceTypeLabel])
+	require.Equal(t, corev1.ServiceExternalTrafficPolicyLocal, svc.Spec.ExternalTrafficPolicy)
+	require.Equal(t, "internal", svc.Annotations["service.beta.kubernetes.io/aws-load-balancer-scheme"])
+	require.Equal(t, []corev1.ServicePort{
+		{Name: "port-8080", Protocol: corev1.ProtocolTCP, Port: 8080, TargetPort: intstr.FromInt(consts.DataPlaneProxyPort)},
+	}, svc.Spec.Ports)
+	require.NotContains(t, ingressSvc.Annotations, "service.beta.kubernetes.io/aws-load-balancer-scheme")
+
+	t.Log("ingress Service is not affected by the private ingress Service")
+	res, _, err = ensureIngressServiceForDataPlane(ctx, logr.Discard(), fakeClient, dp, nil,
+		k8sresources.ServicePortsFromDataPlaneIngressOpt(dp),
+	)
+	require.NoError(t, err)
+	require.Equal(t, op.Noop, res)
+	res, _, err = ensurePrivateIngressServiceForDataPlane(ctx, logr.Discard(), fakeClient, dp, nil)
+	require.NoError(t, err)
+	require.Equal(t, op.Noop, res)
+
+	t.Log("private ingress Service is deleted when it's not configured anymore")
+	dp.Spec.Network.Services.PrivateIngress = nil
+	res, svc, err = ensurePrivateIngressServiceForDataPlane(ctx, logr.Discard(), fakeClient, dp, nil)
+	require.NoError(t, err)
+	require.Equal(t, op.Deleted, res)
+	require.Nil(t, svc)
+	services, err := k8sutils.ListServicesForOwner(ctx, fakeClient, dp.Namespace, dp.UID)
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	require.Equal(t, ingressSvc.Name, services[0].Name)
+}
+
+func TestEnsureAdminServiceForDataPlane(t *testing.T) {
+	ctx := context.Background()
+	dp := builder.NewDataPlaneBuilder().WithObjectMeta(metav1.ObjectMeta{
+		Namespace: "default",
+		Name:      "dp-1",
+	}).Build()
+	fakeClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(dp).
+		Build()
+
+	t.Log("headless Admin API Service is created by default")
+	res, svc, err := ensureAdminServiceForDataPlane(ctx, fakeClient, dp, nil)
+	require.NoError(t, err)
+	require.Equal(t, op.Created, res)
+	require.Equal(t, corev1.ClusterIPNone, svc.Spec.ClusterIP)
+	require.Len(t, svc.Spec.Ports, 1)
+
+	t.Log("Admin API Service options are applied to the existing Service")
+	dp.Spec.Network.Services = &operatorv1beta1.DataPlaneServices{
+		AdminAPI: &operatorv1beta1.DataPlaneAdminAPIServiceOptions{
+			Ports: []operatorv1beta1.DataPlaneServicePort{
+				{Name: "status", Port: consts.DataPlaneStatusPort},
+			},
+			Annotations: map[string]string{"example.com/health-check": "true"},
+			Labels: map[string]string{
+				"team":                           "gateway",
+				consts.DataPlaneServiceTypeLabel: "custom",
+			},
+		},
+	}
+	res, svc, err = ensureAdminServiceForDataPlane(ctx, fakeClient, dp, nil)
+	require.NoError(t, err)
+	require.Equal(t, op.Updated, res)
+	require.Equal(t, corev1.ClusterIPNone, svc.Spec.ClusterIP)
+	require.Equal(t, "true", svc.Annotations["example.com/health-check"])
+	require.Equal(t, "gateway", svc.Labels["team"])
+	require.Equal(t, string(consts.DataPlaneAdminServiceLabelValue), svc.Labels[consts.DataPlaneServiceTypeLabel],
+		"labels used by the operator can't be overridden")
+	require.Equal(t, corev1.ServicePort{
+		Name:       "status",
+		Protocol:   corev1.ProtocolTCP,
+		Port:       consts.DataPlaneStatusPort,
+		TargetPort: intstr.FromInt(consts.DataPlaneStatusPort),
+	}, svc.Spec.Ports[1])
+	res, _, err = ensureAdminServiceForDataPlane(ctx, fakeClient, dp, nil)
+	require.NoError(t, err)
+	require.Equal(t, op.Noop, res)
+
+	t.Log("annotations removed from the options are removed from the Service")
+	dp.Spec.Network.Services.AdminAPI.Annotations = nil
+	res, svc, err = ensureAdminServiceForDataPlane(ctx, fakeClient, dp, nil)
+	require.NoError(t, err)
+	require.Equal(t, op.Updated, res)
+	require.NotContains(t, svc.Annotations, "example.com/health-check")
+
+	t.Log("Service is recreated when it's not headless anymore")
+	headlessName := svc.Name
+	dp.Spec.Network.Services.AdminAPI.Type = corev1.ServiceTypeLoadBalancer
+	res, svc, err = ensureAdminServiceForDataPlane(ctx, fakeClient, dp, nil)
+	require.NoError(t, err)
+	require.Equal(t, op.Created, res)
+	require.Equal(t, corev1.ServiceTypeLoadBalancer, svc.Spec.Type)
+	require.Empty(t, svc.Spec.ClusterIP)
+	require.Equal(t, corev1.ServiceExternalTrafficPolicyCluster, svc.Spec.ExternalTrafficPolicy)
+	services, err := k8sutils.ListServicesForOwner(ctx, fakeClient, dp.Namespace, dp.UID)
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	require.NotEqual(t, headlessName, services[0].Name)
+}
+
+func TestEnsurePodDisruptionBudgetForDataPlane(t *testing.T) {
+	ctx := context.Background()
+
+	dp := &operatorv1beta1.DataPlane{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: operatorv1beta1.SchemeGroupVersion.String(),
+			Kind:       "DataPlane",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dp-1",
+			Namespace: "default",
+			UID:       types.UID("1234"),
+		},
+		Spec: operatorv1beta1.DataPlaneSpec{
+			DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+				Resources: operatorv1beta1.DataPlaneResources{
+					PodDisruptionBudget: &operatorv1beta1.PodDisruptionBudget{
+						Spec: operatorv1beta1.PodDisruptionBudgetSpec{
+							MinAvailable: lo.ToPtr(intstr.FromInt32(1)),
+						},
+					},
+				},
+			},
+		},
+		Status: operatorv1beta1.DataPlaneStatus{
+			Selector: "live",
+		},
+	}
+	fakeClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	listPDBs := func() []policyv1.PodDisruptionBudget {
+		t.Helper()
+		pdbs, err := k8sutils.ListPodDisruptionBudgetsForOwner(ctx, fakeClient, dp.Namespace, dp.UID)
+		require.NoError(t, err)
+		return pdbs
+	}
+
+	t.Log("PodDisruptionBudget is created")
+	res, _, err := ensurePodDisruptionBudgetForDataPlane(ctx, fakeClient, logr.Discard(), dp)
+	require.NoError(t, err)
+	require.Equal(t, op.Created, res)
+	pdbs := listPDBs()
+	require.Len(t, pdbs, 1)
+	require.Equal(t, lo.ToPtr(intstr.FromInt32(1)), pdbs[0].Spec.MinAvailable)
+	require.Equal(t, &metav1.LabelSelector{
+		MatchLabels: map[string]string{"app": dp.Name},
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      consts.OperatorLabelSelector,
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   []string{"live"},
+			},
+		},
+	}, pdbs[0].Spec.Selector)
+
+	t.Log("nothing changes when the PodDisruptionBudget is up to date")
+	res, _, err = ensurePodDisruptionBudgetForDataPlane(ctx, fakeClient, logr.Discard(), dp)
+	require.NoError(t, err)
+	require.Equal(t, op.Noop, res)
+
+	t.Log("PodDisruptionBudget covers preview pods during a BlueGreen rollout")
+	dp.Status.RolloutStatus = &operatorv1beta1.DataPlaneRolloutStatus{
+		Deployment: &operatorv1beta1.DataPlaneRolloutStatusDeployment{
+			Selector: "preview",
+		},
+	}
+	dp.Spec.Resources.PodDisruptionBudget.Spec = operatorv1beta1.PodDisruptionBudgetSpec{
+		MaxUnavailable: lo.ToPtr(intstr.FromString("50%")),
+	}
+	res, _, err = ensurePodDisruptionBudgetForDataPlane(ctx, fakeClient, logr.Discard(), dp)
+	require.NoError(t, err)
+	require.Equal(t, op.Updated, res)
+	pdbs = listPDBs()
+	require.Len(t, pdbs, 1)
+	require.Nil(t, pdbs[0].Spec.MinAvailable)
+	require.Equal(t, lo.ToPtr(intstr.FromString("50%")), pdbs[0].Spec.MaxUnavailable)
+	require.Equal(t, []string{"live", "preview"}, pdbs[0].Spec.Selector.MatchExpressions[0].Values)
+
+	t.Log("PodDisruptionBudget is deleted when it's not configured anymore")
+	dp.Spec.Resources.PodDisruptionBudget = nil
+	res, _, err = ensurePodDisruptionBudgetForDataPlane(ctx, fakeClient, logr.Discard(), dp)
+	require.NoError(t, err)
+	require.Equal(t, op.Noop, res)
+	require.Empty(t, listPDBs())
+}
@@ -0,0 +1,312 @@
+package dataplane
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	"github.com/samber/lo"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/pkg/log"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+	k8sreduce "github.com/kong/gateway-operator/pkg/utils/kubernetes/reduce"
+	k8sresources "github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
+)
+
+// -----------------------------------------------------------------------------
+// DataPlaneBlueGreenReconciler - Preview routing
+// -----------------------------------------------------------------------------
+
+// previewRoutingEnabled returns true when the DataPlane has preview routing configured.
+func previewRoutingEnabled(dataplane *operatorv1beta1.DataPlane) bool {
+	rollout := dataplane.Spec.Deployment.Rollout
+	return rollout != nil && rollout.Strategy.BlueGreen != nil && rollout.Strategy.BlueGreen.PreviewRouting != nil
+}
+
+// ensurePreviewRoutingResources ensures that the Service selecting the pods which
+// receive the requests matching DataPlane's preview routing and the ConfigMap
+// holding the configuration forwarding them are in place.
+// When the preview routing is not configured, they are removed once none of
+// the DataPlane's Deployments uses them anymore.
+func (r *BlueGreenReconciler) ensurePreviewRoutingResources(
+	ctx context.Context,
+	logger logr.Logger,
+	dataplane *operatorv1beta1.DataPlane,
+) error {
+	if !previewRoutingEnabled(dataplane) {
+		return r.deletePreviewRoutingResources(ctx, logger, dataplane)
+	}
+
+	svc, err := r.ensurePreviewRoutingService(ctx, dataplane)
+	if err != nil {
+		return err
+	}
+	return r.ensurePreviewRoutingConfigMap(ctx, dataplane, svc)
+}
+
+func (r *BlueGreenReconciler) ensurePreviewRoutingService(
+	ctx context.Context,
+	dataplane *operatorv1beta1.DataPlane,
+) (*corev1.Service, error) {
+	services, err := listDataPlanePreviewRoutingServices(ctx, r.Client, dataplane)
+	if err != nil {
+		return nil, err
+	}
+	if len(services) > 1 {
+		if err := k8sreduce.ReduceServices(ctx, r.Client, services); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("number of DataPlane preview routing Services reduced")
+	}
+
+	generated := k8sresources.GenerateNewPreviewRoutingServiceForDataPlane(dataplane)
+	if len(services) == 1 {
+		existing := &services[0]
+		old := existing.DeepCopy()
+		var updated bool
+		updated, existing.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existing.ObjectMeta, generated.ObjectMeta)
+		if !cmp.Equal(existing.Spec.Selector, generated.Spec.Selector) {
+			existing.Spec.Selector = generated.Spec.Selector
+			updated = true
+		}
+		if !cmp.Equal(existing.Spec.Ports, generated.Spec.Ports) {
+			existing.Spec.Ports = generated.Spec.Ports
+			updated = true
+		}
+		if updated {
+			if err := r.Client.Patch(ctx, existing, client.MergeFrom(old)); err != nil {
+				return nil, fmt.Errorf("failed updating DataPlane preview routing Service %s: %w", existing.Name, err)
+			}
+		}
+		return existing, nil
+	}
+
+	if err := r.Client.Create(ctx, generated); err != nil {
+		return nil, fmt.Errorf("failed creating preview routing Service for DataPlane %s: %w", dataplane.Name, err)
+	}
+	return generated, nil
+}
+
+func (r *BlueGreenReconciler) ensurePreviewRoutingConfigMap(
+	ctx context.Context,
+	dataplane *operatorv1beta1.DataPlane,
+	svc *corev1.Service,
+) error {
+	configMaps, err := listDataPlanePreviewRoutingConfigMaps(ctx, r.Client, dataplane)
+	if err != nil {
+		return err
+	}
+	if len(configMaps) > 1 {
+		if err := k8sreduce.ReduceConfigMaps(ctx, r.Client, configMaps); err != nil {
+			return err
+		}
+		return errors.New("number of DataPlane preview routing ConfigMaps reduced")
+	}
+
+	generated, err := k8sresources.GenerateNewPreviewRoutingConfigMapForDataPlane(dataplane, svc)
+	if err != nil {
+		return err
+	}
+	if len(configMaps) == 1 {
+		existing := &configMaps[0]
+		old := existing.DeepCopy()
+		var updated bool
+		updated, existing.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existing.ObjectMeta, generated.ObjectMeta)
+		if !cmp.Equal(existing.Data, generated.Data) {
+			existing.Data = generated.Data
+			updated = true
+		}
+		if updated {
+			if err := r.Client.Patch(ctx, existing, client.MergeFrom(old)); err != nil {
+				return fmt.Errorf("failed updating DataPlane preview routing ConfigMap %s: %w", existing.Name, err)
+			}
+		}
+		return nil
+	}
+
+	if err := r.Client.Create(ctx, generated); err != nil {
+		return fmt.Errorf("failed creating preview routing ConfigMap for DataPlane %s: %w", dataplane.Name, err)
+	}
+	return nil
+}
+
+// deletePreviewRoutingResources deletes the DataPlane's preview routing Service
+// and ConfigMap unless any of its Deployments still uses them.
+func (r *BlueGreenReconciler) deletePreviewRoutingResources(
+	ctx context.Context,
+	logger logr.Logger,
+	dataplane *operatorv1beta1.DataPlane,
+) error {
+	configMaps, err := listDataPlanePreviewRoutingConfigMaps(ctx, r.Client, dataplane)
+	if err != nil {
+		return err
+	}
+	services, err := listDataPlanePreviewRoutingServices(ctx, r.Client, dataplane)
+	if err != nil {
+		return err
+	}
+	if len(configMaps) == 0 && len(services) == 0 {
+		return nil
+	}
+
+	deployments, err := k8sutils.ListDeploymentsForOwner(
+		ctx,
+		r.Client,
+		dataplane.Namespace,
+		dataplane.UID,
+		client.MatchingLabels{
+			"app": dataplane.Name,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed listing Deployments for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+	if lo.ContainsBy(deployments, func(d appsv1.Deployment) bool {
+		_, ok := d.Spec.Template.Annotations[consts.DataPlanePreviewRoutingChecksumAnnotation]
+		return ok
+	}) {
+		return nil
+	}
+
+	log.Debug(logger, "removing preview routing Services and ConfigMaps", dataplane)
+	for i := range services {
+		if err := client.IgnoreNotFound(r.Client.Delete(ctx, &services[i])); err != nil {
+			return fmt.Errorf("failed deleting DataPlane preview routing Service %s: %w", services[i].Name, err)
+		}
+	}
+	for i := range configMaps {
+		if err := client.IgnoreNotFound(r.Client.Delete(ctx, &configMaps[i])); err != nil {
+			return fmt.Errorf("failed deleting DataPlane preview routing ConfigMap %s: %w", configMaps[i].Name, err)
+		}
+	}
+	return nil
+}
+
+func listDataPlanePreviewRoutingServices(
+	ctx context.Context,
+	cl client.Client,
+	dataplane *operatorv1beta1.DataPlane,
+) ([]corev1.Service, error) {
+	matchingLabels := k8sresources.GetManagedLabelForOwner(dataplane)
+	matchingLabels["app"] = dataplane.Name
+	matchingLabels[consts.DataPlaneServiceTypeLabel] = string(consts.DataPlanePreviewRoutingServiceLabelValue)
+
+	services, err := k8sutils.ListServicesForOwner(ctx, cl, dataplane.Namespace, dataplane.UID, matchingLabels)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing preview routing Services for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+	return services, nil
+}
+
+func listDataPlanePreviewRoutingConfigMaps(
+	ctx context.Context,
+	cl client.Client,
+	dataplane *operatorv1beta1.DataPlane,
+) ([]corev1.ConfigMap, error) {
+	matchingLabels := k8sresources.GetManagedLabelForOwner(dataplane)
+	matchingLabels["app"] = dataplane.Name
+	matchingLabels[consts.DataPlaneConfigMapTypeLabel] = consts.DataPlanePreviewRoutingConfigMapLabelValue
+
+	configMaps, err := k8sutils.ListConfigMapsForOwner(ctx, cl, dataplane.Namespace, dataplane.UID, matchingLabels)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing preview routing ConfigMaps for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+	return configMaps, nil
+}
+
+// -----------------------------------------------------------------------------
+// DataPlane Deployment - Preview routing
+// -----------------------------------------------------------------------------
+
+// previewRoutingConfigMapForDataPlane returns the ConfigMap holding the DataPlane's
+// preview routing configuration. It returns nil when the preview routing is not
+// configured or its ConfigMap hasn't been created yet, which is done by the
+// BlueGreenReconciler.
+func previewRoutingConfigMapForDataPlane(
+	ctx context.Context,
+	cl client.Client,
+	dataplane *operatorv1beta1.DataPlane,
+) (*corev1.ConfigMap, error) {
+	if !previewRoutingEnabled(dataplane) {
+		return nil, nil
+	}
+	configMaps, err := listDataPlanePreviewRoutingConfigMaps(ctx, cl, dataplane)
+	if err != nil {
+		return nil, err
+	}
+	if len(configMaps) != 1 {
+		return nil, nil
+	}
+	return &configMaps[0], nil
+}
+
+// withPreviewRouting mounts the preview routing configuration in the DataPlane's proxy
+// container and includes it in the Nginx configuration with the KONG_NGINX_HTTP_INCLUDE
+// and KONG_NGINX_PROXY_INCLUDE environment variables.
+// DataPlane validation rejects preview routing when these are set by the user,
+// since Kong accepts a single file for each of them.
+func withPreviewRouting(deployment *k8sresources.Deployment, cm *corev1.ConfigMap) *k8sresources.Deployment {
+	if cm == nil {
+		return deployment
+	}
+	container := k8sutils.GetPodContainerByName(&deployment.Spec.Template.Spec, consts.DataPlaneProxyContainerName)
+	if container == nil {
+		return deployment
+	}
+
+	const volumeName = "preview-routing"
+	deployment.WithVolume(corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: cm.Name},
+			},
+		},
+	}).WithVolumeMount(corev1.VolumeMount{
+		Name:      volumeName,
+		MountPath: consts.DataPlanePreviewRoutingMountPath,
+		ReadOnly:  true,
+	}, consts.DataPlaneProxyContainerName)
+
+	deployment.
+		WithEnvVar(corev1.EnvVar{
+			Name:  "KONG_NGINX_HTTP_INCLUDE",
+			Value: filepath.Join(consts.DataPlanePreviewRoutingMountPath, k8sresources.PreviewRoutingHTTPIncludeFile),
+		}, consts.DataPlaneProxyContainerName).
+		WithEnvVar(corev1.EnvVar{
+			Name:  "KONG_NGINX_PROXY_INCLUDE",
+			Value: filepath.Join(consts.DataPlanePreviewRoutingMountPath, k8sresources.PreviewRoutingProxyIncludeFile),
+		}, consts.DataPlaneProxyContainerName)
+	sort.Sort(k8sutils.SortableEnvVars(container.Env))
+
+	if deployment.Spec.Template.Annotations == nil {
+		deployment.Spec.Template.Annotations = make(map[string]string)
+	}
+	deployment.Spec.Template.Annotations[consts.DataPlanePreviewRoutingChecksumAnnotation] = previewRoutingChecksum(cm.Data)
+
+	return deployment
+}
+
+// previewRoutingChecksum returns a checksum of the preview routing configuration.
+// Setting it on the pod template ensures that pods are rolled out when the
+// configuration changes, since Nginx doesn't reload it on its own.
+func previewRoutingChecksum(data map[string]string) string {
+	h := sha256.New()
+	files := lo.Keys(data)
+	sort.Strings(files)
+	for _, f := range files {
+		fmt.Fprintf(h, "%s\x00%s\x00", f, data[f])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,178 @@
+package dataplane
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+	k8sresources "github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
+)
+
+func previewRoutingTestDataPlane() *operatorv1beta1.DataPlane {
+	return &operatorv1beta1.DataPlane{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: operatorv1beta1.SchemeGroupVersion.String(),
+			Kind:       "DataPlane",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dp",
+			Namespace: "default",
+			UID:       types.UID("1234"),
+		},
+		Spec: operatorv1beta1.DataPlaneSpec{
+			DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+				Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+					Rollout: &operatorv1beta1.Rollout{
+						Strategy: operatorv1beta1.RolloutStrategy{
+							BlueGreen: &operatorv1beta1.BlueGreenStrategy{
+								Promotion: operatorv1beta1.Promotion{
+									Strategy: operatorv1beta1.BreakBeforePromotion,
+								},
+								PreviewRouting: &operatorv1beta1.PreviewRouting{
+									Header: &operatorv1beta1.PreviewRoutingMatch{
+										Name:  "X-Preview",
+										Value: "qa",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Status: operatorv1beta1.DataPlaneStatus{
+			Selector: "live-selector",
+			RolloutStatus: &operatorv1beta1.DataPlaneRolloutStatus{
+				Deployment: &operatorv1beta1.DataPlaneRolloutStatusDeployment{
+					Selector: "preview-selector",
+				},
+			},
+		},
+	}
+}
+
+func TestEnsurePreviewRoutingResources(t *testing.T) {
+	ctx := context.Background()
+	logger := logr.Discard()
+
+	dp := previewRoutingTestDataPlane()
+	fakeClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(dp).
+		Build()
+	r := BlueGreenReconciler{
+		Client: fakeClient,
+	}
+
+	t.Log("Service selecting preview pods and ConfigMap are created")
+	require.NoError(t, r.ensurePreviewRoutingResources(ctx, logger, dp))
+	services, err := listDataPlanePreviewRoutingServices(ctx, fakeClient, dp)
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	require.Equal(t, "preview-selector", services[0].Spec.Selector[consts.OperatorLabelSelector])
+	cm, err := previewRoutingConfigMapForDataPlane(ctx, fakeClient, dp)
+	require.NoError(t, err)
+	require.NotNil(t, cm)
+	require.Contains(t, cm.Data[k8sresources.PreviewRoutingHTTPIncludeFile], services[0].Name+".default.svc:80")
+	require.Contains(t, cm.Data[k8sresources.PreviewRoutingHTTPIncludeFile], services[0].Name+".default.svc:443")
+	require.Contains(t, cm.Data[k8sresources.PreviewRoutingProxyIncludeFile], `if ($http_x_preview = "qa")`)
+
+	t.Log("Service selects live pods when no rollout is in progress")
+	dp.Status.RolloutStatus.Deployment = nil
+	require.NoError(t, r.ensurePreviewRoutingResources(ctx, logger, dp))
+	services, err = listDataPlanePreviewRoutingServices(ctx, fakeClient, dp)
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	require.Equal(t, "live-selector", services[0].Spec.Selector[consts.OperatorLabelSelector])
+
+	t.Log("resources are kept while a Deployment uses them")
+	dp.Spec.Deployment.Rollout.Strategy.BlueGreen.PreviewRouting = nil
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dp-live",
+			Namespace: "default",
+			Labels: map[string]string{
+				"app": dp.Name,
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						consts.DataPlanePreviewRoutingChecksumAnnotation: "checksum",
+					},
+				},
+			},
+		},
+	}
+	k8sutils.SetOwnerForObject(deployment, dp)
+	require.NoError(t, fakeClient.Create(ctx, deployment))
+	require.NoError(t, r.ensurePreviewRoutingResources(ctx, logger, dp))
+	configMaps, err := listDataPlanePreviewRoutingConfigMaps(ctx, fakeClient, dp)
+	require.NoError(t, err)
+	require.Len(t, configMaps, 1)
+
+	t.Log("resources are removed once no Deployment uses them")
+	require.NoError(t, fakeClient.Delete(ctx, deployment))
+	require.NoError(t, r.ensurePreviewRoutingResources(ctx, logger, dp))
+	configMaps, err = listDataPlanePreviewRoutingConfigMaps(ctx, fakeClient, dp)
+	require.NoError(t, err)
+	require.Empty(t, configMaps)
+	services, err = listDataPlanePreviewRoutingServices(ctx, fakeClient, dp)
+	require.NoError(t, err)
+	require.Empty(t, services)
+}
+
+func TestWithPreviewRouting(t *testing.T) {
+	newDeployment := func() *k8sresources.Deployment {
+		return &k8sresources.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: consts.DataPlaneProxyContainerName},
+						},
+					},
+				},
+			},
+		}
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "preview-routing-cm",
+		},
+		Data: map[string]string{
+			k8sresources.PreviewRoutingHTTPIncludeFile:  "http",
+			k8sresources.PreviewRoutingProxyIncludeFile: "proxy",
+		},
+	}
+
+	require.Equal(t, newDeployment(), withPreviewRouting(newDeployment(), nil))
+
+	deployment := withPreviewRouting(newDeployment(), cm)
+	podSpec := &deployment.Spec.Template.Spec
+	require.Len(t, podSpec.Volumes, 1)
+	require.Equal(t, "preview-routing-cm", podSpec.Volumes[0].ConfigMap.Name)
+	container := k8sutils.GetPodContainerByName(podSpec, consts.DataPlaneProxyContainerName)
+	require.Equal(t, "/etc/kong/preview-routing/http.conf", k8sutils.EnvValueByName(container.Env, "KONG_NGINX_HTTP_INCLUDE"))
+	require.Equal(t, "/etc/kong/preview-routing/proxy.conf", k8sutils.EnvValueByName(container.Env, "KONG_NGINX_PROXY_INCLUDE"))
+	checksum := deployment.Spec.Template.Annotations[consts.DataPlanePreviewRoutingChecksumAnnotation]
+	require.NotEmpty(t, checksum)
+
+	cm.Data[k8sresources.PreviewRoutingProxyIncludeFile] = "changed"
+	deployment = withPreviewRouting(newDeployment(), cm)
+	require.NotEqual(t, checksum, deployment.Spec.Template.Annotations[consts.DataPlanePreviewRoutingChecksumAnnotation],
+		"configuration change rolls the pods")
+}
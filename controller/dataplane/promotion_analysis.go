@@ -0,0 +1,400 @@
+package dataplane
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/pkg/log"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+// -----------------------------------------------------------------------------
+// DataPlaneBlueGreenReconciler - Promotion analysis
+// -----------------------------------------------------------------------------
+
+const (
+	// kongHTTPRequestsMetric is the name of the Kong's Prometheus plugin metric
+	// which counts the proxied HTTP requests by their status code.
+	kongHTTPRequestsMetric = "kong_http_requests_total"
+	// kongRequestLatencyMetric is the name of the Kong's Prometheus plugin
+	// histogram of the proxied requests' latency in milliseconds.
+	kongRequestLatencyMetric = "kong_request_latency_ms"
+
+	// promotionAnalysisProbeTimeout is the timeout of a single request sent to
+	// a preview pod during the promotion analysis.
+	promotionAnalysisProbeTimeout = 5 * time.Second
+)
+
+// ensurePromotionAnalysis runs the promotion analysis of the DataPlane's preview
+// pods configured for the AutomaticPromotion strategy.
+// Every interval the preview pods' readiness is probed and their metrics are
+// scraped. The error rate and average latency since the start of the analysis
+// are compared against the configured thresholds.
+// It returns true when all the measurements within the analysis window passed
+// and the preview resources can be promoted. When a measurement doesn't pass,
+// the RolledOut condition is set to AnalysisFailed and the analysis is not
+// retried until the DataPlane's spec changes. Inconclusive measurements (e.g.
+// a probe timed out or no requests were proxied by the preview pods yet) are
+// retried after the interval and don't count towards the passed measurements.
+func (r *BlueGreenReconciler) ensurePromotionAnalysis(
+	ctx context.Context,
+	logger logr.Logger,
+	dataplane *operatorv1beta1.DataPlane,
+) (passed bool, res ctrl.Result, err error) {
+	analysis := dataplane.Spec.Deployment.Rollout.Strategy.BlueGreen.Promotion.Analysis
+
+	c, ok := k8sutils.GetCondition(consts.DataPlaneConditionTypeRolledOut, dataplane.Status.RolloutStatus)
+	if ok && c.Reason == string(consts.DataPlaneConditionReasonRolloutAnalysisFailed) && c.ObservedGeneration == dataplane.Generation {
+		log.Trace(logger, "promotion analysis failed for the current generation, not retrying", dataplane)
+		return false, ctrl.Result{}, nil
+	}
+
+	status := dataplane.Status.RolloutStatus.Analysis
+	if status == nil || status.ObservedGeneration != dataplane.Generation {
+		r.promotionAnalysisBaselines.reset(dataplane.UID)
+		old := dataplane.DeepCopy()
+		dataplane.Status.RolloutStatus.Analysis = &operatorv1beta1.DataPlaneRolloutStatusAnalysis{
+			StartedAt:          metav1.Now(),
+			ObservedGeneration: dataplane.Generation,
+		}
+		if _, err := r.patchRolloutStatus(ctx, logger, old, dataplane); err != nil {
+			return false, ctrl.Result{}, fmt.Errorf("failed initializing promotion analysis status: %w", err)
+		}
+		return false, ctrl.Result{}, nil
+	}
+
+	if status.LastMeasuredAt != nil {
+		if remaining := time.Until(status.LastMeasuredAt.Add(analysis.Interval.Duration)); remaining > 0 {
+			return false, ctrl.Result{RequeueAfter: remaining}, nil
+		}
+	}
+
+	failure, inconclusive, err := r.measurePreviewPods(ctx, dataplane, analysis)
+	if err != nil {
+		return false, ctrl.Result{}, err
+	}
+	if inconclusive != "" {
+		log.Debug(logger, "promotion analysis measurement inconclusive, retrying", dataplane, "reason", inconclusive)
+		old := dataplane.DeepCopy()
+		dataplane.Status.RolloutStatus.Analysis.LastMeasuredAt = &metav1.Time{Time: time.Now()}
+		message := fmt.Sprintf("Promotion analysis in progress: %d measurements passed, last measurement inconclusive: %s",
+			dataplane.Status.RolloutStatus.Analysis.Measurements, inconclusive,
+		)
+		k8sutils.SetCondition(
+			k8sutils.NewConditionWithGeneration(consts.DataPlaneConditionTypeRolledOut, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutProgressing, message, dataplane.Generation),
+			dataplane.Status.RolloutStatus,
+		)
+		if _, err := r.patchRolloutStatus(ctx, logger, old, dataplane); err != nil {
+			return false, ctrl.Result{}, fmt.Errorf("failed updating promotion analysis status: %w", err)
+		}
+		return false, ctrl.Result{RequeueAfter: analysis.Interval.Duration}, nil
+	}
+	if failure != "" {
+		log.Info(logger, "preview pods failed promotion analysis", dataplane, "reason", failure)
+		r.promotionAnalysisBaselines.reset(dataplane.UID)
+		message := fmt.Sprintf("Promotion analysis failed: %s", failure)
+		return false, ctrl.Result{}, r.ensureRolledOutCondition(ctx, logger, dataplane, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutAnalysisFailed, message)
+	}
+
+	old := dataplane.DeepCopy()
+	dataplane.Status.RolloutStatus.Analysis.Measurements++
+	dataplane.Status.RolloutStatus.Analysis.LastMeasuredAt = &metav1.Time{Time: time.Now()}
+	// The first measurement only records the baseline of the preview pods' metrics
+	// so at least one more is needed for the analysis to pass.
+	passed = dataplane.Status.RolloutStatus.Analysis.Measurements > 1 &&
+		time.Since(status.StartedAt.Time) >= analysis.Window.Duration
+	if !passed {
+		message := fmt.Sprintf("Promotion analysis in progress: %d measurements passed", dataplane.Status.RolloutStatus.Analysis.Measurements)
+		k8sutils.SetCondition(
+			k8sutils.NewConditionWithGeneration(consts.DataPlaneConditionTypeRolledOut, metav1.ConditionFalse, consts.DataPlaneConditionReasonRolloutProgressing, message, dataplane.Generation),
+			dataplane.Status.RolloutStatus,
+		)
+	}
+	if _, err := r.patchRolloutStatus(ctx, logger, old, dataplane); err != nil {
+		return false, ctrl.Result{}, fmt.Errorf("failed updating promotion analysis status: %w", err)
+	}
+	if !passed {
+		return false, ctrl.Result{RequeueAfter: analysis.Interval.Duration}, nil
+	}
+
+	r.promotionAnalysisBaselines.reset(dataplane.UID)
+	log.Debug(logger, "preview pods passed promotion analysis", dataplane)
+	return true, ctrl.Result{}, nil
+}
+
+// measurePreviewPods probes and scrapes the metrics of the DataPlane's preview
+// pods. It returns a non empty failure description when the pods don't pass
+// the analysis and a non empty inconclusive description when the measurement
+// couldn't be completed and has to be retried.
+// When thresholds are configured, the preview pods have to expose the metrics
+// they are checked against: Kong's Prometheus plugin doesn't expose them unless
+// status_code_metrics and latency_metrics are enabled.
+func (r *BlueGreenReconciler) measurePreviewPods(
+	ctx context.Context,
+	dataplane *operatorv1beta1.DataPlane,
+	analysis *operatorv1beta1.PromotionAnalysis,
+) (failure string, inconclusive string, err error) {
+	var pods corev1.PodList
+	if err := r.Client.List(ctx, &pods,
+		client.InNamespace(dataplane.Namespace),
+		client.MatchingLabels{
+			"app":                        dataplane.Name,
+			consts.OperatorLabelSelector: dataplane.Status.RolloutStatus.Deployment.Selector,
+		},
+	); err != nil {
+		return "", "", fmt.Errorf("failed listing preview pods for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+
+	var total previewPodMetrics
+	for _, pod := range pods.Items {
+		pod := pod
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		if pod.Status.PodIP == "" {
+			return "", fmt.Sprintf("preview pod %s has no IP assigned", pod.Name), nil
+		}
+		if err := r.promotionAnalysisProber.Ready(ctx, &pod); err != nil {
+			if errors.As(err, &previewPodNotReadyError{}) {
+				return fmt.Sprintf("preview pod %s is not ready: %v", pod.Name, err), "", nil
+			}
+			return "", fmt.Sprintf("failed probing preview pod %s: %v", pod.Name, err), nil
+		}
+		if analysis.MaxErrorRate == nil && analysis.MaxAverageLatency == nil {
+			continue
+		}
+		m, err := r.promotionAnalysisProber.Metrics(ctx, &pod)
+		if err != nil {
+			return "", fmt.Sprintf("failed scraping metrics of preview pod %s: %v", pod.Name, err), nil
+		}
+		if analysis.MaxErrorRate != nil && !m.RequestsExposed {
+			return fmt.Sprintf("preview pod %s doesn't expose %s metric, enable status_code_metrics in the prometheus plugin",
+				pod.Name, kongHTTPRequestsMetric), "", nil
+		}
+		if analysis.MaxAverageLatency != nil && !m.LatencyExposed {
+			return fmt.Sprintf("preview pod %s doesn't expose %s metric, enable latency_metrics in the prometheus plugin",
+				pod.Name, kongRequestLatencyMetric), "", nil
+		}
+		total = total.add(r.promotionAnalysisBaselines.delta(dataplane.UID, pod.Name, m))
+	}
+
+	// The first measurement only records the baseline so there's nothing to check yet.
+	if dataplane.Status.RolloutStatus.Analysis.Measurements == 0 {
+		return "", "", nil
+	}
+
+	if analysis.MaxErrorRate != nil {
+		if total.Requests == 0 {
+			return "", "no requests were proxied by the preview pods since the start of the analysis", nil
+		}
+		if errorRate := total.ServerErrors / total.Requests * 100; errorRate > float64(*analysis.MaxErrorRate) {
+			return fmt.Sprintf("error rate %.2f%% exceeds %d%%", errorRate, *analysis.MaxErrorRate), "", nil
+		}
+	}
+	if analysis.MaxAverageLatency != nil {
+		if total.LatencyCount == 0 {
+			return "", "no request latency was recorded by the preview pods since the start of the analysis", nil
+		}
+		latency := time.Duration(total.LatencySum / total.LatencyCount * float64(time.Millisecond))
+		if latency > analysis.MaxAverageLatency.Duration {
+			return fmt.Sprintf("average latency %s exceeds %s", latency, analysis.MaxAverageLatency.Duration), "", nil
+		}
+	}
+	return "", "", nil
+}
+
+// previewPodMetrics contains the counters of a preview pod's metrics which are
+// used in the promotion analysis.
+type previewPodMetrics struct {
+	Requests     float64
+	ServerErrors float64
+	LatencySum   float64
+	LatencyCount float64
+
+	// RequestsExposed indicates whether the pod exposes the requests metric.
+	RequestsExposed bool
+	// LatencyExposed indicates whether the pod exposes the latency metric.
+	LatencyExposed bool
+}
+
+func (m previewPodMetrics) add(o previewPodMetrics) previewPodMetrics {
+	return previewPodMetrics{
+		Requests:     m.Requests + o.Requests,
+		ServerErrors: m.ServerErrors + o.ServerErrors,
+		LatencySum:   m.LatencySum + o.LatencySum,
+		LatencyCount: m.LatencyCount + o.LatencyCount,
+	}
+}
+
+func (m previewPodMetrics) sub(o previewPodMetrics) previewPodMetrics {
+	return previewPodMetrics{
+		Requests:     m.Requests - o.Requests,
+		ServerErrors: m.ServerErrors - o.ServerErrors,
+		LatencySum:   m.LatencySum - o.LatencySum,
+		LatencyCount: m.LatencyCount - o.LatencyCount,
+	}
+}
+
+// promotionAnalysisBaselines stores the metrics of the preview pods recorded
+// when they were measured for the first time during the promotion analysis.
+// The analysis uses the difference between the current metrics and the baseline.
+type promotionAnalysisBaselines struct {
+	lock      sync.Mutex
+	baselines map[types.UID]map[string]previewPodMetrics
+}
+
+func newPromotionAnalysisBaselines() *promotionAnalysisBaselines {
+	return &promotionAnalysisBaselines{
+		baselines: make(map[types.UID]map[string]previewPodMetrics),
+	}
+}
+
+// reset removes the baselines recorded for the DataPlane with the provided UID.
+func (b *promotionAnalysisBaselines) reset(uid types.UID) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	delete(b.baselines, uid)
+}
+
+// delta returns the difference between the provided metrics of a preview pod
+// and its baseline. When the pod is measured for the first time, the provided
+// metrics become its baseline.
+func (b *promotionAnalysisBaselines) delta(uid types.UID, pod string, m previewPodMetrics) previewPodMetrics {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	pods, ok := b.baselines[uid]
+	if !ok {
+		pods = make(map[string]previewPodMetrics)
+		b.baselines[uid] = pods
+	}
+	baseline, ok := pods[pod]
+	if !ok {
+		pods[pod] = m
+		return previewPodMetrics{}
+	}
+	if m.Requests < baseline.Requests || m.LatencyCount < baseline.LatencyCount {
+		// Counters were reset, e.g. because the proxy container was restarted.
+		pods[pod] = previewPodMetrics{}
+		return m
+	}
+	return m.sub(baseline)
+}
+
+// previewPodNotReadyError is returned by previewPodProber when the pod responded
+// that it's not ready. Other errors (e.g. timeouts) are considered transient.
+type previewPodNotReadyError struct {
+	statusCode int
+}
+
+func (e previewPodNotReadyError) Error() string {
+	return fmt.Sprintf("status endpoint responded with %d", e.statusCode)
+}
+
+// previewPodProber probes the preview pods during the promotion analysis.
+type previewPodProber interface {
+	// Ready returns a previewPodNotReadyError when the pod is not ready to proxy
+	// traffic or another error when the pod couldn't be probed.
+	Ready(ctx context.Context, pod *corev1.Pod) error
+	// Metrics returns the pod's metrics used in the promotion analysis.
+	Metrics(ctx context.Context, pod *corev1.Pod) (previewPodMetrics, error)
+}
+
+// httpPreviewPodProber is a previewPodProber which uses the Kong status API
+// of the preview pods.
+type httpPreviewPodProber struct {
+	client *http.Client
+}
+
+func newHTTPPreviewPodProber() *httpPreviewPodProber {
+	return &httpPreviewPodProber{
+		client: &http.Client{
+			Timeout: promotionAnalysisProbeTimeout,
+		},
+	}
+}
+
+// Ready implements previewPodProber.
+func (p *httpPreviewPodProber) Ready(ctx context.Context, pod *corev1.Pod) error {
+	resp, err := p.get(ctx, pod, "/status/ready")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return previewPodNotReadyError{statusCode: resp.StatusCode}
+	}
+	return nil
+}
+
+// Metrics implements previewPodProber.
+func (p *httpPreviewPodProber) Metrics(ctx context.Context, pod *corev1.Pod) (previewPodMetrics, error) {
+	resp, err := p.get(ctx, pod, "/metrics")
+	if err != nil {
+		return previewPodMetrics{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return previewPodMetrics{}, fmt.Errorf("metrics endpoint responded with %d", resp.StatusCode)
+	}
+	return parsePreviewPodMetrics(resp.Body)
+}
+
+func (p *httpPreviewPodProber) get(ctx context.Context, pod *corev1.Pod, path string) (*http.Response, error) {
+	url := fmt.Sprintf("http://%s%s", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(consts.DataPlaneStatusPort)), path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return p.client.Do(req)
+}
+
+// parsePreviewPodMetrics parses the metrics exposed by Kong's Prometheus plugin
+// in the Prometheus text format.
+func parsePreviewPodMetrics(r io.Reader) (previewPodMetrics, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return previewPodMetrics{}, fmt.Errorf("failed parsing metrics: %w", err)
+	}
+
+	var m previewPodMetrics
+	if f, ok := families[kongHTTPRequestsMetric]; ok {
+		m.RequestsExposed = true
+		for _, metric := range f.GetMetric() {
+			v := metric.GetCounter().GetValue()
+			m.Requests += v
+			for _, l := range metric.GetLabel() {
+				if l.GetName() == "code" && strings.HasPrefix(l.GetValue(), "5") {
+					m.ServerErrors += v
+				}
+			}
+		}
+	}
+	if f, ok := families[kongRequestLatencyMetric]; ok {
+		m.LatencyExposed = true
+		for _, metric := range f.GetMetric() {
+			m.LatencySum += metric.GetHistogram().GetSampleSum()
+			m.LatencyCount += float64(metric.GetHistogram().GetSampleCount())
+		}
+	}
+	return m, nil
+}
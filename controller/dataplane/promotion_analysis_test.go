@@ -0,0 +1,313 @@
+package dataplane
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+func TestParsePreviewPodMetrics(t *testing.T) {
+	const metrics = `# HELP kong_http_requests_total HTTP status codes per consumer/service/route in Kong
+# TYPE kong_http_requests_total counter
+kong_http_requests_total{service="s",route="r",code="200",source="service",workspace="default",consumer=""} 90
+kong_http_requests_total{service="s",route="r",code="503",source="service",workspace="default",consumer=""} 8
+kong_http_requests_total{service="s",route="r",code="500",source="kong",workspace="default",consumer=""} 2
+# HELP kong_request_latency_ms Total latency incurred during requests for each service/route in Kong
+# TYPE kong_request_latency_ms histogram
+kong_request_latency_ms_bucket{service="s",route="r",workspace="default",le="25"} 100
+kong_request_latency_ms_bucket{service="s",route="r",workspace="default",le="+Inf"} 100
+kong_request_latency_ms_sum{service="s",route="r",workspace="default"} 1500
+kong_request_latency_ms_count{service="s",route="r",workspace="default"} 100
+`
+	m, err := parsePreviewPodMetrics(strings.NewReader(metrics))
+	require.NoError(t, err)
+	require.Equal(t, previewPodMetrics{
+		Requests:        100,
+		ServerErrors:    10,
+		LatencySum:      1500,
+		LatencyCount:    100,
+		RequestsExposed: true,
+		LatencyExposed:  true,
+	}, m)
+
+	t.Log("metrics disabled in the prometheus plugin are reported as not exposed")
+	m, err = parsePreviewPodMetrics(strings.NewReader("# TYPE kong_nginx_connections_total gauge\nkong_nginx_connections_total{node_id=\"n\",subsystem=\"http\",state=\"active\"} 1\n"))
+	require.NoError(t, err)
+	require.Equal(t, previewPodMetrics{}, m)
+}
+
+func TestPromotionAnalysisBaselines(t *testing.T) {
+	b := newPromotionAnalysisBaselines()
+	uid := types.UID("1234")
+
+	require.Equal(t, previewPodMetrics{}, b.delta(uid, "pod", previewPodMetrics{Requests: 10}),
+		"first measurement records the baseline")
+	require.Equal(t, previewPodMetrics{Requests: 5}, b.delta(uid, "pod", previewPodMetrics{Requests: 15}))
+	require.Equal(t, previewPodMetrics{Requests: 3}, b.delta(uid, "pod", previewPodMetrics{Requests: 3}),
+		"counters reset is handled")
+	require.Equal(t, previewPodMetrics{Requests: 4}, b.delta(uid, "pod", previewPodMetrics{Requests: 4}))
+
+	b.reset(uid)
+	require.Equal(t, previewPodMetrics{}, b.delta(uid, "pod", previewPodMetrics{Requests: 20}))
+}
+
+type fakePreviewPodProber struct {
+	readyErr        error
+	metrics         previewPodMetrics
+	metricsDisabled bool
+}
+
+func (p *fakePreviewPodProber) Ready(context.Context, *corev1.Pod) error {
+	return p.readyErr
+}
+
+func (p *fakePreviewPodProber) Metrics(context.Context, *corev1.Pod) (previewPodMetrics, error) {
+	m := p.metrics
+	m.RequestsExposed = !p.metricsDisabled
+	m.LatencyExposed = !p.metricsDisabled
+	return m, nil
+}
+
+func TestEnsurePromotionAnalysis(t *testing.T) {
+	ctx := context.Background()
+	logger := logr.Discard()
+
+	newDataPlane := func() *operatorv1beta1.DataPlane {
+		return &operatorv1beta1.DataPlane{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: operatorv1beta1.SchemeGroupVersion.String(),
+				Kind:       "DataPlane",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "dp",
+				Namespace:  "default",
+				UID:        types.UID("1234"),
+				Generation: 2,
+			},
+			Spec: operatorv1beta1.DataPlaneSpec{
+				DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+					Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+						Rollout: &operatorv1beta1.Rollout{
+							Strategy: operatorv1beta1.RolloutStrategy{
+								BlueGreen: &operatorv1beta1.BlueGreenStrategy{
+									Promotion: operatorv1beta1.Promotion{
+										Strategy: operatorv1beta1.AutomaticPromotion,
+										Analysis: &operatorv1beta1.PromotionAnalysis{
+											Window:            metav1.Duration{Duration: time.Minute},
+											Interval:          metav1.Duration{Duration: 10 * time.Second},
+											MaxErrorRate:      lo.ToPtr(int32(5)),
+											MaxAverageLatency: &metav1.Duration{Duration: 100 * time.Millisecond},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Status: operatorv1beta1.DataPlaneStatus{
+				Selector: "live-selector",
+				RolloutStatus: &operatorv1beta1.DataPlaneRolloutStatus{
+					Deployment: &operatorv1beta1.DataPlaneRolloutStatusDeployment{
+						Selector: "preview-selector",
+					},
+				},
+			},
+		}
+	}
+	previewPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dp-preview-pod",
+			Namespace: "default",
+			Labels: map[string]string{
+				"app":                        "dp",
+				consts.OperatorLabelSelector: "preview-selector",
+			},
+		},
+		Status: corev1.PodStatus{
+			PodIP: "10.0.0.1",
+		},
+	}
+
+	setup := func(t *testing.T) (*operatorv1beta1.DataPlane, *BlueGreenReconciler, *fakePreviewPodProber) {
+		dp := newDataPlane()
+		prober := &fakePreviewPodProber{}
+		fakeClient := fakectrlruntimeclient.
+			NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(dp, previewPod).
+			WithStatusSubresource(dp).
+			Build()
+		return dp, &BlueGreenReconciler{
+			Client:                     fakeClient,
+			promotionAnalysisProber:    prober,
+			promotionAnalysisBaselines: newPromotionAnalysisBaselines(),
+		}, prober
+	}
+	ensure := func(t *testing.T, r *BlueGreenReconciler, dp *operatorv1beta1.DataPlane) (bool, time.Duration) {
+		t.Helper()
+		passed, res, err := r.ensurePromotionAnalysis(ctx, logger, dp)
+		require.NoError(t, err)
+		return passed, res.RequeueAfter
+	}
+	// elapse pretends that the provided duration has passed since the last measurement.
+	elapse := func(dp *operatorv1beta1.DataPlane, d time.Duration) {
+		a := dp.Status.RolloutStatus.Analysis
+		a.StartedAt = metav1.NewTime(a.StartedAt.Add(-d))
+		if a.LastMeasuredAt != nil {
+			a.LastMeasuredAt = lo.ToPtr(metav1.NewTime(a.LastMeasuredAt.Add(-d)))
+		}
+	}
+	requireRolledOutCondition := func(t *testing.T, dp *operatorv1beta1.DataPlane, reason consts.ConditionReason) {
+		t.Helper()
+		c, ok := k8sutils.GetCondition(consts.DataPlaneConditionTypeRolledOut, dp.Status.RolloutStatus)
+		require.True(t, ok)
+		require.Equal(t, string(reason), c.Reason)
+	}
+
+	t.Run("analysis passes after the window", func(t *testing.T) {
+		dp, r, prober := setup(t)
+
+		passed, _ := ensure(t, r, dp)
+		require.False(t, passed)
+		require.NotNil(t, dp.Status.RolloutStatus.Analysis)
+		require.Equal(t, int64(2), dp.Status.RolloutStatus.Analysis.ObservedGeneration)
+
+		t.Log("first measurement records the baseline")
+		prober.metrics = previewPodMetrics{Requests: 100, ServerErrors: 50, LatencySum: 100000, LatencyCount: 100}
+		passed, requeueAfter := ensure(t, r, dp)
+		require.False(t, passed)
+		require.Equal(t, 10*time.Second, requeueAfter)
+		require.Equal(t, int32(1), dp.Status.RolloutStatus.Analysis.Measurements)
+		requireRolledOutCondition(t, dp, consts.DataPlaneConditionReasonRolloutProgressing)
+
+		t.Log("measurements are not taken before the interval passes")
+		passed, requeueAfter = ensure(t, r, dp)
+		require.False(t, passed)
+		require.Greater(t, requeueAfter, time.Duration(0))
+		require.Equal(t, int32(1), dp.Status.RolloutStatus.Analysis.Measurements)
+
+		t.Log("measurements within thresholds pass")
+		elapse(dp, 10*time.Second)
+		prober.metrics = previewPodMetrics{Requests: 200, ServerErrors: 52, LatencySum: 105000, LatencyCount: 200}
+		passed, _ = ensure(t, r, dp)
+		require.False(t, passed)
+		require.Equal(t, int32(2), dp.Status.RolloutStatus.Analysis.Measurements)
+
+		t.Log("analysis passes once the window is over")
+		elapse(dp, time.Minute)
+		passed, _ = ensure(t, r, dp)
+		require.True(t, passed)
+	})
+
+	t.Run("analysis fails when the error rate is exceeded", func(t *testing.T) {
+		dp, r, prober := setup(t)
+
+		ensure(t, r, dp)
+		prober.metrics = previewPodMetrics{Requests: 100}
+		ensure(t, r, dp)
+		elapse(dp, 10*time.Second)
+		prober.metrics = previewPodMetrics{Requests: 200, ServerErrors: 10}
+		passed, _ := ensure(t, r, dp)
+		require.False(t, passed)
+		requireRolledOutCondition(t, dp, consts.DataPlaneConditionReasonRolloutAnalysisFailed)
+
+		t.Log("failed analysis is not retried for the same generation")
+		elapse(dp, time.Minute)
+		prober.metrics = previewPodMetrics{Requests: 300, ServerErrors: 10}
+		passed, _ = ensure(t, r, dp)
+		require.False(t, passed)
+		requireRolledOutCondition(t, dp, consts.DataPlaneConditionReasonRolloutAnalysisFailed)
+	})
+
+	t.Run("analysis fails when the average latency is exceeded", func(t *testing.T) {
+		dp, r, prober := setup(t)
+
+		ensure(t, r, dp)
+		ensure(t, r, dp)
+		elapse(dp, 10*time.Second)
+		prober.metrics = previewPodMetrics{Requests: 10, LatencySum: 5000, LatencyCount: 10}
+		passed, _ := ensure(t, r, dp)
+		require.False(t, passed)
+		requireRolledOutCondition(t, dp, consts.DataPlaneConditionReasonRolloutAnalysisFailed)
+	})
+
+	t.Run("analysis fails when a preview pod is not ready", func(t *testing.T) {
+		dp, r, prober := setup(t)
+
+		ensure(t, r, dp)
+		prober.readyErr = previewPodNotReadyError{statusCode: 503}
+		passed, _ := ensure(t, r, dp)
+		require.False(t, passed)
+		requireRolledOutCondition(t, dp, consts.DataPlaneConditionReasonRolloutAnalysisFailed)
+	})
+
+	t.Run("analysis fails when thresholds are configured but metrics are not exposed", func(t *testing.T) {
+		dp, r, prober := setup(t)
+
+		ensure(t, r, dp)
+		prober.metricsDisabled = true
+		passed, _ := ensure(t, r, dp)
+		require.False(t, passed)
+		requireRolledOutCondition(t, dp, consts.DataPlaneConditionReasonRolloutAnalysisFailed)
+		c, _ := k8sutils.GetCondition(consts.DataPlaneConditionTypeRolledOut, dp.Status.RolloutStatus)
+		require.Contains(t, c.Message, "enable status_code_metrics")
+	})
+
+	t.Run("transient probe errors are retried", func(t *testing.T) {
+		dp, r, prober := setup(t)
+
+		ensure(t, r, dp)
+		prober.readyErr = errors.New("context deadline exceeded")
+		passed, requeueAfter := ensure(t, r, dp)
+		require.False(t, passed)
+		require.Equal(t, 10*time.Second, requeueAfter)
+		require.Equal(t, int32(0), dp.Status.RolloutStatus.Analysis.Measurements)
+		requireRolledOutCondition(t, dp, consts.DataPlaneConditionReasonRolloutProgressing)
+
+		t.Log("analysis continues once the preview pod can be probed")
+		elapse(dp, 10*time.Second)
+		prober.readyErr = nil
+		passed, _ = ensure(t, r, dp)
+		require.False(t, passed)
+		require.Equal(t, int32(1), dp.Status.RolloutStatus.Analysis.Measurements)
+	})
+
+	t.Run("analysis holds until preview pods proxy requests", func(t *testing.T) {
+		dp, r, prober := setup(t)
+
+		ensure(t, r, dp)
+		prober.metrics = previewPodMetrics{Requests: 100, LatencySum: 1000, LatencyCount: 100}
+		ensure(t, r, dp)
+
+		t.Log("no requests since the baseline make the measurement inconclusive")
+		elapse(dp, time.Minute)
+		passed, _ := ensure(t, r, dp)
+		require.False(t, passed)
+		require.Equal(t, int32(1), dp.Status.RolloutStatus.Analysis.Measurements)
+		requireRolledOutCondition(t, dp, consts.DataPlaneConditionReasonRolloutProgressing)
+		c, _ := k8sutils.GetCondition(consts.DataPlaneConditionTypeRolledOut, dp.Status.RolloutStatus)
+		require.Contains(t, c.Message, "no requests were proxied")
+
+		t.Log("analysis passes once requests are proxied")
+		elapse(dp, time.Minute)
+		prober.metrics = previewPodMetrics{Requests: 200, LatencySum: 2000, LatencyCount: 200}
+		passed, _ = ensure(t, r, dp)
+		require.True(t, passed)
+	})
+}
@@ -0,0 +1,218 @@
+package dataplane
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/pkg/log"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+// -----------------------------------------------------------------------------
+// DataPlaneBlueGreenReconciler - Rollout timeout, history and rollback
+// -----------------------------------------------------------------------------
+
+// defaultRolloutHistoryLimit is the number of revisions kept in the rollout
+// history when the DataPlane doesn't specify it.
+const defaultRolloutHistoryLimit = 10
+
+// ensureRolloutStartedStatus records in the DataPlane's rollout status the time
+// at which the rollout of its current generation was initiated.
+func (r *BlueGreenReconciler) ensureRolloutStartedStatus(
+	ctx context.Context,
+	logger logr.Logger,
+	dataplane *operatorv1beta1.DataPlane,
+) error {
+	if dataplane.Status.RolloutStatus.ObservedGeneration == dataplane.Generation &&
+		dataplane.Status.RolloutStatus.StartedAt != nil {
+		return nil
+	}
+
+	old := dataplane.DeepCopy()
+	dataplane.Status.RolloutStatus.StartedAt = lo.ToPtr(metav1.Now())
+	dataplane.Status.RolloutStatus.ObservedGeneration = dataplane.Generation
+	if _, err := r.patchRolloutStatus(ctx, logger, old, dataplane); err != nil {
+		return fmt.Errorf("failed updating rollout start in DataPlane %s/%s status: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+	return nil
+}
+
+// rolloutTimeoutRemaining returns the time remaining until the rollout of the
+// DataPlane's current generation times out. It returns false when the DataPlane
+// doesn't have the rollout timeout configured.
+func rolloutTimeoutRemaining(dataplane *operatorv1beta1.DataPlane) (time.Duration, bool) {
+	timeout := dataplane.Spec.Deployment.Rollout.Timeout
+	status := dataplane.Status.RolloutStatus
+	if timeout == nil || status == nil || status.StartedAt == nil || status.ObservedGeneration != dataplane.Generation {
+		return 0, false
+	}
+	return time.Until(status.StartedAt.Add(timeout.Duration)), true
+}
+
+// isRolloutTimedOut returns true when the rollout of the DataPlane's current
+// generation has timed out.
+func isRolloutTimedOut(dataplane *operatorv1beta1.DataPlane) bool {
+	c, ok := k8sutils.GetCondition(consts.DataPlaneConditionTypeRolledOut, dataplane.Status.RolloutStatus)
+	return ok &&
+		c.ObservedGeneration == dataplane.Generation &&
+		c.Reason == string(consts.DataPlaneConditionReasonRolloutTimedOut)
+}
+
+// teardownPreviewSubresources deletes DataPlane's preview subresources and
+// removes the preview Services from its rollout status. The live resources
+// modified by an unfinished Canary rollout are restored first.
+func (r *BlueGreenReconciler) teardownPreviewSubresources(
+	ctx context.Context,
+	logger logr.Logger,
+	dataplane *operatorv1beta1.DataPlane,
+) error {
+	if err := r.restoreLiveResourcesAfterCanary(ctx, logger, dataplane); err != nil {
+		return err
+	}
+	if err := r.deletePreviewSubresources(ctx, logger, dataplane); err != nil {
+		return fmt.Errorf("failed deleting preview DataPlane subresources: %w", err)
+	}
+	if dataplane.Status.RolloutStatus.Services != nil {
+		old := dataplane.DeepCopy()
+		dataplane.Status.RolloutStatus.Services = nil
+		if _, err := r.patchRolloutStatus(ctx, logger, old, dataplane); err != nil {
+			return fmt.Errorf("failed removing preview Services from rollout status: %w", err)
+		}
+	}
+	return nil
+}
+
+// rolloutHistoryForPromotion returns the DataPlane's rollout history with
+// the revision of the preview Deployment which is being promoted.
+// When the history is empty, the revision of the current live Deployment is
+// added first so that it's possible to roll back to it.
+func (r *BlueGreenReconciler) rolloutHistoryForPromotion(
+	ctx context.Context,
+	dataplane *operatorv1beta1.DataPlane,
+	previewDeployment *appsv1.Deployment,
+) ([]operatorv1beta1.DataPlaneRolloutRevision, error) {
+	history := append([]operatorv1beta1.DataPlaneRolloutRevision{}, dataplane.Status.RolloutStatus.History...)
+
+	if len(history) == 0 {
+		deployments, err := listDataPlaneLiveDeployments(ctx, r.Client, dataplane)
+		if err != nil {
+			return nil, fmt.Errorf("failed listing live Deployments for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+		}
+		deployments = lo.Reject(deployments, func(d appsv1.Deployment, _ int) bool {
+			return d.Name == previewDeployment.Name
+		})
+		if len(deployments) > 0 {
+			live := lo.MaxBy(deployments, func(a, b appsv1.Deployment) bool {
+				return a.CreationTimestamp.After(b.CreationTimestamp.Time)
+			})
+			history = append(history, rolloutRevisionForDeployment(&live, 1, live.CreationTimestamp))
+		}
+	}
+
+	var next int64 = 1
+	if len(history) > 0 {
+		next = history[len(history)-1].Revision + 1
+	}
+	history = append(history, rolloutRevisionForDeployment(previewDeployment, next, metav1.Now()))
+
+	limit := int(lo.FromPtrOr(dataplane.Spec.Deployment.Rollout.HistoryLimit, defaultRolloutHistoryLimit))
+	if len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+	return history, nil
+}
+
+// rolloutRevisionForDeployment returns the rollout history revision describing
+// the provided Deployment.
+func rolloutRevisionForDeployment(
+	deployment *appsv1.Deployment,
+	revision int64,
+	promotedAt metav1.Time,
+) operatorv1beta1.DataPlaneRolloutRevision {
+	var image string
+	if container := k8sutils.GetPodContainerByName(&deployment.Spec.Template.Spec, consts.DataPlaneProxyContainerName); container != nil {
+		image = container.Image
+	}
+	return operatorv1beta1.DataPlaneRolloutRevision{
+		Revision:   revision,
+		Image:      image,
+		PromotedAt: promotedAt,
+	}
+}
+
+// ensureRollback rolls the DataPlane back to the revision from its rollout
+// history requested with the rollback-to-revision annotation.
+// The rollback is image-only: the image of the DataPlane's proxy container is
+// set to the revision's image, which initiates a new rollout, and the annotation
+// is removed. Other changes made to the pod template since the revision was
+// promoted are kept.
+// It returns true when the DataPlane was patched.
+func (r *BlueGreenReconciler) ensureRollback(
+	ctx context.Context,
+	logger logr.Logger,
+	dataplane *operatorv1beta1.DataPlane,
+) (bool, error) {
+	value, ok := dataplane.Annotations[operatorv1beta1.DataPlaneRollbackToRevisionAnnotationKey]
+	if !ok {
+		return false, nil
+	}
+
+	old := dataplane.DeepCopy()
+	delete(dataplane.Annotations, operatorv1beta1.DataPlaneRollbackToRevisionAnnotationKey)
+
+	revision, found := findRolloutRevision(dataplane, value)
+	switch {
+	case !found:
+		log.Info(logger, "requested rollback revision not found in rollout history, ignoring", dataplane, "revision", value)
+	case revision.Image == "":
+		log.Info(logger, "requested rollback revision has no image, ignoring", dataplane, "revision", value)
+	default:
+		setDataPlaneProxyImage(dataplane, revision.Image)
+		log.Info(logger, "rolling back DataPlane", dataplane, "revision", revision.Revision, "image", revision.Image)
+	}
+
+	if err := r.Client.Patch(ctx, dataplane, client.MergeFrom(old)); err != nil {
+		return false, fmt.Errorf("failed rolling back DataPlane %s/%s to revision %s: %w", dataplane.Namespace, dataplane.Name, value, err)
+	}
+	return true, nil
+}
+
+// findRolloutRevision returns the revision with the provided number from
+// the DataPlane's rollout history.
+func findRolloutRevision(dataplane *operatorv1beta1.DataPlane, value string) (operatorv1beta1.DataPlaneRolloutRevision, bool) {
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || dataplane.Status.RolloutStatus == nil {
+		return operatorv1beta1.DataPlaneRolloutRevision{}, false
+	}
+	return lo.Find(dataplane.Status.RolloutStatus.History, func(r operatorv1beta1.DataPlaneRolloutRevision) bool {
+		return r.Revision == number
+	})
+}
+
+// setDataPlaneProxyImage sets the image of the proxy container in the DataPlane's
+// pod template spec.
+func setDataPlaneProxyImage(dataplane *operatorv1beta1.DataPlane, image string) {
+	if dataplane.Spec.Deployment.PodTemplateSpec == nil {
+		dataplane.Spec.Deployment.PodTemplateSpec = &corev1.PodTemplateSpec{}
+	}
+	podSpec := &dataplane.Spec.Deployment.PodTemplateSpec.Spec
+	if container := k8sutils.GetPodContainerByName(podSpec, consts.DataPlaneProxyContainerName); container != nil {
+		container.Image = image
+		return
+	}
+	podSpec.Containers = append(podSpec.Containers, corev1.Container{
+		Name:  consts.DataPlaneProxyContainerName,
+		Image: image,
+	})
+}
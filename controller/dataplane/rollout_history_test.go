@@ -0,0 +1,207 @@
+package dataplane
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+func rolloutHistoryTestDataPlane() *operatorv1beta1.DataPlane {
+	return &operatorv1beta1.DataPlane{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: operatorv1beta1.SchemeGroupVersion.String(),
+			Kind:       "DataPlane",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "dp",
+			Namespace:  "default",
+			UID:        types.UID("1234"),
+			Generation: 3,
+		},
+		Spec: operatorv1beta1.DataPlaneSpec{
+			DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+				Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+					Rollout: &operatorv1beta1.Rollout{
+						Strategy: operatorv1beta1.RolloutStrategy{
+							BlueGreen: &operatorv1beta1.BlueGreenStrategy{
+								Promotion: operatorv1beta1.Promotion{
+									Strategy: operatorv1beta1.BreakBeforePromotion,
+								},
+							},
+						},
+						HistoryLimit: lo.ToPtr(int32(2)),
+					},
+				},
+			},
+		},
+		Status: operatorv1beta1.DataPlaneStatus{
+			RolloutStatus: &operatorv1beta1.DataPlaneRolloutStatus{},
+		},
+	}
+}
+
+func deploymentWithProxyImage(name, image string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  consts.DataPlaneProxyContainerName,
+							Image: image,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRolloutTimeout(t *testing.T) {
+	dp := rolloutHistoryTestDataPlane()
+
+	_, ok := rolloutTimeoutRemaining(dp)
+	require.False(t, ok, "there's no timeout when it's not configured")
+
+	dp.Spec.Deployment.Rollout.Timeout = &metav1.Duration{Duration: time.Minute}
+	dp.Status.RolloutStatus.StartedAt = lo.ToPtr(metav1.NewTime(time.Now().Add(-2 * time.Minute)))
+	dp.Status.RolloutStatus.ObservedGeneration = 2
+	_, ok = rolloutTimeoutRemaining(dp)
+	require.False(t, ok, "there's no timeout when the rollout of the current generation hasn't started yet")
+
+	dp.Status.RolloutStatus.ObservedGeneration = 3
+	remaining, ok := rolloutTimeoutRemaining(dp)
+	require.True(t, ok)
+	require.LessOrEqual(t, remaining, -time.Minute)
+
+	require.False(t, isRolloutTimedOut(dp))
+	k8sutils.SetCondition(
+		k8sutils.NewConditionWithGeneration(consts.DataPlaneConditionTypeRolledOut, metav1.ConditionFalse,
+			consts.DataPlaneConditionReasonRolloutTimedOut, consts.DataPlaneConditionMessageRolledOutRolloutTimedOut, 3),
+		dp.Status.RolloutStatus,
+	)
+	require.True(t, isRolloutTimedOut(dp))
+	dp.Generation = 4
+	require.False(t, isRolloutTimedOut(dp), "the rollout of a new generation is not timed out")
+}
+
+func TestRolloutHistoryForPromotion(t *testing.T) {
+	ctx := context.Background()
+	dp := rolloutHistoryTestDataPlane()
+
+	liveDeployment := deploymentWithProxyImage("dp-live", "kong:3.6")
+	liveDeployment.Labels = map[string]string{
+		"app":                                dp.Name,
+		consts.DataPlaneDeploymentStateLabel: consts.DataPlaneStateLabelValueLive,
+	}
+	k8sutils.SetOwnerForObject(liveDeployment, dp)
+	fakeClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(dp, liveDeployment).
+		Build()
+	r := BlueGreenReconciler{
+		Client: fakeClient,
+	}
+
+	t.Log("live Deployment is recorded along with the preview when the history is empty")
+	history, err := r.rolloutHistoryForPromotion(ctx, dp, deploymentWithProxyImage("dp-preview-1", "kong:3.7"))
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, int64(1), history[0].Revision)
+	require.Equal(t, "kong:3.6", history[0].Image)
+	require.Equal(t, int64(2), history[1].Revision)
+	require.Equal(t, "kong:3.7", history[1].Image)
+
+	t.Log("history is bounded by the limit")
+	dp.Status.RolloutStatus.History = history
+	history, err = r.rolloutHistoryForPromotion(ctx, dp, deploymentWithProxyImage("dp-preview-2", "kong:3.8"))
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, int64(2), history[0].Revision)
+	require.Equal(t, int64(3), history[1].Revision)
+	require.Equal(t, "kong:3.8", history[1].Image)
+}
+
+func TestEnsureRollback(t *testing.T) {
+	ctx := context.Background()
+	logger := logr.Discard()
+
+	testCases := []struct {
+		name          string
+		annotation    string
+		expectedImage string
+	}{
+		{
+			name:          "rollback to a revision from history",
+			annotation:    "1",
+			expectedImage: "kong:3.6",
+		},
+		{
+			name:          "unknown revision is ignored",
+			annotation:    "5",
+			expectedImage: "kong:3.7",
+		},
+		{
+			name:          "invalid revision is ignored",
+			annotation:    "previous",
+			expectedImage: "kong:3.7",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dp := rolloutHistoryTestDataPlane()
+			dp.Annotations = map[string]string{
+				operatorv1beta1.DataPlaneRollbackToRevisionAnnotationKey: tc.annotation,
+			}
+			dp.Spec.Deployment.PodTemplateSpec = &deploymentWithProxyImage("", "kong:3.7").Spec.Template
+			dp.Status.RolloutStatus.History = []operatorv1beta1.DataPlaneRolloutRevision{
+				{Revision: 1, Image: "kong:3.6"},
+				{Revision: 2, Image: "kong:3.7"},
+			}
+			fakeClient := fakectrlruntimeclient.
+				NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(dp).
+				Build()
+			r := BlueGreenReconciler{
+				Client: fakeClient,
+			}
+
+			rolledBack, err := r.ensureRollback(ctx, logger, dp)
+			require.NoError(t, err)
+			require.True(t, rolledBack)
+
+			updated := &operatorv1beta1.DataPlane{}
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(dp), updated))
+			require.NotContains(t, updated.Annotations, operatorv1beta1.DataPlaneRollbackToRevisionAnnotationKey)
+			container := k8sutils.GetPodContainerByName(&updated.Spec.Deployment.PodTemplateSpec.Spec, consts.DataPlaneProxyContainerName)
+			require.NotNil(t, container)
+			require.Equal(t, tc.expectedImage, container.Image)
+
+			rolledBack, err = r.ensureRollback(ctx, logger, updated)
+			require.NoError(t, err)
+			require.False(t, rolledBack, "nothing is done without the annotation")
+		})
+	}
+}
@@ -1,20 +1,41 @@
 package dataplane
 
 import (
+	"context"
+	"reflect"
+
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	operatorv1alpha1 "github.com/kong/gateway-operator/api/v1alpha1"
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	operatorerrors "github.com/kong/gateway-operator/internal/errors"
+	"github.com/kong/gateway-operator/internal/utils/index"
+	"github.com/kong/gateway-operator/pkg/consts"
 )
 
 // DataPlaneWatchBuilder creates a controller builder pre-configured with
 // the necessary watches for DataPlane resources that are managed by
-// the operator.
-func DataPlaneWatchBuilder(mgr ctrl.Manager) *builder.Builder {
-	return ctrl.NewControllerManagedBy(mgr).
+// the operator. All DataPlanes are reconciled on changes of the cluster CA
+// Secret, e.g. during its rotation. When kongPluginInstallationsEnabled is true,
+// DataPlanes are also reconciled on changes of KongPluginInstallations they use.
+func DataPlaneWatchBuilder(
+	mgr ctrl.Manager,
+	clusterCASecretNN types.NamespacedName,
+	kongPluginInstallationsEnabled bool,
+) *builder.Builder {
+	b := ctrl.NewControllerManagedBy(mgr).
 		// watch DataPlane objects
 		For(&operatorv1beta1.DataPlane{}).
 		// watch for changes in Secrets created by the dataplane controller
@@ -24,5 +45,128 @@ func DataPlaneWatchBuilder(mgr ctrl.Manager) *builder.Builder {
 		// watch for changes in Deployments created by the dataplane controller
 		Owns(&appsv1.Deployment{}).
 		// watch for changes in HPA created by the dataplane controller
-		Owns(&autoscalingv2.HorizontalPodAutoscaler{})
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		// watch for changes in PodDisruptionBudgets created by the dataplane controller
+		Owns(&policyv1.PodDisruptionBudget{}).
+		// watch for changes in ConfigMaps created by the dataplane controller
+		Owns(&corev1.ConfigMap{}).
+		// watch for changes in database migrations Jobs created by the dataplane controller
+		Owns(&batchv1.Job{}).
+		// watch for changes in the cluster CA Secret which issues DataPlane certificates
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(listDataPlanesForClusterCASecret(mgr.GetClient())),
+			builder.WithPredicates(predicate.NewPredicateFuncs(isClusterCASecret(clusterCASecretNN))),
+		)
+
+	if kongPluginInstallationsEnabled {
+		cl := mgr.GetClient()
+		b = b.
+			// watch for changes in KongPluginInstallations used by DataPlanes
+			Watches(
+				&operatorv1alpha1.KongPluginInstallation{},
+				handler.EnqueueRequestsFromMapFunc(listDataPlanesForKongPluginInstallation(cl)),
+			).
+			// watch for changes in ConfigMaps holding plugins of KongPluginInstallations
+			Watches(
+				&corev1.ConfigMap{},
+				handler.EnqueueRequestsFromMapFunc(listDataPlanesForKongPluginInstallationConfigMap(cl)),
+				builder.WithPredicates(predicate.NewPredicateFuncs(isKongPluginInstallationConfigMap)),
+			)
+	}
+	return b
+}
+
+// -----------------------------------------------------------------------------
+// DataPlane - Watch Predicates
+// -----------------------------------------------------------------------------
+
+func isKongPluginInstallationConfigMap(obj client.Object) bool {
+	return obj.GetLabels()[consts.GatewayOperatorManagedByLabel] == consts.KongPluginInstallationManagedLabelValue
+}
+
+func isClusterCASecret(clusterCASecretNN types.NamespacedName) func(obj client.Object) bool {
+	return func(obj client.Object) bool {
+		return client.ObjectKeyFromObject(obj) == clusterCASecretNN
+	}
+}
+
+// -----------------------------------------------------------------------------
+// DataPlane - Watch Mapping Funcs
+// -----------------------------------------------------------------------------
+
+func listDataPlanesForKongPluginInstallation(cl client.Client) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		kpi, ok := obj.(*operatorv1alpha1.KongPluginInstallation)
+		if !ok {
+			log.FromContext(ctx).Error(
+				operatorerrors.ErrUnexpectedObject,
+				"failed to run map funcs",
+				"expected", "KongPluginInstallation", "found", reflect.TypeOf(obj),
+			)
+			return nil
+		}
+		return listDataPlanesUsingKongPluginInstallation(ctx, cl, client.ObjectKeyFromObject(kpi))
+	}
+}
+
+func listDataPlanesForKongPluginInstallationConfigMap(cl client.Client) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var recs []reconcile.Request
+		for _, ownerRef := range obj.GetOwnerReferences() {
+			if ownerRef.Kind != "KongPluginInstallation" ||
+				ownerRef.APIVersion != operatorv1alpha1.SchemeGroupVersion.String() {
+				continue
+			}
+			recs = append(recs, listDataPlanesUsingKongPluginInstallation(ctx, cl, types.NamespacedName{
+				Namespace: obj.GetNamespace(),
+				Name:      ownerRef.Name,
+			})...)
+		}
+		return recs
+	}
+}
+
+func listDataPlanesForClusterCASecret(cl client.Client) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var dataplanes operatorv1beta1.DataPlaneList
+		if err := cl.List(ctx, &dataplanes); err != nil {
+			log.FromContext(ctx).Error(err, "failed to list DataPlanes in watch", "secret", client.ObjectKeyFromObject(obj))
+			return nil
+		}
+
+		recs := make([]reconcile.Request, 0, len(dataplanes.Items))
+		for _, dp := range dataplanes.Items {
+			recs = append(recs, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: dp.Namespace,
+					Name:      dp.Name,
+				},
+			})
+		}
+		return recs
+	}
+}
+
+func listDataPlanesUsingKongPluginInstallation(ctx context.Context, cl client.Client, kpi types.NamespacedName) []reconcile.Request {
+	var dataplanes operatorv1beta1.DataPlaneList
+	if err := cl.List(ctx, &dataplanes,
+		client.MatchingFields{
+			index.KongPluginInstallationsIndex: kpi.String(),
+		},
+	); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list DataPlanes in watch", "kongPluginInstallation", kpi)
+		return nil
+	}
+
+	recs := make([]reconcile.Request, 0, len(dataplanes.Items))
+	for _, dp := range dataplanes.Items {
+		recs = append(recs, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: dp.Namespace,
+				Name:      dp.Name,
+			},
+		})
+	}
+	return recs
 }
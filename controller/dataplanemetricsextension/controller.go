@@ -0,0 +1,227 @@
+package dataplanemetricsextension
+
+import (
+	"context"
+	"fmt"
+
+	configurationv1 "github.com/kong/kubernetes-configuration/api/configuration/v1"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	operatorv1alpha1 "github.com/kong/gateway-operator/api/v1alpha1"
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/pkg/log"
+	"github.com/kong/gateway-operator/controller/pkg/op"
+	"github.com/kong/gateway-operator/internal/utils/index"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+// -----------------------------------------------------------------------------
+// DataPlaneMetricsExtensionReconciler
+// -----------------------------------------------------------------------------
+
+// DataPlaneMetricsExtensionFinalizerCleanupServices is the finalizer used to remove
+// the Prometheus plugin annotation from the selected Services when the
+// DataPlaneMetricsExtension is deleted.
+const DataPlaneMetricsExtensionFinalizerCleanupServices = "gateway-operator.konghq.com/cleanup-services"
+
+// Reconciler reconciles a DataPlaneMetricsExtension object.
+//
+// It resolves the ControlPlanes referencing the extension through their
+// spec.extensions and, when the extension is accepted, configures the
+// Prometheus plugin for all the Services selected by the extension.
+type Reconciler struct {
+	client.Client
+
+	Scheme          *runtime.Scheme
+	DevelopmentMode bool
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&operatorv1alpha1.DataPlaneMetricsExtension{}).
+		// watch for changes in KongPlugins created by this controller
+		Owns(&configurationv1.KongPlugin{}).
+		// watch for changes in ControlPlanes' extensions
+		Watches(
+			&operatorv1beta1.ControlPlane{},
+			handler.EnqueueRequestsFromMapFunc(r.listDataPlaneMetricsExtensionsForControlPlane),
+		).
+		// watch for changes in Services selected by DataPlaneMetricsExtensions
+		Watches(
+			&corev1.Service{},
+			handler.EnqueueRequestsFromMapFunc(r.listDataPlaneMetricsExtensionsForService),
+		).
+		Complete(r)
+}
+
+// Reconcile reconciles the DataPlaneMetricsExtension resource.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.GetLogger(ctx, "dataplanemetricsextension", r.DevelopmentMode)
+
+	log.Trace(logger, "reconciling DataPlaneMetricsExtension resource", req)
+	var ext operatorv1alpha1.DataPlaneMetricsExtension
+	if err := r.Client.Get(ctx, req.NamespacedName, &ext); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !ext.DeletionTimestamp.IsZero() {
+		log.Trace(logger, "dataplanemetricsextension marked for deletion, removing plugin from selected services", ext)
+		if err := r.ensureServicesNotAnnotated(ctx, &ext, nil); err != nil {
+			return ctrl.Result{}, err
+		}
+		old := ext.DeepCopy()
+		if controllerutil.RemoveFinalizer(&ext, DataPlaneMetricsExtensionFinalizerCleanupServices) {
+			if err := r.Client.Patch(ctx, &ext, client.MergeFrom(old)); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to remove finalizer from dataplanemetricsextension: %w", err)
+			}
+			log.Debug(logger, "services cleanup finalizer removed", ext)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	old := ext.DeepCopy()
+	if controllerutil.AddFinalizer(&ext, DataPlaneMetricsExtensionFinalizerCleanupServices) {
+		if err := r.Client.Patch(ctx, &ext, client.MergeFrom(old)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to add finalizer to dataplanemetricsextension: %w", err)
+		}
+		log.Debug(logger, "services cleanup finalizer added", ext)
+		return ctrl.Result{}, nil // the update will trigger the reconciliation
+	}
+
+	log.Trace(logger, "resolving controlplanes referencing dataplanemetricsextension", ext)
+	var controlPlanes operatorv1beta1.ControlPlaneList
+	if err := r.Client.List(ctx, &controlPlanes,
+		client.MatchingFields{
+			index.DataPlaneMetricsExtensionIndex: client.ObjectKeyFromObject(&ext).String(),
+		},
+	); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list controlplanes referencing dataplanemetricsextension: %w", err)
+	}
+
+	if reason, msg, rejected := validateControlPlaneReferences(&ext, controlPlanes.Items); rejected {
+		log.Debug(logger, "dataplanemetricsextension not accepted: "+msg, ext)
+		if err := r.ensurePrometheusPluginDeleted(ctx, &ext); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.ensureServicesNotAnnotated(ctx, &ext, nil); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.patchStatus(ctx, &ext, nil, metav1.ConditionFalse, reason, msg)
+	}
+
+	log.Trace(logger, "ensuring prometheus plugin for dataplanemetricsextension", ext)
+	res, plugin, err := r.ensurePrometheusPlugin(ctx, &ext)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if res != op.Noop {
+		log.Debug(logger, "prometheus plugin for dataplanemetricsextension modified", ext, "plugin", plugin.Name, "reason", res)
+	}
+
+	log.Trace(logger, "ensuring selected services have the prometheus plugin attached", ext)
+	if err := r.ensureServicesAnnotated(ctx, &ext, plugin.Name); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.ensureServicesNotAnnotated(ctx, &ext, selectedServiceNames(&ext)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	cp := controlPlanes.Items[0]
+	cpRef := &operatorv1alpha1.NamespacedRef{
+		Name:      cp.Name,
+		Namespace: lo.ToPtr(cp.Namespace),
+	}
+	if err := r.patchStatus(ctx, &ext, cpRef,
+		metav1.ConditionTrue,
+		operatorv1alpha1.DataPlaneMetricsExtensionReasonAccepted,
+		fmt.Sprintf("extension accepted by ControlPlane %s", client.ObjectKeyFromObject(&cp)),
+	); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	log.Debug(logger, "reconciliation complete for DataPlaneMetricsExtension resource", ext)
+	return ctrl.Result{}, nil
+}
+
+// validateControlPlaneReferences checks whether the provided ControlPlanes, i.e. those
+// referencing the extension, make a valid association for the extension.
+// When the extension has to be rejected, the reason and a message explaining
+// it are returned together with true.
+func validateControlPlaneReferences(
+	ext *operatorv1alpha1.DataPlaneMetricsExtension,
+	controlPlanes []operatorv1beta1.ControlPlane,
+) (operatorv1alpha1.DataPlaneMetricsExtensionConditionReason, string, bool) {
+	if len(controlPlanes) == 0 {
+		return operatorv1alpha1.DataPlaneMetricsExtensionReasonNotReferenced,
+			"extension is not referenced by any ControlPlane", true
+	}
+
+	for _, cp := range controlPlanes {
+		if cp.Namespace != ext.Namespace {
+			return operatorv1alpha1.DataPlaneMetricsExtensionReasonRefNotPermitted,
+				fmt.Sprintf("extension is referenced by ControlPlane %s from a different namespace", client.ObjectKeyFromObject(&cp)), true
+		}
+	}
+
+	if len(controlPlanes) > 1 {
+		names := make([]string, 0, len(controlPlanes))
+		for _, cp := range controlPlanes {
+			names = append(names, cp.Name)
+		}
+		return operatorv1alpha1.DataPlaneMetricsExtensionReasonMultipleControlPlanes,
+			fmt.Sprintf("extension can be referenced by only one ControlPlane, referenced by: %v", names), true
+	}
+
+	return "", "", false
+}
+
+// patchStatus sets the ControlPlaneRef and the Accepted condition on the
+// DataPlaneMetricsExtension and patches its status when it has changed.
+func (r *Reconciler) patchStatus(
+	ctx context.Context,
+	ext *operatorv1alpha1.DataPlaneMetricsExtension,
+	cpRef *operatorv1alpha1.NamespacedRef,
+	status metav1.ConditionStatus,
+	reason operatorv1alpha1.DataPlaneMetricsExtensionConditionReason,
+	message string,
+) error {
+	old := ext.DeepCopy()
+	ext.Status.ControlPlaneRef = cpRef
+	k8sutils.SetCondition(
+		k8sutils.NewConditionWithGeneration(
+			consts.ConditionType(operatorv1alpha1.DataPlaneMetricsExtensionConditionTypeAccepted),
+			status,
+			consts.ConditionReason(reason),
+			message,
+			ext.Generation,
+		),
+		ext,
+	)
+	if !k8sutils.NeedsUpdate(old, ext) && equalNamespacedRefs(old.Status.ControlPlaneRef, ext.Status.ControlPlaneRef) {
+		return nil
+	}
+	if err := r.Client.Status().Patch(ctx, ext, client.MergeFrom(old)); err != nil {
+		return fmt.Errorf("failed to patch status for dataplanemetricsextension: %w", err)
+	}
+	return nil
+}
+
+func equalNamespacedRefs(a, b *operatorv1alpha1.NamespacedRef) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Name == b.Name && lo.FromPtrOr(a.Namespace, "") == lo.FromPtrOr(b.Namespace, "")
+}
@@ -0,0 +1,11 @@
+package dataplanemetricsextension
+
+//+kubebuilder:rbac:groups=gateway-operator.konghq.com,resources=dataplanemetricsextensions,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=gateway-operator.konghq.com,resources=dataplanemetricsextensions/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=gateway-operator.konghq.com,resources=dataplanemetricsextensions/finalizers,verbs=update
+
+//+kubebuilder:rbac:groups=gateway-operator.konghq.com,resources=controlplanes,verbs=get;list;watch
+
+//+kubebuilder:rbac:groups=configuration.konghq.com,resources=kongplugins,verbs=get;list;watch;create;update;patch;delete
+
+//+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;update;patch
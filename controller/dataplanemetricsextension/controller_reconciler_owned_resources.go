@@ -0,0 +1,257 @@
+package dataplanemetricsextension
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	configurationv1 "github.com/kong/kubernetes-configuration/api/configuration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/kong/gateway-operator/api/v1alpha1"
+	"github.com/kong/gateway-operator/controller/pkg/op"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+// prometheusPluginName is the name of Kong's Prometheus plugin.
+const prometheusPluginName = "prometheus"
+
+// prometheusPluginConfig is the configuration of Kong's Prometheus plugin.
+// Refer to https://docs.konghq.com/hub/kong-inc/prometheus/configuration/ for details.
+type prometheusPluginConfig struct {
+	Latency        bool `json:"latency_metrics"`
+	Bandwidth      bool `json:"bandwidth_metrics"`
+	UpstreamHealth bool `json:"upstream_health_metrics"`
+	StatusCode     bool `json:"status_code_metrics"`
+}
+
+// prometheusPluginNameForExtension returns the name of the KongPlugin created for
+// the provided DataPlaneMetricsExtension.
+func prometheusPluginNameForExtension(ext *operatorv1alpha1.DataPlaneMetricsExtension) string {
+	return ext.Name + "-" + prometheusPluginName
+}
+
+// generatePrometheusPlugin generates the Prometheus KongPlugin configured according
+// to the provided DataPlaneMetricsExtension.
+func generatePrometheusPlugin(ext *operatorv1alpha1.DataPlaneMetricsExtension) (*configurationv1.KongPlugin, error) {
+	config, err := json.Marshal(prometheusPluginConfig{
+		Latency:        ext.Spec.Config.Latency,
+		Bandwidth:      ext.Spec.Config.Bandwidth,
+		UpstreamHealth: ext.Spec.Config.UpstreamHealth,
+		StatusCode:     ext.Spec.Config.StatusCode,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal prometheus plugin configuration: %w", err)
+	}
+
+	plugin := &configurationv1.KongPlugin{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      prometheusPluginNameForExtension(ext),
+			Namespace: ext.Namespace,
+			Labels: map[string]string{
+				consts.GatewayOperatorManagedByLabel: consts.DataPlaneMetricsExtensionManagedLabelValue,
+			},
+		},
+		PluginName: prometheusPluginName,
+		Config: apiextensionsv1.JSON{
+			Raw: config,
+		},
+	}
+	k8sutils.SetOwnerForObject(plugin, ext)
+	return plugin, nil
+}
+
+// ensurePrometheusPlugin ensures that the Prometheus KongPlugin for the provided
+// DataPlaneMetricsExtension exists and is up to date.
+func (r *Reconciler) ensurePrometheusPlugin(
+	ctx context.Context,
+	ext *operatorv1alpha1.DataPlaneMetricsExtension,
+) (op.Result, *configurationv1.KongPlugin, error) {
+	generated, err := generatePrometheusPlugin(ext)
+	if err != nil {
+		return op.Noop, nil, err
+	}
+
+	var existing configurationv1.KongPlugin
+	err = r.Client.Get(ctx, client.ObjectKeyFromObject(generated), &existing)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return op.Noop, nil, fmt.Errorf("failed to get KongPlugin %s: %w", generated.Name, err)
+		}
+		if err := r.Client.Create(ctx, generated); err != nil {
+			return op.Noop, nil, fmt.Errorf("failed to create KongPlugin %s: %w", generated.Name, err)
+		}
+		return op.Created, generated, nil
+	}
+
+	if !k8sutils.IsOwnedByRefUID(&existing, ext.UID) {
+		return op.Noop, nil, fmt.Errorf("KongPlugin %s already exists and is not managed by the DataPlaneMetricsExtension", generated.Name)
+	}
+
+	if existing.PluginName == generated.PluginName &&
+		bytes.Equal(existing.Config.Raw, generated.Config.Raw) &&
+		existing.Labels[consts.GatewayOperatorManagedByLabel] == consts.DataPlaneMetricsExtensionManagedLabelValue {
+		return op.Noop, &existing, nil
+	}
+
+	old := existing.DeepCopy()
+	existing.PluginName = generated.PluginName
+	existing.Config = generated.Config
+	if existing.Labels == nil {
+		existing.Labels = map[string]string{}
+	}
+	existing.Labels[consts.GatewayOperatorManagedByLabel] = consts.DataPlaneMetricsExtensionManagedLabelValue
+	if err := r.Client.Patch(ctx, &existing, client.MergeFrom(old)); err != nil {
+		return op.Noop, nil, fmt.Errorf("failed to update KongPlugin %s: %w", existing.Name, err)
+	}
+	return op.Updated, &existing, nil
+}
+
+// ensurePrometheusPluginDeleted ensures that the Prometheus KongPlugin created for
+// the provided DataPlaneMetricsExtension doesn't exist.
+func (r *Reconciler) ensurePrometheusPluginDeleted(
+	ctx context.Context,
+	ext *operatorv1alpha1.DataPlaneMetricsExtension,
+) error {
+	var plugin configurationv1.KongPlugin
+	nn := types.NamespacedName{Namespace: ext.Namespace, Name: prometheusPluginNameForExtension(ext)}
+	if err := r.Client.Get(ctx, nn, &plugin); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get KongPlugin %s: %w", nn, err)
+	}
+	if !k8sutils.IsOwnedByRefUID(&plugin, ext.UID) {
+		return nil
+	}
+	if err := r.Client.Delete(ctx, &plugin); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete KongPlugin %s: %w", nn, err)
+	}
+	return nil
+}
+
+// serviceLabelForExtension returns the name of the label set on Services which
+// have the provided DataPlaneMetricsExtension's plugin attached.
+func serviceLabelForExtension(ext *operatorv1alpha1.DataPlaneMetricsExtension) string {
+	return consts.DataPlaneMetricsExtensionServiceLabelPrefix + string(ext.UID)
+}
+
+// selectedServiceNames returns the names of Services selected by the DataPlaneMetricsExtension.
+func selectedServiceNames(ext *operatorv1alpha1.DataPlaneMetricsExtension) []string {
+	names := make([]string, 0, len(ext.Spec.ServiceSelector.MatchNames))
+	for _, entry := range ext.Spec.ServiceSelector.MatchNames {
+		names = append(names, entry.Name)
+	}
+	return names
+}
+
+// ensureServicesAnnotated ensures that all the existing Services selected by the
+// DataPlaneMetricsExtension have the provided plugin attached through the
+// konghq.com/plugins annotation and are labeled with the extension's Service label.
+// Services which do not exist are skipped: a reconciliation will be triggered
+// when they get created.
+func (r *Reconciler) ensureServicesAnnotated(
+	ctx context.Context,
+	ext *operatorv1alpha1.DataPlaneMetricsExtension,
+	pluginName string,
+) error {
+	label := serviceLabelForExtension(ext)
+	for _, name := range selectedServiceNames(ext) {
+		var svc corev1.Service
+		nn := types.NamespacedName{Namespace: ext.Namespace, Name: name}
+		if err := r.Client.Get(ctx, nn, &svc); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get Service %s: %w", nn, err)
+		}
+
+		plugins := pluginsFromAnnotation(svc.Annotations)
+		_, labeled := svc.Labels[label]
+		if slices.Contains(plugins, pluginName) && labeled {
+			continue
+		}
+
+		old := svc.DeepCopy()
+		if !slices.Contains(plugins, pluginName) {
+			if svc.Annotations == nil {
+				svc.Annotations = map[string]string{}
+			}
+			svc.Annotations[consts.KongPluginsAnnotation] = strings.Join(append(plugins, pluginName), ",")
+		}
+		if svc.Labels == nil {
+			svc.Labels = map[string]string{}
+		}
+		svc.Labels[label] = "true"
+		if err := r.Client.Patch(ctx, &svc, client.MergeFrom(old)); err != nil {
+			return fmt.Errorf("failed to attach plugin %s to Service %s: %w", pluginName, nn, err)
+		}
+	}
+	return nil
+}
+
+// ensureServicesNotAnnotated ensures that Services labeled with the DataPlaneMetricsExtension's
+// Service label, except for those listed in keep, do not have the extension's plugin
+// attached. The label is removed together with the plugin.
+func (r *Reconciler) ensureServicesNotAnnotated(
+	ctx context.Context,
+	ext *operatorv1alpha1.DataPlaneMetricsExtension,
+	keep []string,
+) error {
+	label := serviceLabelForExtension(ext)
+	var services corev1.ServiceList
+	if err := r.Client.List(ctx, &services,
+		client.InNamespace(ext.Namespace),
+		client.HasLabels{label},
+	); err != nil {
+		return fmt.Errorf("failed to list Services: %w", err)
+	}
+
+	pluginName := prometheusPluginNameForExtension(ext)
+	for i := range services.Items {
+		svc := &services.Items[i]
+		if slices.Contains(keep, svc.Name) {
+			continue
+		}
+
+		old := svc.DeepCopy()
+		delete(svc.Labels, label)
+		plugins := pluginsFromAnnotation(svc.Annotations)
+		if idx := slices.Index(plugins, pluginName); idx >= 0 {
+			plugins = slices.Delete(plugins, idx, idx+1)
+			if len(plugins) == 0 {
+				delete(svc.Annotations, consts.KongPluginsAnnotation)
+			} else {
+				svc.Annotations[consts.KongPluginsAnnotation] = strings.Join(plugins, ",")
+			}
+		}
+		if err := r.Client.Patch(ctx, svc, client.MergeFrom(old)); err != nil {
+			return fmt.Errorf("failed to detach plugin %s from Service %s: %w", pluginName, client.ObjectKeyFromObject(svc), err)
+		}
+	}
+	return nil
+}
+
+// pluginsFromAnnotation returns the list of plugin names from the konghq.com/plugins annotation.
+func pluginsFromAnnotation(annotations map[string]string) []string {
+	value, ok := annotations[consts.KongPluginsAnnotation]
+	if !ok {
+		return nil
+	}
+	var plugins []string
+	for _, p := range strings.Split(value, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			plugins = append(plugins, p)
+		}
+	}
+	return plugins
+}
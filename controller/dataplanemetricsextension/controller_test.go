@@ -0,0 +1,267 @@
+package dataplanemetricsextension
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	configurationv1 "github.com/kong/kubernetes-configuration/api/configuration/v1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorv1alpha1 "github.com/kong/gateway-operator/api/v1alpha1"
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/internal/utils/index"
+	"github.com/kong/gateway-operator/modules/manager/scheme"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+func TestReconciler_Reconcile(t *testing.T) {
+	ctx := context.Background()
+
+	extension := func() *operatorv1alpha1.DataPlaneMetricsExtension {
+		return &operatorv1alpha1.DataPlaneMetricsExtension{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  "default",
+				Name:       "metrics",
+				UID:        types.UID("ext-uid"),
+				Generation: 1,
+				Finalizers: []string{DataPlaneMetricsExtensionFinalizerCleanupServices},
+			},
+			Spec: operatorv1alpha1.DataPlaneMetricsExtensionSpec{
+				ServiceSelector: operatorv1alpha1.ServiceSelector{
+					MatchNames: []operatorv1alpha1.ServiceSelectorEntry{
+						{Name: "httpbin"},
+						{Name: "missing"},
+					},
+				},
+				Config: operatorv1alpha1.MetricsConfig{
+					Latency:    true,
+					StatusCode: true,
+				},
+			},
+		}
+	}
+	controlPlane := func(namespace, name string, extNamespace *string) *operatorv1beta1.ControlPlane {
+		return &operatorv1beta1.ControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+			},
+			Spec: operatorv1beta1.ControlPlaneSpec{
+				ControlPlaneOptions: operatorv1beta1.ControlPlaneOptions{
+					Extensions: []operatorv1alpha1.ExtensionRef{
+						{
+							Group: operatorv1alpha1.SchemeGroupVersion.Group,
+							Kind:  operatorv1alpha1.DataPlaneMetricsExtensionKind,
+							NamespacedRef: operatorv1alpha1.NamespacedRef{
+								Name:      "metrics",
+								Namespace: extNamespace,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	service := func(name string, plugins string) *corev1.Service {
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+			},
+		}
+		if plugins != "" {
+			svc.Annotations = map[string]string{consts.KongPluginsAnnotation: plugins}
+		}
+		if slices.Contains(strings.Split(plugins, ","), "metrics-prometheus") {
+			svc.Labels = map[string]string{serviceLabelForExtension(extension()): "true"}
+		}
+		return svc
+	}
+	existingPlugin := func() *configurationv1.KongPlugin {
+		plugin, err := generatePrometheusPlugin(extension())
+		require.NoError(t, err)
+		return plugin
+	}
+
+	testCases := []struct {
+		name                     string
+		extension                *operatorv1alpha1.DataPlaneMetricsExtension
+		objects                  []client.Object
+		expectedReason           operatorv1alpha1.DataPlaneMetricsExtensionConditionReason
+		expectedControlPlaneRef  *operatorv1alpha1.NamespacedRef
+		expectedPluginConfig     string
+		expectedServicesPlugins  map[string]string
+		expectedExtensionDeleted bool
+	}{
+		{
+			name:      "extension not referenced by any ControlPlane is not accepted",
+			extension: extension(),
+			objects: []client.Object{
+				service("httpbin", "other"),
+			},
+			expectedReason: operatorv1alpha1.DataPlaneMetricsExtensionReasonNotReferenced,
+			expectedServicesPlugins: map[string]string{
+				"httpbin": "other",
+			},
+		},
+		{
+			name:      "extension referenced by a ControlPlane is accepted and plugin is attached to selected Services",
+			extension: extension(),
+			objects: []client.Object{
+				controlPlane("default", "cp", nil),
+				service("httpbin", "other"),
+				service("not-selected", "metrics-prometheus"),
+			},
+			expectedReason: operatorv1alpha1.DataPlaneMetricsExtensionReasonAccepted,
+			expectedControlPlaneRef: &operatorv1alpha1.NamespacedRef{
+				Name:      "cp",
+				Namespace: lo.ToPtr("default"),
+			},
+			expectedPluginConfig: `{"latency_metrics":true,"bandwidth_metrics":false,"upstream_health_metrics":false,"status_code_metrics":true}`,
+			expectedServicesPlugins: map[string]string{
+				"httpbin":      "other,metrics-prometheus",
+				"not-selected": "",
+			},
+		},
+		{
+			name: "outdated plugin gets updated",
+			extension: func() *operatorv1alpha1.DataPlaneMetricsExtension {
+				ext := extension()
+				ext.Spec.Config.Bandwidth = true
+				return ext
+			}(),
+			objects: []client.Object{
+				controlPlane("default", "cp", lo.ToPtr("default")),
+				existingPlugin(),
+				service("httpbin", "metrics-prometheus"),
+			},
+			expectedReason: operatorv1alpha1.DataPlaneMetricsExtensionReasonAccepted,
+			expectedControlPlaneRef: &operatorv1alpha1.NamespacedRef{
+				Name:      "cp",
+				Namespace: lo.ToPtr("default"),
+			},
+			expectedPluginConfig: `{"latency_metrics":true,"bandwidth_metrics":true,"upstream_health_metrics":false,"status_code_metrics":true}`,
+			expectedServicesPlugins: map[string]string{
+				"httpbin": "metrics-prometheus",
+			},
+		},
+		{
+			name:      "extension referenced by multiple ControlPlanes is rejected and its plugin is removed",
+			extension: extension(),
+			objects: []client.Object{
+				controlPlane("default", "cp-1", nil),
+				controlPlane("default", "cp-2", nil),
+				existingPlugin(),
+				service("httpbin", "metrics-prometheus,other"),
+			},
+			expectedReason: operatorv1alpha1.DataPlaneMetricsExtensionReasonMultipleControlPlanes,
+			expectedServicesPlugins: map[string]string{
+				"httpbin": "other",
+			},
+		},
+		{
+			name:      "extension referenced by a ControlPlane from a different namespace is rejected",
+			extension: extension(),
+			objects: []client.Object{
+				controlPlane("other", "cp", lo.ToPtr("default")),
+				service("httpbin", "metrics-prometheus"),
+			},
+			expectedReason: operatorv1alpha1.DataPlaneMetricsExtensionReasonRefNotPermitted,
+			expectedServicesPlugins: map[string]string{
+				"httpbin": "",
+			},
+		},
+		{
+			name: "deleted extension has its plugin detached from Services",
+			extension: func() *operatorv1alpha1.DataPlaneMetricsExtension {
+				ext := extension()
+				ext.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+				return ext
+			}(),
+			objects: []client.Object{
+				controlPlane("default", "cp", nil),
+				service("httpbin", "other,metrics-prometheus"),
+			},
+			expectedServicesPlugins: map[string]string{
+				"httpbin": "other",
+			},
+			expectedExtensionDeleted: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fakectrlruntimeclient.
+				NewClientBuilder().
+				WithScheme(scheme.Get()).
+				WithObjects(tc.extension).
+				WithObjects(tc.objects...).
+				WithStatusSubresource(tc.extension).
+				WithIndex(&operatorv1beta1.ControlPlane{}, index.DataPlaneMetricsExtensionIndex, func(o client.Object) []string {
+					cp := o.(*operatorv1beta1.ControlPlane)
+					var result []string
+					for _, ext := range cp.Spec.Extensions {
+						result = append(result, lo.FromPtrOr(ext.Namespace, cp.Namespace)+"/"+ext.Name)
+					}
+					return result
+				}).
+				Build()
+
+			r := Reconciler{
+				Client: fakeClient,
+				Scheme: scheme.Get(),
+			}
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(tc.extension)})
+			require.NoError(t, err)
+
+			for name, expectedPlugins := range tc.expectedServicesPlugins {
+				var svc corev1.Service
+				require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: name}, &svc))
+				assert.Equal(t, expectedPlugins, svc.Annotations[consts.KongPluginsAnnotation], "Service %s", name)
+				_, labeled := svc.Labels[serviceLabelForExtension(tc.extension)]
+				assert.Equal(t, slices.Contains(strings.Split(expectedPlugins, ","), "metrics-prometheus"), labeled, "Service %s", name)
+			}
+
+			var ext operatorv1alpha1.DataPlaneMetricsExtension
+			err = fakeClient.Get(ctx, client.ObjectKeyFromObject(tc.extension), &ext)
+			if tc.expectedExtensionDeleted {
+				require.True(t, k8serrors.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+
+			c, ok := k8sutils.GetCondition(consts.ConditionType(operatorv1alpha1.DataPlaneMetricsExtensionConditionTypeAccepted), &ext)
+			require.True(t, ok)
+			assert.Equal(t, string(tc.expectedReason), c.Reason)
+			if tc.expectedReason == operatorv1alpha1.DataPlaneMetricsExtensionReasonAccepted {
+				assert.Equal(t, metav1.ConditionTrue, c.Status)
+			} else {
+				assert.Equal(t, metav1.ConditionFalse, c.Status)
+			}
+			assert.Equal(t, tc.expectedControlPlaneRef, ext.Status.ControlPlaneRef)
+
+			var plugin configurationv1.KongPlugin
+			err = fakeClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "metrics-prometheus"}, &plugin)
+			if tc.expectedPluginConfig == "" {
+				require.True(t, k8serrors.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "prometheus", plugin.PluginName)
+			assert.JSONEq(t, tc.expectedPluginConfig, string(plugin.Config.Raw))
+			assert.True(t, k8sutils.IsOwnedByRefUID(&plugin, ext.UID))
+		})
+	}
+}
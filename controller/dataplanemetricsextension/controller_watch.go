@@ -0,0 +1,108 @@
+package dataplanemetricsextension
+
+import (
+	"context"
+	"reflect"
+	"slices"
+
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1alpha1 "github.com/kong/gateway-operator/api/v1alpha1"
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	operatorerrors "github.com/kong/gateway-operator/internal/errors"
+)
+
+// -----------------------------------------------------------------------------
+// DataPlaneMetricsExtensionReconciler - Watch Mapping Funcs
+// -----------------------------------------------------------------------------
+
+// listDataPlaneMetricsExtensionsForControlPlane returns the DataPlaneMetricsExtensions
+// referenced by the ControlPlane's spec.extensions and those which have the
+// ControlPlane set in their status (to handle removal of the reference).
+func (r *Reconciler) listDataPlaneMetricsExtensionsForControlPlane(ctx context.Context, obj client.Object) (recs []reconcile.Request) {
+	cp, ok := obj.(*operatorv1beta1.ControlPlane)
+	if !ok {
+		log.FromContext(ctx).Error(
+			operatorerrors.ErrUnexpectedObject,
+			"failed to run map funcs",
+			"expected", "ControlPlane", "found", reflect.TypeOf(obj),
+		)
+		return
+	}
+
+	for _, ext := range cp.Spec.Extensions {
+		if ext.Group != operatorv1alpha1.SchemeGroupVersion.Group ||
+			ext.Kind != operatorv1alpha1.DataPlaneMetricsExtensionKind {
+			continue
+		}
+		namespace := cp.Namespace
+		if ext.Namespace != nil && *ext.Namespace != "" {
+			namespace = *ext.Namespace
+		}
+		recs = append(recs, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: namespace,
+				Name:      ext.Name,
+			},
+		})
+	}
+
+	var extensions operatorv1alpha1.DataPlaneMetricsExtensionList
+	if err := r.Client.List(ctx, &extensions); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list DataPlaneMetricsExtensions in watch", "controlplane", client.ObjectKeyFromObject(cp))
+		return
+	}
+	for _, ext := range extensions.Items {
+		ref := ext.Status.ControlPlaneRef
+		if ref == nil || ref.Name != cp.Name || lo.FromPtrOr(ref.Namespace, ext.Namespace) != cp.Namespace {
+			continue
+		}
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: ext.Namespace,
+				Name:      ext.Name,
+			},
+		}
+		if !slices.Contains(recs, req) {
+			recs = append(recs, req)
+		}
+	}
+	return
+}
+
+// listDataPlaneMetricsExtensionsForService returns the DataPlaneMetricsExtensions
+// from the Service's namespace which select the Service.
+func (r *Reconciler) listDataPlaneMetricsExtensionsForService(ctx context.Context, obj client.Object) (recs []reconcile.Request) {
+	svc, ok := obj.(*corev1.Service)
+	if !ok {
+		log.FromContext(ctx).Error(
+			operatorerrors.ErrUnexpectedObject,
+			"failed to run map funcs",
+			"expected", "Service", "found", reflect.TypeOf(obj),
+		)
+		return
+	}
+
+	var extensions operatorv1alpha1.DataPlaneMetricsExtensionList
+	if err := r.Client.List(ctx, &extensions, client.InNamespace(svc.Namespace)); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list DataPlaneMetricsExtensions in watch", "service", client.ObjectKeyFromObject(svc))
+		return
+	}
+	for _, ext := range extensions.Items {
+		if !slices.Contains(selectedServiceNames(&ext), svc.Name) {
+			continue
+		}
+		recs = append(recs, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: ext.Namespace,
+				Name:      ext.Name,
+			},
+		})
+	}
+	return
+}
@@ -15,6 +15,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -22,6 +23,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
@@ -32,6 +34,7 @@ import (
 	"github.com/kong/gateway-operator/controller/pkg/watch"
 	operatorerrors "github.com/kong/gateway-operator/internal/errors"
 	gwtypes "github.com/kong/gateway-operator/internal/types"
+	dputils "github.com/kong/gateway-operator/internal/utils/dataplane"
 	"github.com/kong/gateway-operator/pkg/consts"
 	gatewayutils "github.com/kong/gateway-operator/pkg/utils/gateway"
 	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
@@ -59,7 +62,7 @@ const provisionDataPlaneFailRetryAfter = 5 * time.Second
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		// watch Gateway objects, filtering out any Gateways which are not configured with
 		// a supported GatewayClass controller name.
 		For(&gwtypes.Gateway{},
@@ -98,8 +101,34 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 		// This is required to properly support Gateway's listeners.allowedRoutes.namespaces.selector.
 		Watches(
 			&corev1.Namespace{},
-			handler.EnqueueRequestsFromMapFunc(r.listManagedGatewaysInNamespace)).
-		Complete(r)
+			handler.EnqueueRequestsFromMapFunc(r.listManagedGatewaysInNamespace))
+
+	// watch GRPCRoutes and L4 routes so that Gateway listener status can be updated.
+	// GRPCRoute CRD is part of the Gateway API standard channel since v1.1.0 and
+	// L4 routes' CRDs are part of the experimental channel, hence they are watched
+	// only when installed.
+	checker := k8sutils.CRDChecker{Client: mgr.GetClient()}
+	for _, route := range []struct {
+		obj     client.Object
+		gvr     schema.GroupVersionResource
+		mapFunc handler.MapFunc
+	}{
+		{obj: &gwtypes.GRPCRoute{}, gvr: gatewayv1.SchemeGroupVersion.WithResource("grpcroutes"), mapFunc: r.listGatewaysAttachedByGRPCRoute},
+		{obj: &gwtypes.TCPRoute{}, gvr: gatewayv1alpha2.SchemeGroupVersion.WithResource("tcproutes"), mapFunc: r.listGatewaysAttachedByL4Route},
+		{obj: &gwtypes.TLSRoute{}, gvr: gatewayv1alpha2.SchemeGroupVersion.WithResource("tlsroutes"), mapFunc: r.listGatewaysAttachedByL4Route},
+		{obj: &gwtypes.UDPRoute{}, gvr: gatewayv1alpha2.SchemeGroupVersion.WithResource("udproutes"), mapFunc: r.listGatewaysAttachedByL4Route},
+	} {
+		if ok, err := checker.CRDExists(route.gvr); err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+		b = b.Watches(
+			route.obj,
+			handler.EnqueueRequestsFromMapFunc(route.mapFunc))
+	}
+
+	return b.Complete(r)
 }
 
 // Reconcile moves the current state of an object to the intended state.
@@ -190,7 +219,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 
 	log.Trace(logger, "determining configuration", gateway)
-	gatewayConfig, err := r.getOrCreateGatewayConfiguration(ctx, gwc.GatewayClass)
+	gatewayConfig, err := r.getOrCreateGatewayConfiguration(ctx, gwc.GatewayClass, &gateway)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -443,7 +472,7 @@ func (r *Reconciler) provisionDataPlane(
 	// if not configured in gatewayconfiguration, compare deployment option of dataplane with an empty one.
 	expectedDataPlaneOptions := &operatorv1beta1.DataPlaneOptions{}
 	if gatewayConfig.Spec.DataPlaneOptions != nil {
-		expectedDataPlaneOptions = gatewayConfigDataPlaneOptionsToDataPlaneOptions(*gatewayConfig.Spec.DataPlaneOptions)
+		expectedDataPlaneOptions = dputils.OptionsFromGatewayConfiguration(*gatewayConfig.Spec.DataPlaneOptions)
 	}
 	// Don't require setting defaults for DataPlane when using Gateway CRD.
 	setDataPlaneOptionsDefaults(expectedDataPlaneOptions, r.DefaultDataPlaneImage)
@@ -456,10 +485,13 @@ func (r *Reconciler) provisionDataPlane(
 		)
 		return nil, errWrap
 	}
+	setDataPlaneStreamListen(expectedDataPlaneOptions, gateway)
 
-	if !dataplaneSpecDeepEqual(&dataplane.Spec.DataPlaneOptions, expectedDataPlaneOptions) {
+	metaUpdated, expectedDataPlaneMeta := k8sutils.UpdateInfrastructureMetadata(dataplane.ObjectMeta, gatewayInfrastructureMetadata(gateway))
+	if metaUpdated || !dataplaneSpecDeepEqual(&dataplane.Spec.DataPlaneOptions, expectedDataPlaneOptions) {
 		log.Trace(logger, "dataplane config is out of date, updating", gateway)
 		oldDataPlane := dataplane.DeepCopy()
+		dataplane.ObjectMeta = expectedDataPlaneMeta
 		dataplane.Spec.DataPlaneOptions = *expectedDataPlaneOptions
 
 		if err = r.Client.Patch(ctx, dataplane, client.MergeFrom(oldDataPlane)); err != nil {
@@ -559,9 +591,11 @@ func (r *Reconciler) provisionControlPlane(
 	// Don't require setting defaults for ControlPlane when using Gateway CRD.
 	setControlPlaneOptionsDefaults(expectedControlPlaneOptions)
 
-	if !controlplanecontroller.SpecDeepEqual(&controlPlane.Spec.ControlPlaneOptions, expectedControlPlaneOptions) {
+	metaUpdated, expectedControlPlaneMeta := k8sutils.UpdateInfrastructureMetadata(controlPlane.ObjectMeta, gatewayInfrastructureMetadata(gateway))
+	if metaUpdated || !controlplanecontroller.SpecDeepEqual(&controlPlane.Spec.ControlPlaneOptions, expectedControlPlaneOptions) {
 		log.Trace(logger, "controlplane config is out of date, updating", gateway)
 		controlplaneOld := controlPlane.DeepCopy()
+		controlPlane.ObjectMeta = expectedControlPlaneMeta
 		controlPlane.Spec.ControlPlaneOptions = *expectedControlPlaneOptions
 		if err := r.Client.Patch(ctx, controlPlane, client.MergeFrom(controlplaneOld)); err != nil {
 			k8sutils.SetCondition(
@@ -2,10 +2,12 @@ package gateway
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -15,10 +17,12 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
@@ -27,6 +31,7 @@ import (
 	"github.com/kong/gateway-operator/controller/pkg/secrets"
 	operatorerrors "github.com/kong/gateway-operator/internal/errors"
 	gwtypes "github.com/kong/gateway-operator/internal/types"
+	dputils "github.com/kong/gateway-operator/internal/utils/dataplane"
 	"github.com/kong/gateway-operator/internal/utils/gatewayclass"
 	"github.com/kong/gateway-operator/pkg/consts"
 	gatewayutils "github.com/kong/gateway-operator/pkg/utils/gateway"
@@ -51,14 +56,16 @@ func (r *Reconciler) createDataPlane(ctx context.Context,
 		},
 	}
 	if gatewayConfig.Spec.DataPlaneOptions != nil {
-		dataplane.Spec.DataPlaneOptions = *gatewayConfigDataPlaneOptionsToDataPlaneOptions(*gatewayConfig.Spec.DataPlaneOptions)
+		dataplane.Spec.DataPlaneOptions = *dputils.OptionsFromGatewayConfiguration(*gatewayConfig.Spec.DataPlaneOptions)
 	}
 	setDataPlaneOptionsDefaults(&dataplane.Spec.DataPlaneOptions, r.DefaultDataPlaneImage)
 	if err := setDataPlaneIngressServicePorts(&dataplane.Spec.DataPlaneOptions, gateway.Spec.Listeners); err != nil {
 		return nil, err
 	}
+	setDataPlaneStreamListen(&dataplane.Spec.DataPlaneOptions, gateway)
 	k8sutils.SetOwnerForObject(dataplane, gateway)
 	gatewayutils.LabelObjectAsGatewayManaged(dataplane)
+	k8sutils.SetInfrastructureMetadata(&dataplane.ObjectMeta, gatewayInfrastructureMetadata(gateway))
 	err := r.Client.Create(ctx, dataplane)
 	if err != nil {
 		return nil, err
@@ -92,6 +99,7 @@ func (r *Reconciler) createControlPlane(
 	setControlPlaneOptionsDefaults(&controlplane.Spec.ControlPlaneOptions)
 	k8sutils.SetOwnerForObject(controlplane, gateway)
 	gatewayutils.LabelObjectAsGatewayManaged(controlplane)
+	k8sutils.SetInfrastructureMetadata(&controlplane.ObjectMeta, gatewayInfrastructureMetadata(gateway))
 	return r.Client.Create(ctx, controlplane)
 }
 
@@ -123,29 +131,59 @@ func (r *Reconciler) getGatewayAddresses(
 	if count == 0 {
 		return []gwtypes.GatewayStatusAddress{}, fmt.Errorf("no Services found for DataPlane %s/%s", dataplane.Namespace, dataplane.Name)
 	}
-	return gatewayAddressesFromService(services[0])
-}
-
-func gatewayConfigDataPlaneOptionsToDataPlaneOptions(opts operatorv1beta1.GatewayConfigDataPlaneOptions) *operatorv1beta1.DataPlaneOptions {
-	dataPlaneOptions := &operatorv1beta1.DataPlaneOptions{
-		Deployment: opts.Deployment,
+	addresses, err := gatewayAddressesFromService(services[0])
+	if err != nil {
+		return []gwtypes.GatewayStatusAddress{}, err
 	}
 
-	if opts.Network.Services != nil && opts.Network.Services.Ingress != nil {
-		dataPlaneOptions.Network = operatorv1beta1.DataPlaneNetworkOptions{
-			Services: &operatorv1beta1.DataPlaneServices{
-				Ingress: &operatorv1beta1.DataPlaneServiceOptions{
-					ServiceOptions: operatorv1beta1.ServiceOptions{
-						Type:                  opts.Network.Services.Ingress.Type,
-						Annotations:           opts.Network.Services.Ingress.Annotations,
-						ExternalTrafficPolicy: opts.Network.Services.Ingress.ExternalTrafficPolicy,
-					},
-				},
-			},
+	// The private ingress Service addresses follow the ingress Service ones.
+	privateServices, err := k8sutils.ListServicesForOwner(
+		ctx,
+		r.Client,
+		dataplane.Namespace,
+		dataplane.UID,
+		client.MatchingLabels{
+			consts.GatewayOperatorManagedByLabel: consts.DataPlaneManagedLabelValue,
+			consts.DataPlaneServiceTypeLabel:     string(consts.DataPlanePrivateIngressServiceLabelValue),
+		},
+	)
+	if err != nil {
+		return []gwtypes.GatewayStatusAddress{}, err
+	}
+	if len(privateServices) > 1 {
+		return []gwtypes.GatewayStatusAddress{}, fmt.Errorf("DataPlane %s/%s has multiple private Services", dataplane.Namespace, dataplane.Name)
+	}
+	for _, svc := range privateServices {
+		privateAddresses, err := gatewayAddressesFromService(svc)
+		if err != nil {
+			return []gwtypes.GatewayStatusAddress{}, err
 		}
+		addresses = append(addresses, privateAddresses...)
 	}
+	return addresses, nil
+}
 
-	return dataPlaneOptions
+// gatewayInfrastructureMetadata returns the labels and annotations from the
+// Gateway's spec.infrastructure which are propagated to the objects managed
+// for the Gateway.
+func gatewayInfrastructureMetadata(gateway *gwtypes.Gateway) k8sutils.InfrastructureMetadata {
+	var infra k8sutils.InfrastructureMetadata
+	if gateway.Spec.Infrastructure == nil {
+		return infra
+	}
+	for k, v := range gateway.Spec.Infrastructure.Labels {
+		if infra.Labels == nil {
+			infra.Labels = make(map[string]string, len(gateway.Spec.Infrastructure.Labels))
+		}
+		infra.Labels[string(k)] = string(v)
+	}
+	for k, v := range gateway.Spec.Infrastructure.Annotations {
+		if infra.Annotations == nil {
+			infra.Annotations = make(map[string]string, len(gateway.Spec.Infrastructure.Annotations))
+		}
+		infra.Annotations[string(k)] = string(v)
+	}
+	return infra
 }
 
 func gatewayAddressesFromService(svc corev1.Service) ([]gwtypes.GatewayStatusAddress, error) {
@@ -199,16 +237,41 @@ func (r *Reconciler) verifyGatewayClassSupport(ctx context.Context, gateway *gwt
 	return gwc, nil
 }
 
-func (r *Reconciler) getOrCreateGatewayConfiguration(ctx context.Context, gatewayClass *gatewayv1.GatewayClass) (*operatorv1beta1.GatewayConfiguration, error) {
-	gatewayConfig, err := r.getGatewayConfigForGatewayClass(ctx, gatewayClass)
+// getOrCreateGatewayConfiguration returns the GatewayConfiguration for the provided
+// Gateway. The GatewayConfiguration referenced by the Gateway's
+// spec.infrastructure.parametersRef is merged over the one referenced by its
+// GatewayClass. When neither of them references a GatewayConfiguration, an empty
+// one is returned.
+//
+// Since the Gateway's configuration is merged field by field, it can only change
+// the fields it sets to a value: fields omitted when empty (e.g. a Service type set
+// to "") and null values are dropped before merging, so the Gateway's configuration
+// cannot reset a field set by the GatewayClass' configuration to its zero value.
+// Likewise, entries of maps and of lists merged by key (e.g. annotations, containers
+// or env vars) can be added or changed but not removed. Pointer fields (e.g. replicas)
+// can be set to their zero value.
+func (r *Reconciler) getOrCreateGatewayConfiguration(
+	ctx context.Context,
+	gatewayClass *gatewayv1.GatewayClass,
+	gateway *gwtypes.Gateway,
+) (*operatorv1beta1.GatewayConfiguration, error) {
+	gatewayClassConfig, err := r.getGatewayConfigForGatewayClass(ctx, gatewayClass)
+	if err != nil {
+		if !errors.Is(err, operatorerrors.ErrObjectMissingParametersRef) {
+			return nil, err
+		}
+		gatewayClassConfig = new(operatorv1beta1.GatewayConfiguration)
+	}
+
+	gatewayConfig, err := r.getGatewayConfigForGateway(ctx, gateway)
 	if err != nil {
 		if errors.Is(err, operatorerrors.ErrObjectMissingParametersRef) {
-			return new(operatorv1beta1.GatewayConfiguration), nil
+			return gatewayClassConfig, nil
 		}
 		return nil, err
 	}
 
-	return gatewayConfig, nil
+	return mergeGatewayConfigurations(gatewayClassConfig, gatewayConfig)
 }
 
 func (r *Reconciler) getGatewayConfigForGatewayClass(ctx context.Context, gatewayClass *gatewayv1.GatewayClass) (*operatorv1beta1.GatewayConfiguration, error) {
@@ -216,23 +279,8 @@ func (r *Reconciler) getGatewayConfigForGatewayClass(ctx context.Context, gatewa
 		return nil, fmt.Errorf("%w, gatewayClass = %s", operatorerrors.ErrObjectMissingParametersRef, gatewayClass.Name)
 	}
 
-	if string(gatewayClass.Spec.ParametersRef.Group) != operatorv1beta1.SchemeGroupVersion.Group ||
-		string(gatewayClass.Spec.ParametersRef.Kind) != "GatewayConfiguration" {
-		return nil, &k8serrors.StatusError{
-			ErrStatus: metav1.Status{
-				Status: metav1.StatusFailure,
-				Code:   http.StatusBadRequest,
-				Reason: metav1.StatusReasonInvalid,
-				Details: &metav1.StatusDetails{
-					Kind: string(gatewayClass.Spec.ParametersRef.Kind),
-					Causes: []metav1.StatusCause{{
-						Type: metav1.CauseTypeFieldValueNotSupported,
-						Message: fmt.Sprintf("controller only supports %s %s resources for GatewayClass parametersRef",
-							operatorv1beta1.SchemeGroupVersion.Group, "GatewayConfiguration"),
-					}},
-				},
-			},
-		}
+	if !gatewayclass.IsGatewayConfigurationParametersRef(gatewayClass.Spec.ParametersRef.Group, gatewayClass.Spec.ParametersRef.Kind) {
+		return nil, unsupportedParametersRefError(operatorv1beta1.GatewayConfigurationTargetKindGatewayClass, gatewayClass.Spec.ParametersRef.Kind)
 	}
 
 	if gatewayClass.Spec.ParametersRef.Namespace == nil ||
@@ -248,6 +296,106 @@ func (r *Reconciler) getGatewayConfigForGatewayClass(ctx context.Context, gatewa
 	}, gatewayConfig)
 }
 
+// getGatewayConfigForGateway returns the GatewayConfiguration referenced by the
+// Gateway's spec.infrastructure.parametersRef. The reference is local, hence the
+// GatewayConfiguration is looked up in the Gateway's namespace.
+func (r *Reconciler) getGatewayConfigForGateway(ctx context.Context, gateway *gwtypes.Gateway) (*operatorv1beta1.GatewayConfiguration, error) {
+	if gateway.Spec.Infrastructure == nil || gateway.Spec.Infrastructure.ParametersRef == nil {
+		return nil, fmt.Errorf("%w, gateway = %s/%s", operatorerrors.ErrObjectMissingParametersRef, gateway.Namespace, gateway.Name)
+	}
+
+	parametersRef := gateway.Spec.Infrastructure.ParametersRef
+	if !gatewayclass.IsGatewayConfigurationParametersRef(parametersRef.Group, parametersRef.Kind) {
+		return nil, unsupportedParametersRefError(operatorv1beta1.GatewayConfigurationTargetKindGateway, parametersRef.Kind)
+	}
+
+	if parametersRef.Name == "" {
+		return nil, fmt.Errorf("Gateway %s/%s has invalid infrastructure ParametersRef: name must be provided", gateway.Namespace, gateway.Name)
+	}
+
+	gatewayConfig := new(operatorv1beta1.GatewayConfiguration)
+	return gatewayConfig, r.Client.Get(ctx, client.ObjectKey{
+		Namespace: gateway.Namespace,
+		Name:      parametersRef.Name,
+	}, gatewayConfig)
+}
+
+func unsupportedParametersRefError(target operatorv1beta1.GatewayConfigurationTargetKind, kind gatewayv1.Kind) error {
+	return &k8serrors.StatusError{
+		ErrStatus: metav1.Status{
+			Status: metav1.StatusFailure,
+			Code:   http.StatusBadRequest,
+			Reason: metav1.StatusReasonInvalid,
+			Details: &metav1.StatusDetails{
+				Kind: string(kind),
+				Causes: []metav1.StatusCause{{
+					Type: metav1.CauseTypeFieldValueNotSupported,
+					Message: fmt.Sprintf("controller only supports %s %s resources for %s parametersRef",
+						operatorv1beta1.SchemeGroupVersion.Group, "GatewayConfiguration", target),
+				}},
+			},
+		},
+	}
+}
+
+// mergeGatewayConfigurations merges the spec of the override GatewayConfiguration
+// over the spec of the base one using a strategic merge patch, so that e.g. the
+// containers of the pod templates are merged by name.
+// Fields which are not set in the override are kept from the base.
+func mergeGatewayConfigurations(base, override *operatorv1beta1.GatewayConfiguration) (*operatorv1beta1.GatewayConfiguration, error) {
+	baseBytes, err := json.Marshal(base.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON for GatewayConfiguration %s: %w", base.Name, err)
+	}
+
+	overrideBytes, err := json.Marshal(override.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON for GatewayConfiguration %s: %w", override.Name, err)
+	}
+	// Zero values of fields which are not omitted when empty (e.g. the containers
+	// of a pod template) are marshalled as null, which a strategic merge patch
+	// would interpret as a removal of the field from the base.
+	var overrideObj map[string]any
+	if err := json.Unmarshal(overrideBytes, &overrideObj); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON for GatewayConfiguration %s: %w", override.Name, err)
+	}
+	if overrideBytes, err = json.Marshal(removeNullValues(overrideObj)); err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON for GatewayConfiguration %s: %w", override.Name, err)
+	}
+
+	mergedBytes, err := strategicpatch.StrategicMergePatch(baseBytes, overrideBytes, &operatorv1beta1.GatewayConfigurationSpec{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge GatewayConfiguration %s over %s: %w", override.Name, base.Name, err)
+	}
+
+	merged := override.DeepCopy()
+	merged.Spec = operatorv1beta1.GatewayConfigurationSpec{}
+	if err := json.Unmarshal(mergedBytes, &merged.Spec); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal merged GatewayConfiguration %s: %w", override.Name, err)
+	}
+
+	return merged, nil
+}
+
+// removeNullValues recursively removes the null values from the provided object.
+func removeNullValues(obj map[string]any) map[string]any {
+	for k, v := range obj {
+		switch v := v.(type) {
+		case nil:
+			delete(obj, k)
+		case map[string]any:
+			obj[k] = removeNullValues(v)
+		case []any:
+			for i, e := range v {
+				if m, ok := e.(map[string]any); ok {
+					v[i] = removeNullValues(m)
+				}
+			}
+		}
+	}
+	return obj
+}
+
 func (r *Reconciler) ensureDataPlaneHasNetworkPolicy(
 	ctx context.Context,
 	gateway *gwtypes.Gateway,
@@ -273,6 +421,7 @@ func (r *Reconciler) ensureDataPlaneHasNetworkPolicy(
 	}
 	k8sutils.SetOwnerForObject(generatedPolicy, gateway)
 	gatewayutils.LabelObjectAsGatewayManaged(generatedPolicy)
+	k8sutils.SetInfrastructureMetadata(&generatedPolicy.ObjectMeta, gatewayInfrastructureMetadata(gateway))
 
 	if count == 1 {
 		var (
@@ -328,6 +477,19 @@ func generateDataPlaneNetworkPolicy(
 			proxySSLPort = intstr.FromInt(kongListenConfig.SSLEndpoint.Port)
 		}
 	}
+	var streamPorts []networkingv1.NetworkPolicyPort
+	if streamListen := k8sutils.EnvValueByName(container.Env, "KONG_STREAM_LISTEN"); streamListen != "" {
+		endpoints, err := parseKongStreamListenEnv(streamListen)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing KONG_STREAM_LISTEN env: %w", err)
+		}
+		for _, e := range endpoints {
+			streamPorts = append(streamPorts, networkingv1.NetworkPolicyPort{
+				Protocol: lo.ToPtr(e.Protocol),
+				Port:     lo.ToPtr(intstr.FromInt(e.Port)),
+			})
+		}
+	}
 	if adminListen := k8sutils.EnvValueByName(container.Env, "KONG_ADMIN_LISTEN"); adminListen != "" {
 		kongListenConfig, err := parseKongListenEnv(adminListen)
 		if err != nil {
@@ -358,10 +520,10 @@ func generateDataPlaneNetworkPolicy(
 	}
 
 	allowProxyIngress := networkingv1.NetworkPolicyIngressRule{
-		Ports: []networkingv1.NetworkPolicyPort{
+		Ports: append([]networkingv1.NetworkPolicyPort{
 			{Protocol: &protocolTCP, Port: &proxyPort},
 			{Protocol: &protocolTCP, Port: &proxySSLPort},
-		},
+		}, streamPorts...),
 	}
 
 	allowMetricsIngress := networkingv1.NetworkPolicyIngressRule{
@@ -535,18 +697,13 @@ func (l listenerConditionAwareT) SetConditions(conditions []metav1.Condition) {
 
 // supportedRoutesByProtocol returns a map of maps to relate each protocolType with the
 // set of supported Routes.
-//
-// Note: the inner maps have only one element as for now, but in future they will be improved,
-// as each protocolType can be compatible with many different route types.
 func supportedRoutesByProtocol() map[gatewayv1.ProtocolType]map[gatewayv1.Kind]struct{} {
 	return map[gatewayv1.ProtocolType]map[gatewayv1.Kind]struct{}{
-		gatewayv1.HTTPProtocolType:  {"HTTPRoute": {}},
-		gatewayv1.HTTPSProtocolType: {"HTTPRoute": {}},
-
-		// L4 routes not supported yet
-		// gatewayv1.TLSProtocolType:   {"TLSRoute": {}},
-		// gatewayv1.TCPProtocolType:   {"TCPRoute": {}},
-		// gatewayv1.UDPProtocolType:   {"UDPRoute": {}},
+		gatewayv1.HTTPProtocolType:  {"HTTPRoute": {}, "GRPCRoute": {}},
+		gatewayv1.HTTPSProtocolType: {"HTTPRoute": {}, "GRPCRoute": {}},
+		gatewayv1.TLSProtocolType:   {"TLSRoute": {}},
+		gatewayv1.TCPProtocolType:   {"TCPRoute": {}},
+		gatewayv1.UDPProtocolType:   {"UDPRoute": {}},
 	}
 }
 
@@ -698,57 +855,108 @@ func countAttachedRoutesForGatewayListener(ctx context.Context, g *gwtypes.Gatew
 		}
 	}
 
-	kindsForProtocol, protocolSupported := supportedRoutesByProtocol()[listener.Protocol]
+	kindsForProtocol := supportedRoutesByProtocol()[listener.Protocol]
+	var kinds []gatewayv1.Kind
 	switch len(allowedRoutes.Kinds) {
 	case 0:
-		if protocolSupported {
-			for k := range kindsForProtocol {
-				// NOTE: Count other types of routes when they are supported.
-
-				switch k {
-				case "HTTPRoute":
-					httpRoutes, err := gatewayutils.ListHTTPRoutesForGateway(ctx, cl, g, opts...)
-					if err != nil {
-						return 0, fmt.Errorf(
-							"failed to list HTTPRoutes for Gateway %s when counting AttachedRoutes: %w",
-							client.ObjectKeyFromObject(g), err,
-						)
-					}
-					count += countAttachedHTTPRoutes(listener.Name, httpRoutes)
-				default:
-					return 0, fmt.Errorf("unsupported route kind: %T", k)
-				}
-			}
-		}
+		kinds = lo.Keys(kindsForProtocol)
 	default:
-		if lo.ContainsBy(allowedRoutes.Kinds, func(gvk gatewayv1.RouteGroupKind) bool {
+		for _, gvk := range allowedRoutes.Kinds {
 			if _, ok := kindsForProtocol[gvk.Kind]; !ok {
-				return false
+				continue
 			}
-			return gvk.Group != nil && *gvk.Group == gatewayv1.Group(gatewayv1.GroupVersion.Group)
-		}) {
-			httpRoutes, err := gatewayutils.ListHTTPRoutesForGateway(ctx, cl, g, opts...)
-			if err != nil {
-				return 0, fmt.Errorf(
-					"failed to list HTTPRoutes for Gateway %s when counting AttachedRoutes: %w",
-					client.ObjectKeyFromObject(g), err,
-				)
+			if gvk.Group == nil || *gvk.Group != gatewayv1.Group(gatewayv1.GroupVersion.Group) {
+				continue
 			}
+			kinds = append(kinds, gvk.Kind)
+		}
+	}
 
-			count += countAttachedHTTPRoutes(listener.Name, httpRoutes)
+	for _, kind := range lo.Uniq(kinds) {
+		kindCount, err := countAttachedRoutesOfKind(ctx, g, listener.Name, kind, cl, opts...)
+		if err != nil {
+			return 0, err
 		}
+		count += kindCount
 	}
 
 	return count, nil
 }
 
-// countAttachedHTTPRoutes counts the number of attached HTTPRoutes for a given listener,
+// countAttachedRoutesOfKind counts the number of attached routes of the provided
+// kind for a given listener.
+// CRDs of routes other than HTTPRoute (GRPCRoute was added to the Gateway API
+// standard channel only in v1.1.0, L4 routes are part of the experimental channel)
+// might not be installed, in which case there are no routes of that kind to count.
+func countAttachedRoutesOfKind(
+	ctx context.Context,
+	g *gwtypes.Gateway,
+	listenerName gatewayv1.SectionName,
+	kind gatewayv1.Kind,
+	cl client.Client,
+	opts ...client.ListOption,
+) (int32, error) {
+	var (
+		count int32
+		err   error
+	)
+	switch kind {
+	case "HTTPRoute":
+		var routes []gwtypes.HTTPRoute
+		routes, err = gatewayutils.ListHTTPRoutesForGateway(ctx, cl, g, opts...)
+		count = countAttachedRoutes(listenerName, routes, func(r gwtypes.HTTPRoute) []gatewayv1.ParentReference {
+			return r.Spec.ParentRefs
+		})
+	case "GRPCRoute":
+		var routes []gwtypes.GRPCRoute
+		routes, err = gatewayutils.ListGRPCRoutesForGateway(ctx, cl, g, opts...)
+		count = countAttachedRoutes(listenerName, routes, func(r gwtypes.GRPCRoute) []gatewayv1.ParentReference {
+			return r.Spec.ParentRefs
+		})
+	case "TCPRoute":
+		var routes []gwtypes.TCPRoute
+		routes, err = gatewayutils.ListTCPRoutesForGateway(ctx, cl, g, opts...)
+		count = countAttachedRoutes(listenerName, routes, func(r gwtypes.TCPRoute) []gatewayv1.ParentReference {
+			return r.Spec.ParentRefs
+		})
+	case "TLSRoute":
+		var routes []gwtypes.TLSRoute
+		routes, err = gatewayutils.ListTLSRoutesForGateway(ctx, cl, g, opts...)
+		count = countAttachedRoutes(listenerName, routes, func(r gwtypes.TLSRoute) []gatewayv1.ParentReference {
+			return r.Spec.ParentRefs
+		})
+	case "UDPRoute":
+		var routes []gwtypes.UDPRoute
+		routes, err = gatewayutils.ListUDPRoutesForGateway(ctx, cl, g, opts...)
+		count = countAttachedRoutes(listenerName, routes, func(r gwtypes.UDPRoute) []gatewayv1.ParentReference {
+			return r.Spec.ParentRefs
+		})
+	default:
+		return 0, fmt.Errorf("unsupported route kind: %s", kind)
+	}
+	if err != nil {
+		if kind != "HTTPRoute" && meta.IsNoMatchError(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf(
+			"failed to list %ss for Gateway %s when counting AttachedRoutes: %w",
+			kind, client.ObjectKeyFromObject(g), err,
+		)
+	}
+	return count, nil
+}
+
+// countAttachedRoutes counts the number of attached routes for a given listener,
 // taking into account the ParentRefs' sectionName.
-func countAttachedHTTPRoutes(listenerName gatewayv1.SectionName, httpRoutes []gatewayv1.HTTPRoute) int32 {
+func countAttachedRoutes[T any](
+	listenerName gatewayv1.SectionName,
+	routes []T,
+	parentRefsForRoute func(T) []gatewayv1.ParentReference,
+) int32 {
 	var count int32
 
-	for _, httpRoute := range httpRoutes {
-		if lo.ContainsBy(httpRoute.Spec.ParentRefs, func(parentRef gatewayv1.ParentReference) bool {
+	for _, route := range routes {
+		if lo.ContainsBy(parentRefsForRoute(route), func(parentRef gatewayv1.ParentReference) bool {
 			return parentRef.SectionName == nil || *parentRef.SectionName == listenerName
 		}) {
 			count++
@@ -773,8 +981,10 @@ func (g *gatewayConditionsAndListenersAwareT) setConflicted() {
 				continue
 			}
 			// If two listeners specify the same port and different protocols, they have a protocol conflict,
-			// and the conflicted condition must be updated accordingly.
-			if l.Port == l2.Port && l.Protocol != l2.Protocol {
+			// and the conflicted condition must be updated accordingly. So do two TLS listeners with
+			// different TLS modes as the DataPlane can't both terminate and pass TLS through on one port.
+			if l.Port == l2.Port && (l.Protocol != l2.Protocol ||
+				l.Protocol == gatewayv1.TLSProtocolType && listenerTLSMode(l) != listenerTLSMode(l2)) {
 				conflictedCondition.Status = metav1.ConditionTrue
 				conflictedCondition.Reason = string(gatewayv1.ListenerReasonProtocolConflict)
 				break
@@ -792,6 +1002,14 @@ func (g *gatewayConditionsAndListenersAwareT) setConflicted() {
 	}
 }
 
+// listenerTLSMode returns the TLS mode of the listener, defaulting to Terminate.
+func listenerTLSMode(l gatewayv1.Listener) gatewayv1.TLSModeType {
+	if l.TLS == nil || l.TLS.Mode == nil {
+		return gatewayv1.TLSModeTerminate
+	}
+	return *l.TLS.Mode
+}
+
 // setProgrammed sets the gateway Programmed condition by setting the underlying
 // Gateway Programmed status to true.
 // It also sets the listeners Programmed condition by setting the underlying
@@ -819,6 +1037,9 @@ func (g *gatewayConditionsAndListenersAwareT) setProgrammed() {
 	}
 }
 
+// setDataPlaneIngressServicePorts adds a port for each of the Gateway's listeners to
+// the DataPlane's ingress Service and, when configured, to its private ingress Service.
+// The ports are appended to the ones already set in the options of each Service.
 func setDataPlaneIngressServicePorts(opts *operatorv1beta1.DataPlaneOptions, listeners []gatewayv1.Listener) error {
 	if len(listeners) == 0 {
 		return nil
@@ -833,7 +1054,10 @@ func setDataPlaneIngressServicePorts(opts *operatorv1beta1.DataPlaneOptions, lis
 		}
 	}
 
-	var errs error
+	var (
+		ports []operatorv1beta1.DataPlaneServicePort
+		errs  error
+	)
 	for i, l := range listeners {
 		var name string
 		// If the listener name is set, use it. Otherwise, we need to be sure the
@@ -852,15 +1076,104 @@ func setDataPlaneIngressServicePorts(opts *operatorv1beta1.DataPlaneOptions, lis
 			port.TargetPort = intstr.FromInt(consts.DataPlaneProxySSLPort)
 		case gatewayv1.HTTPProtocolType:
 			port.TargetPort = intstr.FromInt(consts.DataPlaneProxyPort)
+		case gatewayv1.TLSProtocolType, gatewayv1.TCPProtocolType, gatewayv1.UDPProtocolType:
+			// Stream listeners listen on the same port in the DataPlane as the
+			// Gateway listener so that L4 routes can match on the destination port.
+			if lo.Contains(dataPlaneReservedPorts, int(l.Port)) {
+				errs = errors.Join(errs, fmt.Errorf("listener %d uses port %d which is reserved by the DataPlane", i, l.Port))
+				continue
+			}
+			port.TargetPort = intstr.FromInt(int(l.Port))
+			if l.Protocol == gatewayv1.UDPProtocolType {
+				port.Protocol = corev1.ProtocolUDP
+			}
 		default:
 			errs = errors.Join(errs, fmt.Errorf("listener %d uses unsupported protocol %s", i, l.Protocol))
 			continue
 		}
-		opts.Network.Services.Ingress.Ports = append(opts.Network.Services.Ingress.Ports, port)
+		ports = append(ports, port)
+	}
+
+	opts.Network.Services.Ingress.Ports = append(opts.Network.Services.Ingress.Ports, ports...)
+	// The private ingress Service exposes the listeners too, next to its own ports.
+	if privateIngress := opts.Network.Services.PrivateIngress; privateIngress != nil {
+		privateIngress.Ports = append(privateIngress.Ports, ports...)
 	}
 	return errs
 }
 
+// dataPlaneReservedPorts are the ports the DataPlane listens on for purposes
+// other than the stream listeners, hence they can't be used by L4 listeners.
+var dataPlaneReservedPorts = []int{
+	consts.DataPlaneProxyPort,
+	consts.DataPlaneProxySSLPort,
+	consts.DataPlaneAdminAPIPort,
+	consts.DataPlaneMetricsPort,
+}
+
+// setDataPlaneStreamListen configures the DataPlane proxy container's stream
+// listeners (KONG_STREAM_LISTEN) for the TLS, TCP and UDP Gateway listeners.
+// Conflicted listeners are skipped: nginx fails to start with two different
+// listen directives on the same port, which would take the whole DataPlane down.
+// It has to be called after setDataPlaneOptionsDefaults which ensures that the
+// proxy container is present and after the Gateway's listeners Conflicted
+// conditions have been set.
+func setDataPlaneStreamListen(opts *operatorv1beta1.DataPlaneOptions, gateway *gwtypes.Gateway) {
+	var (
+		streamListen []string
+		seen         = make(map[string]struct{})
+	)
+	for _, l := range gateway.Spec.Listeners {
+		if lo.Contains(dataPlaneReservedPorts, int(l.Port)) || isListenerConflicted(gateway, l.Name) {
+			continue
+		}
+		var flags string
+		switch l.Protocol {
+		case gatewayv1.TLSProtocolType:
+			flags = "reuseport backlog=16384"
+			if listenerTLSMode(l) == gatewayv1.TLSModeTerminate {
+				flags = "ssl " + flags
+			}
+		case gatewayv1.TCPProtocolType:
+			flags = "reuseport backlog=16384"
+		case gatewayv1.UDPProtocolType:
+			flags = "udp reuseport"
+		default:
+			continue
+		}
+		// Several listeners can use the same port and protocol, e.g. TLS
+		// listeners with different hostnames.
+		listen := fmt.Sprintf("0.0.0.0:%d %s", l.Port, flags)
+		if _, ok := seen[listen]; ok {
+			continue
+		}
+		seen[listen] = struct{}{}
+		streamListen = append(streamListen, listen)
+	}
+	if len(streamListen) == 0 {
+		return
+	}
+
+	container := k8sutils.GetPodContainerByName(&opts.Deployment.PodTemplateSpec.Spec, consts.DataPlaneProxyContainerName)
+	if container == nil {
+		return
+	}
+	container.Env = k8sutils.UpdateEnv(container.Env, "KONG_STREAM_LISTEN", strings.Join(streamListen, ", "))
+}
+
+// isListenerConflicted returns true if the Gateway's listener with the provided
+// name has the Conflicted condition set to True.
+func isListenerConflicted(gateway *gwtypes.Gateway, name gatewayv1.SectionName) bool {
+	for i := range gateway.Status.Listeners {
+		if gateway.Status.Listeners[i].Name != name {
+			continue
+		}
+		c, ok := k8sutils.GetCondition(consts.ConditionType(gatewayv1.ListenerConditionConflicted), listenerConditionsAware(&gateway.Status.Listeners[i]))
+		return ok && c.Status == metav1.ConditionTrue
+	}
+	return false
+}
+
 // getSupportedKindsWithResolvedRefsCondition returns all the route kinds supported by the listener, along with the resolvedRefs
 // condition, that is based on the presence of errors in such a field.
 func getSupportedKindsWithResolvedRefsCondition(ctx context.Context, c client.Client, gatewayNamespace string, generation int64, listener gatewayv1.Listener) (supportedKinds []gatewayv1.RouteGroupKind, resolvedRefsCondition metav1.Condition, err error) {
@@ -875,8 +1188,12 @@ func getSupportedKindsWithResolvedRefsCondition(ctx context.Context, c client.Cl
 	}
 
 	message := ""
-	if listener.TLS != nil {
-		// We currently do not support TLSRoutes, hence only TLS termination supported.
+	// TLS listeners in Passthrough mode don't terminate TLS, hence they don't
+	// reference any certificates.
+	isTLSPassthrough := listener.Protocol == gatewayv1.TLSProtocolType &&
+		listener.TLS != nil && listener.TLS.Mode != nil && *listener.TLS.Mode == gatewayv1.TLSModePassthrough
+	if listener.TLS != nil && !isTLSPassthrough {
+		// TLS passthrough is only supported by TLS listeners.
 		if *listener.TLS.Mode != gatewayv1.TLSModeTerminate {
 			resolvedRefsCondition.Status = metav1.ConditionFalse
 			resolvedRefsCondition.Reason = string(gatewayv1.ListenerReasonInvalidCertificateRef)
@@ -949,8 +1266,10 @@ func getSupportedKindsWithResolvedRefsCondition(ctx context.Context, c client.Cl
 	}
 
 	if listener.AllowedRoutes == nil || len(listener.AllowedRoutes.Kinds) == 0 {
-		supportedRoutes := supportedRoutesByProtocol()[listener.Protocol]
-		for routeKind := range supportedRoutes {
+		// Sort the kinds so that the listener status is stable.
+		supportedRoutes := lo.Keys(supportedRoutesByProtocol()[listener.Protocol])
+		slices.Sort(supportedRoutes)
+		for _, routeKind := range supportedRoutes {
 			supportedKinds = append(supportedKinds, gatewayv1.RouteGroupKind{
 				Group: (*gatewayv1.Group)(&gatewayv1.GroupVersion.Group),
 				Kind:  routeKind,
@@ -1086,6 +1405,44 @@ func parseKongListenEnv(str string) (kongListenConfig, error) {
 	return kongListenConfig, nil
 }
 
+type streamListenEndpoint struct {
+	Port     int
+	Protocol corev1.Protocol
+}
+
+// parseKongStreamListenEnv parses the provided kong stream listen string and
+// returns all the endpoints it defines.
+//
+// One can find more information about the kong stream listen format at:
+// - https://docs.konghq.com/gateway/3.0.x/reference/configuration/#stream_listen
+func parseKongStreamListenEnv(str string) ([]streamListenEndpoint, error) {
+	var endpoints []streamListenEndpoint
+	for _, s := range strings.Split(str, ",") {
+		s = strings.TrimSpace(s)
+		if s == "off" {
+			continue
+		}
+		hostPort, flags, _ := strings.Cut(s, " ")
+		_, port, err := net.SplitHostPort(hostPort)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing host %s: %w", hostPort, err)
+		}
+		p, err := strconv.Atoi(port)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing port %s: %w", port, err)
+		}
+		protocol := corev1.ProtocolTCP
+		if lo.Contains(strings.Fields(flags), "udp") {
+			protocol = corev1.ProtocolUDP
+		}
+		endpoints = append(endpoints, streamListenEndpoint{
+			Port:     p,
+			Protocol: protocol,
+		})
+	}
+	return endpoints, nil
+}
+
 func gatewayStatusNeedsUpdate(oldGateway, newGateway gatewayConditionsAndListenersAwareT) bool {
 	oldCondAccepted, okOld := k8sutils.GetCondition(consts.ConditionType(gatewayv1.GatewayConditionAccepted), oldGateway)
 	newCondAccepted, _ := k8sutils.GetCondition(consts.ConditionType(gatewayv1.GatewayConditionAccepted), newGateway)
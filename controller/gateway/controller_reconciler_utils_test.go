@@ -9,10 +9,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
@@ -550,6 +552,44 @@ func TestSetDataPlaneIngressServicePorts(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "L4 listeners",
+			listeners: []gwtypes.Listener{
+				{
+					Name:     "tls",
+					Protocol: gatewayv1.TLSProtocolType,
+					Port:     gatewayv1.PortNumber(9443),
+				},
+				{
+					Name:     "tcp",
+					Protocol: gatewayv1.TCPProtocolType,
+					Port:     gatewayv1.PortNumber(9000),
+				},
+				{
+					Name:     "udp",
+					Protocol: gatewayv1.UDPProtocolType,
+					Port:     gatewayv1.PortNumber(9001),
+				},
+			},
+			expectedPorts: []operatorv1beta1.DataPlaneServicePort{
+				{
+					Name:       "tls",
+					Port:       9443,
+					TargetPort: intstr.FromInt(9443),
+				},
+				{
+					Name:       "tcp",
+					Port:       9000,
+					TargetPort: intstr.FromInt(9000),
+				},
+				{
+					Name:       "udp",
+					Port:       9001,
+					TargetPort: intstr.FromInt(9001),
+					Protocol:   corev1.ProtocolUDP,
+				},
+			},
+		},
 		{
 			name: "some invalid listeners",
 			listeners: []gwtypes.Listener{
@@ -559,10 +599,15 @@ func TestSetDataPlaneIngressServicePorts(t *testing.T) {
 					Port:     gatewayv1.PortNumber(80),
 				},
 				{
-					Name:     "udp",
-					Protocol: gatewayv1.UDPProtocolType,
+					Name:     "sctp",
+					Protocol: gatewayv1.ProtocolType("SCTP"),
 					Port:     gatewayv1.PortNumber(8899),
 				},
+				{
+					Name:     "tcp",
+					Protocol: gatewayv1.TCPProtocolType,
+					Port:     gatewayv1.PortNumber(consts.DataPlaneAdminAPIPort),
+				},
 			},
 			expectedPorts: []operatorv1beta1.DataPlaneServicePort{
 				{
@@ -571,19 +616,178 @@ func TestSetDataPlaneIngressServicePorts(t *testing.T) {
 					TargetPort: intstr.FromInt(consts.DataPlaneProxyPort),
 				},
 			},
-			expectedError: errors.New("listener 1 uses unsupported protocol UDP"),
+			expectedError: errors.New("listener 1 uses unsupported protocol SCTP\nlistener 2 uses port 8444 which is reserved by the DataPlane"),
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			tc := tc
-			err := setDataPlaneIngressServicePorts(&operatorv1beta1.DataPlaneOptions{}, tc.listeners)
+			opts := &operatorv1beta1.DataPlaneOptions{}
+			err := setDataPlaneIngressServicePorts(opts, tc.listeners)
 			if tc.expectedError == nil {
 				require.NoError(t, err)
 			} else {
 				require.EqualError(t, err, tc.expectedError.Error())
 			}
+			if tc.expectedPorts != nil {
+				require.Equal(t, tc.expectedPorts, opts.Network.Services.Ingress.Ports)
+			}
+
+			t.Log("private ingress Service exposes the listeners next to its own ports")
+			privateIngressPort := operatorv1beta1.DataPlaneServicePort{
+				Name:       "private",
+				Port:       8080,
+				TargetPort: intstr.FromInt(consts.DataPlaneProxyPort),
+			}
+			opts = &operatorv1beta1.DataPlaneOptions{
+				Network: operatorv1beta1.DataPlaneNetworkOptions{
+					Services: &operatorv1beta1.DataPlaneServices{
+						PrivateIngress: &operatorv1beta1.DataPlaneServiceOptions{
+							Ports: []operatorv1beta1.DataPlaneServicePort{privateIngressPort},
+						},
+					},
+				},
+			}
+			_ = setDataPlaneIngressServicePorts(opts, tc.listeners)
+			if tc.expectedPorts != nil {
+				require.Equal(t, tc.expectedPorts, opts.Network.Services.Ingress.Ports)
+				require.Equal(t, append([]operatorv1beta1.DataPlaneServicePort{privateIngressPort}, tc.expectedPorts...),
+					opts.Network.Services.PrivateIngress.Ports)
+			}
+		})
+	}
+}
+
+func TestSetDataPlaneStreamListen(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		listeners            []gwtypes.Listener
+		expectedStreamListen string
+	}{
+		{
+			name: "only HTTP listeners",
+			listeners: []gwtypes.Listener{
+				{
+					Name:     "http",
+					Protocol: gwtypes.HTTPProtocolType,
+					Port:     gatewayv1.PortNumber(80),
+				},
+			},
+		},
+		{
+			name: "L4 listeners",
+			listeners: []gwtypes.Listener{
+				{
+					Name:     "http",
+					Protocol: gwtypes.HTTPProtocolType,
+					Port:     gatewayv1.PortNumber(80),
+				},
+				{
+					Name:     "tls-terminate",
+					Protocol: gatewayv1.TLSProtocolType,
+					Port:     gatewayv1.PortNumber(9443),
+					TLS: &gatewayv1.GatewayTLSConfig{
+						Mode: lo.ToPtr(gatewayv1.TLSModeTerminate),
+					},
+				},
+				{
+					Name:     "tls-passthrough",
+					Protocol: gatewayv1.TLSProtocolType,
+					Port:     gatewayv1.PortNumber(9444),
+					TLS: &gatewayv1.GatewayTLSConfig{
+						Mode: lo.ToPtr(gatewayv1.TLSModePassthrough),
+					},
+				},
+				{
+					Name:     "tcp",
+					Protocol: gatewayv1.TCPProtocolType,
+					Port:     gatewayv1.PortNumber(9000),
+				},
+				{
+					Name:     "tcp-2",
+					Protocol: gatewayv1.TCPProtocolType,
+					Port:     gatewayv1.PortNumber(9000),
+				},
+				{
+					Name:     "udp",
+					Protocol: gatewayv1.UDPProtocolType,
+					Port:     gatewayv1.PortNumber(9001),
+				},
+			},
+			expectedStreamListen: "0.0.0.0:9443 ssl reuseport backlog=16384, " +
+				"0.0.0.0:9444 reuseport backlog=16384, " +
+				"0.0.0.0:9000 reuseport backlog=16384, " +
+				"0.0.0.0:9001 udp reuseport",
+		},
+		{
+			name: "TLS listeners with different modes on the same port are skipped",
+			listeners: []gwtypes.Listener{
+				{
+					Name:     "tls-terminate",
+					Protocol: gatewayv1.TLSProtocolType,
+					Port:     gatewayv1.PortNumber(9443),
+					TLS: &gatewayv1.GatewayTLSConfig{
+						Mode: lo.ToPtr(gatewayv1.TLSModeTerminate),
+					},
+				},
+				{
+					Name:     "tls-passthrough",
+					Protocol: gatewayv1.TLSProtocolType,
+					Port:     gatewayv1.PortNumber(9443),
+					TLS: &gatewayv1.GatewayTLSConfig{
+						Mode: lo.ToPtr(gatewayv1.TLSModePassthrough),
+					},
+				},
+				{
+					Name:     "tcp",
+					Protocol: gatewayv1.TCPProtocolType,
+					Port:     gatewayv1.PortNumber(9000),
+				},
+			},
+			expectedStreamListen: "0.0.0.0:9000 reuseport backlog=16384",
+		},
+		{
+			name: "TCP and TLS listeners on the same port are skipped",
+			listeners: []gwtypes.Listener{
+				{
+					Name:     "tls",
+					Protocol: gatewayv1.TLSProtocolType,
+					Port:     gatewayv1.PortNumber(9443),
+				},
+				{
+					Name:     "tcp",
+					Protocol: gatewayv1.TCPProtocolType,
+					Port:     gatewayv1.PortNumber(9443),
+				},
+				{
+					Name:     "udp",
+					Protocol: gatewayv1.UDPProtocolType,
+					Port:     gatewayv1.PortNumber(9001),
+				},
+			},
+			expectedStreamListen: "0.0.0.0:9001 udp reuseport",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &gwtypes.Gateway{
+				Spec: gatewayv1.GatewaySpec{
+					Listeners: tc.listeners,
+				},
+			}
+			gwConditionAware := gatewayConditionsAndListenersAware(gateway)
+			gwConditionAware.initListenersStatus()
+			gwConditionAware.setConflicted()
+
+			opts := &operatorv1beta1.DataPlaneOptions{}
+			setDataPlaneOptionsDefaults(opts, consts.DefaultDataPlaneImage)
+			setDataPlaneStreamListen(opts, gateway)
+
+			container := k8sutils.GetPodContainerByName(&opts.Deployment.PodTemplateSpec.Spec, consts.DataPlaneProxyContainerName)
+			require.NotNil(t, container)
+			require.Equal(t, tc.expectedStreamListen, k8sutils.EnvValueByName(container.Env, "KONG_STREAM_LISTEN"))
 		})
 	}
 }
@@ -874,6 +1078,10 @@ func TestGetSupportedKindsWithResolvedRefsCondition(t *testing.T) {
 				Protocol: gwtypes.HTTPProtocolType,
 			},
 			expectedSupportedKinds: []gwtypes.RouteGroupKind{
+				{
+					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
+					Kind:  "GRPCRoute",
+				},
 				{
 					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
 					Kind:  "HTTPRoute",
@@ -892,7 +1100,34 @@ func TestGetSupportedKindsWithResolvedRefsCondition(t *testing.T) {
 			listener: gwtypes.Listener{
 				Protocol: gatewayv1.UDPProtocolType,
 			},
-			expectedSupportedKinds: []gwtypes.RouteGroupKind{},
+			expectedSupportedKinds: []gwtypes.RouteGroupKind{
+				{
+					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
+					Kind:  "UDPRoute",
+				},
+			},
+			expectedResolvedRefsCondition: metav1.Condition{
+				Type:               string(gatewayv1.ListenerConditionResolvedRefs),
+				Status:             metav1.ConditionTrue,
+				Reason:             string(gatewayv1.ListenerReasonResolvedRefs),
+				Message:            "Listeners' references are accepted.",
+				ObservedGeneration: generation,
+			},
+		},
+		{
+			name: "tls passthrough, TLS protocol, no allowed routes",
+			listener: gwtypes.Listener{
+				Protocol: gatewayv1.TLSProtocolType,
+				TLS: &gatewayv1.GatewayTLSConfig{
+					Mode: lo.ToPtr(gatewayv1.TLSModePassthrough),
+				},
+			},
+			expectedSupportedKinds: []gwtypes.RouteGroupKind{
+				{
+					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
+					Kind:  "TLSRoute",
+				},
+			},
 			expectedResolvedRefsCondition: metav1.Condition{
 				Type:               string(gatewayv1.ListenerConditionResolvedRefs),
 				Status:             metav1.ConditionTrue,
@@ -959,6 +1194,10 @@ func TestGetSupportedKindsWithResolvedRefsCondition(t *testing.T) {
 				},
 			},
 			expectedSupportedKinds: []gwtypes.RouteGroupKind{
+				{
+					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
+					Kind:  "GRPCRoute",
+				},
 				{
 					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
 					Kind:  "HTTPRoute",
@@ -999,6 +1238,10 @@ func TestGetSupportedKindsWithResolvedRefsCondition(t *testing.T) {
 				},
 			},
 			expectedSupportedKinds: []gwtypes.RouteGroupKind{
+				{
+					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
+					Kind:  "GRPCRoute",
+				},
 				{
 					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
 					Kind:  "HTTPRoute",
@@ -1030,6 +1273,10 @@ func TestGetSupportedKindsWithResolvedRefsCondition(t *testing.T) {
 				},
 			},
 			expectedSupportedKinds: []gwtypes.RouteGroupKind{
+				{
+					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
+					Kind:  "GRPCRoute",
+				},
 				{
 					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
 					Kind:  "HTTPRoute",
@@ -1058,6 +1305,10 @@ func TestGetSupportedKindsWithResolvedRefsCondition(t *testing.T) {
 				},
 			},
 			expectedSupportedKinds: []gwtypes.RouteGroupKind{
+				{
+					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
+					Kind:  "GRPCRoute",
+				},
 				{
 					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
 					Kind:  "HTTPRoute",
@@ -1088,6 +1339,10 @@ func TestGetSupportedKindsWithResolvedRefsCondition(t *testing.T) {
 				},
 			},
 			expectedSupportedKinds: []gwtypes.RouteGroupKind{
+				{
+					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
+					Kind:  "GRPCRoute",
+				},
 				{
 					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
 					Kind:  "HTTPRoute",
@@ -1128,6 +1383,10 @@ func TestGetSupportedKindsWithResolvedRefsCondition(t *testing.T) {
 				},
 			},
 			expectedSupportedKinds: []gwtypes.RouteGroupKind{
+				{
+					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
+					Kind:  "GRPCRoute",
+				},
 				{
 					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
 					Kind:  "HTTPRoute",
@@ -1192,6 +1451,10 @@ func TestGetSupportedKindsWithResolvedRefsCondition(t *testing.T) {
 				},
 			},
 			expectedSupportedKinds: []gwtypes.RouteGroupKind{
+				{
+					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
+					Kind:  "GRPCRoute",
+				},
 				{
 					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
 					Kind:  "HTTPRoute",
@@ -1233,6 +1496,10 @@ func TestGetSupportedKindsWithResolvedRefsCondition(t *testing.T) {
 				},
 			},
 			expectedSupportedKinds: []gwtypes.RouteGroupKind{
+				{
+					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
+					Kind:  "GRPCRoute",
+				},
 				{
 					Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
 					Kind:  "HTTPRoute",
@@ -1689,6 +1956,173 @@ func TestCountAttachedRoutesForGatewayListener(t *testing.T) {
 			ExpectedRoutes: []int32{1},
 			ExpectedError:  []error{nil},
 		},
+		{
+			Name: "HTTPRoute and GRPCRoute attached to HTTP listener",
+			Gateway: gwtypes.Gateway{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: gatewayv1.GroupVersion.String(),
+					Kind:       "Gateway",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-gw",
+					Namespace: "test-namespace",
+				},
+				Spec: gwtypes.GatewaySpec{
+					Listeners: []gwtypes.Listener{
+						{
+							Name:     gatewayv1.SectionName("http"),
+							Protocol: gwtypes.HTTPProtocolType,
+							AllowedRoutes: &gwtypes.AllowedRoutes{
+								Namespaces: &gwtypes.RouteNamespaces{
+									From: lo.ToPtr(gwtypes.NamespacesFromSame),
+								},
+							},
+						},
+						{
+							Name:     gatewayv1.SectionName("grpc-only"),
+							Protocol: gwtypes.HTTPProtocolType,
+							AllowedRoutes: &gwtypes.AllowedRoutes{
+								Namespaces: &gwtypes.RouteNamespaces{
+									From: lo.ToPtr(gwtypes.NamespacesFromSame),
+								},
+								Kinds: []gwtypes.RouteGroupKind{
+									{
+										Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
+										Kind:  "GRPCRoute",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Objects: []client.Object{
+				&gwtypes.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "http-route",
+						Namespace: "test-namespace",
+					},
+					Spec: gwtypes.HTTPRouteSpec{
+						CommonRouteSpec: gwtypes.CommonRouteSpec{
+							ParentRefs: []gwtypes.ParentReference{
+								{
+									Name: gwtypes.ObjectName("test-gw"),
+								},
+							},
+						},
+					},
+				},
+				&gwtypes.GRPCRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "grpc-route",
+						Namespace: "test-namespace",
+					},
+					Spec: gwtypes.GRPCRouteSpec{
+						CommonRouteSpec: gwtypes.CommonRouteSpec{
+							ParentRefs: []gwtypes.ParentReference{
+								{
+									Name: gwtypes.ObjectName("test-gw"),
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectedRoutes: []int32{2, 1},
+			ExpectedError:  []error{nil, nil},
+		},
+		{
+			Name: "TCPRoute and UDPRoute attached to L4 listeners",
+			Gateway: gwtypes.Gateway{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: gatewayv1.GroupVersion.String(),
+					Kind:       "Gateway",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-gw",
+					Namespace: "test-namespace",
+				},
+				Spec: gwtypes.GatewaySpec{
+					Listeners: []gwtypes.Listener{
+						{
+							Name:     gatewayv1.SectionName("tcp"),
+							Protocol: gatewayv1.TCPProtocolType,
+							AllowedRoutes: &gwtypes.AllowedRoutes{
+								Namespaces: &gwtypes.RouteNamespaces{
+									From: lo.ToPtr(gwtypes.NamespacesFromSame),
+								},
+							},
+						},
+						{
+							Name:     gatewayv1.SectionName("udp"),
+							Protocol: gatewayv1.UDPProtocolType,
+							AllowedRoutes: &gwtypes.AllowedRoutes{
+								Namespaces: &gwtypes.RouteNamespaces{
+									From: lo.ToPtr(gwtypes.NamespacesFromSame),
+								},
+								Kinds: []gwtypes.RouteGroupKind{
+									{
+										Group: (*gwtypes.Group)(&gatewayv1.GroupVersion.Group),
+										Kind:  "UDPRoute",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Objects: []client.Object{
+				&gwtypes.TCPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "tcp-route",
+						Namespace: "test-namespace",
+					},
+					Spec: gwtypes.TCPRouteSpec{
+						CommonRouteSpec: gwtypes.CommonRouteSpec{
+							ParentRefs: []gwtypes.ParentReference{
+								{
+									Name:        gwtypes.ObjectName("test-gw"),
+									SectionName: lo.ToPtr(gatewayv1.SectionName("tcp")),
+								},
+							},
+						},
+					},
+				},
+				&gwtypes.UDPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "udp-route-1",
+						Namespace: "test-namespace",
+					},
+					Spec: gwtypes.UDPRouteSpec{
+						CommonRouteSpec: gwtypes.CommonRouteSpec{
+							ParentRefs: []gwtypes.ParentReference{
+								{
+									Name:        gwtypes.ObjectName("test-gw"),
+									SectionName: lo.ToPtr(gatewayv1.SectionName("udp")),
+								},
+							},
+						},
+					},
+				},
+				&gwtypes.UDPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "udp-route-2",
+						Namespace: "test-namespace",
+					},
+					Spec: gwtypes.UDPRouteSpec{
+						CommonRouteSpec: gwtypes.CommonRouteSpec{
+							ParentRefs: []gwtypes.ParentReference{
+								{
+									Name: gwtypes.ObjectName("test-gw"),
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectedRoutes: []int32{1, 2},
+			ExpectedError:  []error{nil, nil},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1709,3 +2143,486 @@ func TestCountAttachedRoutesForGatewayListener(t *testing.T) {
 		})
 	}
 }
+
+func TestEnsureDataPlaneHasNetworkPolicyInfrastructureMetadata(t *testing.T) {
+	ctx := context.Background()
+	gateway := &gwtypes.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gw",
+			Namespace: "default",
+			UID:       "gw-uid",
+		},
+		Spec: gatewayv1.GatewaySpec{
+			Infrastructure: &gatewayv1.GatewayInfrastructure{
+				Labels: map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue{
+					"cost-center": "1234",
+				},
+				Annotations: map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue{
+					"sidecar.istio.io/inject": "false",
+				},
+			},
+		},
+	}
+	dataplane := &operatorv1beta1.DataPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dp",
+			Namespace: "default",
+		},
+		Spec: operatorv1beta1.DataPlaneSpec{
+			DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+				Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+					DeploymentOptions: operatorv1beta1.DeploymentOptions{
+						PodTemplateSpec: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{Name: consts.DataPlaneProxyContainerName},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	controlplane := &operatorv1beta1.ControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cp",
+			Namespace: "default",
+		},
+	}
+	r := &Reconciler{
+		Client: fakectrlruntimeclient.NewClientBuilder().
+			WithScheme(scheme.Get()).
+			Build(),
+	}
+	getNetworkPolicy := func() networkingv1.NetworkPolicy {
+		var networkPolicies networkingv1.NetworkPolicyList
+		require.NoError(t, r.Client.List(ctx, &networkPolicies, client.InNamespace("default")))
+		require.Len(t, networkPolicies.Items, 1)
+		return networkPolicies.Items[0]
+	}
+
+	t.Log("creating the NetworkPolicy with the Gateway's infrastructure labels and annotations")
+	createdOrUpdated, err := r.ensureDataPlaneHasNetworkPolicy(ctx, gateway, dataplane, controlplane)
+	require.NoError(t, err)
+	require.True(t, createdOrUpdated)
+	networkPolicy := getNetworkPolicy()
+	require.Equal(t, "1234", networkPolicy.Labels["cost-center"])
+	require.Equal(t, "false", networkPolicy.Annotations["sidecar.istio.io/inject"])
+
+	t.Log("removing the Gateway's infrastructure annotations removes them from the NetworkPolicy")
+	gateway.Spec.Infrastructure.Annotations = nil
+	createdOrUpdated, err = r.ensureDataPlaneHasNetworkPolicy(ctx, gateway, dataplane, controlplane)
+	require.NoError(t, err)
+	require.True(t, createdOrUpdated)
+	networkPolicy = getNetworkPolicy()
+	require.Equal(t, "1234", networkPolicy.Labels["cost-center"])
+	require.NotContains(t, networkPolicy.Annotations, "sidecar.istio.io/inject")
+
+	t.Log("the NetworkPolicy isn't updated when the infrastructure metadata is up to date")
+	createdOrUpdated, err = r.ensureDataPlaneHasNetworkPolicy(ctx, gateway, dataplane, controlplane)
+	require.NoError(t, err)
+	require.False(t, createdOrUpdated)
+}
+
+func TestGetOrCreateGatewayConfiguration(t *testing.T) {
+	ctx := context.Background()
+	gatewayClassConfig := &operatorv1beta1.GatewayConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "class-config",
+			Namespace: "kong-system",
+		},
+		Spec: operatorv1beta1.GatewayConfigurationSpec{
+			DataPlaneOptions: &operatorv1beta1.GatewayConfigDataPlaneOptions{
+				Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+					DeploymentOptions: operatorv1beta1.DeploymentOptions{
+						Replicas: lo.ToPtr(int32(3)),
+						PodTemplateSpec: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:  consts.DataPlaneProxyContainerName,
+										Image: "kong:3.7",
+										Env: []corev1.EnvVar{
+											{Name: "KONG_LOG_LEVEL", Value: "debug"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				Network: operatorv1beta1.GatewayConfigDataPlaneNetworkOptions{
+					Services: &operatorv1beta1.GatewayConfigDataPlaneServices{
+						Ingress: &operatorv1beta1.GatewayConfigServiceOptions{
+							ServiceOptions: operatorv1beta1.ServiceOptions{
+								Type: corev1.ServiceTypeLoadBalancer,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	gatewayConfig := &operatorv1beta1.GatewayConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gateway-config",
+			Namespace: "default",
+		},
+		Spec: operatorv1beta1.GatewayConfigurationSpec{
+			DataPlaneOptions: &operatorv1beta1.GatewayConfigDataPlaneOptions{
+				Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+					DeploymentOptions: operatorv1beta1.DeploymentOptions{
+						PodTemplateSpec: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:  consts.DataPlaneProxyContainerName,
+										Image: "kong:3.8",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	gatewayClass := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "kong",
+		},
+		Spec: gatewayv1.GatewayClassSpec{
+			ParametersRef: &gatewayv1.ParametersReference{
+				Group:     gatewayv1.Group(operatorv1beta1.SchemeGroupVersion.Group),
+				Kind:      "GatewayConfiguration",
+				Namespace: lo.ToPtr(gatewayv1.Namespace(gatewayClassConfig.Namespace)),
+				Name:      gatewayClassConfig.Name,
+			},
+		},
+	}
+	newGateway := func(parametersRef *gatewayv1.LocalParametersReference) *gwtypes.Gateway {
+		gateway := &gwtypes.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gw",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: gatewayv1.ObjectName(gatewayClass.Name),
+			},
+		}
+		if parametersRef != nil {
+			gateway.Spec.Infrastructure = &gatewayv1.GatewayInfrastructure{
+				ParametersRef: parametersRef,
+			}
+		}
+		return gateway
+	}
+	r := &Reconciler{
+		Client: fakectrlruntimeclient.NewClientBuilder().
+			WithScheme(scheme.Get()).
+			WithObjects(gatewayClassConfig, gatewayConfig).
+			Build(),
+	}
+
+	t.Log("verifying that the GatewayClass configuration is used when the Gateway doesn't reference one")
+	config, err := r.getOrCreateGatewayConfiguration(ctx, gatewayClass, newGateway(nil))
+	require.NoError(t, err)
+	require.Equal(t, gatewayClassConfig.Spec, config.Spec)
+
+	t.Log("verifying that the Gateway configuration is merged over the GatewayClass configuration")
+	config, err = r.getOrCreateGatewayConfiguration(ctx, gatewayClass, newGateway(&gatewayv1.LocalParametersReference{
+		Group: gatewayv1.Group(operatorv1beta1.SchemeGroupVersion.Group),
+		Kind:  "GatewayConfiguration",
+		Name:  gatewayConfig.Name,
+	}))
+	require.NoError(t, err)
+	require.Equal(t, gatewayConfig.Name, config.Name)
+	require.Equal(t, gatewayConfig.Namespace, config.Namespace)
+	require.NotNil(t, config.Spec.DataPlaneOptions)
+	deploymentOptions := config.Spec.DataPlaneOptions.Deployment.DeploymentOptions
+	require.Equal(t, lo.ToPtr(int32(3)), deploymentOptions.Replicas)
+	require.NotNil(t, deploymentOptions.PodTemplateSpec)
+	require.Equal(t, []corev1.Container{
+		{
+			Name:  consts.DataPlaneProxyContainerName,
+			Image: "kong:3.8",
+			Env: []corev1.EnvVar{
+				{Name: "KONG_LOG_LEVEL", Value: "debug"},
+			},
+		},
+	}, deploymentOptions.PodTemplateSpec.Spec.Containers)
+	require.Equal(t, gatewayClassConfig.Spec.DataPlaneOptions.Network, config.Spec.DataPlaneOptions.Network)
+
+	t.Log("verifying that the Gateway configuration is used as it is when the GatewayClass doesn't reference one")
+	config, err = r.getOrCreateGatewayConfiguration(ctx, &gatewayv1.GatewayClass{}, newGateway(&gatewayv1.LocalParametersReference{
+		Group: gatewayv1.Group(operatorv1beta1.SchemeGroupVersion.Group),
+		Kind:  "GatewayConfiguration",
+		Name:  gatewayConfig.Name,
+	}))
+	require.NoError(t, err)
+	require.Equal(t, gatewayConfig.Spec, config.Spec)
+
+	t.Log("verifying that an empty configuration is returned when neither the Gateway nor the GatewayClass reference one")
+	config, err = r.getOrCreateGatewayConfiguration(ctx, &gatewayv1.GatewayClass{}, newGateway(nil))
+	require.NoError(t, err)
+	require.Equal(t, operatorv1beta1.GatewayConfigurationSpec{}, config.Spec)
+
+	t.Log("verifying that unsupported Gateway parametersRef kinds are rejected")
+	_, err = r.getOrCreateGatewayConfiguration(ctx, gatewayClass, newGateway(&gatewayv1.LocalParametersReference{
+		Group: "",
+		Kind:  "ConfigMap",
+		Name:  gatewayConfig.Name,
+	}))
+	require.Error(t, err)
+
+	t.Log("verifying that a missing Gateway configuration is an error")
+	_, err = r.getOrCreateGatewayConfiguration(ctx, gatewayClass, newGateway(&gatewayv1.LocalParametersReference{
+		Group: gatewayv1.Group(operatorv1beta1.SchemeGroupVersion.Group),
+		Kind:  "GatewayConfiguration",
+		Name:  "missing",
+	}))
+	require.Error(t, err)
+}
+
+func TestMergeGatewayConfigurations(t *testing.T) {
+	base := &operatorv1beta1.GatewayConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "class-config",
+			Namespace: "kong-system",
+		},
+		Spec: operatorv1beta1.GatewayConfigurationSpec{
+			DataPlaneOptions: &operatorv1beta1.GatewayConfigDataPlaneOptions{
+				Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+					DeploymentOptions: operatorv1beta1.DeploymentOptions{
+						Replicas: lo.ToPtr(int32(3)),
+						PodTemplateSpec: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:  consts.DataPlaneProxyContainerName,
+										Image: "kong:3.7",
+										Env: []corev1.EnvVar{
+											{Name: "KONG_LOG_LEVEL", Value: "debug"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				Network: operatorv1beta1.GatewayConfigDataPlaneNetworkOptions{
+					Services: &operatorv1beta1.GatewayConfigDataPlaneServices{
+						Ingress: &operatorv1beta1.GatewayConfigServiceOptions{
+							ServiceOptions: operatorv1beta1.ServiceOptions{
+								Type: corev1.ServiceTypeLoadBalancer,
+								Annotations: map[string]string{
+									"class": "annotation",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		override operatorv1beta1.GatewayConfigDataPlaneOptions
+		assert   func(t *testing.T, merged *operatorv1beta1.GatewayConfigDataPlaneOptions)
+	}{
+		{
+			name: "fields set to a value are overridden",
+			override: operatorv1beta1.GatewayConfigDataPlaneOptions{
+				Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+					DeploymentOptions: operatorv1beta1.DeploymentOptions{
+						Replicas: lo.ToPtr(int32(1)),
+					},
+				},
+				Network: operatorv1beta1.GatewayConfigDataPlaneNetworkOptions{
+					Services: &operatorv1beta1.GatewayConfigDataPlaneServices{
+						Ingress: &operatorv1beta1.GatewayConfigServiceOptions{
+							ServiceOptions: operatorv1beta1.ServiceOptions{
+								Type: corev1.ServiceTypeClusterIP,
+							},
+						},
+					},
+				},
+			},
+			assert: func(t *testing.T, merged *operatorv1beta1.GatewayConfigDataPlaneOptions) {
+				require.Equal(t, lo.ToPtr(int32(1)), merged.Deployment.Replicas)
+				require.Equal(t, corev1.ServiceTypeClusterIP, merged.Network.Services.Ingress.Type)
+			},
+		},
+		{
+			name: "pointer fields can be set to their zero value",
+			override: operatorv1beta1.GatewayConfigDataPlaneOptions{
+				Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+					DeploymentOptions: operatorv1beta1.DeploymentOptions{
+						Replicas: lo.ToPtr(int32(0)),
+					},
+				},
+			},
+			assert: func(t *testing.T, merged *operatorv1beta1.GatewayConfigDataPlaneOptions) {
+				require.Equal(t, lo.ToPtr(int32(0)), merged.Deployment.Replicas)
+			},
+		},
+		{
+			name: "fields omitted when empty cannot be reset to their zero value",
+			override: operatorv1beta1.GatewayConfigDataPlaneOptions{
+				Network: operatorv1beta1.GatewayConfigDataPlaneNetworkOptions{
+					Services: &operatorv1beta1.GatewayConfigDataPlaneServices{
+						Ingress: &operatorv1beta1.GatewayConfigServiceOptions{
+							ServiceOptions: operatorv1beta1.ServiceOptions{
+								Type:        "",
+								Annotations: map[string]string{},
+							},
+						},
+					},
+				},
+			},
+			assert: func(t *testing.T, merged *operatorv1beta1.GatewayConfigDataPlaneOptions) {
+				require.Equal(t, corev1.ServiceTypeLoadBalancer, merged.Network.Services.Ingress.Type)
+				require.Equal(t, map[string]string{"class": "annotation"}, merged.Network.Services.Ingress.Annotations)
+			},
+		},
+		{
+			name: "entries of lists merged by key are merged with the base ones and cannot be removed",
+			override: operatorv1beta1.GatewayConfigDataPlaneOptions{
+				Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+					DeploymentOptions: operatorv1beta1.DeploymentOptions{
+						PodTemplateSpec: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:  consts.DataPlaneProxyContainerName,
+										Image: "kong:3.8",
+										Env: []corev1.EnvVar{
+											{Name: "KONG_NGINX_WORKER_PROCESSES", Value: "2"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			assert: func(t *testing.T, merged *operatorv1beta1.GatewayConfigDataPlaneOptions) {
+				require.NotNil(t, merged.Deployment.PodTemplateSpec)
+				require.Equal(t, []corev1.Container{
+					{
+						Name:  consts.DataPlaneProxyContainerName,
+						Image: "kong:3.8",
+						Env: []corev1.EnvVar{
+							{Name: "KONG_NGINX_WORKER_PROCESSES", Value: "2"},
+							{Name: "KONG_LOG_LEVEL", Value: "debug"},
+						},
+					},
+				}, merged.Deployment.PodTemplateSpec.Spec.Containers)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			override := &operatorv1beta1.GatewayConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "gateway-config",
+					Namespace: "default",
+				},
+				Spec: operatorv1beta1.GatewayConfigurationSpec{
+					DataPlaneOptions: &tc.override,
+				},
+			}
+			merged, err := mergeGatewayConfigurations(base, override)
+			require.NoError(t, err)
+			require.NotNil(t, merged.Spec.DataPlaneOptions)
+			tc.assert(t, merged.Spec.DataPlaneOptions)
+		})
+	}
+}
+
+func TestListGatewaysForGatewayConfig(t *testing.T) {
+	ctx := context.Background()
+	gatewayConfig := &operatorv1beta1.GatewayConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "config",
+			Namespace: "default",
+		},
+	}
+	parametersRef := &gatewayv1.LocalParametersReference{
+		Group: gatewayv1.Group(operatorv1beta1.SchemeGroupVersion.Group),
+		Kind:  "GatewayConfiguration",
+		Name:  gatewayConfig.Name,
+	}
+	objects := []client.Object{
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "class-with-config",
+			},
+			Spec: gatewayv1.GatewayClassSpec{
+				ParametersRef: &gatewayv1.ParametersReference{
+					Group:     parametersRef.Group,
+					Kind:      parametersRef.Kind,
+					Namespace: lo.ToPtr(gatewayv1.Namespace(gatewayConfig.Namespace)),
+					Name:      gatewayConfig.Name,
+				},
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gw-class",
+				Namespace: "other",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "class-with-config",
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gw-infrastructure",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "other-class",
+				Infrastructure: &gatewayv1.GatewayInfrastructure{
+					ParametersRef: parametersRef,
+				},
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gw-infrastructure-other-namespace",
+				Namespace: "other",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "other-class",
+				Infrastructure: &gatewayv1.GatewayInfrastructure{
+					ParametersRef: parametersRef,
+				},
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gw-unrelated",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "other-class",
+			},
+		},
+	}
+	r := &Reconciler{
+		Client: fakectrlruntimeclient.NewClientBuilder().
+			WithScheme(scheme.Get()).
+			WithObjects(objects...).
+			Build(),
+	}
+
+	recs := r.listGatewaysForGatewayConfig(ctx, gatewayConfig)
+	names := lo.Map(recs, func(rec reconcile.Request, _ int) string {
+		return rec.String()
+	})
+	require.ElementsMatch(t, []string{"other/gw-class", "default/gw-infrastructure"}, names)
+}
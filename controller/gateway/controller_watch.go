@@ -19,6 +19,7 @@ import (
 	"github.com/kong/gateway-operator/controller/pkg/controlplane"
 	operatorerrors "github.com/kong/gateway-operator/internal/errors"
 	gwtypes "github.com/kong/gateway-operator/internal/types"
+	"github.com/kong/gateway-operator/internal/utils/gatewayclass"
 	"github.com/kong/gateway-operator/pkg/consts"
 	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
 	"github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
@@ -153,10 +154,7 @@ func (r *Reconciler) listGatewaysForGatewayConfig(ctx context.Context, obj clien
 
 	matchingGatewayClasses := make(map[string]struct{})
 	for _, gatewayClass := range gatewayClassList.Items {
-		if gatewayClass.Spec.ParametersRef != nil &&
-			string(gatewayClass.Spec.ParametersRef.Group) == operatorv1beta1.SchemeGroupVersion.Group &&
-			string(gatewayClass.Spec.ParametersRef.Kind) == "GatewayConfiguration" &&
-			gatewayClass.Spec.ParametersRef.Name == gatewayConfig.Name {
+		if gatewayclass.ReferencesGatewayConfiguration(&gatewayClass, gatewayConfig) {
 			matchingGatewayClasses[gatewayClass.Name] = struct{}{}
 		}
 	}
@@ -173,7 +171,8 @@ func (r *Reconciler) listGatewaysForGatewayConfig(ctx context.Context, obj clien
 
 	var recs []reconcile.Request
 	for _, gateway := range gatewayList.Items {
-		if _, ok := matchingGatewayClasses[string(gateway.Spec.GatewayClassName)]; ok {
+		if _, ok := matchingGatewayClasses[string(gateway.Spec.GatewayClassName)]; ok ||
+			gatewayclass.GatewayReferencesGatewayConfiguration(&gateway, gatewayConfig) {
 			recs = append(recs, reconcile.Request{
 				NamespacedName: types.NamespacedName{
 					Namespace: gateway.Namespace,
@@ -282,14 +281,66 @@ func (r *Reconciler) listGatewaysAttachedByHTTPRoute(ctx context.Context, obj cl
 		)
 		return nil
 	}
+	return r.listGatewaysForParentRefs(ctx, "HTTPRoute", httpRoute.Name, httpRoute.Spec.ParentRefs)
+}
+
+// listGatewaysAttachedByGRPCRoute is a watch predicate which finds all Gateways mentioned
+// in GRPCRoutes' Parents field.
+func (r *Reconciler) listGatewaysAttachedByGRPCRoute(ctx context.Context, obj client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+
+	grpcRoute, ok := obj.(*gwtypes.GRPCRoute)
+	if !ok {
+		logger.Error(
+			fmt.Errorf("unexpected object type"),
+			"GRPCRoute watch predicate received unexpected object type",
+			"expected", "*gatewayapi.GRPCRoute", "found", reflect.TypeOf(obj),
+		)
+		return nil
+	}
+	return r.listGatewaysForParentRefs(ctx, "GRPCRoute", grpcRoute.Name, grpcRoute.Spec.ParentRefs)
+}
+
+// listGatewaysAttachedByL4Route is a watch predicate which finds all Gateways mentioned
+// in TCPRoutes', TLSRoutes' or UDPRoutes' Parents field.
+func (r *Reconciler) listGatewaysAttachedByL4Route(ctx context.Context, obj client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+
+	switch route := obj.(type) {
+	case *gwtypes.TCPRoute:
+		return r.listGatewaysForParentRefs(ctx, "TCPRoute", route.Name, route.Spec.ParentRefs)
+	case *gwtypes.TLSRoute:
+		return r.listGatewaysForParentRefs(ctx, "TLSRoute", route.Name, route.Spec.ParentRefs)
+	case *gwtypes.UDPRoute:
+		return r.listGatewaysForParentRefs(ctx, "UDPRoute", route.Name, route.Spec.ParentRefs)
+	default:
+		logger.Error(
+			fmt.Errorf("unexpected object type"),
+			"L4 route watch predicate received unexpected object type",
+			"expected", "*gatewayapi.TCPRoute, *gatewayapi.TLSRoute or *gatewayapi.UDPRoute", "found", reflect.TypeOf(obj),
+		)
+		return nil
+	}
+}
+
+// listGatewaysForParentRefs returns reconcile requests for all Gateways referenced
+// by the provided route's ParentRefs.
+func (r *Reconciler) listGatewaysForParentRefs(
+	ctx context.Context,
+	routeKind string,
+	routeName string,
+	parentRefs []gatewayv1.ParentReference,
+) []reconcile.Request {
+	logger := log.FromContext(ctx)
+
 	gateways := &gatewayv1.GatewayList{}
 	if err := r.Client.List(ctx, gateways); err != nil {
-		logger.Error(err, "Failed to list gateways in watch", "HTTPRoute", httpRoute.Name)
+		logger.Error(err, "Failed to list gateways in watch", routeKind, routeName)
 		return nil
 	}
 	var recs []reconcile.Request
 	for _, gateway := range gateways.Items {
-		for _, parentRef := range httpRoute.Spec.ParentRefs {
+		for _, parentRef := range parentRefs {
 			if parentRef.Group != nil && string(*parentRef.Group) == gatewayv1.GroupName &&
 				parentRef.Kind != nil && string(*parentRef.Kind) == "Gateway" &&
 				string(parentRef.Name) == gateway.Name {
@@ -0,0 +1,197 @@
+package gatewayconfiguration
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/pkg/log"
+	gwtypes "github.com/kong/gateway-operator/internal/types"
+	"github.com/kong/gateway-operator/internal/utils/gatewayclass"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+// -----------------------------------------------------------------------------
+// GatewayConfigurationReconciler
+// -----------------------------------------------------------------------------
+
+// Validator validates GatewayConfiguration objects.
+type Validator interface {
+	Validate(gatewayConfig *operatorv1beta1.GatewayConfiguration) error
+}
+
+// Reconciler reconciles a GatewayConfiguration object.
+type Reconciler struct {
+	client.Client
+	Scheme          *runtime.Scheme
+	DevelopmentMode bool
+	Validator       Validator
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&operatorv1beta1.GatewayConfiguration{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// watch for changes in GatewayClasses so that the GatewayClasses using
+		// a GatewayConfiguration are kept up to date.
+		Watches(
+			&gatewayv1.GatewayClass{},
+			handler.EnqueueRequestsFromMapFunc(r.listGatewayConfigurationsForGatewayClass),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// watch for changes in Gateways so that the Gateways using a GatewayConfiguration
+		// are kept up to date.
+		Watches(
+			&gwtypes.Gateway{},
+			handler.EnqueueRequestsFromMapFunc(r.listGatewayConfigurationsForGateway),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// Reconcile validates the GatewayConfiguration and reports the validation result
+// along with the GatewayClasses and Gateways which use it in its status.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.GetLogger(ctx, "gatewayconfiguration", r.DevelopmentMode)
+
+	log.Trace(logger, "reconciling gatewayconfiguration resource", req)
+
+	gatewayConfig := new(operatorv1beta1.GatewayConfiguration)
+	if err := r.Client.Get(ctx, req.NamespacedName, gatewayConfig); err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Debug(logger, "object enqueued no longer exists, skipping", req)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	oldGatewayConfig := gatewayConfig.DeepCopy()
+
+	if err := r.Validator.Validate(gatewayConfig); err != nil {
+		log.Debug(logger, "gatewayconfiguration is invalid", gatewayConfig, "error", err.Error())
+		setAcceptedCondition(gatewayConfig, metav1.ConditionFalse, consts.GatewayConfigurationConditionReasonInvalid, err.Error())
+	} else {
+		setAcceptedCondition(gatewayConfig, metav1.ConditionTrue, consts.GatewayConfigurationConditionReasonAccepted,
+			"the gatewayconfiguration has been accepted by the operator")
+	}
+
+	gatewayClasses, gateways, err := r.listGatewayConfigurationUsers(ctx, gatewayConfig)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	gatewayConfig.Status.GatewayClasses = gatewayClasses
+	gatewayConfig.Status.Gateways = gateways
+
+	if equality.Semantic.DeepEqual(oldGatewayConfig.Status, gatewayConfig.Status) {
+		log.Debug(logger, "gatewayconfiguration status is up to date", gatewayConfig)
+		return ctrl.Result{}, nil
+	}
+	if err := r.Client.Status().Patch(ctx, gatewayConfig, client.MergeFrom(oldGatewayConfig)); err != nil {
+		if k8serrors.IsConflict(err) {
+			log.Debug(logger, "conflict found when updating gatewayconfiguration status, retrying", gatewayConfig)
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed updating GatewayConfiguration status: %w", err)
+	}
+	log.Debug(logger, "gatewayconfiguration status updated", gatewayConfig)
+
+	return ctrl.Result{}, nil
+}
+
+// setAcceptedCondition sets the Accepted condition on the GatewayConfiguration.
+// The condition is left untouched when it didn't change so that its last
+// transition time is kept.
+func setAcceptedCondition(
+	gatewayConfig *operatorv1beta1.GatewayConfiguration,
+	status metav1.ConditionStatus,
+	reason consts.ConditionReason,
+	message string,
+) {
+	condition := k8sutils.NewConditionWithGeneration(
+		consts.GatewayConfigurationConditionTypeAccepted, status, reason, message, gatewayConfig.Generation,
+	)
+	if current, ok := k8sutils.GetCondition(consts.GatewayConfigurationConditionTypeAccepted, gatewayConfig); ok &&
+		current.Status == condition.Status &&
+		current.Reason == condition.Reason &&
+		current.Message == condition.Message &&
+		current.ObservedGeneration == condition.ObservedGeneration {
+		return
+	}
+	k8sutils.SetCondition(condition, gatewayConfig)
+}
+
+// listGatewayConfigurationUsers returns the names of the GatewayClasses and the
+// Gateways which use the provided GatewayConfiguration. Only the GatewayClasses
+// controlled by the operator and the Gateways of those GatewayClasses are taken
+// into account.
+func (r *Reconciler) listGatewayConfigurationUsers(
+	ctx context.Context,
+	gatewayConfig *operatorv1beta1.GatewayConfiguration,
+) ([]string, []operatorv1beta1.GatewayConfigurationGatewayReference, error) {
+	var gatewayClassList gatewayv1.GatewayClassList
+	if err := r.Client.List(ctx, &gatewayClassList); err != nil {
+		return nil, nil, fmt.Errorf("failed listing GatewayClasses: %w", err)
+	}
+
+	var (
+		controlledGatewayClasses = make(map[string]struct{})
+		gatewayClasses           []string
+	)
+	for i := range gatewayClassList.Items {
+		gwc := &gatewayClassList.Items[i]
+		if !gatewayclass.DecorateGatewayClass(gwc).IsControlled() {
+			continue
+		}
+		controlledGatewayClasses[gwc.Name] = struct{}{}
+		if gatewayclass.ReferencesGatewayConfiguration(gwc, gatewayConfig) {
+			gatewayClasses = append(gatewayClasses, gwc.Name)
+		}
+	}
+	slices.Sort(gatewayClasses)
+
+	var gatewayList gatewayv1.GatewayList
+	if err := r.Client.List(ctx, &gatewayList); err != nil {
+		return nil, nil, fmt.Errorf("failed listing Gateways: %w", err)
+	}
+
+	var gateways []operatorv1beta1.GatewayConfigurationGatewayReference
+	for i := range gatewayList.Items {
+		gateway := &gatewayList.Items[i]
+		if _, ok := controlledGatewayClasses[string(gateway.Spec.GatewayClassName)]; !ok {
+			continue
+		}
+		ref := operatorv1beta1.GatewayConfigurationGatewayReference{
+			Namespace: gateway.Namespace,
+			Name:      gateway.Name,
+		}
+		switch {
+		case gatewayclass.GatewayReferencesGatewayConfiguration(gateway, gatewayConfig):
+			ref.TargetKind = operatorv1beta1.GatewayConfigurationTargetKindGateway
+		case slices.Contains(gatewayClasses, string(gateway.Spec.GatewayClassName)):
+			ref.TargetKind = operatorv1beta1.GatewayConfigurationTargetKindGatewayClass
+		default:
+			continue
+		}
+		gateways = append(gateways, ref)
+	}
+	slices.SortFunc(gateways, func(a, b operatorv1beta1.GatewayConfigurationGatewayReference) int {
+		if c := strings.Compare(a.Namespace, b.Namespace); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return gatewayClasses, gateways, nil
+}
@@ -0,0 +1,10 @@
+package gatewayconfiguration
+
+// -----------------------------------------------------------------------------
+// GatewayConfigurationReconciler - RBAC Permissions
+// -----------------------------------------------------------------------------
+
+//+kubebuilder:rbac:groups=gateway-operator.konghq.com,resources=gatewayconfigurations,verbs=get;list;watch
+//+kubebuilder:rbac:groups=gateway-operator.konghq.com,resources=gatewayconfigurations/status,verbs=get;patch;update
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gatewayclasses,verbs=get;list;watch
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch
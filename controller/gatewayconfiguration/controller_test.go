@@ -0,0 +1,247 @@
+package gatewayconfiguration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	gwtypes "github.com/kong/gateway-operator/internal/types"
+	"github.com/kong/gateway-operator/modules/manager/scheme"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+	"github.com/kong/gateway-operator/pkg/vars"
+)
+
+type fakeValidator struct {
+	err error
+}
+
+func (v *fakeValidator) Validate(*operatorv1beta1.GatewayConfiguration) error {
+	return v.err
+}
+
+func TestGatewayConfigurationReconciler_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	gatewayConfig := &operatorv1beta1.GatewayConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "config",
+			Namespace:  "default",
+			Generation: 1,
+		},
+	}
+	parametersRef := &gatewayv1.ParametersReference{
+		Group:     gatewayv1.Group(operatorv1beta1.SchemeGroupVersion.Group),
+		Kind:      "GatewayConfiguration",
+		Namespace: lo.ToPtr(gatewayv1.Namespace(gatewayConfig.Namespace)),
+		Name:      gatewayConfig.Name,
+	}
+	objects := []client.Object{
+		gatewayConfig,
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "kong",
+			},
+			Spec: gatewayv1.GatewayClassSpec{
+				ControllerName: gatewayv1.GatewayController(vars.ControllerName()),
+				ParametersRef:  parametersRef,
+			},
+		},
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "kong-without-config",
+			},
+			Spec: gatewayv1.GatewayClassSpec{
+				ControllerName: gatewayv1.GatewayController(vars.ControllerName()),
+			},
+		},
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "other-controller",
+			},
+			Spec: gatewayv1.GatewayClassSpec{
+				ControllerName: "example.com/other-controller",
+				ParametersRef:  parametersRef,
+			},
+		},
+		&gwtypes.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gw-class",
+				Namespace: "other",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "kong",
+			},
+		},
+		&gwtypes.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gw-infrastructure",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "kong-without-config",
+				Infrastructure: &gatewayv1.GatewayInfrastructure{
+					ParametersRef: &gatewayv1.LocalParametersReference{
+						Group: parametersRef.Group,
+						Kind:  parametersRef.Kind,
+						Name:  gatewayConfig.Name,
+					},
+				},
+			},
+		},
+		&gwtypes.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gw-unrelated",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "kong-without-config",
+			},
+		},
+		&gwtypes.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gw-other-controller",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "other-controller",
+			},
+		},
+	}
+	validator := &fakeValidator{}
+	r := &Reconciler{
+		Client: fakectrlruntimeclient.NewClientBuilder().
+			WithScheme(scheme.Get()).
+			WithObjects(objects...).
+			WithStatusSubresource(gatewayConfig).
+			Build(),
+		Validator: validator,
+	}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(gatewayConfig)}
+	getGatewayConfig := func() *operatorv1beta1.GatewayConfiguration {
+		gatewayConfig := new(operatorv1beta1.GatewayConfiguration)
+		require.NoError(t, r.Client.Get(ctx, req.NamespacedName, gatewayConfig))
+		return gatewayConfig
+	}
+
+	t.Log("verifying that a valid GatewayConfiguration is accepted and its users are reported")
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	current := getGatewayConfig()
+	accepted, ok := k8sutils.GetCondition(consts.GatewayConfigurationConditionTypeAccepted, current)
+	require.True(t, ok)
+	require.Equal(t, metav1.ConditionTrue, accepted.Status)
+	require.Equal(t, string(consts.GatewayConfigurationConditionReasonAccepted), accepted.Reason)
+	require.Equal(t, int64(1), accepted.ObservedGeneration)
+	require.Equal(t, []string{"kong"}, current.Status.GatewayClasses)
+	require.Equal(t, []operatorv1beta1.GatewayConfigurationGatewayReference{
+		{
+			Namespace:  "default",
+			Name:       "gw-infrastructure",
+			TargetKind: operatorv1beta1.GatewayConfigurationTargetKindGateway,
+		},
+		{
+			Namespace:  "other",
+			Name:       "gw-class",
+			TargetKind: operatorv1beta1.GatewayConfigurationTargetKindGatewayClass,
+		},
+	}, current.Status.Gateways)
+
+	t.Log("verifying that the status isn't updated when it's up to date")
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, current.ResourceVersion, getGatewayConfig().ResourceVersion)
+
+	t.Log("verifying that an invalid GatewayConfiguration is not accepted")
+	validator.err = errors.New("invalid dataPlaneOptions: DataPlane requires an image")
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	accepted, ok = k8sutils.GetCondition(consts.GatewayConfigurationConditionTypeAccepted, getGatewayConfig())
+	require.True(t, ok)
+	require.Equal(t, metav1.ConditionFalse, accepted.Status)
+	require.Equal(t, string(consts.GatewayConfigurationConditionReasonInvalid), accepted.Reason)
+	require.Equal(t, validator.err.Error(), accepted.Message)
+
+	t.Log("verifying that a missing GatewayConfiguration is not an error")
+	_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKey{Namespace: "default", Name: "missing"}})
+	require.NoError(t, err)
+}
+
+func TestListGatewayConfigurationsForGateway(t *testing.T) {
+	ctx := context.Background()
+	gatewayClass := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "kong",
+		},
+		Spec: gatewayv1.GatewayClassSpec{
+			ControllerName: gatewayv1.GatewayController(vars.ControllerName()),
+			ParametersRef: &gatewayv1.ParametersReference{
+				Group:     gatewayv1.Group(operatorv1beta1.SchemeGroupVersion.Group),
+				Kind:      "GatewayConfiguration",
+				Namespace: lo.ToPtr(gatewayv1.Namespace("kong-system")),
+				Name:      "class-config",
+			},
+		},
+	}
+	previousConfig := &operatorv1beta1.GatewayConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "previous-config",
+			Namespace: "default",
+		},
+		Status: operatorv1beta1.GatewayConfigurationStatus{
+			Gateways: []operatorv1beta1.GatewayConfigurationGatewayReference{
+				{
+					Namespace:  "default",
+					Name:       "gw",
+					TargetKind: operatorv1beta1.GatewayConfigurationTargetKindGateway,
+				},
+			},
+		},
+	}
+	gateway := &gwtypes.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gw",
+			Namespace: "default",
+		},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: gatewayv1.ObjectName(gatewayClass.Name),
+			Infrastructure: &gatewayv1.GatewayInfrastructure{
+				ParametersRef: &gatewayv1.LocalParametersReference{
+					Group: gatewayv1.Group(operatorv1beta1.SchemeGroupVersion.Group),
+					Kind:  "GatewayConfiguration",
+					Name:  "gateway-config",
+				},
+			},
+		},
+	}
+	r := &Reconciler{
+		Client: fakectrlruntimeclient.NewClientBuilder().
+			WithScheme(scheme.Get()).
+			WithObjects(gatewayClass, previousConfig, gateway).
+			Build(),
+	}
+
+	recs := r.listGatewayConfigurationsForGateway(ctx, gateway)
+	require.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: client.ObjectKey{Namespace: "default", Name: "gateway-config"}},
+		{NamespacedName: client.ObjectKey{Namespace: "kong-system", Name: "class-config"}},
+		{NamespacedName: client.ObjectKey{Namespace: "default", Name: "previous-config"}},
+	}, recs)
+
+	t.Log("verifying that the GatewayConfigurations reporting a GatewayClass are enqueued for it")
+	previousConfig.Status.GatewayClasses = []string{gatewayClass.Name}
+	require.NoError(t, r.Client.Update(ctx, previousConfig))
+	recs = r.listGatewayConfigurationsForGatewayClass(ctx, gatewayClass)
+	require.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: client.ObjectKey{Namespace: "kong-system", Name: "class-config"}},
+		{NamespacedName: client.ObjectKey{Namespace: "default", Name: "previous-config"}},
+	}, recs)
+}
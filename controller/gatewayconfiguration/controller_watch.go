@@ -0,0 +1,123 @@
+package gatewayconfiguration
+
+import (
+	"context"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	operatorerrors "github.com/kong/gateway-operator/internal/errors"
+	gwtypes "github.com/kong/gateway-operator/internal/types"
+	"github.com/kong/gateway-operator/internal/utils/gatewayclass"
+)
+
+// -----------------------------------------------------------------------------
+// GatewayConfigurationReconciler - Watch Map Funcs
+// -----------------------------------------------------------------------------
+
+// listGatewayConfigurationsForGatewayClass returns the GatewayConfiguration
+// referenced by the GatewayClass along with the GatewayConfigurations which
+// report the GatewayClass in their status, so that GatewayClasses which no
+// longer reference them are removed from their status.
+func (r *Reconciler) listGatewayConfigurationsForGatewayClass(ctx context.Context, obj client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+
+	gwc, ok := obj.(*gatewayv1.GatewayClass)
+	if !ok {
+		logger.Error(
+			operatorerrors.ErrUnexpectedObject,
+			"failed to run map funcs",
+			"expected", "GatewayClass", "found", reflect.TypeOf(obj),
+		)
+		return nil
+	}
+
+	recs := make(map[types.NamespacedName]struct{})
+	if ref := gwc.Spec.ParametersRef; ref != nil && ref.Namespace != nil &&
+		gatewayclass.IsGatewayConfigurationParametersRef(ref.Group, ref.Kind) {
+		recs[types.NamespacedName{Namespace: string(*ref.Namespace), Name: ref.Name}] = struct{}{}
+	}
+
+	gatewayConfigs, err := r.listGatewayConfigurations(ctx)
+	if err != nil {
+		logger.Error(err, "failed to run map funcs")
+		return toRequests(recs)
+	}
+	for _, gatewayConfig := range gatewayConfigs {
+		for _, name := range gatewayConfig.Status.GatewayClasses {
+			if name == gwc.Name {
+				recs[client.ObjectKeyFromObject(&gatewayConfig)] = struct{}{}
+			}
+		}
+	}
+
+	return toRequests(recs)
+}
+
+// listGatewayConfigurationsForGateway returns the GatewayConfigurations referenced
+// by the Gateway and by its GatewayClass along with the GatewayConfigurations
+// which report the Gateway in their status, so that Gateways which no longer
+// use them are removed from their status.
+func (r *Reconciler) listGatewayConfigurationsForGateway(ctx context.Context, obj client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+
+	gateway, ok := obj.(*gwtypes.Gateway)
+	if !ok {
+		logger.Error(
+			operatorerrors.ErrUnexpectedObject,
+			"failed to run map funcs",
+			"expected", "Gateway", "found", reflect.TypeOf(obj),
+		)
+		return nil
+	}
+
+	recs := make(map[types.NamespacedName]struct{})
+	if infra := gateway.Spec.Infrastructure; infra != nil && infra.ParametersRef != nil &&
+		gatewayclass.IsGatewayConfigurationParametersRef(infra.ParametersRef.Group, infra.ParametersRef.Kind) {
+		recs[types.NamespacedName{Namespace: gateway.Namespace, Name: infra.ParametersRef.Name}] = struct{}{}
+	}
+
+	var gwc gatewayv1.GatewayClass
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: string(gateway.Spec.GatewayClassName)}, &gwc); err == nil {
+		if ref := gwc.Spec.ParametersRef; ref != nil && ref.Namespace != nil &&
+			gatewayclass.IsGatewayConfigurationParametersRef(ref.Group, ref.Kind) {
+			recs[types.NamespacedName{Namespace: string(*ref.Namespace), Name: ref.Name}] = struct{}{}
+		}
+	}
+
+	gatewayConfigs, err := r.listGatewayConfigurations(ctx)
+	if err != nil {
+		logger.Error(err, "failed to run map funcs")
+		return toRequests(recs)
+	}
+	for _, gatewayConfig := range gatewayConfigs {
+		for _, ref := range gatewayConfig.Status.Gateways {
+			if ref.Namespace == gateway.Namespace && ref.Name == gateway.Name {
+				recs[client.ObjectKeyFromObject(&gatewayConfig)] = struct{}{}
+			}
+		}
+	}
+
+	return toRequests(recs)
+}
+
+func (r *Reconciler) listGatewayConfigurations(ctx context.Context) ([]operatorv1beta1.GatewayConfiguration, error) {
+	var gatewayConfigList operatorv1beta1.GatewayConfigurationList
+	if err := r.Client.List(ctx, &gatewayConfigList); err != nil {
+		return nil, err
+	}
+	return gatewayConfigList.Items, nil
+}
+
+func toRequests(recs map[types.NamespacedName]struct{}) []reconcile.Request {
+	requests := make([]reconcile.Request, 0, len(recs))
+	for nn := range recs {
+		requests = append(requests, reconcile.Request{NamespacedName: nn})
+	}
+	return requests
+}
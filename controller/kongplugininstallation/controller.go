@@ -0,0 +1,354 @@
+package kongplugininstallation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kong/gateway-operator/api/v1alpha1"
+	"github.com/kong/gateway-operator/controller/kongplugininstallation/image"
+	"github.com/kong/gateway-operator/controller/pkg/log"
+	"github.com/kong/gateway-operator/controller/pkg/op"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+	k8sreduce "github.com/kong/gateway-operator/pkg/utils/kubernetes/reduce"
+)
+
+// ----------------------------------------------------------------------------
+// KongPluginInstallationReconciler
+// ----------------------------------------------------------------------------
+
+// fetchPluginRetryInterval is the interval after which fetching a plugin is
+// retried when it failed, e.g. because the registry was temporarily unavailable.
+const fetchPluginRetryInterval = time.Minute
+
+// Reconciler reconciles a KongPluginInstallation object.
+type Reconciler struct {
+	client.Client
+
+	Scheme          *runtime.Scheme
+	DevelopmentMode bool
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		// Status updates are performed by this controller, so react only on spec changes
+		// to avoid fetching the image once again after every status update.
+		For(&v1alpha1.KongPluginInstallation{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// watch for changes in ConfigMaps created by this controller
+		Owns(&corev1.ConfigMap{}).
+		// watch for changes in Secrets referenced as image pull secrets
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.listKongPluginInstallationsForSecret),
+		)
+
+	// ReferenceGrants allow referencing image pull secrets from other namespaces.
+	// When their CRD is not installed, such references are never allowed.
+	checker := k8sutils.CRDChecker{Client: mgr.GetClient()}
+	if ok, err := checker.CRDExists(gatewayv1beta1.SchemeGroupVersion.WithResource("referencegrants")); err != nil {
+		return err
+	} else if ok {
+		b = b.Watches(
+			&gatewayv1beta1.ReferenceGrant{},
+			handler.EnqueueRequestsFromMapFunc(r.listKongPluginInstallationsForReferenceGrant),
+		)
+	}
+
+	return b.Complete(r)
+}
+
+// Reconcile reconciles the KongPluginInstallation resource.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.GetLogger(ctx, "kongplugininstallation", r.DevelopmentMode)
+
+	var kpi v1alpha1.KongPluginInstallation
+	if err := r.Client.Get(ctx, req.NamespacedName, &kpi); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if kpi.GetDeletionTimestamp() != nil {
+		// The ConfigMap with the plugin is garbage collected through its owner reference.
+		log.Debug(logger, "kongplugininstallation is being deleted, ignoring", kpi)
+		return ctrl.Result{}, nil
+	}
+
+	if c, ok := k8sutils.GetCondition(consts.ConditionType(v1alpha1.KongPluginInstallationConditionStatusAccepted), &kpi); !ok ||
+		c.ObservedGeneration != kpi.Generation {
+		log.Trace(logger, "marking kongplugininstallation as pending", kpi)
+		if err := r.setAcceptedCondition(
+			ctx, &kpi, metav1.ConditionUnknown, v1alpha1.KongPluginInstallationReasonPending, "plugin is being fetched",
+		); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	log.Trace(logger, "fetching credentials for kongplugininstallation", kpi)
+	credentials, err := r.credentialsForKongPluginInstallation(ctx, &kpi)
+	if err != nil {
+		var errInvalidSecret invalidPullSecretError
+		if !errors.As(err, &errInvalidSecret) {
+			return ctrl.Result{}, err
+		}
+		log.Debug(logger, "invalid image pull secret for kongplugininstallation", kpi, "error", err)
+		return ctrl.Result{}, r.setAcceptedCondition(
+			ctx, &kpi, metav1.ConditionFalse, v1alpha1.KongPluginInstallationReasonFailed, err.Error(),
+		)
+	}
+
+	log.Trace(logger, "fetching plugin for kongplugininstallation", kpi, "image", kpi.Spec.Image)
+	files, err := image.FetchPlugin(ctx, kpi.Spec.Image, credentials)
+	if err != nil {
+		log.Debug(logger, "failed to fetch plugin for kongplugininstallation, retrying", kpi, "error", err, "after", fetchPluginRetryInterval)
+		// The failure may be transient (e.g. the registry is unavailable) or the image
+		// may be pushed later on, hence fetching the plugin is retried.
+		return ctrl.Result{RequeueAfter: fetchPluginRetryInterval}, r.setAcceptedCondition(
+			ctx, &kpi, metav1.ConditionFalse, v1alpha1.KongPluginInstallationReasonFailed,
+			fmt.Sprintf("failed to fetch plugin: %v", err),
+		)
+	}
+
+	log.Trace(logger, "ensuring configmap with plugin for kongplugininstallation", kpi)
+	res, cm, err := r.ensureConfigMapForKongPluginInstallation(ctx, &kpi, files)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if res != op.Noop {
+		log.Debug(logger, "configmap with plugin for kongplugininstallation modified", kpi, "configmap", cm.Name, "reason", res)
+	}
+
+	oldKPI := kpi.DeepCopy()
+	kpi.Status.UnderlyingConfigMapName = cm.Name
+	k8sutils.SetCondition(
+		k8sutils.NewConditionWithGeneration(
+			consts.ConditionType(v1alpha1.KongPluginInstallationConditionStatusAccepted),
+			metav1.ConditionTrue,
+			consts.ConditionReason(v1alpha1.KongPluginInstallationReasonReady),
+			"plugin successfully fetched and stored",
+			kpi.Generation,
+		),
+		&kpi,
+	)
+	if k8sutils.NeedsUpdate(oldKPI, &kpi) || oldKPI.Status.UnderlyingConfigMapName != kpi.Status.UnderlyingConfigMapName {
+		if err := r.Client.Status().Patch(ctx, &kpi, client.MergeFrom(oldKPI)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to patch status for kongplugininstallation: %w", err)
+		}
+		log.Info(logger, "kongplugininstallation marked as ready", kpi)
+	}
+
+	log.Debug(logger, "reconciliation complete for kongplugininstallation resource", kpi)
+	return ctrl.Result{}, nil
+}
+
+// setAcceptedCondition sets the Accepted condition on the KongPluginInstallation and patches its status.
+func (r *Reconciler) setAcceptedCondition(
+	ctx context.Context,
+	kpi *v1alpha1.KongPluginInstallation,
+	status metav1.ConditionStatus,
+	reason v1alpha1.KongPluginInstallationConditionReason,
+	message string,
+) error {
+	oldKPI := kpi.DeepCopy()
+	k8sutils.SetCondition(
+		k8sutils.NewConditionWithGeneration(
+			consts.ConditionType(v1alpha1.KongPluginInstallationConditionStatusAccepted),
+			status,
+			consts.ConditionReason(reason),
+			message,
+			kpi.Generation,
+		),
+		kpi,
+	)
+	if !k8sutils.NeedsUpdate(oldKPI, kpi) {
+		return nil
+	}
+	if err := r.Client.Status().Patch(ctx, kpi, client.MergeFrom(oldKPI)); err != nil {
+		return fmt.Errorf("failed to patch status for kongplugininstallation: %w", err)
+	}
+	return nil
+}
+
+// invalidPullSecretError is returned when the image pull secret referenced by a
+// KongPluginInstallation doesn't exist or can't be used. It can't be recovered
+// from without a change of the Secret, the KongPluginInstallation or a ReferenceGrant,
+// all of which are watched, so it's not retried.
+type invalidPullSecretError struct {
+	msg string
+}
+
+func (e invalidPullSecretError) Error() string {
+	return e.msg
+}
+
+// credentialsForKongPluginInstallation returns credentials for the registry based on the
+// Secret referenced by the KongPluginInstallation or nil when no Secret is referenced.
+func (r *Reconciler) credentialsForKongPluginInstallation(
+	ctx context.Context,
+	kpi *v1alpha1.KongPluginInstallation,
+) (auth.CredentialFunc, error) {
+	if kpi.Spec.ImagePullSecretRef == nil {
+		return nil, nil
+	}
+
+	nn := pullSecretNamespacedName(kpi)
+	if nn.Namespace != kpi.Namespace {
+		granted, err := r.isPullSecretReferenceGranted(ctx, kpi.Namespace, nn)
+		if err != nil {
+			return nil, err
+		}
+		if !granted {
+			return nil, invalidPullSecretError{msg: fmt.Sprintf(
+				"referenced image pull secret %s is in a different namespace and no ReferenceGrant allows referencing it", nn,
+			)}
+		}
+	}
+
+	var secret corev1.Secret
+	if err := r.Client.Get(ctx, nn, &secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, invalidPullSecretError{msg: fmt.Sprintf("referenced image pull secret %s not found", nn)}
+		}
+		return nil, fmt.Errorf("failed to get image pull secret %s: %w", nn, err)
+	}
+	if secret.Type != corev1.SecretTypeDockerConfigJson {
+		return nil, invalidPullSecretError{msg: fmt.Sprintf(
+			"referenced image pull secret %s is of type %q, expected %q", nn, secret.Type, corev1.SecretTypeDockerConfigJson,
+		)}
+	}
+	credentials, err := image.CredentialsFromDockerConfigJSON(secret.Data[corev1.DockerConfigJsonKey])
+	if err != nil {
+		return nil, invalidPullSecretError{msg: fmt.Sprintf("referenced image pull secret %s is invalid: %v", nn, err)}
+	}
+	return credentials, nil
+}
+
+// isPullSecretReferenceGranted returns true when a ReferenceGrant in the namespace of
+// the image pull secret allows KongPluginInstallations from the provided namespace
+// to reference it.
+func (r *Reconciler) isPullSecretReferenceGranted(ctx context.Context, kpiNamespace string, secret types.NamespacedName) (bool, error) {
+	var referenceGrants gatewayv1beta1.ReferenceGrantList
+	if err := r.Client.List(ctx, &referenceGrants, client.InNamespace(secret.Namespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed listing ReferenceGrants in namespace %s: %w", secret.Namespace, err)
+	}
+	return isPullSecretReferenceGranted(kpiNamespace, secret.Name, referenceGrants.Items), nil
+}
+
+func isPullSecretReferenceGranted(kpiNamespace, secretName string, referenceGrants []gatewayv1beta1.ReferenceGrant) bool {
+	for _, rg := range referenceGrants {
+		var fromFound bool
+		for _, from := range rg.Spec.From {
+			if string(from.Group) != v1alpha1.SchemeGroupVersion.Group ||
+				from.Kind != "KongPluginInstallation" ||
+				string(from.Namespace) != kpiNamespace {
+				continue
+			}
+			fromFound = true
+			break
+		}
+		if !fromFound {
+			continue
+		}
+		for _, to := range rg.Spec.To {
+			if to.Group != "" && to.Group != "core" {
+				continue
+			}
+			if to.Kind != "Secret" {
+				continue
+			}
+			if to.Name != nil && *to.Name != gatewayv1.ObjectName(secretName) {
+				continue
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// pullSecretNamespacedName returns the namespaced name of the image pull secret referenced
+// by the KongPluginInstallation. The namespace defaults to the KongPluginInstallation's one.
+func pullSecretNamespacedName(kpi *v1alpha1.KongPluginInstallation) types.NamespacedName {
+	nn := types.NamespacedName{
+		Namespace: kpi.Spec.ImagePullSecretRef.Namespace,
+		Name:      kpi.Spec.ImagePullSecretRef.Name,
+	}
+	if nn.Namespace == "" {
+		nn.Namespace = kpi.Namespace
+	}
+	return nn
+}
+
+// ensureConfigMapForKongPluginInstallation ensures that the ConfigMap holding the plugin's files exists
+// and is up to date.
+func (r *Reconciler) ensureConfigMapForKongPluginInstallation(
+	ctx context.Context,
+	kpi *v1alpha1.KongPluginInstallation,
+	files map[string]string,
+) (op.Result, *corev1.ConfigMap, error) {
+	matchingLabels := client.MatchingLabels{
+		consts.GatewayOperatorManagedByLabel: consts.KongPluginInstallationManagedLabelValue,
+	}
+	configMaps, err := k8sutils.ListConfigMapsForOwner(ctx, r.Client, kpi.Namespace, kpi.UID, matchingLabels)
+	if err != nil {
+		return op.Noop, nil, fmt.Errorf("failed listing ConfigMaps for KongPluginInstallation %s/%s: %w", kpi.Namespace, kpi.Name, err)
+	}
+
+	count := len(configMaps)
+	if count > 1 {
+		if err := k8sreduce.ReduceConfigMaps(ctx, r.Client, configMaps); err != nil {
+			return op.Noop, nil, err
+		}
+		return op.Noop, nil, errors.New("number of ConfigMaps reduced")
+	}
+
+	if count == 1 {
+		existing := &configMaps[0]
+		if maps.Equal(existing.Data, files) && len(existing.BinaryData) == 0 {
+			return op.Noop, existing, nil
+		}
+		existing.Data = files
+		existing.BinaryData = nil
+		if err := r.Client.Update(ctx, existing); err != nil {
+			return op.Noop, nil, fmt.Errorf("failed updating ConfigMap %s: %w", existing.Name, err)
+		}
+		return op.Updated, existing, nil
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    kpi.Namespace,
+			GenerateName: kpi.Name + "-",
+			Labels:       matchingLabels,
+		},
+		Data: files,
+	}
+	k8sutils.SetOwnerForObject(cm, kpi)
+	if err := r.Client.Create(ctx, cm); err != nil {
+		return op.Noop, nil, fmt.Errorf("failed creating ConfigMap for KongPluginInstallation %s/%s: %w", kpi.Namespace, kpi.Name, err)
+	}
+	return op.Created, cm, nil
+}
@@ -0,0 +1,287 @@
+package kongplugininstallation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kong/gateway-operator/api/v1alpha1"
+	"github.com/kong/gateway-operator/modules/manager/scheme"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+	"github.com/kong/gateway-operator/test/helpers"
+)
+
+func TestReconciler_Reconcile(t *testing.T) {
+	ctx := context.Background()
+
+	const username, password = "kong", "secret"
+	publicRegistry := helpers.StartOCIRegistry(t)
+	privateRegistry := helpers.StartOCIRegistry(t, helpers.WithOCIRegistryBasicAuth(username, password))
+
+	pluginV1 := map[string]string{
+		"handler.lua": "return { PRIORITY = 1000, VERSION = \"1.0\" }",
+		"schema.lua":  "return { name = \"myheader\", fields = {} }",
+	}
+	pluginV2 := map[string]string{
+		"handler.lua": "return { PRIORITY = 1000, VERSION = \"2.0\" }",
+		"schema.lua":  "return { name = \"myheader\", fields = {} }",
+	}
+	helpers.PushPluginImage(t, ctx, publicRegistry+"/plugins/myheader:1.0", pluginV1, auth.EmptyCredential)
+	helpers.PushPluginImage(t, ctx, publicRegistry+"/plugins/myheader:2.0", pluginV2, auth.EmptyCredential)
+	helpers.PushPluginImage(t, ctx, privateRegistry+"/plugins/myheader:1.0", pluginV1, auth.Credential{
+		Username: username,
+		Password: password,
+	})
+
+	kpi := func(image string, pullSecret *corev1.SecretReference) *v1alpha1.KongPluginInstallation {
+		return &v1alpha1.KongPluginInstallation{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  "default",
+				Name:       "myheader",
+				UID:        types.UID("kpi-uid"),
+				Generation: 1,
+			},
+			Spec: v1alpha1.KongPluginInstallationSpec{
+				Image:              image,
+				ImagePullSecretRef: pullSecret,
+			},
+		}
+	}
+	pullSecretInNamespace := func(namespace string, secretType corev1.SecretType, dockerConfigJSON string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "registry-credentials",
+			},
+			Type: secretType,
+			Data: map[string][]byte{
+				corev1.DockerConfigJsonKey: []byte(dockerConfigJSON),
+			},
+		}
+	}
+	pullSecret := func(secretType corev1.SecretType, dockerConfigJSON string) *corev1.Secret {
+		return pullSecretInNamespace("default", secretType, dockerConfigJSON)
+	}
+	referenceGrant := func(toName *gatewayv1beta1.ObjectName) *gatewayv1beta1.ReferenceGrant {
+		return &gatewayv1beta1.ReferenceGrant{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "secrets",
+				Name:      "allow-kpi",
+			},
+			Spec: gatewayv1beta1.ReferenceGrantSpec{
+				From: []gatewayv1beta1.ReferenceGrantFrom{
+					{
+						Group:     gatewayv1beta1.Group(v1alpha1.SchemeGroupVersion.Group),
+						Kind:      "KongPluginInstallation",
+						Namespace: "default",
+					},
+				},
+				To: []gatewayv1beta1.ReferenceGrantTo{
+					{
+						Kind: "Secret",
+						Name: toName,
+					},
+				},
+			},
+		}
+	}
+	validDockerConfigJSON := fmt.Sprintf(`{"auths":{%q:{"username":%q,"password":%q}}}`, privateRegistry, username, password)
+
+	testCases := []struct {
+		name              string
+		kpi               *v1alpha1.KongPluginInstallation
+		objects           []client.Object
+		expectedStatus    metav1.ConditionStatus
+		expectedReason    v1alpha1.KongPluginInstallationConditionReason
+		expectedMessage   string
+		expectedConfigMap map[string]string
+		expectedResult    ctrl.Result
+	}{
+		{
+			name:              "plugin from a public registry",
+			kpi:               kpi(publicRegistry+"/plugins/myheader:1.0", nil),
+			expectedStatus:    metav1.ConditionTrue,
+			expectedReason:    v1alpha1.KongPluginInstallationReasonReady,
+			expectedConfigMap: pluginV1,
+		},
+		{
+			name: "plugin from a private registry",
+			kpi: kpi(privateRegistry+"/plugins/myheader:1.0", &corev1.SecretReference{
+				Name: "registry-credentials",
+			}),
+			objects: []client.Object{
+				pullSecret(corev1.SecretTypeDockerConfigJson, validDockerConfigJSON),
+			},
+			expectedStatus:    metav1.ConditionTrue,
+			expectedReason:    v1alpha1.KongPluginInstallationReasonReady,
+			expectedConfigMap: pluginV1,
+		},
+		{
+			name: "existing ConfigMap is updated",
+			kpi:  kpi(publicRegistry+"/plugins/myheader:2.0", nil),
+			objects: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "myheader-abcde",
+						Labels: map[string]string{
+							consts.GatewayOperatorManagedByLabel: consts.KongPluginInstallationManagedLabelValue,
+						},
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion: v1alpha1.SchemeGroupVersion.String(),
+								Kind:       "KongPluginInstallation",
+								Name:       "myheader",
+								UID:        types.UID("kpi-uid"),
+							},
+						},
+					},
+					Data: pluginV1,
+				},
+			},
+			expectedStatus:    metav1.ConditionTrue,
+			expectedReason:    v1alpha1.KongPluginInstallationReasonReady,
+			expectedConfigMap: pluginV2,
+		},
+		{
+			name:            "image does not exist",
+			kpi:             kpi(publicRegistry+"/plugins/myheader:3.0", nil),
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1alpha1.KongPluginInstallationReasonFailed,
+			expectedMessage: "failed to fetch plugin",
+			expectedResult:  ctrl.Result{RequeueAfter: time.Minute},
+		},
+		{
+			name:            "missing credentials for a private registry",
+			kpi:             kpi(privateRegistry+"/plugins/myheader:1.0", nil),
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1alpha1.KongPluginInstallationReasonFailed,
+			expectedMessage: "failed to fetch plugin",
+			expectedResult:  ctrl.Result{RequeueAfter: time.Minute},
+		},
+		{
+			name: "pull secret from another namespace without a ReferenceGrant",
+			kpi: kpi(privateRegistry+"/plugins/myheader:1.0", &corev1.SecretReference{
+				Namespace: "secrets",
+				Name:      "registry-credentials",
+			}),
+			objects: []client.Object{
+				pullSecretInNamespace("secrets", corev1.SecretTypeDockerConfigJson, validDockerConfigJSON),
+			},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1alpha1.KongPluginInstallationReasonFailed,
+			expectedMessage: "no ReferenceGrant allows referencing it",
+		},
+		{
+			name: "pull secret from another namespace with a ReferenceGrant for a different Secret",
+			kpi: kpi(privateRegistry+"/plugins/myheader:1.0", &corev1.SecretReference{
+				Namespace: "secrets",
+				Name:      "registry-credentials",
+			}),
+			objects: []client.Object{
+				pullSecretInNamespace("secrets", corev1.SecretTypeDockerConfigJson, validDockerConfigJSON),
+				referenceGrant(lo.ToPtr(gatewayv1beta1.ObjectName("other-credentials"))),
+			},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1alpha1.KongPluginInstallationReasonFailed,
+			expectedMessage: "no ReferenceGrant allows referencing it",
+		},
+		{
+			name: "pull secret from another namespace with a ReferenceGrant",
+			kpi: kpi(privateRegistry+"/plugins/myheader:1.0", &corev1.SecretReference{
+				Namespace: "secrets",
+				Name:      "registry-credentials",
+			}),
+			objects: []client.Object{
+				pullSecretInNamespace("secrets", corev1.SecretTypeDockerConfigJson, validDockerConfigJSON),
+				referenceGrant(nil),
+			},
+			expectedStatus:    metav1.ConditionTrue,
+			expectedReason:    v1alpha1.KongPluginInstallationReasonReady,
+			expectedConfigMap: pluginV1,
+		},
+		{
+			name: "referenced pull secret does not exist",
+			kpi: kpi(privateRegistry+"/plugins/myheader:1.0", &corev1.SecretReference{
+				Name: "registry-credentials",
+			}),
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1alpha1.KongPluginInstallationReasonFailed,
+			expectedMessage: "referenced image pull secret default/registry-credentials not found",
+		},
+		{
+			name: "referenced pull secret has a wrong type",
+			kpi: kpi(privateRegistry+"/plugins/myheader:1.0", &corev1.SecretReference{
+				Name: "registry-credentials",
+			}),
+			objects: []client.Object{
+				pullSecret(corev1.SecretTypeOpaque, validDockerConfigJSON),
+			},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1alpha1.KongPluginInstallationReasonFailed,
+			expectedMessage: `is of type "Opaque", expected "kubernetes.io/dockerconfigjson"`,
+		},
+		{
+			name: "referenced pull secret is invalid",
+			kpi: kpi(privateRegistry+"/plugins/myheader:1.0", &corev1.SecretReference{
+				Name: "registry-credentials",
+			}),
+			objects: []client.Object{
+				pullSecret(corev1.SecretTypeDockerConfigJson, `{}`),
+			},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1alpha1.KongPluginInstallationReasonFailed,
+			expectedMessage: "referenced image pull secret default/registry-credentials is invalid",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fakectrlruntimeclient.
+				NewClientBuilder().
+				WithScheme(scheme.Get()).
+				WithObjects(append(tc.objects, tc.kpi)...).
+				WithStatusSubresource(tc.kpi).
+				Build()
+
+			reconciler := Reconciler{
+				Client: fakeClient,
+			}
+			res, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(tc.kpi)})
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedResult, res)
+
+			var kpi v1alpha1.KongPluginInstallation
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(tc.kpi), &kpi))
+			c, ok := k8sutils.GetCondition(consts.ConditionType(v1alpha1.KongPluginInstallationConditionStatusAccepted), &kpi)
+			require.True(t, ok, "Accepted condition should be set")
+			require.Equal(t, tc.expectedStatus, c.Status)
+			require.EqualValues(t, tc.expectedReason, c.Reason)
+			require.Contains(t, c.Message, tc.expectedMessage)
+			require.Equal(t, kpi.Generation, c.ObservedGeneration)
+
+			configMaps, err := k8sutils.ListConfigMapsForOwner(ctx, fakeClient, kpi.Namespace, kpi.UID)
+			require.NoError(t, err)
+			if tc.expectedConfigMap == nil {
+				require.Empty(t, kpi.Status.UnderlyingConfigMapName)
+				return
+			}
+			require.Len(t, configMaps, 1)
+			require.Equal(t, configMaps[0].Name, kpi.Status.UnderlyingConfigMapName)
+			require.Equal(t, tc.expectedConfigMap, configMaps[0].Data)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package kongplugininstallation
+
+import (
+	"context"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kong/gateway-operator/api/v1alpha1"
+	operatorerrors "github.com/kong/gateway-operator/internal/errors"
+	"github.com/kong/gateway-operator/internal/utils/index"
+)
+
+// -----------------------------------------------------------------------------
+// KongPluginInstallationReconciler - Watch Mapping Funcs
+// -----------------------------------------------------------------------------
+
+func (r *Reconciler) listKongPluginInstallationsForSecret(ctx context.Context, obj client.Object) (recs []reconcile.Request) {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		log.FromContext(ctx).Error(
+			operatorerrors.ErrUnexpectedObject,
+			"failed to run map funcs",
+			"expected", "Secret", "found", reflect.TypeOf(obj),
+		)
+		return
+	}
+
+	var kpis v1alpha1.KongPluginInstallationList
+	if err := r.Client.List(ctx, &kpis,
+		client.MatchingFields{
+			index.ImagePullSecretIndex: client.ObjectKeyFromObject(secret).String(),
+		},
+	); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list KongPluginInstallations in watch", "secret", client.ObjectKeyFromObject(secret))
+		return
+	}
+
+	for _, kpi := range kpis.Items {
+		recs = append(recs, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: kpi.Namespace,
+				Name:      kpi.Name,
+			},
+		})
+	}
+	return
+}
+
+// listKongPluginInstallationsForReferenceGrant returns the KongPluginInstallations
+// which reference an image pull secret in the namespace of the ReferenceGrant.
+func (r *Reconciler) listKongPluginInstallationsForReferenceGrant(ctx context.Context, obj client.Object) (recs []reconcile.Request) {
+	grant, ok := obj.(*gatewayv1beta1.ReferenceGrant)
+	if !ok {
+		log.FromContext(ctx).Error(
+			operatorerrors.ErrUnexpectedObject,
+			"failed to run map funcs",
+			"expected", "ReferenceGrant", "found", reflect.TypeOf(obj),
+		)
+		return
+	}
+
+	var kpis v1alpha1.KongPluginInstallationList
+	if err := r.Client.List(ctx, &kpis); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list KongPluginInstallations in watch", "referencegrant", client.ObjectKeyFromObject(grant))
+		return
+	}
+
+	for _, kpi := range kpis.Items {
+		if kpi.Spec.ImagePullSecretRef == nil ||
+			kpi.Spec.ImagePullSecretRef.Namespace != grant.Namespace ||
+			kpi.Namespace == grant.Namespace {
+			continue
+		}
+		recs = append(recs, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: kpi.Namespace,
+				Name:      kpi.Name,
+			},
+		})
+	}
+	return
+}
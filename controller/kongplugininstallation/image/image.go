@@ -0,0 +1,304 @@
+package image
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"path"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/retry"
+)
+
+const (
+	// MaxPluginSize is the maximum total size of the files of a plugin. It's
+	// bound by the maximum size of a ConfigMap the plugin is stored in.
+	MaxPluginSize = 1024 * 1024
+
+	// maxLayerSize is the maximum size of a single (possibly compressed) image layer
+	// that will be downloaded.
+	maxLayerSize = 10 * MaxPluginSize
+
+	// maxManifestSize is the maximum size of a manifest that will be downloaded.
+	maxManifestSize = 4 * 1024 * 1024
+)
+
+// RequiredPluginFiles are files that every Kong custom plugin has to contain.
+var RequiredPluginFiles = []string{"handler.lua", "schema.lua"}
+
+// dockerConfigJSON represents the content of a Secret of type kubernetes.io/dockerconfigjson.
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+type dockerConfigEntry struct {
+	Username      string `json:"username,omitempty"`
+	Password      string `json:"password,omitempty"`
+	Auth          string `json:"auth,omitempty"`
+	IdentityToken string `json:"identitytoken,omitempty"`
+	RegistryToken string `json:"registrytoken,omitempty"`
+}
+
+// CredentialsFromDockerConfigJSON parses the content of the .dockerconfigjson key of
+// a Secret of type kubernetes.io/dockerconfigjson and returns a function that provides
+// credentials for registries listed in it.
+// See: https://kubernetes.io/docs/tasks/configure-pod-container/pull-image-private-registry.
+func CredentialsFromDockerConfigJSON(configJSON []byte) (auth.CredentialFunc, error) {
+	var cfg dockerConfigJSON
+	if err := json.Unmarshal(configJSON, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse docker config JSON: %w", err)
+	}
+	if len(cfg.Auths) == 0 {
+		return nil, errors.New("docker config JSON contains no credentials (missing or empty \"auths\")")
+	}
+
+	creds := make(map[string]auth.Credential, len(cfg.Auths))
+	for registry, entry := range cfg.Auths {
+		cred := auth.Credential{
+			Username:     entry.Username,
+			Password:     entry.Password,
+			RefreshToken: entry.IdentityToken,
+			AccessToken:  entry.RegistryToken,
+		}
+		if entry.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode auth field for registry %s: %w", registry, err)
+			}
+			username, password, ok := strings.Cut(string(decoded), ":")
+			if !ok {
+				return nil, fmt.Errorf("invalid auth field for registry %s: expected username:password", registry)
+			}
+			cred.Username, cred.Password = username, password
+		}
+		creds[normalizeRegistryHost(registry)] = cred
+	}
+
+	return func(_ context.Context, hostport string) (auth.Credential, error) {
+		if cred, ok := creds[normalizeRegistryHost(hostport)]; ok {
+			return cred, nil
+		}
+		return auth.EmptyCredential, nil
+	}, nil
+}
+
+// normalizeRegistryHost returns the host (with port if present) of a registry
+// as it's used by the remote repository client. It strips a scheme and path
+// from keys like "https://index.docker.io/v1/" and maps Docker Hub aliases
+// to the host that serves the registry API.
+func normalizeRegistryHost(registry string) string {
+	host := registry
+	if strings.Contains(host, "://") {
+		if u, err := url.Parse(host); err == nil {
+			host = u.Host
+		}
+	}
+	host, _, _ = strings.Cut(host, "/")
+	switch host {
+	case "docker.io", "index.docker.io":
+		return "registry-1.docker.io"
+	}
+	return host
+}
+
+// FetchPlugin fetches the image (OCI image or OCI artifact) from the registry and
+// returns the files of the Kong custom plugin it contains, keyed by file name.
+// When credentials is nil, the image is fetched anonymously.
+//
+// The plugin files have to be placed in the root of the image. Only files with
+// the .lua extension are taken into account and the files listed in
+// RequiredPluginFiles have to be present.
+func FetchPlugin(ctx context.Context, imageURL string, credentials auth.CredentialFunc) (map[string]string, error) {
+	repo, err := remote.NewRepository(imageURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image reference %q: %w", imageURL, err)
+	}
+	// Follow the convention of container runtimes and treat loopback
+	// registries as insecure ones.
+	repo.PlainHTTP = isLoopback(repo.Reference.Host())
+	repo.Client = &auth.Client{
+		Client:     retry.DefaultClient,
+		Cache:      auth.NewCache(),
+		Credential: credentials,
+	}
+
+	reference := repo.Reference.Reference
+	if reference == "" {
+		reference = "latest"
+	}
+	desc, err := repo.Resolve(ctx, reference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve image %q: %w", imageURL, err)
+	}
+	manifest, err := fetchManifest(ctx, repo, desc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch manifest of image %q: %w", imageURL, err)
+	}
+
+	files := make(map[string]string)
+	for _, layer := range manifest.Layers {
+		if layer.Size > maxLayerSize {
+			return nil, fmt.Errorf("layer %s of image %q exceeds the maximum size of %d bytes", layer.Digest, imageURL, maxLayerSize)
+		}
+		blob, err := content.FetchAll(ctx, repo, layer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch layer %s of image %q: %w", layer.Digest, imageURL, err)
+		}
+		if err := extractLayer(layer, blob, files); err != nil {
+			return nil, fmt.Errorf("failed to extract layer %s of image %q: %w", layer.Digest, imageURL, err)
+		}
+	}
+
+	if err := validatePluginFiles(files); err != nil {
+		return nil, fmt.Errorf("image %q does not contain a valid plugin: %w", imageURL, err)
+	}
+	return files, nil
+}
+
+// fetchManifest fetches the image manifest described by desc. When desc points
+// to an image index, the first manifest listed in it is used, since plugins
+// consist of platform independent files.
+func fetchManifest(ctx context.Context, repo *remote.Repository, desc ocispec.Descriptor) (ocispec.Manifest, error) {
+	for range 2 {
+		if desc.Size > maxManifestSize {
+			return ocispec.Manifest{}, fmt.Errorf("manifest %s exceeds the maximum size of %d bytes", desc.Digest, maxManifestSize)
+		}
+		b, err := content.FetchAll(ctx, repo, desc)
+		if err != nil {
+			return ocispec.Manifest{}, err
+		}
+		switch desc.MediaType {
+		case ocispec.MediaTypeImageIndex, mediaTypeDockerManifestList:
+			var index ocispec.Index
+			if err := json.Unmarshal(b, &index); err != nil {
+				return ocispec.Manifest{}, fmt.Errorf("failed to parse image index: %w", err)
+			}
+			if len(index.Manifests) == 0 {
+				return ocispec.Manifest{}, errors.New("image index contains no manifests")
+			}
+			desc = index.Manifests[0]
+		case ocispec.MediaTypeImageManifest, mediaTypeDockerManifest:
+			var manifest ocispec.Manifest
+			if err := json.Unmarshal(b, &manifest); err != nil {
+				return ocispec.Manifest{}, fmt.Errorf("failed to parse image manifest: %w", err)
+			}
+			return manifest, nil
+		default:
+			return ocispec.Manifest{}, fmt.Errorf("unsupported manifest media type %q", desc.MediaType)
+		}
+	}
+	return ocispec.Manifest{}, errors.New("nested image indexes are not supported")
+}
+
+const (
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+)
+
+// extractLayer extracts plugin files from a layer into files. Layers of container
+// images are (possibly gzipped) tarballs, while layers of OCI artifacts (e.g. pushed
+// with the ORAS CLI) are the files themselves, named by the title annotation.
+func extractLayer(layer ocispec.Descriptor, blob []byte, files map[string]string) error {
+	if title, ok := layer.Annotations[ocispec.AnnotationTitle]; ok && !strings.Contains(layer.MediaType, "tar") {
+		return addPluginFile(files, title, blob)
+	}
+
+	var r io.Reader = bytes.NewReader(blob)
+	// Check the gzip magic number instead of relying on the media type,
+	// since not every image builder sets it consistently.
+	if len(blob) >= 2 && blob[0] == 0x1f && blob[1] == 0x8b {
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer gr.Close()
+		r = gr
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tar archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if hdr.Size > MaxPluginSize {
+			return fmt.Errorf("file %s exceeds the maximum size of %d bytes", hdr.Name, MaxPluginSize)
+		}
+		b, err := io.ReadAll(io.LimitReader(tr, MaxPluginSize+1))
+		if err != nil {
+			return fmt.Errorf("failed to read file %s: %w", hdr.Name, err)
+		}
+		if err := addPluginFile(files, hdr.Name, b); err != nil {
+			return err
+		}
+	}
+}
+
+// addPluginFile adds a Lua file to files, ensuring it's placed in the root of the
+// image and that the total size of the plugin is within MaxPluginSize. Other files
+// are ignored.
+func addPluginFile(files map[string]string, name string, b []byte) error {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	// Files other than Lua sources (e.g. licenses or READMEs) are not a part of the plugin.
+	if path.Ext(name) != ".lua" {
+		return nil
+	}
+	if dir, _ := path.Split(name); dir != "" {
+		return fmt.Errorf("file %s is not placed in the root of the image, nested directories are not supported", name)
+	}
+
+	size := len(b)
+	for n, content := range files {
+		if n != name {
+			size += len(content)
+		}
+	}
+	if size > MaxPluginSize {
+		return fmt.Errorf("plugin exceeds the maximum size of %d bytes", MaxPluginSize)
+	}
+	files[name] = string(b)
+	return nil
+}
+
+func validatePluginFiles(files map[string]string) error {
+	var missing []string
+	for _, f := range RequiredPluginFiles {
+		if _, ok := files[f]; !ok {
+			missing = append(missing, f)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required files: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func isLoopback(hostport string) bool {
+	host, _, err := net.SplitHostPort(hostport)
+	if err != nil {
+		host = hostport
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
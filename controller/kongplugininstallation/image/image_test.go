@@ -0,0 +1,208 @@
+package image_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	"github.com/kong/gateway-operator/controller/kongplugininstallation/image"
+	"github.com/kong/gateway-operator/test/helpers"
+)
+
+func TestFetchPlugin(t *testing.T) {
+	ctx := context.Background()
+	validPlugin := map[string]string{
+		"handler.lua": "return { PRIORITY = 1000, VERSION = \"0.1\" }",
+		"schema.lua":  "return { name = \"myheader\", fields = {} }",
+	}
+
+	t.Run("public registry", func(t *testing.T) {
+		registry := helpers.StartOCIRegistry(t)
+		helpers.PushPluginImage(t, ctx, registry+"/plugins/myheader:1.0", validPlugin, auth.EmptyCredential)
+		helpers.PushPluginArtifact(t, ctx, registry+"/plugins/myheader-artifact:1.0", validPlugin)
+		helpers.PushPluginImage(t, ctx, registry+"/plugins/myheader:latest", validPlugin, auth.EmptyCredential)
+		helpers.PushPluginImage(t, ctx, registry+"/plugins/with-extra-files:1.0", map[string]string{
+			"handler.lua": validPlugin["handler.lua"],
+			"schema.lua":  validPlugin["schema.lua"],
+			"LICENSE":     "Apache-2.0",
+		}, auth.EmptyCredential)
+		helpers.PushPluginImage(t, ctx, registry+"/plugins/missing-schema:1.0", map[string]string{
+			"handler.lua": validPlugin["handler.lua"],
+		}, auth.EmptyCredential)
+		helpers.PushPluginImage(t, ctx, registry+"/plugins/nested:1.0", map[string]string{
+			"handler.lua":               validPlugin["handler.lua"],
+			"schema.lua":                validPlugin["schema.lua"],
+			"migrations/000_base.lua":   "return {}",
+			"migrations/001_update.lua": "return {}",
+		}, auth.EmptyCredential)
+		helpers.PushPluginImage(t, ctx, registry+"/plugins/too-big:1.0", map[string]string{
+			"handler.lua": validPlugin["handler.lua"],
+			"schema.lua":  string(make([]byte, image.MaxPluginSize)),
+		}, auth.EmptyCredential)
+
+		for _, tc := range []struct {
+			name          string
+			image         string
+			expectedFiles map[string]string
+			expectedErr   string
+		}{
+			{
+				name:          "container image",
+				image:         registry + "/plugins/myheader:1.0",
+				expectedFiles: validPlugin,
+			},
+			{
+				name:          "OCI artifact",
+				image:         registry + "/plugins/myheader-artifact:1.0",
+				expectedFiles: validPlugin,
+			},
+			{
+				name:          "image without tag defaults to latest",
+				image:         registry + "/plugins/myheader",
+				expectedFiles: validPlugin,
+			},
+			{
+				name:          "files other than Lua ones are ignored",
+				image:         registry + "/plugins/with-extra-files:1.0",
+				expectedFiles: validPlugin,
+			},
+			{
+				name:        "missing required file",
+				image:       registry + "/plugins/missing-schema:1.0",
+				expectedErr: "missing required files: schema.lua",
+			},
+			{
+				name:        "nested directories",
+				image:       registry + "/plugins/nested:1.0",
+				expectedErr: "nested directories are not supported",
+			},
+			{
+				name:        "plugin too big",
+				image:       registry + "/plugins/too-big:1.0",
+				expectedErr: "exceeds the maximum size",
+			},
+			{
+				name:        "non existing image",
+				image:       registry + "/plugins/myheader:2.0",
+				expectedErr: "failed to resolve image",
+			},
+			{
+				name:        "invalid image reference",
+				image:       registry + "/plugins/MyHeader:1.0",
+				expectedErr: "invalid image reference",
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				files, err := image.FetchPlugin(ctx, tc.image, nil)
+				if tc.expectedErr != "" {
+					require.ErrorContains(t, err, tc.expectedErr)
+					return
+				}
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedFiles, files)
+			})
+		}
+	})
+
+	t.Run("private registry", func(t *testing.T) {
+		const username, password = "kong", "secret"
+		registry := helpers.StartOCIRegistry(t, helpers.WithOCIRegistryBasicAuth(username, password))
+		helpers.PushPluginImage(t, ctx, registry+"/plugins/myheader:1.0", validPlugin, auth.Credential{
+			Username: username,
+			Password: password,
+		})
+
+		_, err := image.FetchPlugin(ctx, registry+"/plugins/myheader:1.0", nil)
+		require.ErrorContains(t, err, "failed to resolve image")
+
+		wrongCredentials, err := image.CredentialsFromDockerConfigJSON(dockerConfigJSON(registry, username, "wrong"))
+		require.NoError(t, err)
+		_, err = image.FetchPlugin(ctx, registry+"/plugins/myheader:1.0", wrongCredentials)
+		require.ErrorContains(t, err, "failed to resolve image")
+
+		credentials, err := image.CredentialsFromDockerConfigJSON(dockerConfigJSON(registry, username, password))
+		require.NoError(t, err)
+		files, err := image.FetchPlugin(ctx, registry+"/plugins/myheader:1.0", credentials)
+		require.NoError(t, err)
+		require.Equal(t, validPlugin, files)
+	})
+}
+
+func TestCredentialsFromDockerConfigJSON(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name         string
+		configJSON   string
+		host         string
+		expectedCred auth.Credential
+		expectedErr  string
+	}{
+		{
+			name:         "username and password",
+			configJSON:   `{"auths":{"registry.example.com":{"username":"user","password":"pass"}}}`,
+			host:         "registry.example.com",
+			expectedCred: auth.Credential{Username: "user", Password: "pass"},
+		},
+		{
+			name: "auth field takes precedence",
+			configJSON: fmt.Sprintf(`{"auths":{"registry.example.com:5000":{"username":"user","password":"pass","auth":"%s"}}}`,
+				base64.StdEncoding.EncodeToString([]byte("other:secret"))),
+			host:         "registry.example.com:5000",
+			expectedCred: auth.Credential{Username: "other", Password: "secret"},
+		},
+		{
+			name:         "Docker Hub legacy key",
+			configJSON:   `{"auths":{"https://index.docker.io/v1/":{"username":"user","password":"pass"}}}`,
+			host:         "registry-1.docker.io",
+			expectedCred: auth.Credential{Username: "user", Password: "pass"},
+		},
+		{
+			name:         "identity token",
+			configJSON:   `{"auths":{"registry.example.com":{"identitytoken":"token"}}}`,
+			host:         "registry.example.com",
+			expectedCred: auth.Credential{RefreshToken: "token"},
+		},
+		{
+			name:         "no credentials for a registry",
+			configJSON:   `{"auths":{"registry.example.com":{"username":"user","password":"pass"}}}`,
+			host:         "other.example.com",
+			expectedCred: auth.EmptyCredential,
+		},
+		{
+			name:        "invalid JSON",
+			configJSON:  `{"auths":`,
+			expectedErr: "failed to parse docker config JSON",
+		},
+		{
+			name:        "no auths",
+			configJSON:  `{}`,
+			expectedErr: "contains no credentials",
+		},
+		{
+			name:        "invalid auth field",
+			configJSON:  `{"auths":{"registry.example.com":{"auth":"not base64"}}}`,
+			expectedErr: "failed to decode auth field",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			credentials, err := image.CredentialsFromDockerConfigJSON([]byte(tc.configJSON))
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			cred, err := credentials(ctx, tc.host)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCred, cred)
+		})
+	}
+}
+
+func dockerConfigJSON(registry, username, password string) []byte {
+	return []byte(fmt.Sprintf(`{"auths":{%q:{"username":%q,"password":%q}}}`, registry, username, password))
+}
@@ -0,0 +1,103 @@
+package konnect
+
+import (
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// KonnectAdoptionPolicyAnnotation is the annotation which can be set on Konnect
+	// entities to adopt entities already existing in Konnect instead of creating
+	// new ones. Supported values are "name" and "tag".
+	KonnectAdoptionPolicyAnnotation = "gateway-operator.konghq.com/konnect-adopt-by"
+
+	// KonnectAdoptionTagAnnotation is the annotation holding the tag used to find
+	// the entity to adopt when the "tag" adoption policy is used.
+	KonnectAdoptionTagAnnotation = "gateway-operator.konghq.com/konnect-adopt-tag"
+)
+
+// AdoptionPolicy describes how an entity already existing in Konnect is matched
+// with the entity in the cluster.
+type AdoptionPolicy string
+
+const (
+	// AdoptionPolicyNone means that no adoption is performed and entities are
+	// always created in Konnect.
+	AdoptionPolicyNone AdoptionPolicy = ""
+	// AdoptionPolicyName means that the entity existing in Konnect with the same
+	// name (username for Consumers) is adopted.
+	AdoptionPolicyName AdoptionPolicy = "name"
+	// AdoptionPolicyTag means that the only entity existing in Konnect tagged with
+	// the tag set in KonnectAdoptionTagAnnotation is adopted.
+	AdoptionPolicyTag AdoptionPolicy = "tag"
+)
+
+// getAdoptionPolicy returns the adoption policy configured on the provided object
+// and, for the tag policy, the tag to match entities with.
+func getAdoptionPolicy(obj metav1.Object) (AdoptionPolicy, string, error) {
+	annotations := obj.GetAnnotations()
+	switch policy := AdoptionPolicy(annotations[KonnectAdoptionPolicyAnnotation]); policy {
+	case AdoptionPolicyNone, AdoptionPolicyName:
+		return policy, "", nil
+	case AdoptionPolicyTag:
+		tag := annotations[KonnectAdoptionTagAnnotation]
+		if tag == "" {
+			return policy, "", fmt.Errorf("adoption by tag requires %s annotation to be set", KonnectAdoptionTagAnnotation)
+		}
+		return policy, tag, nil
+	default:
+		return policy, "", fmt.Errorf("unsupported adoption policy %q set in %s annotation", policy, KonnectAdoptionPolicyAnnotation)
+	}
+}
+
+// findEntityToAdopt returns the Konnect ID of the entity which should be adopted
+// by the provided object, according to its adoption policy.
+// Empty ID is returned when there's no entity to adopt and a new one should be created.
+//
+// getIDByName is used to get the ID of the entity with the provided name and
+// listIDsByTag to list IDs of entities tagged with the provided tag.
+func findEntityToAdopt(
+	obj metav1.Object,
+	name *string,
+	getIDByName func(name string) (string, error),
+	listIDsByTag func(tag string) ([]string, error),
+) (string, error) {
+	policy, tag, err := getAdoptionPolicy(obj)
+	if err != nil {
+		return "", AdoptionError{Err: err}
+	}
+
+	switch policy {
+	case AdoptionPolicyName:
+		if name == nil || *name == "" {
+			return "", AdoptionError{Err: errors.New("adoption by name requires the entity name to be set")}
+		}
+		id, err := getIDByName(*name)
+		if err != nil {
+			if errIsNotFound(err) {
+				return "", nil
+			}
+			return "", AdoptionError{Err: fmt.Errorf("failed to get entity named %q: %w", *name, err)}
+		}
+		return id, nil
+
+	case AdoptionPolicyTag:
+		ids, err := listIDsByTag(tag)
+		if err != nil {
+			return "", AdoptionError{Err: fmt.Errorf("failed to list entities tagged %q: %w", tag, err)}
+		}
+		switch len(ids) {
+		case 0:
+			return "", nil
+		case 1:
+			return ids[0], nil
+		default:
+			return "", AdoptionError{Err: fmt.Errorf("found %d entities tagged %q, expected at most 1: %v", len(ids), tag, ids)}
+		}
+
+	default:
+		return "", nil
+	}
+}
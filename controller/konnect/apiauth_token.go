@@ -0,0 +1,129 @@
+package konnect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	sdkkonnectgoerrs "github.com/Kong/sdk-konnect-go/models/sdkerrors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+
+	konnectv1alpha1 "github.com/kong/kubernetes-configuration/api/konnect/v1alpha1"
+)
+
+const (
+	// KonnectAPIAuthTokenSecretAnnotation is the annotation which can be set on
+	// a KonnectAPIAuthConfiguration to use the Konnect token (either a personal
+	// or a system account access token) stored in the Secret with the provided
+	// name, in the KonnectAPIAuthConfiguration's namespace, instead of the token
+	// set in its spec.
+	// Changes of the Secret, e.g. token rotation, trigger revalidation of the
+	// KonnectAPIAuthConfiguration.
+	// The KonnectAPIAuthConfiguration API is defined in kubernetes-configuration
+	// and still requires spec.token, which is ignored when this annotation is set.
+	KonnectAPIAuthTokenSecretAnnotation = "gateway-operator.konghq.com/konnect-token-secret"
+	// KonnectAPIAuthTokenSecretKeyAnnotation is the annotation which can be set
+	// on a KonnectAPIAuthConfiguration to select the key of the Secret's data
+	// holding the token. Defaults to "token".
+	KonnectAPIAuthTokenSecretKeyAnnotation = "gateway-operator.konghq.com/konnect-token-secret-key"
+
+	defaultKonnectTokenSecretKey = "token"
+)
+
+// getKonnectToken returns the Konnect token of the provided KonnectAPIAuthConfiguration.
+// The token is read from the Secret set in KonnectAPIAuthTokenSecretAnnotation
+// annotation if present, otherwise the token from the spec is used.
+// TokenSecretError is returned when the Secret does not exist or is invalid.
+func getKonnectToken(
+	ctx context.Context,
+	cl client.Client,
+	apiAuth *konnectv1alpha1.KonnectAPIAuthConfiguration,
+) (SDKToken, error) {
+	secretName, ok := apiAuth.GetAnnotations()[KonnectAPIAuthTokenSecretAnnotation]
+	if !ok {
+		return SDKToken(apiAuth.Spec.Token), nil
+	}
+
+	var (
+		secret corev1.Secret
+		nn     = types.NamespacedName{
+			Namespace: apiAuth.GetNamespace(),
+			Name:      secretName,
+		}
+	)
+	if err := cl.Get(ctx, nn, &secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "", TokenSecretError{Err: fmt.Errorf("secret %s not found", nn)}
+		}
+		return "", fmt.Errorf("failed to get Secret %s: %w", nn, err)
+	}
+
+	key := defaultKonnectTokenSecretKey
+	if k, ok := apiAuth.GetAnnotations()[KonnectAPIAuthTokenSecretKeyAnnotation]; ok && k != "" {
+		key = k
+	}
+	token, ok := secret.Data[key]
+	if !ok || len(token) == 0 {
+		return "", TokenSecretError{Err: fmt.Errorf("secret %s does not contain key %s", nn, key)}
+	}
+	return SDKToken(token), nil
+}
+
+// isUnauthorizedError returns true when Konnect rejected the request because
+// the token used to authenticate it is not valid, e.g. it has expired or has
+// been revoked.
+func isUnauthorizedError(err error) bool {
+	var (
+		errUnauthorized *sdkkonnectgoerrs.UnauthorizedError
+		errSDK          *sdkkonnectgoerrs.SDKError
+	)
+	return errors.As(err, &errUnauthorized) ||
+		errors.As(err, &errSDK) && errSDK.StatusCode == http.StatusUnauthorized
+}
+
+// setTokenExpiredCondition sets the TokenExpired condition on the provided
+// KonnectAPIAuthConfiguration according to whether Konnect rejected its token
+// as unauthorized. It returns true if the condition has changed.
+func setTokenExpiredCondition(
+	apiAuth *konnectv1alpha1.KonnectAPIAuthConfiguration,
+	expired bool,
+) bool {
+	var (
+		status                         = metav1.ConditionFalse
+		reason  consts.ConditionReason = KonnectAPIAuthConfigurationTokenExpiredReasonNotExpired
+		message                        = "Token is accepted by Konnect"
+	)
+	if expired {
+		status = metav1.ConditionTrue
+		reason = KonnectAPIAuthConfigurationTokenExpiredReasonExpired
+		message = "Token is rejected by Konnect, it has expired or has been revoked"
+	}
+
+	if cond, ok := k8sutils.GetCondition(KonnectAPIAuthConfigurationTokenExpiredConditionType, apiAuth); ok &&
+		cond.Status == status &&
+		cond.Reason == string(reason) &&
+		cond.Message == message &&
+		cond.ObservedGeneration == apiAuth.GetGeneration() {
+		return false
+	}
+
+	k8sutils.SetCondition(
+		k8sutils.NewConditionWithGeneration(
+			KonnectAPIAuthConfigurationTokenExpiredConditionType,
+			status,
+			reason,
+			message,
+			apiAuth.GetGeneration(),
+		),
+		apiAuth,
+	)
+	return true
+}
@@ -11,6 +11,43 @@ const (
 	// KonnectEntityProgrammedReason is the reason for the Programmed condition.
 	// It is set when the entity has been programmed in Konnect.
 	KonnectEntityProgrammedReason = "Programmed"
+	// KonnectEntityProgrammedReasonKonnectAPIOpFailed is the reason for the Programmed
+	// condition. It is set when the entity has failed to be programmed in Konnect.
+	KonnectEntityProgrammedReasonKonnectAPIOpFailed = "KonnectAPIOpFailed"
+	// KonnectEntityProgrammedReasonConflict is the reason for the Programmed
+	// condition. It is set when Konnect API has responded with a conflict,
+	// e.g. because an entity with the same name already exists in Konnect.
+	KonnectEntityProgrammedReasonConflict = "Conflict"
+	// KonnectEntityProgrammedReasonNotFound is the reason for the Programmed
+	// condition. It is set when the entity (or the entity it depends on) could
+	// not be found in Konnect.
+	KonnectEntityProgrammedReasonNotFound = "NotFound"
+	// KonnectEntityProgrammedReasonFailedToResolveRefs is the reason for the
+	// Programmed condition. It is set when the references of the entity,
+	// e.g. to a ControlPlane or a KongService, could not be resolved.
+	KonnectEntityProgrammedReasonFailedToResolveRefs = "FailedToResolveRefs"
+	// KonnectEntityProgrammedReasonAdoptionFailed is the reason for the Programmed
+	// condition. It is set when the entity existing in Konnect could not be
+	// adopted, e.g. because multiple entities matched the adoption policy.
+	KonnectEntityProgrammedReasonAdoptionFailed = "AdoptionFailed"
+)
+
+const (
+	// KonnectEntityDriftDetectedConditionType is the type of the condition that
+	// indicates whether the entity in Konnect has been found to differ from
+	// the spec (e.g. because it has been modified in Konnect UI) during the last
+	// periodic synchronization.
+	KonnectEntityDriftDetectedConditionType = "DriftDetected"
+
+	// KonnectEntityDriftDetectedReasonNoDrift is the reason used with the
+	// DriftDetected condition type indicating that the entity in Konnect matched
+	// the spec.
+	KonnectEntityDriftDetectedReasonNoDrift = "NoDrift"
+	// KonnectEntityDriftDetectedReasonDriftCorrected is the reason used with the
+	// DriftDetected condition type indicating that the entity in Konnect differed
+	// from the spec and that the spec has been re-applied.
+	// Condition message lists the fields which differed.
+	KonnectEntityDriftDetectedReasonDriftCorrected = "DriftCorrected"
 )
 
 const (
@@ -49,3 +86,20 @@ const (
 	// referenced by the entity is invalid.
 	KonnectEntityAPIAuthConfigurationReasonInvalid = "Invalid"
 )
+
+const (
+	// KonnectAPIAuthConfigurationTokenExpiredConditionType is the type of the
+	// condition that indicates whether the Konnect token used by the
+	// KonnectAPIAuthConfiguration has expired, which is derived from Konnect
+	// API rejecting the token as unauthorized.
+	KonnectAPIAuthConfigurationTokenExpiredConditionType = "TokenExpired"
+
+	// KonnectAPIAuthConfigurationTokenExpiredReasonExpired is the reason used
+	// with the TokenExpired condition type indicating that the token has expired
+	// or has been revoked.
+	KonnectAPIAuthConfigurationTokenExpiredReasonExpired = "Expired"
+	// KonnectAPIAuthConfigurationTokenExpiredReasonNotExpired is the reason used
+	// with the TokenExpired condition type indicating that the token has been
+	// accepted by Konnect.
+	KonnectAPIAuthConfigurationTokenExpiredReasonNotExpired = "NotExpired"
+)
@@ -0,0 +1,146 @@
+package konnect
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+// driftIgnoredFields are the fields of Konnect entities which are not taken into
+// account when detecting drift: read-only fields set by Konnect and write-only
+// fields which are never returned by Konnect API.
+var driftIgnoredFields = []string{
+	"id",
+	"created_at",
+	"updated_at",
+	"url",
+}
+
+// driftEntityMissing is reported as the drift when the entity could not be
+// found in Konnect, e.g. because it has been deleted in Konnect UI.
+const driftEntityMissing = "<entity missing>"
+
+// diffEntity returns the sorted names of the fields of the entity in Konnect
+// (actual) which differ from the desired state generated from the spec (desired).
+// Only fields set in the desired state are compared: fields left unset (null)
+// in the spec are defaulted by Konnect (e.g. connect_timeout of a Service),
+// so their values in Konnect are not considered a drift. The same applies to
+// unset fields of nested objects. Empty collections are equal to unset ones.
+func diffEntity(desired, actual any) ([]string, error) {
+	desiredFields, err := entityFields(desired)
+	if err != nil {
+		return nil, err
+	}
+	actualFields, err := entityFields(actual)
+	if err != nil {
+		return nil, err
+	}
+
+	var drift []string
+	for field, desiredValue := range desiredFields {
+		if !slices.Contains(driftIgnoredFields, field) &&
+			!equalFieldValues(desiredValue, actualFields[field]) {
+			drift = append(drift, field)
+		}
+	}
+	slices.Sort(drift)
+	return drift, nil
+}
+
+// entityFields returns the fields of the provided Konnect entity as they
+// are sent to and received from Konnect API.
+func entityFields(entity any) (map[string]any, error) {
+	b, err := json.Marshal(entity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %T: %w", entity, err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %T: %w", entity, err)
+	}
+	return fields, nil
+}
+
+// equalFieldValues returns true when the actual value matches the desired one.
+// Unset (null) desired values, including the ones in nested objects, match any
+// actual value since Konnect fills them in with defaults.
+func equalFieldValues(desired, actual any) bool {
+	if desired == nil {
+		return true
+	}
+	if isEmptyFieldValue(desired) && isEmptyFieldValue(actual) {
+		return true
+	}
+	desiredObj, ok := desired.(map[string]any)
+	if !ok {
+		return reflect.DeepEqual(desired, actual)
+	}
+	actualObj, ok := actual.(map[string]any)
+	if !ok {
+		return false
+	}
+	for k, v := range desiredObj {
+		if !equalFieldValues(v, actualObj[k]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isEmptyFieldValue(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+// setDriftDetectedCondition sets the DriftDetected condition on the provided object
+// according to the drift detected between its spec and the entity in Konnect.
+// specChanged indicates that the difference is caused by a change of the spec
+// and hence it's not reported as a drift.
+func setDriftDetectedCondition(obj conditionsAwareObject, specChanged bool, drift []string) {
+	var (
+		status                         = metav1.ConditionFalse
+		reason  consts.ConditionReason = KonnectEntityDriftDetectedReasonNoDrift
+		message                        = "Entity in Konnect matches the spec"
+	)
+	switch {
+	case len(drift) == 0:
+	case specChanged:
+		message = "Entity in Konnect has been updated to match the spec"
+	case slices.Contains(drift, driftEntityMissing):
+		status = metav1.ConditionTrue
+		reason = KonnectEntityDriftDetectedReasonDriftCorrected
+		message = "Entity could not be found in Konnect, it has been re-created from the spec"
+	default:
+		status = metav1.ConditionTrue
+		reason = KonnectEntityDriftDetectedReasonDriftCorrected
+		message = fmt.Sprintf("Entity in Konnect differed from the spec in fields: %s, the spec has been re-applied",
+			strings.Join(drift, ", "),
+		)
+	}
+
+	k8sutils.SetCondition(
+		k8sutils.NewConditionWithGeneration(
+			KonnectEntityDriftDetectedConditionType,
+			status,
+			reason,
+			message,
+			obj.GetGeneration(),
+		),
+		obj,
+	)
+}
@@ -0,0 +1,125 @@
+package konnect
+
+import (
+	"testing"
+
+	sdkkonnectgocomp "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffEntity(t *testing.T) {
+	testCases := []struct {
+		name          string
+		desired       any
+		actual        any
+		expectedDrift []string
+	}{
+		{
+			name: "defaults and read-only fields are not reported",
+			desired: sdkkonnectgocomp.ServiceInput{
+				Host: "example.com",
+				Name: lo.ToPtr("svc"),
+			},
+			actual: sdkkonnectgocomp.Service{
+				Host:      "example.com",
+				Name:      lo.ToPtr("svc"),
+				ID:        lo.ToPtr("svc-id"),
+				CreatedAt: lo.ToPtr(int64(1)),
+				UpdatedAt: lo.ToPtr(int64(2)),
+			},
+		},
+		{
+			name: "write-only url is not reported",
+			desired: sdkkonnectgocomp.ServiceInput{
+				Host: "example.com",
+				URL:  lo.ToPtr("http://example.com"),
+			},
+			actual: sdkkonnectgocomp.Service{
+				Host: "example.com",
+			},
+		},
+		{
+			name: "changed fields are reported",
+			desired: sdkkonnectgocomp.ServiceInput{
+				Host: "example.com",
+				Port: lo.ToPtr(int64(8080)),
+			},
+			actual: sdkkonnectgocomp.Service{
+				Host: "example.org",
+				Port: lo.ToPtr(int64(80)),
+			},
+			expectedDrift: []string{"host", "port"},
+		},
+		{
+			name: "fields unset in the spec are not reported",
+			desired: sdkkonnectgocomp.ConsumerInput{
+				Username: lo.ToPtr("user"),
+			},
+			actual: sdkkonnectgocomp.Consumer{
+				Username: lo.ToPtr("user"),
+				CustomID: lo.ToPtr("custom-id"),
+			},
+		},
+		{
+			name: "Konnect defaults of fields unset in the spec are not reported",
+			desired: sdkkonnectgocomp.ServiceInput{
+				Host: "example.com",
+			},
+			actual: sdkkonnectgocomp.Service{
+				Host:           "example.com",
+				ConnectTimeout: lo.ToPtr(int64(60000)),
+				ReadTimeout:    lo.ToPtr(int64(60000)),
+				WriteTimeout:   lo.ToPtr(int64(60000)),
+				Retries:        lo.ToPtr(int64(5)),
+				Port:           lo.ToPtr(int64(80)),
+				Protocol:       lo.ToPtr(sdkkonnectgocomp.ProtocolHTTP),
+				Enabled:        lo.ToPtr(true),
+			},
+		},
+		{
+			name: "fields set in the spec and changed in Konnect are reported",
+			desired: sdkkonnectgocomp.ServiceInput{
+				Host:           "example.com",
+				ConnectTimeout: lo.ToPtr(int64(1000)),
+			},
+			actual: sdkkonnectgocomp.Service{
+				Host:           "example.com",
+				ConnectTimeout: lo.ToPtr(int64(60000)),
+				Retries:        lo.ToPtr(int64(5)),
+			},
+			expectedDrift: []string{"connect_timeout"},
+		},
+		{
+			name: "collections emptied in the spec are reported",
+			desired: sdkkonnectgocomp.RouteInput{
+				Paths: []string{"/"},
+				Hosts: []string{},
+			},
+			actual: sdkkonnectgocomp.Route{
+				Paths: []string{"/"},
+				Hosts: []string{"added-in-ui.example.com"},
+			},
+			expectedDrift: []string{"hosts"},
+		},
+		{
+			name: "empty collections are equal to unset ones",
+			desired: sdkkonnectgocomp.RouteInput{
+				Paths: []string{"/"},
+				Tags:  []string{},
+			},
+			actual: sdkkonnectgocomp.Route{
+				Paths: []string{"/"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			drift, err := diffEntity(tc.desired, tc.actual)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedDrift, drift)
+		})
+	}
+}
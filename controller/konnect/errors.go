@@ -20,3 +20,53 @@ func (e FailedKonnectOpError[T]) Error() string {
 func (e FailedKonnectOpError[T]) Unwrap() error {
 	return e.Err
 }
+
+// ReferenceResolutionError is an error type that is returned when a reference
+// of a Konnect entity to another entity (e.g. a ControlPlane or a KongService)
+// cannot be resolved.
+type ReferenceResolutionError struct {
+	Err error
+}
+
+// Error implements the error interface.
+func (e ReferenceResolutionError) Error() string {
+	return fmt.Sprintf("failed to resolve reference: %v", e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e ReferenceResolutionError) Unwrap() error {
+	return e.Err
+}
+
+// AdoptionError is an error type that is returned when an entity existing
+// in Konnect cannot be adopted.
+type AdoptionError struct {
+	Err error
+}
+
+// Error implements the error interface.
+func (e AdoptionError) Error() string {
+	return fmt.Sprintf("failed to adopt entity: %v", e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e AdoptionError) Unwrap() error {
+	return e.Err
+}
+
+// TokenSecretError is an error type that is returned when the Konnect token
+// cannot be read from the Secret referenced by a KonnectAPIAuthConfiguration,
+// e.g. because the Secret does not exist or does not contain the token.
+type TokenSecretError struct {
+	Err error
+}
+
+// Error implements the error interface.
+func (e TokenSecretError) Error() string {
+	return fmt.Sprintf("failed to get Konnect token from Secret: %v", e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e TokenSecretError) Unwrap() error {
+	return e.Err
+}
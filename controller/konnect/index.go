@@ -0,0 +1,45 @@
+package konnect
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	konnectv1alpha1 "github.com/kong/kubernetes-configuration/api/konnect/v1alpha1"
+)
+
+const (
+	// KonnectAPIAuthTokenSecretIndex is the key to be used to access the Secrets set in the
+	// KonnectAPIAuthTokenSecretAnnotation annotation indexed values, in a form of <namespace>/<name>.
+	KonnectAPIAuthTokenSecretIndex = "konnectAPIAuthTokenSecret"
+)
+
+// TokenSecretOnKonnectAPIAuthConfiguration indexes the KonnectAPIAuthConfiguration
+// KonnectAPIAuthTokenSecretAnnotation annotation on the "konnectAPIAuthTokenSecret" key.
+func TokenSecretOnKonnectAPIAuthConfiguration(ctx context.Context, c cache.Cache) error {
+	if _, err := c.GetInformer(ctx, &konnectv1alpha1.KonnectAPIAuthConfiguration{}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get informer for v1alpha1 KonnectAPIAuthConfiguration: %w, disabling indexing KonnectAPIAuthConfigurations' token Secrets", err)
+	}
+
+	return c.IndexField(ctx, &konnectv1alpha1.KonnectAPIAuthConfiguration{}, KonnectAPIAuthTokenSecretIndex, konnectAPIAuthTokenSecretIndexValues)
+}
+
+// konnectAPIAuthTokenSecretIndexValues returns the token Secret of the KonnectAPIAuthConfiguration
+// for the KonnectAPIAuthTokenSecretIndex index.
+func konnectAPIAuthTokenSecretIndexValues(o client.Object) []string {
+	apiAuth, ok := o.(*konnectv1alpha1.KonnectAPIAuthConfiguration)
+	if !ok {
+		return []string{}
+	}
+	secretName, ok := apiAuth.GetAnnotations()[KonnectAPIAuthTokenSecretAnnotation]
+	if !ok || secretName == "" {
+		return []string{}
+	}
+	return []string{apiAuth.Namespace + "/" + secretName}
+}
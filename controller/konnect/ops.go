@@ -2,17 +2,23 @@ package konnect
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
-	sdkkonnectgo "github.com/Kong/sdk-konnect-go"
+	sdkkonnectgoerrs "github.com/Kong/sdk-konnect-go/models/sdkerrors"
 	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kong/gateway-operator/controller/pkg/log"
+	"github.com/kong/gateway-operator/pkg/consts"
 	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+
+	configurationv1 "github.com/kong/kubernetes-configuration/api/configuration/v1"
+	configurationv1alpha1 "github.com/kong/kubernetes-configuration/api/configuration/v1alpha1"
 )
 
 // Op is the Konnect operation type.
@@ -31,10 +37,17 @@ const (
 func Create[
 	T SupportedKonnectEntityType,
 	TEnt EntityType[T],
-](ctx context.Context, sdk *sdkkonnectgo.SDK, logger logr.Logger, cl client.Client, e *T) (*T, error) {
+](ctx context.Context, sdk SDKWrapper, logger logr.Logger, cl client.Client, e *T) (*T, error) {
 	defer logOpComplete[T, TEnt](logger, time.Now(), CreateOp, e)
 
-	switch ent := any(e).(type) { //nolint:gocritic
+	switch ent := any(e).(type) {
+	case *configurationv1alpha1.KongService:
+		return e, createService(ctx, sdk.GetServicesSDK(), ent)
+	case *configurationv1alpha1.KongRoute:
+		return e, createRoute(ctx, sdk.GetRoutesSDK(), cl, ent)
+	case *configurationv1.KongConsumer:
+		return e, createConsumer(ctx, sdk.GetConsumersSDK(), ent)
+
 	// ---------------------------------------------------------------------
 	// TODO: add other Konnect types
 
@@ -47,10 +60,17 @@ func Create[
 func Delete[
 	T SupportedKonnectEntityType,
 	TEnt EntityType[T],
-](ctx context.Context, sdk *sdkkonnectgo.SDK, logger logr.Logger, cl client.Client, e *T) error {
+](ctx context.Context, sdk SDKWrapper, logger logr.Logger, cl client.Client, e *T) error {
 	defer logOpComplete[T, TEnt](logger, time.Now(), DeleteOp, e)
 
-	switch ent := any(e).(type) { //nolint:gocritic
+	switch ent := any(e).(type) {
+	case *configurationv1alpha1.KongService:
+		return deleteService(ctx, sdk.GetServicesSDK(), ent)
+	case *configurationv1alpha1.KongRoute:
+		return deleteRoute(ctx, sdk.GetRoutesSDK(), ent)
+	case *configurationv1.KongConsumer:
+		return deleteConsumer(ctx, sdk.GetConsumersSDK(), ent)
+
 	// ---------------------------------------------------------------------
 	// TODO: add other Konnect types
 
@@ -63,19 +83,23 @@ func Delete[
 func Update[
 	T SupportedKonnectEntityType,
 	TEnt EntityType[T],
-](ctx context.Context, sdk *sdkkonnectgo.SDK, logger logr.Logger, cl client.Client, e *T) (ctrl.Result, error) {
+](ctx context.Context, sdk SDKWrapper, logger logr.Logger, cl client.Client, e *T) (ctrl.Result, error) {
 	var (
 		ent                = TEnt(e)
 		condProgrammed, ok = k8sutils.GetCondition(KonnectEntityProgrammedConditionType, ent)
 		now                = time.Now()
 		timeFromLastUpdate = time.Since(condProgrammed.LastTransitionTime.Time)
+		// specChanged is true when the spec of the entity has changed since it
+		// was last programmed in Konnect, so that the differences found between
+		// the spec and the entity in Konnect are not reported as a drift.
+		specChanged = !ok ||
+			condProgrammed.Status != metav1.ConditionTrue ||
+			condProgrammed.ObservedGeneration != ent.GetObjectMeta().GetGeneration()
 	)
 	// If the entity is already programmed and the last update was less than
 	// the configured sync period, requeue after the remaining time.
-	if ok &&
-		condProgrammed.Status == metav1.ConditionTrue &&
+	if !specChanged &&
 		condProgrammed.Reason == KonnectEntityProgrammedReason &&
-		condProgrammed.ObservedGeneration == ent.GetObjectMeta().GetGeneration() &&
 		timeFromLastUpdate <= configurableSyncPeriod {
 		requeueAfter := configurableSyncPeriod - timeFromLastUpdate
 		log.Debug(logger, "no need for update, requeueing after configured sync period", e,
@@ -91,13 +115,38 @@ func Update[
 
 	defer logOpComplete[T, TEnt](logger, now, UpdateOp, e)
 
-	switch ent := any(e).(type) { //nolint:gocritic
+	// The entity in Konnect is compared with the spec. Unless the spec has
+	// changed, the spec is re-applied only when they differ.
+	var (
+		drift []string
+		err   error
+	)
+	switch ent := any(e).(type) {
+	case *configurationv1alpha1.KongService:
+		drift, err = updateService(ctx, sdk.GetServicesSDK(), ent, specChanged)
+	case *configurationv1alpha1.KongRoute:
+		drift, err = updateRoute(ctx, sdk.GetRoutesSDK(), cl, ent, specChanged)
+	case *configurationv1.KongConsumer:
+		drift, err = updateConsumer(ctx, sdk.GetConsumersSDK(), ent, specChanged)
+
 	// ---------------------------------------------------------------------
 	// TODO: add other Konnect types
 
 	default:
 		return ctrl.Result{}, fmt.Errorf("unsupported entity type %T", ent)
 	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if len(drift) > 0 && !specChanged {
+		log.Info(logger, "entity in Konnect differed from the spec, the spec has been re-applied", e,
+			"drift", drift,
+		)
+	}
+	setDriftDetectedCondition(ent, specChanged, drift)
+
+	return ctrl.Result{}, nil
 }
 
 func logOpComplete[
@@ -111,3 +160,98 @@ func logOpComplete[
 		"konnect_id", e.GetKonnectStatus().GetKonnectID(),
 	)
 }
+
+// conditionsAwareObject is an object which has status conditions.
+type conditionsAwareObject interface {
+	client.Object
+	k8sutils.ConditionsAware
+}
+
+// SetKonnectEntityProgrammedCondition sets the Programmed condition to True
+// on the provided object.
+func SetKonnectEntityProgrammedCondition(obj conditionsAwareObject) {
+	k8sutils.SetCondition(
+		k8sutils.NewConditionWithGeneration(
+			KonnectEntityProgrammedConditionType,
+			metav1.ConditionTrue,
+			KonnectEntityProgrammedReason,
+			"",
+			obj.GetGeneration(),
+		),
+		obj,
+	)
+}
+
+// SetKonnectEntityProgrammedConditionFalse sets the Programmed condition to False
+// on the provided object, using the reason matching the provided error.
+func SetKonnectEntityProgrammedConditionFalse(obj conditionsAwareObject, err error) {
+	k8sutils.SetCondition(
+		k8sutils.NewConditionWithGeneration(
+			KonnectEntityProgrammedConditionType,
+			metav1.ConditionFalse,
+			programmedConditionReasonForError(err),
+			err.Error(),
+			obj.GetGeneration(),
+		),
+		obj,
+	)
+}
+
+// programmedConditionReasonForError returns the Programmed condition reason
+// matching the provided error returned from an operation against Konnect API.
+func programmedConditionReasonForError(err error) consts.ConditionReason {
+	var (
+		refErr      ReferenceResolutionError
+		adoptionErr AdoptionError
+	)
+	switch {
+	case errors.As(err, &refErr):
+		return KonnectEntityProgrammedReasonFailedToResolveRefs
+	case errors.As(err, &adoptionErr):
+		return KonnectEntityProgrammedReasonAdoptionFailed
+	case errIsConflict(err):
+		return KonnectEntityProgrammedReasonConflict
+	case errIsNotFound(err):
+		return KonnectEntityProgrammedReasonNotFound
+	default:
+		return KonnectEntityProgrammedReasonKonnectAPIOpFailed
+	}
+}
+
+// errIsNotFound returns true if the provided error is a Konnect API 404 error.
+func errIsNotFound(err error) bool {
+	var (
+		errNotFound *sdkkonnectgoerrs.NotFoundError
+		errSDK      *sdkkonnectgoerrs.SDKError
+	)
+	return errors.As(err, &errNotFound) ||
+		errors.As(err, &errSDK) && errSDK.StatusCode == http.StatusNotFound
+}
+
+// errIsConflict returns true if the provided error is a Konnect API 409 error.
+func errIsConflict(err error) bool {
+	var (
+		errConflict *sdkkonnectgoerrs.ConflictError
+		errSDK      *sdkkonnectgoerrs.SDKError
+	)
+	return errors.As(err, &errConflict) ||
+		errors.As(err, &errSDK) && errSDK.StatusCode == http.StatusConflict
+}
+
+// getControlPlaneID returns the Konnect ID of the ControlPlane referenced by
+// the provided reference.
+func getControlPlaneID(ref configurationv1alpha1.ControlPlaneRef) (string, error) {
+	switch ref.Type {
+	case configurationv1alpha1.ControlPlaneRefKonnectID:
+		if ref.KonnectID == nil || *ref.KonnectID == "" {
+			return "", ReferenceResolutionError{
+				Err: fmt.Errorf("ControlPlane reference of type %s has no Konnect ID set", ref.Type),
+			}
+		}
+		return *ref.KonnectID, nil
+	default:
+		return "", ReferenceResolutionError{
+			Err: fmt.Errorf("unsupported ControlPlane reference type %q", ref.Type),
+		}
+	}
+}
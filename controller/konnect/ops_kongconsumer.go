@@ -0,0 +1,180 @@
+package konnect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sdkkonnectgocomp "github.com/Kong/sdk-konnect-go/models/components"
+	sdkkonnectgoops "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/samber/lo"
+
+	configurationv1 "github.com/kong/kubernetes-configuration/api/configuration/v1"
+)
+
+// createConsumer creates the Konnect Consumer for the provided KongConsumer and
+// stores its Konnect ID and the ID of its ControlPlane in the KongConsumer's status.
+// When the KongConsumer is configured to adopt an existing Consumer, the matching
+// Consumer is updated instead of creating a new one.
+func createConsumer(
+	ctx context.Context,
+	sdk ConsumersSDK,
+	consumer *configurationv1.KongConsumer,
+) error {
+	cpID, err := getControlPlaneID(consumer.Spec.ControlPlaneRef)
+	if err != nil {
+		SetKonnectEntityProgrammedConditionFalse(consumer, err)
+		return err
+	}
+	consumer.Status.Konnect.ControlPlaneID = cpID
+
+	input := kongConsumerToSDKConsumerInput(consumer)
+	id, err := findEntityToAdopt(consumer, input.Username,
+		func(name string) (string, error) {
+			resp, err := sdk.GetConsumer(ctx, cpID, name)
+			if err != nil {
+				return "", err
+			}
+			if resp == nil || resp.Consumer == nil || resp.Consumer.ID == nil {
+				return "", errors.New("response does not contain the Consumer ID")
+			}
+			return *resp.Consumer.ID, nil
+		},
+		func(tag string) ([]string, error) {
+			resp, err := sdk.ListConsumer(ctx, sdkkonnectgoops.ListConsumerRequest{
+				ControlPlaneID: cpID,
+				Tags:           &tag,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if resp == nil || resp.Object == nil {
+				return nil, nil
+			}
+			return lo.FilterMap(resp.Object.Data, func(e sdkkonnectgocomp.Consumer, _ int) (string, bool) {
+				return lo.FromPtr(e.ID), e.ID != nil
+			}), nil
+		},
+	)
+	if err != nil {
+		SetKonnectEntityProgrammedConditionFalse(consumer, err)
+		return err
+	}
+	if id != "" {
+		// Adopt the existing Consumer, making sure it matches the spec.
+		if _, err := sdk.UpsertConsumer(ctx, sdkkonnectgoops.UpsertConsumerRequest{
+			ControlPlaneID: cpID,
+			ConsumerID:     id,
+			Consumer:       input,
+		}); err != nil {
+			SetKonnectEntityProgrammedConditionFalse(consumer, err)
+			return err
+		}
+		consumer.Status.Konnect.SetKonnectID(id)
+		SetKonnectEntityProgrammedCondition(consumer)
+		return nil
+	}
+
+	resp, err := sdk.CreateConsumer(ctx, cpID, input)
+	if err != nil {
+		SetKonnectEntityProgrammedConditionFalse(consumer, err)
+		return err
+	}
+	if resp == nil || resp.Consumer == nil || resp.Consumer.ID == nil {
+		err := errors.New("failed creating Consumer: response does not contain the Consumer ID")
+		SetKonnectEntityProgrammedConditionFalse(consumer, err)
+		return err
+	}
+
+	consumer.Status.Konnect.SetKonnectID(*resp.Consumer.ID)
+	SetKonnectEntityProgrammedCondition(consumer)
+	return nil
+}
+
+// updateConsumer compares the Konnect Consumer identified by the Konnect ID stored
+// in the provided KongConsumer's status with the KongConsumer's spec and updates
+// it if they differ or if the spec has changed since it was last programmed, so
+// that fields removed from the spec get unset too. Fields which differed are returned.
+// It is assumed that the KongConsumer has already been created in Konnect.
+func updateConsumer(
+	ctx context.Context,
+	sdk ConsumersSDK,
+	consumer *configurationv1.KongConsumer,
+	specChanged bool,
+) ([]string, error) {
+	cpID, err := getControlPlaneID(consumer.Spec.ControlPlaneRef)
+	if err != nil {
+		SetKonnectEntityProgrammedConditionFalse(consumer, err)
+		return nil, err
+	}
+	consumer.Status.Konnect.ControlPlaneID = cpID
+
+	var (
+		id    = consumer.Status.Konnect.GetKonnectID()
+		input = kongConsumerToSDKConsumerInput(consumer)
+		drift []string
+	)
+	resp, err := sdk.GetConsumer(ctx, cpID, id)
+	switch {
+	case errIsNotFound(err) || err == nil && (resp == nil || resp.Consumer == nil):
+		drift = []string{driftEntityMissing}
+	case err != nil:
+		SetKonnectEntityProgrammedConditionFalse(consumer, err)
+		return nil, err
+	default:
+		if drift, err = diffEntity(input, resp.Consumer); err != nil {
+			SetKonnectEntityProgrammedConditionFalse(consumer, err)
+			return nil, err
+		}
+	}
+
+	// The drift is only found for fields set in the spec, hence the spec is
+	// always applied when it has changed.
+	if specChanged || len(drift) > 0 {
+		if _, err := sdk.UpsertConsumer(ctx, sdkkonnectgoops.UpsertConsumerRequest{
+			ControlPlaneID: cpID,
+			ConsumerID:     id,
+			Consumer:       input,
+		}); err != nil {
+			SetKonnectEntityProgrammedConditionFalse(consumer, err)
+			return nil, err
+		}
+	}
+
+	SetKonnectEntityProgrammedCondition(consumer)
+	return drift, nil
+}
+
+// deleteConsumer deletes the Konnect Consumer identified by the Konnect ID stored
+// in the provided KongConsumer's status.
+// Konnect Consumer which cannot be found in Konnect is considered deleted.
+func deleteConsumer(
+	ctx context.Context,
+	sdk ConsumersSDK,
+	consumer *configurationv1.KongConsumer,
+) error {
+	id := consumer.Status.Konnect.GetKonnectID()
+	if id == "" {
+		return nil
+	}
+
+	if _, err := sdk.DeleteConsumer(ctx, consumer.Status.Konnect.ControlPlaneID, id); err != nil {
+		if errIsNotFound(err) {
+			return nil
+		}
+		return FailedKonnectOpError[configurationv1.KongConsumer]{
+			Op:  DeleteOp,
+			Err: fmt.Errorf("failed to delete Consumer %s: %w", id, err),
+		}
+	}
+	return nil
+}
+
+func kongConsumerToSDKConsumerInput(
+	consumer *configurationv1.KongConsumer,
+) sdkkonnectgocomp.ConsumerInput {
+	return sdkkonnectgocomp.ConsumerInput{
+		CustomID: lo.EmptyableToPtr(consumer.CustomID),
+		Username: lo.EmptyableToPtr(consumer.Username),
+	}
+}
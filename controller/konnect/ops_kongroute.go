@@ -0,0 +1,267 @@
+package konnect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sdkkonnectgo "github.com/Kong/sdk-konnect-go"
+	sdkkonnectgocomp "github.com/Kong/sdk-konnect-go/models/components"
+	sdkkonnectgoops "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/samber/lo"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configurationv1alpha1 "github.com/kong/kubernetes-configuration/api/configuration/v1alpha1"
+)
+
+// createRoute creates the Konnect Route for the provided KongRoute and stores
+// its Konnect ID and the IDs of its ControlPlane and Service in the KongRoute's status.
+// When the KongRoute is configured to adopt an existing Route, the matching
+// Route is updated instead of creating a new one.
+func createRoute(
+	ctx context.Context,
+	sdk RoutesSDK,
+	cl client.Client,
+	route *configurationv1alpha1.KongRoute,
+) error {
+	if err := resolveRouteRefs(ctx, cl, route); err != nil {
+		SetKonnectEntityProgrammedConditionFalse(route, err)
+		return err
+	}
+	cpID := route.Status.Konnect.ControlPlaneID
+
+	input := kongRouteToSDKRouteInput(route)
+	id, err := findEntityToAdopt(route, input.Name,
+		func(name string) (string, error) {
+			resp, err := sdk.GetRoute(ctx, cpID, name)
+			if err != nil {
+				return "", err
+			}
+			if resp == nil || resp.Route == nil || resp.Route.ID == nil {
+				return "", errors.New("response does not contain the Route ID")
+			}
+			return *resp.Route.ID, nil
+		},
+		func(tag string) ([]string, error) {
+			resp, err := sdk.ListRoute(ctx, sdkkonnectgoops.ListRouteRequest{
+				ControlPlaneID: cpID,
+				Tags:           &tag,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if resp == nil || resp.Object == nil {
+				return nil, nil
+			}
+			return lo.FilterMap(resp.Object.Data, func(e sdkkonnectgocomp.Route, _ int) (string, bool) {
+				return lo.FromPtr(e.ID), e.ID != nil
+			}), nil
+		},
+	)
+	if err != nil {
+		SetKonnectEntityProgrammedConditionFalse(route, err)
+		return err
+	}
+	if id != "" {
+		// Adopt the existing Route, making sure it matches the spec.
+		if _, err := sdk.UpsertRoute(ctx, sdkkonnectgoops.UpsertRouteRequest{
+			ControlPlaneID: cpID,
+			RouteID:        id,
+			Route:          input,
+		}); err != nil {
+			SetKonnectEntityProgrammedConditionFalse(route, err)
+			return err
+		}
+		route.Status.Konnect.SetKonnectID(id)
+		SetKonnectEntityProgrammedCondition(route)
+		return nil
+	}
+
+	resp, err := sdk.CreateRoute(ctx, cpID, input)
+	if err != nil {
+		SetKonnectEntityProgrammedConditionFalse(route, err)
+		return err
+	}
+	if resp == nil || resp.Route == nil || resp.Route.ID == nil {
+		err := errors.New("failed creating Route: response does not contain the Route ID")
+		SetKonnectEntityProgrammedConditionFalse(route, err)
+		return err
+	}
+
+	route.Status.Konnect.SetKonnectID(*resp.Route.ID)
+	SetKonnectEntityProgrammedCondition(route)
+	return nil
+}
+
+// updateRoute compares the Konnect Route identified by the Konnect ID stored
+// in the provided KongRoute's status with the KongRoute's spec and updates
+// it if they differ or if the spec has changed since it was last programmed, so
+// that fields removed from the spec get unset too. Fields which differed are returned.
+// It is assumed that the KongRoute has already been created in Konnect.
+func updateRoute(
+	ctx context.Context,
+	sdk RoutesSDK,
+	cl client.Client,
+	route *configurationv1alpha1.KongRoute,
+	specChanged bool,
+) ([]string, error) {
+	if err := resolveRouteRefs(ctx, cl, route); err != nil {
+		SetKonnectEntityProgrammedConditionFalse(route, err)
+		return nil, err
+	}
+	cpID := route.Status.Konnect.ControlPlaneID
+
+	var (
+		id    = route.Status.Konnect.GetKonnectID()
+		input = kongRouteToSDKRouteInput(route)
+		drift []string
+	)
+	resp, err := sdk.GetRoute(ctx, cpID, id)
+	switch {
+	case errIsNotFound(err) || err == nil && (resp == nil || resp.Route == nil):
+		drift = []string{driftEntityMissing}
+	case err != nil:
+		SetKonnectEntityProgrammedConditionFalse(route, err)
+		return nil, err
+	default:
+		if drift, err = diffEntity(input, resp.Route); err != nil {
+			SetKonnectEntityProgrammedConditionFalse(route, err)
+			return nil, err
+		}
+	}
+
+	// The drift is only found for fields set in the spec, hence the spec is
+	// always applied when it has changed.
+	if specChanged || len(drift) > 0 {
+		if _, err := sdk.UpsertRoute(ctx, sdkkonnectgoops.UpsertRouteRequest{
+			ControlPlaneID: cpID,
+			RouteID:        id,
+			Route:          input,
+		}); err != nil {
+			SetKonnectEntityProgrammedConditionFalse(route, err)
+			return nil, err
+		}
+	}
+
+	SetKonnectEntityProgrammedCondition(route)
+	return drift, nil
+}
+
+// deleteRoute deletes the Konnect Route identified by the Konnect ID stored
+// in the provided KongRoute's status.
+// Konnect Route which cannot be found in Konnect is considered deleted.
+func deleteRoute(
+	ctx context.Context,
+	sdk RoutesSDK,
+	route *configurationv1alpha1.KongRoute,
+) error {
+	id := route.Status.Konnect.GetKonnectID()
+	if id == "" {
+		return nil
+	}
+
+	if _, err := sdk.DeleteRoute(ctx, route.Status.Konnect.ControlPlaneID, id); err != nil {
+		if errIsNotFound(err) {
+			return nil
+		}
+		return FailedKonnectOpError[configurationv1alpha1.KongRoute]{
+			Op:  DeleteOp,
+			Err: fmt.Errorf("failed to delete Route %s: %w", id, err),
+		}
+	}
+	return nil
+}
+
+// resolveRouteRefs resolves the Konnect IDs of the ControlPlane and the KongService
+// referenced by the provided KongRoute and stores them in the KongRoute's status.
+func resolveRouteRefs(
+	ctx context.Context,
+	cl client.Client,
+	route *configurationv1alpha1.KongRoute,
+) error {
+	cpID, err := getControlPlaneID(route.Spec.ControlPlaneRef)
+	if err != nil {
+		return err
+	}
+	route.Status.Konnect.ControlPlaneID = cpID
+
+	ref := route.Spec.ServiceRef
+	switch ref.Type {
+	case "":
+		// Routes in Konnect do not need to be associated with a Service.
+		route.Status.Konnect.ServiceID = ""
+		return nil
+	case configurationv1alpha1.ServiceRefNamespacedRef:
+		if ref.NamespacedRef == nil {
+			return ReferenceResolutionError{
+				Err: fmt.Errorf("Service reference of type %s has no namespaced reference set", ref.Type),
+			}
+		}
+		if ns := ref.NamespacedRef.Namespace; ns != "" && ns != route.Namespace {
+			return ReferenceResolutionError{
+				Err: fmt.Errorf("cross namespace Service reference to %s/%s is not supported", ns, ref.NamespacedRef.Name),
+			}
+		}
+	default:
+		return ReferenceResolutionError{
+			Err: fmt.Errorf("unsupported Service reference type %q", ref.Type),
+		}
+	}
+
+	nn := types.NamespacedName{
+		Name:      ref.NamespacedRef.Name,
+		Namespace: route.Namespace,
+	}
+	var svc configurationv1alpha1.KongService
+	if err := cl.Get(ctx, nn, &svc); err != nil {
+		return ReferenceResolutionError{
+			Err: fmt.Errorf("failed to get KongService %s: %w", nn, err),
+		}
+	}
+
+	if svc.Status.Konnect.GetKonnectID() == "" {
+		return ReferenceResolutionError{
+			Err: fmt.Errorf("KongService %s has not been created in Konnect yet", nn),
+		}
+	}
+	if svc.Status.Konnect.ControlPlaneID != cpID {
+		return ReferenceResolutionError{
+			Err: fmt.Errorf("KongService %s belongs to a different ControlPlane (%s) than the KongRoute (%s)",
+				nn, svc.Status.Konnect.ControlPlaneID, cpID,
+			),
+		}
+	}
+	route.Status.Konnect.ServiceID = svc.Status.Konnect.GetKonnectID()
+	return nil
+}
+
+func kongRouteToSDKRouteInput(
+	route *configurationv1alpha1.KongRoute,
+) sdkkonnectgocomp.RouteInput {
+	input := sdkkonnectgocomp.RouteInput{
+		Destinations:            route.Spec.KongRouteAPISpec.Destinations,
+		Headers:                 route.Spec.KongRouteAPISpec.Headers,
+		Hosts:                   route.Spec.KongRouteAPISpec.Hosts,
+		HTTPSRedirectStatusCode: route.Spec.KongRouteAPISpec.HTTPSRedirectStatusCode,
+		Methods:                 route.Spec.KongRouteAPISpec.Methods,
+		Name:                    route.Spec.KongRouteAPISpec.Name,
+		PathHandling:            route.Spec.KongRouteAPISpec.PathHandling,
+		Paths:                   route.Spec.KongRouteAPISpec.Paths,
+		PreserveHost:            route.Spec.KongRouteAPISpec.PreserveHost,
+		Protocols:               route.Spec.KongRouteAPISpec.Protocols,
+		RegexPriority:           route.Spec.KongRouteAPISpec.RegexPriority,
+		RequestBuffering:        route.Spec.KongRouteAPISpec.RequestBuffering,
+		ResponseBuffering:       route.Spec.KongRouteAPISpec.ResponseBuffering,
+		Snis:                    route.Spec.KongRouteAPISpec.Snis,
+		Sources:                 route.Spec.KongRouteAPISpec.Sources,
+		StripPath:               route.Spec.KongRouteAPISpec.StripPath,
+		Tags:                    route.Spec.KongRouteAPISpec.Tags,
+	}
+	if serviceID := route.Status.Konnect.ServiceID; serviceID != "" {
+		input.Service = &sdkkonnectgocomp.RouteService{
+			ID: sdkkonnectgo.String(serviceID),
+		}
+	}
+	return input
+}
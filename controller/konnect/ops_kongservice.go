@@ -0,0 +1,192 @@
+package konnect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sdkkonnectgocomp "github.com/Kong/sdk-konnect-go/models/components"
+	sdkkonnectgoops "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/samber/lo"
+
+	configurationv1alpha1 "github.com/kong/kubernetes-configuration/api/configuration/v1alpha1"
+)
+
+// createService creates the Konnect Service for the provided KongService and
+// stores its Konnect ID and the ID of its ControlPlane in the KongService's status.
+// When the KongService is configured to adopt an existing Service, the matching
+// Service is updated instead of creating a new one.
+func createService(
+	ctx context.Context,
+	sdk ServicesSDK,
+	svc *configurationv1alpha1.KongService,
+) error {
+	cpID, err := getControlPlaneID(svc.Spec.ControlPlaneRef)
+	if err != nil {
+		SetKonnectEntityProgrammedConditionFalse(svc, err)
+		return err
+	}
+	svc.Status.Konnect.ControlPlaneID = cpID
+
+	input := kongServiceToSDKServiceInput(svc)
+	id, err := findEntityToAdopt(svc, input.Name,
+		func(name string) (string, error) {
+			resp, err := sdk.GetService(ctx, cpID, name)
+			if err != nil {
+				return "", err
+			}
+			if resp == nil || resp.Service == nil || resp.Service.ID == nil {
+				return "", errors.New("response does not contain the Service ID")
+			}
+			return *resp.Service.ID, nil
+		},
+		func(tag string) ([]string, error) {
+			resp, err := sdk.ListService(ctx, sdkkonnectgoops.ListServiceRequest{
+				ControlPlaneID: cpID,
+				Tags:           &tag,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if resp == nil || resp.Object == nil {
+				return nil, nil
+			}
+			return lo.FilterMap(resp.Object.Data, func(s sdkkonnectgocomp.Service, _ int) (string, bool) {
+				return lo.FromPtr(s.ID), s.ID != nil
+			}), nil
+		},
+	)
+	if err != nil {
+		SetKonnectEntityProgrammedConditionFalse(svc, err)
+		return err
+	}
+	if id != "" {
+		// Adopt the existing Service, making sure it matches the spec.
+		if _, err := sdk.UpsertService(ctx, sdkkonnectgoops.UpsertServiceRequest{
+			ControlPlaneID: cpID,
+			ServiceID:      id,
+			Service:        input,
+		}); err != nil {
+			SetKonnectEntityProgrammedConditionFalse(svc, err)
+			return err
+		}
+		svc.Status.Konnect.SetKonnectID(id)
+		SetKonnectEntityProgrammedCondition(svc)
+		return nil
+	}
+
+	resp, err := sdk.CreateService(ctx, cpID, input)
+	if err != nil {
+		SetKonnectEntityProgrammedConditionFalse(svc, err)
+		return err
+	}
+	if resp == nil || resp.Service == nil || resp.Service.ID == nil {
+		err := errors.New("failed creating Service: response does not contain the Service ID")
+		SetKonnectEntityProgrammedConditionFalse(svc, err)
+		return err
+	}
+
+	svc.Status.Konnect.SetKonnectID(*resp.Service.ID)
+	SetKonnectEntityProgrammedCondition(svc)
+	return nil
+}
+
+// updateService compares the Konnect Service identified by the Konnect ID stored
+// in the provided KongService's status with the KongService's spec and updates
+// it if they differ or if the spec has changed since it was last programmed, so
+// that fields removed from the spec get unset too. Fields which differed are returned.
+// It is assumed that the KongService has already been created in Konnect.
+func updateService(
+	ctx context.Context,
+	sdk ServicesSDK,
+	svc *configurationv1alpha1.KongService,
+	specChanged bool,
+) ([]string, error) {
+	cpID, err := getControlPlaneID(svc.Spec.ControlPlaneRef)
+	if err != nil {
+		SetKonnectEntityProgrammedConditionFalse(svc, err)
+		return nil, err
+	}
+	svc.Status.Konnect.ControlPlaneID = cpID
+
+	var (
+		id    = svc.Status.Konnect.GetKonnectID()
+		input = kongServiceToSDKServiceInput(svc)
+		drift []string
+	)
+	resp, err := sdk.GetService(ctx, cpID, id)
+	switch {
+	case errIsNotFound(err) || err == nil && (resp == nil || resp.Service == nil):
+		drift = []string{driftEntityMissing}
+	case err != nil:
+		SetKonnectEntityProgrammedConditionFalse(svc, err)
+		return nil, err
+	default:
+		if drift, err = diffEntity(input, resp.Service); err != nil {
+			SetKonnectEntityProgrammedConditionFalse(svc, err)
+			return nil, err
+		}
+	}
+
+	// The drift is only found for fields set in the spec, hence the spec is
+	// always applied when it has changed.
+	if specChanged || len(drift) > 0 {
+		if _, err := sdk.UpsertService(ctx, sdkkonnectgoops.UpsertServiceRequest{
+			ControlPlaneID: cpID,
+			ServiceID:      id,
+			Service:        input,
+		}); err != nil {
+			SetKonnectEntityProgrammedConditionFalse(svc, err)
+			return nil, err
+		}
+	}
+
+	SetKonnectEntityProgrammedCondition(svc)
+	return drift, nil
+}
+
+// deleteService deletes the Konnect Service identified by the Konnect ID stored
+// in the provided KongService's status.
+// Konnect Service which cannot be found in Konnect is considered deleted.
+func deleteService(
+	ctx context.Context,
+	sdk ServicesSDK,
+	svc *configurationv1alpha1.KongService,
+) error {
+	id := svc.Status.Konnect.GetKonnectID()
+	if id == "" {
+		return nil
+	}
+
+	if _, err := sdk.DeleteService(ctx, svc.Status.Konnect.ControlPlaneID, id); err != nil {
+		if errIsNotFound(err) {
+			return nil
+		}
+		return FailedKonnectOpError[configurationv1alpha1.KongService]{
+			Op:  DeleteOp,
+			Err: fmt.Errorf("failed to delete Service %s: %w", id, err),
+		}
+	}
+	return nil
+}
+
+func kongServiceToSDKServiceInput(
+	svc *configurationv1alpha1.KongService,
+) sdkkonnectgocomp.ServiceInput {
+	return sdkkonnectgocomp.ServiceInput{
+		URL:            svc.Spec.KongServiceAPISpec.URL,
+		ConnectTimeout: svc.Spec.KongServiceAPISpec.ConnectTimeout,
+		Enabled:        svc.Spec.KongServiceAPISpec.Enabled,
+		Host:           svc.Spec.KongServiceAPISpec.Host,
+		Name:           svc.Spec.KongServiceAPISpec.Name,
+		Path:           svc.Spec.KongServiceAPISpec.Path,
+		Port:           svc.Spec.KongServiceAPISpec.Port,
+		Protocol:       svc.Spec.KongServiceAPISpec.Protocol,
+		ReadTimeout:    svc.Spec.KongServiceAPISpec.ReadTimeout,
+		Retries:        svc.Spec.KongServiceAPISpec.Retries,
+		Tags:           svc.Spec.KongServiceAPISpec.Tags,
+		TLSVerify:      svc.Spec.KongServiceAPISpec.TLSVerify,
+		TLSVerifyDepth: svc.Spec.KongServiceAPISpec.TLSVerifyDepth,
+		WriteTimeout:   svc.Spec.KongServiceAPISpec.WriteTimeout,
+	}
+}
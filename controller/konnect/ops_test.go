@@ -0,0 +1,675 @@
+package konnect
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	sdkkonnectgocomp "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kong/gateway-operator/modules/manager/scheme"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+
+	configurationv1 "github.com/kong/kubernetes-configuration/api/configuration/v1"
+	configurationv1alpha1 "github.com/kong/kubernetes-configuration/api/configuration/v1alpha1"
+)
+
+func konnectIDControlPlaneRef(id string) configurationv1alpha1.ControlPlaneRef {
+	return configurationv1alpha1.ControlPlaneRef{
+		Type:      configurationv1alpha1.ControlPlaneRefKonnectID,
+		KonnectID: lo.ToPtr(id),
+	}
+}
+
+func requireProgrammedCondition(
+	t *testing.T,
+	obj k8sutils.ConditionsAware,
+	status metav1.ConditionStatus,
+	reason string,
+) {
+	t.Helper()
+
+	c, ok := k8sutils.GetCondition(KonnectEntityProgrammedConditionType, obj)
+	require.True(t, ok, "Programmed condition not set")
+	assert.Equal(t, status, c.Status)
+	assert.Equal(t, reason, c.Reason)
+}
+
+func TestKongServiceOps(t *testing.T) {
+	ctx := context.Background()
+	cl := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Get()).Build()
+
+	kongService := func() *configurationv1alpha1.KongService {
+		return &configurationv1alpha1.KongService{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  "default",
+				Name:       "svc",
+				Generation: 2,
+			},
+			Spec: configurationv1alpha1.KongServiceSpec{
+				ControlPlaneRef: konnectIDControlPlaneRef("cp-id"),
+				KongServiceAPISpec: configurationv1alpha1.KongServiceAPISpec{
+					Host: "example.com",
+					Name: lo.ToPtr("svc"),
+				},
+			},
+		}
+	}
+	createdKongService := func() *configurationv1alpha1.KongService {
+		svc := kongService()
+		svc.Status.Konnect.SetKonnectID("svc-id")
+		svc.Status.Konnect.ControlPlaneID = "cp-id"
+		return svc
+	}
+
+	t.Run("create", func(t *testing.T) {
+		testCases := []struct {
+			name            string
+			svc             *configurationv1alpha1.KongService
+			createErr       error
+			expectedErr     bool
+			expectedStatus  metav1.ConditionStatus
+			expectedReason  string
+			expectedID      string
+			expectedSDKCall bool
+		}{
+			{
+				name:            "successful create stores Konnect IDs in status",
+				svc:             kongService(),
+				expectedStatus:  metav1.ConditionTrue,
+				expectedReason:  KonnectEntityProgrammedReason,
+				expectedID:      "svc-id",
+				expectedSDKCall: true,
+			},
+			{
+				name:            "conflict is reported in Programmed condition",
+				svc:             kongService(),
+				createErr:       sdkErrorWithStatus(http.StatusConflict),
+				expectedErr:     true,
+				expectedStatus:  metav1.ConditionFalse,
+				expectedReason:  KonnectEntityProgrammedReasonConflict,
+				expectedSDKCall: true,
+			},
+			{
+				name:            "not found is reported in Programmed condition",
+				svc:             kongService(),
+				createErr:       sdkErrorWithStatus(http.StatusNotFound),
+				expectedErr:     true,
+				expectedStatus:  metav1.ConditionFalse,
+				expectedReason:  KonnectEntityProgrammedReasonNotFound,
+				expectedSDKCall: true,
+			},
+			{
+				name:            "other API errors are reported in Programmed condition",
+				svc:             kongService(),
+				createErr:       sdkErrorWithStatus(http.StatusInternalServerError),
+				expectedErr:     true,
+				expectedStatus:  metav1.ConditionFalse,
+				expectedReason:  KonnectEntityProgrammedReasonKonnectAPIOpFailed,
+				expectedSDKCall: true,
+			},
+			{
+				name: "unsupported ControlPlane reference is not sent to Konnect",
+				svc: func() *configurationv1alpha1.KongService {
+					svc := kongService()
+					svc.Spec.ControlPlaneRef = configurationv1alpha1.ControlPlaneRef{
+						Type: configurationv1alpha1.ControlPlaneRefKIC,
+					}
+					return svc
+				}(),
+				expectedErr:    true,
+				expectedStatus: metav1.ConditionFalse,
+				expectedReason: KonnectEntityProgrammedReasonFailedToResolveRefs,
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				sdk := newFakeSDK()
+				sdk.services.createdID = "svc-id"
+				sdk.services.create.err = tc.createErr
+
+				_, err := Create[configurationv1alpha1.KongService](ctx, sdk, logr.Discard(), cl, tc.svc)
+				if tc.expectedErr {
+					require.Error(t, err)
+				} else {
+					require.NoError(t, err)
+				}
+
+				requireProgrammedCondition(t, tc.svc, tc.expectedStatus, tc.expectedReason)
+				assert.Equal(t, tc.expectedID, tc.svc.Status.Konnect.GetKonnectID())
+				assert.Equal(t, tc.expectedSDKCall, sdk.services.create.called)
+				if tc.expectedSDKCall {
+					assert.Equal(t, "cp-id", sdk.services.create.controlPlaneID)
+					assert.Equal(t, "cp-id", tc.svc.Status.Konnect.ControlPlaneID)
+					assert.Equal(t, "example.com", sdk.services.lastInput.Host)
+				}
+			})
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		sdk := newFakeSDK()
+		svc := createdKongService()
+		_, err := Update[configurationv1alpha1.KongService](ctx, sdk, logr.Discard(), cl, svc)
+		require.NoError(t, err)
+		requireProgrammedCondition(t, svc, metav1.ConditionTrue, KonnectEntityProgrammedReason)
+		assert.True(t, sdk.services.upsert.called)
+
+		sdk = newFakeSDK()
+		sdk.services.upsert.err = sdkErrorWithStatus(http.StatusNotFound)
+		svc = createdKongService()
+		_, err = Update[configurationv1alpha1.KongService](ctx, sdk, logr.Discard(), cl, svc)
+		require.Error(t, err)
+		requireProgrammedCondition(t, svc, metav1.ConditionFalse, KonnectEntityProgrammedReasonNotFound)
+	})
+
+	t.Run("update is skipped within sync period for programmed entity", func(t *testing.T) {
+		sdk := newFakeSDK()
+		svc := createdKongService()
+		SetKonnectEntityProgrammedCondition(svc)
+		res, err := Update[configurationv1alpha1.KongService](ctx, sdk, logr.Discard(), cl, svc)
+		require.NoError(t, err)
+		assert.Positive(t, res.RequeueAfter)
+		assert.False(t, sdk.services.upsert.called)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		testCases := []struct {
+			name            string
+			svc             *configurationv1alpha1.KongService
+			deleteErr       error
+			expectedErr     bool
+			expectedSDKCall bool
+		}{
+			{
+				name:            "successful delete",
+				svc:             createdKongService(),
+				expectedSDKCall: true,
+			},
+			{
+				name:            "entity not found in Konnect is considered deleted",
+				svc:             createdKongService(),
+				deleteErr:       sdkErrorWithStatus(http.StatusNotFound),
+				expectedSDKCall: true,
+			},
+			{
+				name:            "API errors are returned",
+				svc:             createdKongService(),
+				deleteErr:       sdkErrorWithStatus(http.StatusInternalServerError),
+				expectedErr:     true,
+				expectedSDKCall: true,
+			},
+			{
+				name: "entity never created in Konnect is not deleted",
+				svc:  kongService(),
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				sdk := newFakeSDK()
+				sdk.services.delete.err = tc.deleteErr
+
+				err := Delete[configurationv1alpha1.KongService](ctx, sdk, logr.Discard(), cl, tc.svc)
+				if tc.expectedErr {
+					require.Error(t, err)
+				} else {
+					require.NoError(t, err)
+				}
+				assert.Equal(t, tc.expectedSDKCall, sdk.services.delete.called)
+			})
+		}
+	})
+}
+
+func TestKongServiceDriftDetection(t *testing.T) {
+	ctx := context.Background()
+	cl := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Get()).Build()
+
+	programmedKongService := func() *configurationv1alpha1.KongService {
+		svc := &configurationv1alpha1.KongService{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  "default",
+				Name:       "svc",
+				Generation: 1,
+			},
+			Spec: configurationv1alpha1.KongServiceSpec{
+				ControlPlaneRef: konnectIDControlPlaneRef("cp-id"),
+				KongServiceAPISpec: configurationv1alpha1.KongServiceAPISpec{
+					Host: "example.com",
+				},
+			},
+		}
+		svc.Status.Konnect.SetKonnectID("svc-id")
+		svc.Status.Konnect.ControlPlaneID = "cp-id"
+		// Programmed condition older than the sync period so that the entity
+		// gets synchronized.
+		svc.Status.Conditions = []metav1.Condition{
+			{
+				Type:               KonnectEntityProgrammedConditionType,
+				Status:             metav1.ConditionTrue,
+				Reason:             KonnectEntityProgrammedReason,
+				ObservedGeneration: 1,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * configurableSyncPeriod)),
+			},
+		}
+		return svc
+	}
+
+	testCases := []struct {
+		name              string
+		svc               *configurationv1alpha1.KongService
+		remote            *sdkkonnectgocomp.Service
+		getErr            error
+		expectedUpsert    bool
+		expectedStatus    metav1.ConditionStatus
+		expectedReason    string
+		expectedInMessage string
+	}{
+		{
+			name: "entity matching the spec is not updated",
+			svc:  programmedKongService(),
+			remote: &sdkkonnectgocomp.Service{
+				ID:   lo.ToPtr("svc-id"),
+				Host: "example.com",
+			},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: KonnectEntityDriftDetectedReasonNoDrift,
+		},
+		{
+			name: "entity modified in Konnect is updated and drift is reported",
+			svc:  programmedKongService(),
+			remote: &sdkkonnectgocomp.Service{
+				ID:   lo.ToPtr("svc-id"),
+				Host: "example.org",
+				Tags: []string{"ui"},
+			},
+			expectedUpsert:    true,
+			expectedStatus:    metav1.ConditionTrue,
+			expectedReason:    KonnectEntityDriftDetectedReasonDriftCorrected,
+			expectedInMessage: "fields: host,",
+		},
+		{
+			name: "Konnect defaults of fields unset in the spec are not reported",
+			svc:  programmedKongService(),
+			remote: &sdkkonnectgocomp.Service{
+				ID:             lo.ToPtr("svc-id"),
+				Host:           "example.com",
+				ConnectTimeout: lo.ToPtr(int64(60000)),
+				Retries:        lo.ToPtr(int64(5)),
+			},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: KonnectEntityDriftDetectedReasonNoDrift,
+		},
+		{
+			name:              "entity deleted in Konnect is re-created and drift is reported",
+			svc:               programmedKongService(),
+			getErr:            sdkErrorWithStatus(http.StatusNotFound),
+			expectedUpsert:    true,
+			expectedStatus:    metav1.ConditionTrue,
+			expectedReason:    KonnectEntityDriftDetectedReasonDriftCorrected,
+			expectedInMessage: "re-created",
+		},
+		{
+			name: "spec change is not reported as drift",
+			svc: func() *configurationv1alpha1.KongService {
+				svc := programmedKongService()
+				svc.Generation = 2
+				svc.Spec.Host = "example.net"
+				return svc
+			}(),
+			remote: &sdkkonnectgocomp.Service{
+				ID:   lo.ToPtr("svc-id"),
+				Host: "example.com",
+			},
+			expectedUpsert: true,
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: KonnectEntityDriftDetectedReasonNoDrift,
+		},
+		{
+			name: "field removed from the spec is unset in Konnect",
+			svc: func() *configurationv1alpha1.KongService {
+				svc := programmedKongService()
+				svc.Generation = 2
+				return svc
+			}(),
+			remote: &sdkkonnectgocomp.Service{
+				ID:   lo.ToPtr("svc-id"),
+				Host: "example.com",
+				Path: lo.ToPtr("/removed"),
+			},
+			expectedUpsert: true,
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: KonnectEntityDriftDetectedReasonNoDrift,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sdk := newFakeSDK()
+			sdk.services.remote = tc.remote
+			sdk.services.get.err = tc.getErr
+
+			_, err := Update[configurationv1alpha1.KongService](ctx, sdk, logr.Discard(), cl, tc.svc)
+			require.NoError(t, err)
+
+			assert.Equal(t, "svc-id", sdk.services.lastGetID)
+			assert.Equal(t, tc.expectedUpsert, sdk.services.upsert.called)
+			requireProgrammedCondition(t, tc.svc, metav1.ConditionTrue, KonnectEntityProgrammedReason)
+
+			c, ok := k8sutils.GetCondition(KonnectEntityDriftDetectedConditionType, tc.svc)
+			require.True(t, ok, "DriftDetected condition not set")
+			assert.Equal(t, tc.expectedStatus, c.Status)
+			assert.Equal(t, tc.expectedReason, c.Reason)
+			assert.Contains(t, c.Message, tc.expectedInMessage)
+		})
+	}
+}
+
+func TestKongServiceAdoption(t *testing.T) {
+	ctx := context.Background()
+	cl := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Get()).Build()
+
+	kongService := func(annotations map[string]string) *configurationv1alpha1.KongService {
+		return &configurationv1alpha1.KongService{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "svc",
+				Annotations: annotations,
+			},
+			Spec: configurationv1alpha1.KongServiceSpec{
+				ControlPlaneRef: konnectIDControlPlaneRef("cp-id"),
+				KongServiceAPISpec: configurationv1alpha1.KongServiceAPISpec{
+					Host: "example.com",
+					Name: lo.ToPtr("svc-name"),
+				},
+			},
+		}
+	}
+	remoteService := func(id string) sdkkonnectgocomp.Service {
+		return sdkkonnectgocomp.Service{ID: lo.ToPtr(id), Host: "example.com"}
+	}
+
+	testCases := []struct {
+		name           string
+		svc            *configurationv1alpha1.KongService
+		remote         *sdkkonnectgocomp.Service
+		getErr         error
+		listed         []sdkkonnectgocomp.Service
+		expectedErr    bool
+		expectedReason string
+		expectedID     string
+		expectedCreate bool
+	}{
+		{
+			name: "entity with matching name is adopted",
+			svc: kongService(map[string]string{
+				KonnectAdoptionPolicyAnnotation: string(AdoptionPolicyName),
+			}),
+			remote:         lo.ToPtr(remoteService("existing-id")),
+			expectedReason: KonnectEntityProgrammedReason,
+			expectedID:     "existing-id",
+		},
+		{
+			name: "entity is created when no entity with matching name exists",
+			svc: kongService(map[string]string{
+				KonnectAdoptionPolicyAnnotation: string(AdoptionPolicyName),
+			}),
+			getErr:         sdkErrorWithStatus(http.StatusNotFound),
+			expectedReason: KonnectEntityProgrammedReason,
+			expectedID:     "created-id",
+			expectedCreate: true,
+		},
+		{
+			name: "entity with matching tag is adopted",
+			svc: kongService(map[string]string{
+				KonnectAdoptionPolicyAnnotation: string(AdoptionPolicyTag),
+				KonnectAdoptionTagAnnotation:    "team-a",
+			}),
+			listed:         []sdkkonnectgocomp.Service{remoteService("tagged-id")},
+			expectedReason: KonnectEntityProgrammedReason,
+			expectedID:     "tagged-id",
+		},
+		{
+			name: "multiple entities with matching tag cannot be adopted",
+			svc: kongService(map[string]string{
+				KonnectAdoptionPolicyAnnotation: string(AdoptionPolicyTag),
+				KonnectAdoptionTagAnnotation:    "team-a",
+			}),
+			listed: []sdkkonnectgocomp.Service{
+				remoteService("tagged-id-1"),
+				remoteService("tagged-id-2"),
+			},
+			expectedErr:    true,
+			expectedReason: KonnectEntityProgrammedReasonAdoptionFailed,
+		},
+		{
+			name: "adoption by tag requires the tag annotation",
+			svc: kongService(map[string]string{
+				KonnectAdoptionPolicyAnnotation: string(AdoptionPolicyTag),
+			}),
+			expectedErr:    true,
+			expectedReason: KonnectEntityProgrammedReasonAdoptionFailed,
+		},
+		{
+			name: "unsupported adoption policy is rejected",
+			svc: kongService(map[string]string{
+				KonnectAdoptionPolicyAnnotation: "uid",
+			}),
+			expectedErr:    true,
+			expectedReason: KonnectEntityProgrammedReasonAdoptionFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sdk := newFakeSDK()
+			sdk.services.createdID = "created-id"
+			sdk.services.remote = tc.remote
+			sdk.services.get.err = tc.getErr
+			sdk.services.listed = tc.listed
+
+			_, err := Create[configurationv1alpha1.KongService](ctx, sdk, logr.Discard(), cl, tc.svc)
+			if tc.expectedErr {
+				require.Error(t, err)
+				requireProgrammedCondition(t, tc.svc, metav1.ConditionFalse, tc.expectedReason)
+				assert.False(t, sdk.services.create.called)
+				assert.False(t, sdk.services.upsert.called)
+				return
+			}
+
+			require.NoError(t, err)
+			requireProgrammedCondition(t, tc.svc, metav1.ConditionTrue, tc.expectedReason)
+			assert.Equal(t, tc.expectedID, tc.svc.Status.Konnect.GetKonnectID())
+			assert.Equal(t, tc.expectedCreate, sdk.services.create.called)
+			assert.Equal(t, !tc.expectedCreate, sdk.services.upsert.called)
+			if sdk.services.get.called {
+				assert.Equal(t, "svc-name", sdk.services.lastGetID)
+			}
+		})
+	}
+}
+
+func TestKongRouteOps(t *testing.T) {
+	ctx := context.Background()
+
+	kongRoute := func() *configurationv1alpha1.KongRoute {
+		return &configurationv1alpha1.KongRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "route",
+			},
+			Spec: configurationv1alpha1.KongRouteSpec{
+				ControlPlaneRef: konnectIDControlPlaneRef("cp-id"),
+				ServiceRef: configurationv1alpha1.ServiceRef{
+					Type: configurationv1alpha1.ServiceRefNamespacedRef,
+					NamespacedRef: &configurationv1alpha1.NamespacedServiceRef{
+						Name: "svc",
+					},
+				},
+				KongRouteAPISpec: configurationv1alpha1.KongRouteAPISpec{
+					Paths: []string{"/"},
+				},
+			},
+		}
+	}
+	kongService := func(konnectID, cpID string) *configurationv1alpha1.KongService {
+		svc := &configurationv1alpha1.KongService{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "svc",
+			},
+		}
+		svc.Status.Konnect.SetKonnectID(konnectID)
+		svc.Status.Konnect.ControlPlaneID = cpID
+		return svc
+	}
+
+	testCases := []struct {
+		name              string
+		route             *configurationv1alpha1.KongRoute
+		objects           []client.Object
+		expectedErr       bool
+		expectedReason    string
+		expectedServiceID string
+	}{
+		{
+			name:              "route referencing programmed KongService is created",
+			route:             kongRoute(),
+			objects:           []client.Object{kongService("svc-id", "cp-id")},
+			expectedReason:    KonnectEntityProgrammedReason,
+			expectedServiceID: "svc-id",
+		},
+		{
+			name: "route without service reference is created",
+			route: func() *configurationv1alpha1.KongRoute {
+				route := kongRoute()
+				route.Spec.ServiceRef = configurationv1alpha1.ServiceRef{}
+				return route
+			}(),
+			expectedReason: KonnectEntityProgrammedReason,
+		},
+		{
+			name:           "route referencing non existing KongService is not created",
+			route:          kongRoute(),
+			expectedErr:    true,
+			expectedReason: KonnectEntityProgrammedReasonFailedToResolveRefs,
+		},
+		{
+			name:           "route referencing KongService not created in Konnect is not created",
+			route:          kongRoute(),
+			objects:        []client.Object{kongService("", "")},
+			expectedErr:    true,
+			expectedReason: KonnectEntityProgrammedReasonFailedToResolveRefs,
+		},
+		{
+			name: "route referencing KongService from a different namespace is not created",
+			route: func() *configurationv1alpha1.KongRoute {
+				route := kongRoute()
+				route.Spec.ServiceRef.NamespacedRef.Namespace = "other"
+				return route
+			}(),
+			objects:        []client.Object{kongService("svc-id", "cp-id")},
+			expectedErr:    true,
+			expectedReason: KonnectEntityProgrammedReasonFailedToResolveRefs,
+		},
+		{
+			name:           "route referencing KongService from a different ControlPlane is not created",
+			route:          kongRoute(),
+			objects:        []client.Object{kongService("svc-id", "other-cp-id")},
+			expectedErr:    true,
+			expectedReason: KonnectEntityProgrammedReasonFailedToResolveRefs,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cl := fakectrlruntimeclient.NewClientBuilder().
+				WithScheme(scheme.Get()).
+				WithObjects(tc.objects...).
+				Build()
+			sdk := newFakeSDK()
+			sdk.routes.createdID = "route-id"
+
+			_, err := Create[configurationv1alpha1.KongRoute](ctx, sdk, logr.Discard(), cl, tc.route)
+			if tc.expectedErr {
+				require.Error(t, err)
+				requireProgrammedCondition(t, tc.route, metav1.ConditionFalse, tc.expectedReason)
+				assert.False(t, sdk.routes.create.called)
+				return
+			}
+
+			require.NoError(t, err)
+			requireProgrammedCondition(t, tc.route, metav1.ConditionTrue, tc.expectedReason)
+			assert.Equal(t, "route-id", tc.route.Status.Konnect.GetKonnectID())
+			assert.Equal(t, "cp-id", tc.route.Status.Konnect.ControlPlaneID)
+			assert.Equal(t, tc.expectedServiceID, tc.route.Status.Konnect.ServiceID)
+			if tc.expectedServiceID != "" {
+				require.NotNil(t, sdk.routes.lastInput.Service)
+				assert.Equal(t, tc.expectedServiceID, *sdk.routes.lastInput.Service.ID)
+			} else {
+				assert.Nil(t, sdk.routes.lastInput.Service)
+			}
+		})
+	}
+}
+
+func TestKongConsumerOps(t *testing.T) {
+	ctx := context.Background()
+	cl := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Get()).Build()
+
+	consumer := &configurationv1.KongConsumer{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "consumer",
+		},
+		Username: "user",
+		Spec: configurationv1.KongConsumerSpec{
+			ControlPlaneRef: konnectIDControlPlaneRef("cp-id"),
+		},
+	}
+
+	sdk := newFakeSDK()
+	sdk.consumers.createdID = "consumer-id"
+	_, err := Create[configurationv1.KongConsumer](ctx, sdk, logr.Discard(), cl, consumer)
+	require.NoError(t, err)
+	requireProgrammedCondition(t, consumer, metav1.ConditionTrue, KonnectEntityProgrammedReason)
+	assert.Equal(t, "consumer-id", consumer.Status.Konnect.GetKonnectID())
+	assert.Equal(t, "cp-id", consumer.Status.Konnect.ControlPlaneID)
+	assert.Equal(t, lo.ToPtr("user"), sdk.consumers.lastInput.Username)
+	assert.Nil(t, sdk.consumers.lastInput.CustomID)
+
+	sdk.consumers.upsert.err = sdkErrorWithStatus(http.StatusConflict)
+	consumer.Status.Conditions = nil
+	_, err = Update[configurationv1.KongConsumer](ctx, sdk, logr.Discard(), cl, consumer)
+	require.Error(t, err)
+	requireProgrammedCondition(t, consumer, metav1.ConditionFalse, KonnectEntityProgrammedReasonConflict)
+
+	sdk.consumers.delete.err = sdkErrorWithStatus(http.StatusNotFound)
+	require.NoError(t, Delete[configurationv1.KongConsumer](ctx, sdk, logr.Discard(), cl, consumer))
+	assert.True(t, sdk.consumers.delete.called)
+}
+
+func TestProgrammedConditionReasonForError(t *testing.T) {
+	assert.Equal(t,
+		consts.ConditionReason(KonnectEntityProgrammedReasonFailedToResolveRefs),
+		programmedConditionReasonForError(FailedKonnectOpError[configurationv1alpha1.KongRoute]{
+			Op:  CreateOp,
+			Err: ReferenceResolutionError{Err: assert.AnError},
+		}),
+	)
+	assert.Equal(t,
+		consts.ConditionReason(KonnectEntityProgrammedReasonKonnectAPIOpFailed),
+		programmedConditionReasonForError(assert.AnError),
+	)
+}
@@ -0,0 +1,571 @@
+package konnect
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	sdkkonnectgocomp "github.com/Kong/sdk-konnect-go/models/components"
+	sdkkonnectgoops "github.com/Kong/sdk-konnect-go/models/operations"
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/pkg/log"
+	operatorerrors "github.com/kong/gateway-operator/internal/errors"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+	k8sresources "github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
+
+	konnectv1alpha1 "github.com/kong/kubernetes-configuration/api/konnect/v1alpha1"
+)
+
+// KonnectDataPlaneReconciler registers the client certificates of DataPlanes
+// configured to connect to a Konnect control plane in hybrid mode.
+// It generates the certificate, registers it with the control plane and stores
+// it, along with the control plane's cluster endpoints, in a Secret which is
+// used by the DataPlane controller to configure the DataPlane's Deployment.
+// The certificate is deregistered when the DataPlane is deleted or it no longer
+// references the control plane.
+type KonnectDataPlaneReconciler struct {
+	SDKCache        *SDKCache
+	DevelopmentMode bool
+	Client          client.Client
+}
+
+// NewKonnectDataPlaneReconciler creates a new KonnectDataPlaneReconciler.
+func NewKonnectDataPlaneReconciler(
+	sdkCache *SDKCache,
+	developmentMode bool,
+	client client.Client,
+) *KonnectDataPlaneReconciler {
+	return &KonnectDataPlaneReconciler{
+		SDKCache:        sdkCache,
+		DevelopmentMode: developmentMode,
+		Client:          client,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KonnectDataPlaneReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&operatorv1beta1.DataPlane{},
+			builder.WithPredicates(
+				predicate.GenerationChangedPredicate{},
+				dataPlaneKonnectPredicate(),
+			),
+		).
+		Owns(&corev1.Secret{}).
+		Watches(
+			&konnectv1alpha1.KonnectAPIAuthConfiguration{},
+			handler.EnqueueRequestsFromMapFunc(
+				enqueueDataPlaneForKonnectAPIAuthConfiguration(mgr.GetClient()),
+			),
+		).
+		Named("KonnectDataPlane").
+		Complete(r)
+}
+
+// Reconcile reconciles the Konnect client certificate of a DataPlane.
+func (r *KonnectDataPlaneReconciler) Reconcile(
+	ctx context.Context, req ctrl.Request,
+) (ctrl.Result, error) {
+	logger := log.GetLogger(ctx, "KonnectDataPlane", r.DevelopmentMode)
+
+	var dataplane operatorv1beta1.DataPlane
+	if err := r.Client.Get(ctx, req.NamespacedName, &dataplane); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	secrets, err := listKonnectDataPlaneCertificateSecrets(ctx, r.Client, &dataplane)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	konnectOpts := dataplane.Spec.Konnect
+	if !dataplane.DeletionTimestamp.IsZero() || konnectOpts == nil {
+		if len(secrets) > 0 {
+			log.Debug(logger, "deregistering Konnect DataPlane certificates", &dataplane)
+		}
+		for i := range secrets {
+			if err := r.deleteCertificate(ctx, logger, &dataplane, &secrets[i]); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		if controllerutil.RemoveFinalizer(&dataplane, KonnectCleanupFinalizer) {
+			if err := r.Client.Update(ctx, &dataplane); err != nil {
+				if k8serrors.IsConflict(err) {
+					return ctrl.Result{Requeue: true}, nil
+				}
+				return ctrl.Result{}, fmt.Errorf("failed to remove finalizer %s: %w", KonnectCleanupFinalizer, err)
+			}
+		}
+		if dataplane.DeletionTimestamp.IsZero() {
+			return ctrl.Result{}, r.removeCertificateRegisteredCondition(ctx, &dataplane)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if controllerutil.AddFinalizer(&dataplane, KonnectCleanupFinalizer) {
+		if err := r.Client.Update(ctx, &dataplane); err != nil {
+			if k8serrors.IsConflict(err) {
+				return ctrl.Result{Requeue: true}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("failed to add finalizer %s: %w", KonnectCleanupFinalizer, err)
+		}
+		// NOTE: we requeue here because the finalizer doesn't change the DataPlane's
+		// generation and hence its update is filtered out by the predicates.
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// Certificates registered with a different control plane or using a different
+	// KonnectAPIAuthConfiguration are deregistered so that a new one gets registered.
+	// If there's more than one Secret, all but the first one are removed.
+	var secret *corev1.Secret
+	for i := range secrets {
+		s := &secrets[i]
+		if secret == nil &&
+			s.Annotations[consts.DataPlaneKonnectControlPlaneIDAnnotation] == konnectOpts.ControlPlaneID &&
+			s.Annotations[consts.DataPlaneKonnectAPIAuthConfigurationAnnotation] == konnectOpts.APIAuthConfigurationRef.Name {
+			secret = s
+			continue
+		}
+		log.Debug(logger, "deregistering outdated Konnect DataPlane certificate", &dataplane, "secret", s.Name)
+		if err := r.deleteCertificate(ctx, logger, &dataplane, s); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	sdk, err := r.sdkForAPIAuthConfiguration(ctx, dataplane.Namespace, konnectOpts.APIAuthConfigurationRef.Name)
+	if err != nil {
+		var errRef ReferenceResolutionError
+		if errors.As(err, &errRef) {
+			// The DataPlane gets reconciled again when the KonnectAPIAuthConfiguration changes.
+			log.Info(logger, "cannot register Konnect DataPlane certificate", &dataplane, "reason", err.Error())
+			return ctrl.Result{}, r.ensureCertificateRegisteredCondition(ctx, &dataplane, metav1.ConditionFalse,
+				consts.DataPlaneConditionReasonKonnectCertificateRegistrationFailed, err.Error())
+		}
+		return ctrl.Result{}, err
+	}
+
+	res, err := r.ensureCertificate(ctx, logger, &dataplane, secret, sdk)
+	if err != nil {
+		if errCond := r.ensureCertificateRegisteredCondition(ctx, &dataplane, metav1.ConditionFalse,
+			consts.DataPlaneConditionReasonKonnectCertificateRegistrationFailed, err.Error()); errCond != nil {
+			return ctrl.Result{}, errors.Join(err, errCond)
+		}
+		return ctrl.Result{}, err
+	}
+	if err := r.ensureCertificateRegisteredCondition(ctx, &dataplane, metav1.ConditionTrue,
+		consts.DataPlaneConditionReasonKonnectCertificateRegistered, ""); err != nil {
+		return ctrl.Result{}, err
+	}
+	return res, nil
+}
+
+// ensureCertificate registers the DataPlane's client certificate stored in
+// the provided Secret with the Konnect control plane, generating the Secret
+// when it doesn't exist yet, and stores the control plane's endpoints in it.
+func (r *KonnectDataPlaneReconciler) ensureCertificate(
+	ctx context.Context,
+	logger logr.Logger,
+	dataplane *operatorv1beta1.DataPlane,
+	secret *corev1.Secret,
+	sdk SDKWrapper,
+) (ctrl.Result, error) {
+	konnectOpts := dataplane.Spec.Konnect
+	var err error
+
+	if secret == nil {
+		secret, err = generateKonnectDataPlaneCertificateSecret(dataplane)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.Client.Create(ctx, secret); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed creating Konnect certificate Secret for DataPlane %s: %w", dataplane.Name, err)
+		}
+		log.Debug(logger, "created Konnect DataPlane certificate Secret", dataplane, "secret", secret.Name)
+	}
+
+	old := secret.DeepCopy()
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	if secret.Annotations[consts.DataPlaneKonnectCertificateIDAnnotation] == "" {
+		// The certificate might have been registered already without its ID being
+		// stored, e.g. when updating the Secret failed. Reuse it not to leak it.
+		id, err := findRegisteredCertificateID(ctx, sdk, konnectOpts.ControlPlaneID, string(secret.Data[consts.TLSCRT]))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if id != "" {
+			log.Debug(logger, "found registered Konnect DataPlane certificate", dataplane, "controlPlaneID", konnectOpts.ControlPlaneID, "certificateID", id)
+			secret.Annotations[consts.DataPlaneKonnectCertificateIDAnnotation] = id
+		}
+	}
+	if secret.Annotations[consts.DataPlaneKonnectCertificateIDAnnotation] == "" {
+		resp, err := sdk.GetDataPlaneCertificatesSDK().CreateDataplaneCertificate(ctx, konnectOpts.ControlPlaneID,
+			&sdkkonnectgocomp.DataPlaneClientCertificateRequest{
+				Cert: string(secret.Data[consts.TLSCRT]),
+			},
+		)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed registering Konnect certificate of DataPlane %s: %w", dataplane.Name, err)
+		}
+		id := resp.GetDataPlaneClientCertificate().GetItem().GetID()
+		if id == nil || *id == "" {
+			return ctrl.Result{}, fmt.Errorf("failed registering Konnect certificate of DataPlane %s: no certificate ID in response", dataplane.Name)
+		}
+		log.Info(logger, "registered Konnect DataPlane certificate", dataplane, "controlPlaneID", konnectOpts.ControlPlaneID, "certificateID", *id)
+		secret.Annotations[consts.DataPlaneKonnectCertificateIDAnnotation] = *id
+	}
+
+	cpResp, err := sdk.GetControlPlanesSDK().GetControlPlane(ctx, konnectOpts.ControlPlaneID)
+	if err != nil {
+		// Store the certificate ID even if the control plane can't be retrieved,
+		// so that the certificate isn't registered again.
+		if errUpdate := r.updateSecret(ctx, old, secret); errUpdate != nil {
+			return ctrl.Result{}, errUpdate
+		}
+		return ctrl.Result{}, fmt.Errorf("failed getting Konnect control plane %s: %w", konnectOpts.ControlPlaneID, err)
+	}
+	cpConfig := cpResp.GetControlPlane().GetConfig()
+	if endpoint := cpConfig.GetControlPlaneEndpoint(); endpoint != nil {
+		secret.Annotations[consts.DataPlaneKonnectControlPlaneEndpointAnnotation] = *endpoint
+	}
+	if endpoint := cpConfig.GetTelemetryEndpoint(); endpoint != nil {
+		secret.Annotations[consts.DataPlaneKonnectTelemetryEndpointAnnotation] = *endpoint
+	}
+	if err := r.updateSecret(ctx, old, secret); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// NOTE: We requeue here to pick up changes of the control plane's endpoints.
+	// Konnect does not allow subscribing to changes so we need to poll them.
+	return ctrl.Result{
+		RequeueAfter: configurableSyncPeriod,
+	}, nil
+}
+
+// updateSecret updates the provided Secret if it differs from its old version.
+func (r *KonnectDataPlaneReconciler) updateSecret(ctx context.Context, old, secret *corev1.Secret) error {
+	if reflect.DeepEqual(old.Annotations, secret.Annotations) {
+		return nil
+	}
+	if err := r.Client.Update(ctx, secret); err != nil {
+		return fmt.Errorf("failed updating Konnect certificate Secret %s: %w", secret.Name, err)
+	}
+	return nil
+}
+
+// ensureCertificateRegisteredCondition sets the DataPlane's KonnectCertificateRegistered
+// condition and patches its status when the condition changes.
+func (r *KonnectDataPlaneReconciler) ensureCertificateRegisteredCondition(
+	ctx context.Context,
+	dataplane *operatorv1beta1.DataPlane,
+	status metav1.ConditionStatus,
+	reason consts.ConditionReason,
+	message string,
+) error {
+	old := dataplane.DeepCopy()
+	k8sutils.SetCondition(
+		k8sutils.NewConditionWithGeneration(
+			consts.DataPlaneConditionTypeKonnectCertificateRegistered,
+			status,
+			reason,
+			message,
+			dataplane.Generation,
+		),
+		dataplane,
+	)
+	if !k8sutils.NeedsUpdate(old, dataplane) {
+		return nil
+	}
+	if err := r.Client.Status().Patch(ctx, dataplane, client.MergeFrom(old)); err != nil {
+		return fmt.Errorf("failed patching Konnect certificate status of DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+	return nil
+}
+
+// removeCertificateRegisteredCondition removes the DataPlane's KonnectCertificateRegistered
+// condition once it's no longer configured to connect to Konnect.
+func (r *KonnectDataPlaneReconciler) removeCertificateRegisteredCondition(
+	ctx context.Context,
+	dataplane *operatorv1beta1.DataPlane,
+) error {
+	if _, ok := k8sutils.GetCondition(consts.DataPlaneConditionTypeKonnectCertificateRegistered, dataplane); !ok {
+		return nil
+	}
+	old := dataplane.DeepCopy()
+	dataplane.Status.Conditions = lo.Reject(dataplane.Status.Conditions, func(c metav1.Condition, _ int) bool {
+		return c.Type == string(consts.DataPlaneConditionTypeKonnectCertificateRegistered)
+	})
+	if err := r.Client.Status().Patch(ctx, dataplane, client.MergeFrom(old)); err != nil {
+		return fmt.Errorf("failed patching Konnect certificate status of DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+	return nil
+}
+
+// findRegisteredCertificateID returns the ID of the provided certificate when
+// it's already registered with the Konnect control plane or an empty string otherwise.
+func findRegisteredCertificateID(
+	ctx context.Context,
+	sdk SDKWrapper,
+	controlPlaneID string,
+	cert string,
+) (string, error) {
+	resp, err := sdk.GetDataPlaneCertificatesSDK().ListDpClientCertificates(ctx, sdkkonnectgoops.ListDpClientCertificatesRequest{
+		ControlPlaneID: controlPlaneID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed listing Konnect certificates of control plane %s: %w", controlPlaneID, err)
+	}
+	for _, c := range resp.GetListDataPlaneCertificatesResponse().GetItems() {
+		item := c.GetItem()
+		if id := item.GetID(); id != nil && strings.TrimSpace(lo.FromPtr(item.GetCert())) == strings.TrimSpace(cert) {
+			return *id, nil
+		}
+	}
+	return "", nil
+}
+
+// deleteCertificate deregisters the certificate stored in the provided Secret
+// from the Konnect control plane it has been registered with and deletes the Secret.
+// When the KonnectAPIAuthConfiguration used to register the certificate doesn't
+// exist anymore or is invalid the certificate can't be deregistered and the Secret
+// is deleted right away, not to block the deletion of the DataPlane.
+func (r *KonnectDataPlaneReconciler) deleteCertificate(
+	ctx context.Context,
+	logger logr.Logger,
+	dataplane *operatorv1beta1.DataPlane,
+	secret *corev1.Secret,
+) error {
+	var (
+		certID = secret.Annotations[consts.DataPlaneKonnectCertificateIDAnnotation]
+		cpID   = secret.Annotations[consts.DataPlaneKonnectControlPlaneIDAnnotation]
+	)
+	if certID != "" {
+		sdk, err := r.sdkForAPIAuthConfiguration(ctx, secret.Namespace, secret.Annotations[consts.DataPlaneKonnectAPIAuthConfigurationAnnotation])
+		if err != nil {
+			var errRef ReferenceResolutionError
+			if !errors.As(err, &errRef) {
+				return err
+			}
+			log.Info(logger, "cannot deregister Konnect DataPlane certificate", dataplane,
+				"controlPlaneID", cpID, "certificateID", certID, "reason", err.Error())
+		} else {
+			_, err := sdk.GetDataPlaneCertificatesSDK().DeleteDataplaneCertificate(ctx, cpID, certID)
+			if err != nil && !errIsNotFound(err) {
+				return fmt.Errorf("failed deregistering Konnect certificate %s of DataPlane %s: %w", certID, dataplane.Name, err)
+			}
+			log.Info(logger, "deregistered Konnect DataPlane certificate", dataplane, "controlPlaneID", cpID, "certificateID", certID)
+		}
+	}
+
+	if err := client.IgnoreNotFound(r.Client.Delete(ctx, secret)); err != nil {
+		return fmt.Errorf("failed deleting Konnect certificate Secret %s: %w", secret.Name, err)
+	}
+	return nil
+}
+
+// sdkForAPIAuthConfiguration returns the SDK for the KonnectAPIAuthConfiguration
+// with the provided name. ReferenceResolutionError is returned when the
+// KonnectAPIAuthConfiguration doesn't exist or is not valid.
+func (r *KonnectDataPlaneReconciler) sdkForAPIAuthConfiguration(
+	ctx context.Context,
+	namespace string,
+	name string,
+) (SDKWrapper, error) {
+	var (
+		apiAuth    konnectv1alpha1.KonnectAPIAuthConfiguration
+		apiAuthRef = types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		}
+	)
+	if err := r.Client.Get(ctx, apiAuthRef, &apiAuth); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, ReferenceResolutionError{
+				Err: fmt.Errorf("KonnectAPIAuthConfiguration %s not found: %w", apiAuthRef, err),
+			}
+		}
+		return nil, fmt.Errorf("failed to get KonnectAPIAuthConfiguration %s: %w", apiAuthRef, err)
+	}
+	if cond, present := k8sutils.GetCondition(KonnectEntityAPIAuthConfigurationValidConditionType, &apiAuth); !present ||
+		cond.Status != metav1.ConditionTrue {
+		return nil, ReferenceResolutionError{
+			Err: fmt.Errorf("KonnectAPIAuthConfiguration %s is not valid", apiAuthRef),
+		}
+	}
+
+	token, err := getKonnectToken(ctx, r.Client, &apiAuth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Konnect token of KonnectAPIAuthConfiguration %s: %w", apiAuthRef, err)
+	}
+	return r.SDKCache.Get(apiAuthRef, apiAuth.Spec.ServerURL, token), nil
+}
+
+// listKonnectDataPlaneCertificateSecrets lists the Secrets holding the DataPlane's
+// Konnect client certificates.
+func listKonnectDataPlaneCertificateSecrets(
+	ctx context.Context,
+	cl client.Client,
+	dataplane *operatorv1beta1.DataPlane,
+) ([]corev1.Secret, error) {
+	matchingLabels := k8sresources.GetManagedLabelForOwner(dataplane)
+	matchingLabels[consts.CertPurposeLabel] = consts.DataPlaneKonnectCertificatePurposeLabelValue
+
+	secrets, err := k8sutils.ListSecretsForOwner(ctx, cl, dataplane.UID, client.InNamespace(dataplane.Namespace), matchingLabels)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing Konnect certificate Secrets for DataPlane %s/%s: %w", dataplane.Namespace, dataplane.Name, err)
+	}
+	return secrets, nil
+}
+
+// generateKonnectDataPlaneCertificateSecret generates a Secret holding a new
+// self-signed client certificate for the DataPlane along with its private key.
+// Konnect control planes pin the registered DataPlane certificates, so the
+// certificate doesn't have to be signed by any CA.
+func generateKonnectDataPlaneCertificateSecret(dataplane *operatorv1beta1.DataPlane) (*corev1.Secret, error) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   fmt.Sprintf("%s.%s", dataplane.Name, dataplane.Namespace),
+			Organization: []string{"Kong, Inc."},
+			Country:      []string{"US"},
+		},
+		NotBefore: now.Add(-time.Hour),
+		// The certificate lasts for 10 years, like the cluster certificates issued
+		// by the operator. It's replaced only when the Secret gets deleted.
+		NotAfter:              now.AddDate(10, 0, 0),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &priv.PublicKey, priv)
+	if err != nil {
+		return nil, err
+	}
+	privDer, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := k8sresources.GenerateNewTLSSecret(dataplane,
+		k8sresources.SecretWithLabel(consts.CertPurposeLabel, consts.DataPlaneKonnectCertificatePurposeLabelValue),
+	)
+	secret.GenerateName = k8sutils.TrimGenerateName(fmt.Sprintf("%s-konnect-%s-", consts.DataPlanePrefix, dataplane.Name))
+	secret.Annotations = map[string]string{
+		consts.DataPlaneKonnectControlPlaneIDAnnotation:       dataplane.Spec.Konnect.ControlPlaneID,
+		consts.DataPlaneKonnectAPIAuthConfigurationAnnotation: dataplane.Spec.Konnect.APIAuthConfigurationRef.Name,
+	}
+	secret.Data = map[string][]byte{
+		consts.TLSCRT: pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: der,
+		}),
+		consts.TLSKey: pem.EncodeToMemory(&pem.Block{
+			Type:  "EC PRIVATE KEY",
+			Bytes: privDer,
+		}),
+	}
+	return secret, nil
+}
+
+// dataPlaneKonnectPredicate filters out the events of DataPlanes which neither
+// are nor were configured to connect to Konnect.
+func dataPlaneKonnectPredicate() predicate.Funcs {
+	hasKonnect := func(obj client.Object) bool {
+		dataplane, ok := obj.(*operatorv1beta1.DataPlane)
+		return ok && dataplane.Spec.Konnect != nil
+	}
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return hasKonnect(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return hasKonnect(e.ObjectOld) || hasKonnect(e.ObjectNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return hasKonnect(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return hasKonnect(e.Object)
+		},
+	}
+}
+
+// enqueueDataPlaneForKonnectAPIAuthConfiguration returns a map function enqueueing
+// the DataPlanes referencing the KonnectAPIAuthConfiguration, e.g. so that their
+// certificates get registered once it becomes valid.
+func enqueueDataPlaneForKonnectAPIAuthConfiguration(
+	cl client.Client,
+) func(ctx context.Context, obj client.Object) []reconcile.Request {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		apiAuth, ok := obj.(*konnectv1alpha1.KonnectAPIAuthConfiguration)
+		if !ok {
+			ctrllog.FromContext(ctx).Error(
+				operatorerrors.ErrUnexpectedObject,
+				"failed to run map funcs",
+				"expected", "KonnectAPIAuthConfiguration", "found", reflect.TypeOf(obj),
+			)
+			return nil
+		}
+
+		var dataplanes operatorv1beta1.DataPlaneList
+		if err := cl.List(ctx, &dataplanes, client.InNamespace(apiAuth.Namespace)); err != nil {
+			ctrllog.FromContext(ctx).Error(err, "failed to list DataPlanes", "namespace", apiAuth.Namespace)
+			return nil
+		}
+
+		var ret []reconcile.Request
+		for _, dp := range dataplanes.Items {
+			if dp.Spec.Konnect == nil || dp.Spec.Konnect.APIAuthConfigurationRef.Name != apiAuth.Name {
+				continue
+			}
+			ret = append(ret, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: dp.Namespace,
+					Name:      dp.Name,
+				},
+			})
+		}
+		return ret
+	}
+}
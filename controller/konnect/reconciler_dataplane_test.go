@@ -0,0 +1,340 @@
+package konnect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	sdkkonnectgo "github.com/Kong/sdk-konnect-go"
+	sdkkonnectgocomp "github.com/Kong/sdk-konnect-go/models/components"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/modules/manager/scheme"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+// fakeKonnectServer is a fake Konnect API server serving the control planes
+// and DataPlane client certificates endpoints.
+type fakeKonnectServer struct {
+	*httptest.Server
+
+	lock sync.Mutex
+	// certificates holds the registered certificates by control plane ID and certificate ID.
+	certificates map[string]map[string]string
+	nextID       int
+}
+
+func newFakeKonnectServer(t *testing.T, token string) *fakeKonnectServer {
+	s := &fakeKonnectServer{
+		certificates: make(map[string]map[string]string),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2/control-planes/{cp}", func(w http.ResponseWriter, r *http.Request) {
+		cp := r.PathValue("cp")
+		writeJSON(w, http.StatusOK, sdkkonnectgocomp.ControlPlane{
+			ID:   sdkkonnectgo.String(cp),
+			Name: sdkkonnectgo.String(cp),
+			Config: &sdkkonnectgocomp.Config{
+				ControlPlaneEndpoint: sdkkonnectgo.String(fmt.Sprintf("https://%s.us.cp0.konghq.tech", cp)),
+				TelemetryEndpoint:    sdkkonnectgo.String(fmt.Sprintf("https://%s.us.tp0.konghq.tech", cp)),
+			},
+		})
+	})
+	mux.HandleFunc("POST /v2/control-planes/{cp}/dp-client-certificates", func(w http.ResponseWriter, r *http.Request) {
+		var req sdkkonnectgocomp.DataPlaneClientCertificateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Cert == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		s.nextID++
+		id := fmt.Sprintf("cert-%d", s.nextID)
+		cp := r.PathValue("cp")
+		if s.certificates[cp] == nil {
+			s.certificates[cp] = make(map[string]string)
+		}
+		s.certificates[cp][id] = req.Cert
+		writeJSON(w, http.StatusCreated, sdkkonnectgocomp.DataPlaneClientCertificate{
+			Item: &sdkkonnectgocomp.DataPlaneClientCertificateItem{
+				ID:   sdkkonnectgo.String(id),
+				Cert: sdkkonnectgo.String(req.Cert),
+			},
+		})
+	})
+	mux.HandleFunc("GET /v2/control-planes/{cp}/dp-client-certificates", func(w http.ResponseWriter, r *http.Request) {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		var items []sdkkonnectgocomp.DataPlaneClientCertificate
+		for id, cert := range s.certificates[r.PathValue("cp")] {
+			items = append(items, sdkkonnectgocomp.DataPlaneClientCertificate{
+				Item: &sdkkonnectgocomp.DataPlaneClientCertificateItem{
+					ID:   sdkkonnectgo.String(id),
+					Cert: sdkkonnectgo.String(cert),
+				},
+			})
+		}
+		writeJSON(w, http.StatusOK, sdkkonnectgocomp.ListDataPlaneCertificatesResponse{Items: items})
+	})
+	mux.HandleFunc("DELETE /v2/control-planes/{cp}/dp-client-certificates/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		cp, id := r.PathValue("cp"), r.PathValue("id")
+		if _, ok := s.certificates[cp][id]; !ok {
+			writeJSON(w, http.StatusNotFound, map[string]any{"status": http.StatusNotFound})
+			return
+		}
+		delete(s.certificates[cp], id)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// registeredCertificates returns the certificates registered with the control plane.
+func (s *fakeKonnectServer) registeredCertificates(cp string) map[string]string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	ret := make(map[string]string, len(s.certificates[cp]))
+	for id, cert := range s.certificates[cp] {
+		ret[id] = cert
+	}
+	return ret
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// fakeKonnectServerSDKFactory creates real Konnect SDKs sending requests
+// to the fake Konnect server.
+type fakeKonnectServerSDKFactory struct {
+	server *fakeKonnectServer
+}
+
+func (f fakeKonnectServerSDKFactory) NewKonnectSDK(_ string, token SDKToken) SDKWrapper {
+	return sdkWrapper{
+		sdk: sdkkonnectgo.New(
+			sdkkonnectgo.WithSecurity(sdkkonnectgocomp.Security{
+				PersonalAccessToken: sdkkonnectgo.String(string(token)),
+			}),
+			sdkkonnectgo.WithServerURL(f.server.URL),
+			sdkkonnectgo.WithClient(f.server.Client()),
+		),
+	}
+}
+
+func TestKonnectDataPlaneReconciler(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeKonnectServer(t, "kpat_spec")
+
+	apiAuth := apiAuthWithAnnotations(nil)
+	apiAuth.Status.Conditions = []metav1.Condition{
+		{
+			Type:   string(KonnectEntityAPIAuthConfigurationValidConditionType),
+			Status: metav1.ConditionTrue,
+			Reason: string(KonnectEntityAPIAuthConfigurationReasonValid),
+		},
+	}
+	dp := &operatorv1beta1.DataPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "dp",
+			UID:       types.UID("1234"),
+		},
+		Spec: operatorv1beta1.DataPlaneSpec{
+			DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+				Konnect: &operatorv1beta1.DataPlaneKonnectOptions{
+					ControlPlaneID: "cp-1",
+					APIAuthConfigurationRef: operatorv1beta1.KonnectAPIAuthConfigurationRef{
+						Name: apiAuth.Name,
+					},
+				},
+			},
+		},
+	}
+	fakeClient := fakectrlruntimeclient.NewClientBuilder().
+		WithScheme(scheme.Get()).
+		WithObjects(dp, apiAuth).
+		WithStatusSubresource(dp).
+		Build()
+	r := NewKonnectDataPlaneReconciler(
+		NewSDKCache(fakeKonnectServerSDKFactory{server: server}),
+		false,
+		fakeClient,
+	)
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(dp)}
+
+	reconcile := func(t *testing.T) {
+		t.Helper()
+		// The first reconciliation adds the finalizer.
+		for i := 0; i < 2; i++ {
+			_, err := r.Reconcile(ctx, req)
+			require.NoError(t, err)
+		}
+	}
+	getSecrets := func(t *testing.T) []corev1.Secret {
+		t.Helper()
+		var current operatorv1beta1.DataPlane
+		require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, &current))
+		secrets, err := listKonnectDataPlaneCertificateSecrets(ctx, fakeClient, &current)
+		require.NoError(t, err)
+		return secrets
+	}
+
+	t.Log("certificate is generated and registered with the control plane")
+	reconcile(t)
+	secrets := getSecrets(t)
+	require.Len(t, secrets, 1)
+	secret := secrets[0]
+	certID := secret.Annotations[consts.DataPlaneKonnectCertificateIDAnnotation]
+	require.NotEmpty(t, certID)
+	require.Equal(t, map[string]string{certID: string(secret.Data[consts.TLSCRT])}, server.registeredCertificates("cp-1"))
+	require.NotEmpty(t, secret.Data[consts.TLSKey])
+	require.Equal(t, "https://cp-1.us.cp0.konghq.tech", secret.Annotations[consts.DataPlaneKonnectControlPlaneEndpointAnnotation])
+	require.Equal(t, "https://cp-1.us.tp0.konghq.tech", secret.Annotations[consts.DataPlaneKonnectTelemetryEndpointAnnotation])
+	var current operatorv1beta1.DataPlane
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, &current))
+	require.True(t, k8sutils.IsConditionTrue(consts.DataPlaneConditionTypeKonnectCertificateRegistered, &current))
+
+	t.Log("certificate is not registered again")
+	reconcile(t)
+	require.Len(t, getSecrets(t), 1)
+	require.Len(t, server.registeredCertificates("cp-1"), 1)
+
+	t.Log("registered certificate is reused when its ID wasn't stored in the Secret")
+	secret = getSecrets(t)[0]
+	delete(secret.Annotations, consts.DataPlaneKonnectCertificateIDAnnotation)
+	require.NoError(t, fakeClient.Update(ctx, &secret))
+	reconcile(t)
+	secrets = getSecrets(t)
+	require.Len(t, secrets, 1)
+	require.Equal(t, certID, secrets[0].Annotations[consts.DataPlaneKonnectCertificateIDAnnotation])
+	require.Len(t, server.registeredCertificates("cp-1"), 1)
+
+	t.Log("certificate is moved to the new control plane when it changes")
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, &current))
+	current.Spec.Konnect.ControlPlaneID = "cp-2"
+	require.NoError(t, fakeClient.Update(ctx, &current))
+	reconcile(t)
+	require.Empty(t, server.registeredCertificates("cp-1"))
+	secrets = getSecrets(t)
+	require.Len(t, secrets, 1)
+	require.Equal(t, "cp-2", secrets[0].Annotations[consts.DataPlaneKonnectControlPlaneIDAnnotation])
+	require.Len(t, server.registeredCertificates("cp-2"), 1)
+
+	t.Log("certificate is deregistered when the DataPlane is deleted")
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, &current))
+	require.NoError(t, fakeClient.Delete(ctx, &current))
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Empty(t, server.registeredCertificates("cp-2"))
+	var secretList corev1.SecretList
+	require.NoError(t, fakeClient.List(ctx, &secretList))
+	require.Empty(t, secretList.Items)
+	require.True(t, k8serrors.IsNotFound(fakeClient.Get(ctx, req.NamespacedName, &current)),
+		"DataPlane is gone once the finalizer is removed")
+}
+
+func TestKonnectDataPlaneReconcilerWithoutValidAPIAuthConfiguration(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeKonnectServer(t, "kpat_spec")
+
+	dp := &operatorv1beta1.DataPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "default",
+			Name:       "dp",
+			UID:        types.UID("1234"),
+			Finalizers: []string{KonnectCleanupFinalizer},
+		},
+		Spec: operatorv1beta1.DataPlaneSpec{
+			DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+				Konnect: &operatorv1beta1.DataPlaneKonnectOptions{
+					ControlPlaneID: "cp-1",
+					APIAuthConfigurationRef: operatorv1beta1.KonnectAPIAuthConfigurationRef{
+						Name: "api-auth",
+					},
+				},
+			},
+		},
+	}
+	fakeClient := fakectrlruntimeclient.NewClientBuilder().
+		WithScheme(scheme.Get()).
+		WithObjects(dp, apiAuthWithAnnotations(nil)).
+		WithStatusSubresource(dp).
+		Build()
+	r := NewKonnectDataPlaneReconciler(
+		NewSDKCache(fakeKonnectServerSDKFactory{server: server}),
+		false,
+		fakeClient,
+	)
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(dp)}
+
+	t.Log("certificate is not generated until the KonnectAPIAuthConfiguration is valid")
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	secrets, err := listKonnectDataPlaneCertificateSecrets(ctx, fakeClient, dp)
+	require.NoError(t, err)
+	require.Empty(t, secrets)
+	require.Empty(t, server.registeredCertificates("cp-1"))
+	var current operatorv1beta1.DataPlane
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, &current))
+	c, ok := k8sutils.GetCondition(consts.DataPlaneConditionTypeKonnectCertificateRegistered, &current)
+	require.True(t, ok)
+	require.Equal(t, metav1.ConditionFalse, c.Status)
+	require.Equal(t, string(consts.DataPlaneConditionReasonKonnectCertificateRegistrationFailed), c.Reason)
+
+	t.Log("removing the Konnect configuration removes the finalizer and the condition")
+	current.Spec.Konnect = nil
+	require.NoError(t, fakeClient.Update(ctx, &current))
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, &current))
+	require.NotContains(t, current.Finalizers, KonnectCleanupFinalizer)
+	_, ok = k8sutils.GetCondition(consts.DataPlaneConditionTypeKonnectCertificateRegistered, &current)
+	require.False(t, ok)
+}
+
+func TestDataPlaneKonnectPredicate(t *testing.T) {
+	withKonnect := &operatorv1beta1.DataPlane{
+		Spec: operatorv1beta1.DataPlaneSpec{
+			DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+				Konnect: &operatorv1beta1.DataPlaneKonnectOptions{ControlPlaneID: "cp-1"},
+			},
+		},
+	}
+	withoutKonnect := &operatorv1beta1.DataPlane{}
+
+	p := dataPlaneKonnectPredicate()
+	require.True(t, p.Create(event.CreateEvent{Object: withKonnect}))
+	require.False(t, p.Create(event.CreateEvent{Object: withoutKonnect}))
+	require.True(t, p.Update(event.UpdateEvent{ObjectOld: withKonnect, ObjectNew: withoutKonnect}),
+		"removing the Konnect configuration deregisters the certificate")
+	require.False(t, p.Update(event.UpdateEvent{ObjectOld: withoutKonnect, ObjectNew: withoutKonnect}))
+}
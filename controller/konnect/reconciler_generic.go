@@ -5,8 +5,6 @@ import (
 	"fmt"
 	"time"
 
-	sdkkonnectgo "github.com/Kong/sdk-konnect-go"
-	sdkkonnectgocomp "github.com/Kong/sdk-konnect-go/models/components"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -37,6 +35,7 @@ const (
 // KonnectEntityReconciler reconciles a Konnect entities.
 // It uses the generic type constraints to constrain the supported types.
 type KonnectEntityReconciler[T SupportedKonnectEntityType, TEnt EntityType[T]] struct {
+	SDKCache        *SDKCache
 	DevelopmentMode bool
 	Client          client.Client
 }
@@ -48,10 +47,12 @@ func NewKonnectEntityReconciler[
 	TEnt EntityType[T],
 ](
 	t T,
+	sdkCache *SDKCache,
 	developmentMode bool,
 	client client.Client,
 ) *KonnectEntityReconciler[T, TEnt] {
 	return &KonnectEntityReconciler[T, TEnt]{
+		SDKCache:        sdkCache,
 		DevelopmentMode: developmentMode,
 		Client:          client,
 	}
@@ -187,16 +188,13 @@ func (r *KonnectEntityReconciler[T, TEnt]) Reconcile(
 		return ctrl.Result{}, nil
 	}
 
-	// NOTE: We need to create a new SDK instance for each reconciliation
-	// because the token is retrieved in runtime through KonnectAPIAuthConfiguration.
-	sdk := sdkkonnectgo.New(
-		sdkkonnectgo.WithSecurity(
-			sdkkonnectgocomp.Security{
-				PersonalAccessToken: sdkkonnectgo.String(apiAuth.Spec.Token),
-			},
-		),
-		sdkkonnectgo.WithServerURL("https://"+apiAuth.Spec.ServerURL),
-	)
+	// NOTE: The token is retrieved in runtime through KonnectAPIAuthConfiguration
+	// so the SDK is taken from the cache which re-creates it when the token changes.
+	token, err := getKonnectToken(ctx, r.Client, &apiAuth)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get Konnect token of KonnectAPIAuthConfiguration %s: %w", apiAuthRef, err)
+	}
+	sdk := r.SDKCache.Get(apiAuthRef, apiAuth.Spec.ServerURL, token)
 
 	if delTimestamp := ent.GetDeletionTimestamp(); !delTimestamp.IsZero() {
 		logger.Info("resource is being deleted")
@@ -275,7 +273,17 @@ func (r *KonnectEntityReconciler[T, TEnt]) Reconcile(
 	}
 
 	if res, err := Update[T, TEnt](ctx, sdk, logger, r.Client, ent); err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to update object: %w", err)
+		// Update the status to store the Programmed condition reflecting the failure.
+		if errStatus := r.Client.Status().Update(ctx, ent); errStatus != nil {
+			if k8serrors.IsConflict(errStatus) {
+				return ctrl.Result{Requeue: true}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("failed to update status after failed update: %w", errStatus)
+		}
+		return ctrl.Result{}, FailedKonnectOpError[T]{
+			Op:  UpdateOp,
+			Err: err,
+		}
 	} else if res.Requeue || res.RequeueAfter > 0 {
 		return res, nil
 	}
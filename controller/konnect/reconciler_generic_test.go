@@ -38,7 +38,7 @@ func testNewKonnectEntityReconciler[
 			Scheme: scheme.Get(),
 		})
 		require.NoError(t, err)
-		reconciler := NewKonnectEntityReconciler[T, TEnt](ent, false, cl)
+		reconciler := NewKonnectEntityReconciler[T, TEnt](ent, NewSDKCache(&fakeSDKFactory{}), false, cl)
 		require.NoError(t, reconciler.SetupWithManager(mgr))
 	})
 }
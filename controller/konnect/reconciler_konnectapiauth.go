@@ -2,14 +2,19 @@ package konnect
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	sdkkonnectgoops "github.com/Kong/sdk-konnect-go/models/operations"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	"github.com/kong/gateway-operator/controller/pkg/log"
 	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
@@ -19,19 +24,19 @@ import (
 
 // KonnectAPIAuthConfigurationReconciler reconciles a KonnectAPIAuthConfiguration object.
 type KonnectAPIAuthConfigurationReconciler struct {
-	SDKFactory      SDKFactory
+	SDKCache        *SDKCache
 	DevelopmentMode bool
 	Client          client.Client
 }
 
 // NewKonnectAPIAuthConfigurationReconciler creates a new KonnectAPIAuthConfigurationReconciler.
 func NewKonnectAPIAuthConfigurationReconciler(
-	sdkFactory SDKFactory,
+	sdkCache *SDKCache,
 	developmentMode bool,
 	client client.Client,
 ) *KonnectAPIAuthConfigurationReconciler {
 	return &KonnectAPIAuthConfigurationReconciler{
-		SDKFactory:      sdkFactory,
+		SDKCache:        sdkCache,
 		DevelopmentMode: developmentMode,
 		Client:          client,
 	}
@@ -41,6 +46,15 @@ func NewKonnectAPIAuthConfigurationReconciler(
 func (r *KonnectAPIAuthConfigurationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	b := ctrl.NewControllerManagedBy(mgr).
 		For(&konnectv1alpha1.KonnectAPIAuthConfiguration{}).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(
+				enqueueKonnectAPIAuthConfigurationForSecret(mgr.GetClient()),
+			),
+			builder.WithPredicates(
+				predicate.NewPredicateFuncs(isKonnectAPIAuthTokenSecret(mgr.GetClient())),
+			),
+		).
 		Named("KonnectAPIAuthConfiguration")
 
 	return b.Complete(r)
@@ -53,6 +67,7 @@ func (r *KonnectAPIAuthConfigurationReconciler) Reconcile(
 	var apiAuth konnectv1alpha1.KonnectAPIAuthConfiguration
 	if err := r.Client.Get(ctx, req.NamespacedName, &apiAuth); err != nil {
 		if k8serrors.IsNotFound(err) {
+			r.SDKCache.Delete(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, err
@@ -78,13 +93,26 @@ func (r *KonnectAPIAuthConfigurationReconciler) Reconcile(
 			}, nil
 		}
 
+		r.SDKCache.Delete(req.NamespacedName)
 		return ctrl.Result{}, nil
 	}
 
-	sdk := r.SDKFactory.NewKonnectSDK(
-		"https://"+apiAuth.Spec.ServerURL,
-		SDKToken(apiAuth.Spec.Token),
-	)
+	token, err := getKonnectToken(ctx, r.Client, &apiAuth)
+	if err != nil {
+		r.SDKCache.Delete(req.NamespacedName)
+		res, errStatus := r.updateInvalidStatus(ctx, &apiAuth, false, err.Error())
+		if errStatus != nil || res.Requeue {
+			return res, errStatus
+		}
+		// Changes of the referenced Secret trigger the reconciliation so there's
+		// no need to retry when the Secret is missing or invalid.
+		if errors.As(err, &TokenSecretError{}) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	sdk := r.SDKCache.Get(req.NamespacedName, apiAuth.Spec.ServerURL, token)
 
 	// TODO(pmalek): check if api auth config has a valid status condition
 	// If not then return an error.
@@ -95,35 +123,27 @@ func (r *KonnectAPIAuthConfigurationReconciler) Reconcile(
 
 	// NOTE: This is needed because currently the SDK only lists the prod global API as supported:
 	// https://github.com/Kong/sdk-konnect-go/blob/999d9a987e1aa7d2e09ac11b1450f4563adf21ea/models/operations/getorganizationsme.go#L10-L12
-	respOrg, err := sdk.Me.GetOrganizationsMe(ctx, sdkkonnectgoops.WithServerURL("https://"+apiAuth.Spec.ServerURL))
+	respOrg, err := sdk.GetMeSDK().GetOrganizationsMe(ctx, sdkkonnectgoops.WithServerURL("https://"+apiAuth.Spec.ServerURL))
 	if err != nil {
-		if cond, ok := k8sutils.GetCondition(KonnectEntityAPIAuthConfigurationValidConditionType, &apiAuth); !ok ||
-			cond.Status != metav1.ConditionFalse ||
-			cond.Reason != KonnectEntityAPIAuthConfigurationReasonInvalid ||
-			cond.ObservedGeneration != apiAuth.GetGeneration() ||
-			apiAuth.Status.OrganizationID != "" ||
-			apiAuth.Status.ServerURL != apiAuth.Spec.ServerURL {
-
-			apiAuth.Status.OrganizationID = ""
-			apiAuth.Status.ServerURL = apiAuth.Spec.ServerURL
-
-			res, err := updateStatusWithCondition(
-				ctx, r.Client, &apiAuth,
-				KonnectEntityAPIAuthConfigurationValidConditionType,
-				metav1.ConditionFalse,
-				KonnectEntityAPIAuthConfigurationReasonInvalid,
-				err.Error(),
-			)
-			if err != nil || res.Requeue {
-				return res, err
-			}
-			return ctrl.Result{}, fmt.Errorf("failed to get organization info from Konnect: %w", err)
+		r.SDKCache.Delete(req.NamespacedName)
+		// Konnect rejects expired (or revoked) tokens as unauthorized. Such tokens
+		// can't become valid again so there's no need to retry until the token changes.
+		if isUnauthorizedError(err) {
+			log.Debug(logger, "Konnect token has been rejected as unauthorized", apiAuth)
+			expiryChanged := setTokenExpiredCondition(&apiAuth, true)
+			return r.updateInvalidStatus(ctx, &apiAuth, expiryChanged, err.Error())
+		}
+		res, errStatus := r.updateInvalidStatus(ctx, &apiAuth, false, err.Error())
+		if errStatus != nil || res.Requeue {
+			return res, errStatus
 		}
 		return ctrl.Result{}, fmt.Errorf("failed to get organization info from Konnect: %w", err)
 	}
+	expiryChanged := setTokenExpiredCondition(&apiAuth, false)
 
 	// Update the status only if it would change to prevent unnecessary updates.
-	if cond, ok := k8sutils.GetCondition(KonnectEntityAPIAuthConfigurationValidConditionType, &apiAuth); !ok ||
+	if cond, ok := k8sutils.GetCondition(KonnectEntityAPIAuthConfigurationValidConditionType, &apiAuth); expiryChanged ||
+		!ok ||
 		cond.Status != metav1.ConditionTrue ||
 		cond.Message != "" ||
 		cond.Reason != KonnectEntityAPIAuthConfigurationReasonValid ||
@@ -144,8 +164,39 @@ func (r *KonnectAPIAuthConfigurationReconciler) Reconcile(
 		if err != nil || res.Requeue {
 			return res, err
 		}
-		return ctrl.Result{}, nil
 	}
 
 	return ctrl.Result{}, nil
 }
+
+// updateInvalidStatus sets the APIAuthValid condition of the provided
+// KonnectAPIAuthConfiguration to false with the provided message and clears
+// the organization ID in its status.
+// The status is updated only if it would change or when force is true.
+func (r *KonnectAPIAuthConfigurationReconciler) updateInvalidStatus(
+	ctx context.Context,
+	apiAuth *konnectv1alpha1.KonnectAPIAuthConfiguration,
+	force bool,
+	message string,
+) (ctrl.Result, error) {
+	if cond, ok := k8sutils.GetCondition(KonnectEntityAPIAuthConfigurationValidConditionType, apiAuth); !force &&
+		ok &&
+		cond.Status == metav1.ConditionFalse &&
+		cond.Reason == KonnectEntityAPIAuthConfigurationReasonInvalid &&
+		cond.ObservedGeneration == apiAuth.GetGeneration() &&
+		apiAuth.Status.OrganizationID == "" &&
+		apiAuth.Status.ServerURL == apiAuth.Spec.ServerURL {
+		return ctrl.Result{}, nil
+	}
+
+	apiAuth.Status.OrganizationID = ""
+	apiAuth.Status.ServerURL = apiAuth.Spec.ServerURL
+
+	return updateStatusWithCondition(
+		ctx, r.Client, apiAuth,
+		KonnectEntityAPIAuthConfigurationValidConditionType,
+		metav1.ConditionFalse,
+		KonnectEntityAPIAuthConfigurationReasonInvalid,
+		message,
+	)
+}
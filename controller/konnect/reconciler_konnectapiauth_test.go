@@ -0,0 +1,273 @@
+package konnect
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/kong/gateway-operator/modules/manager/scheme"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+
+	konnectv1alpha1 "github.com/kong/kubernetes-configuration/api/konnect/v1alpha1"
+)
+
+func tokenSecret(name, key, token string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+		},
+		Data: map[string][]byte{
+			key: []byte(token),
+		},
+	}
+}
+
+func apiAuthWithAnnotations(annotations map[string]string) *konnectv1alpha1.KonnectAPIAuthConfiguration {
+	return &konnectv1alpha1.KonnectAPIAuthConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "api-auth",
+			Generation:  1,
+			Annotations: annotations,
+		},
+		Spec: konnectv1alpha1.KonnectAPIAuthConfigurationSpec{
+			Token:     "kpat_spec",
+			ServerURL: "us.api.konghq.tech",
+		},
+	}
+}
+
+func TestGetKonnectToken(t *testing.T) {
+	testCases := []struct {
+		name              string
+		annotations       map[string]string
+		objects           []client.Object
+		expectedToken     SDKToken
+		expectedSecretErr bool
+	}{
+		{
+			name:          "token from spec",
+			expectedToken: "kpat_spec",
+		},
+		{
+			name: "token from Secret using the default key",
+			annotations: map[string]string{
+				KonnectAPIAuthTokenSecretAnnotation: "konnect-token",
+			},
+			objects: []client.Object{
+				tokenSecret("konnect-token", "token", "spat_secret"),
+			},
+			expectedToken: "spat_secret",
+		},
+		{
+			name: "token from Secret using a custom key",
+			annotations: map[string]string{
+				KonnectAPIAuthTokenSecretAnnotation:    "konnect-token",
+				KonnectAPIAuthTokenSecretKeyAnnotation: "system-account-token",
+			},
+			objects: []client.Object{
+				tokenSecret("konnect-token", "system-account-token", "spat_secret"),
+			},
+			expectedToken: "spat_secret",
+		},
+		{
+			name: "Secret not found",
+			annotations: map[string]string{
+				KonnectAPIAuthTokenSecretAnnotation: "konnect-token",
+			},
+			expectedSecretErr: true,
+		},
+		{
+			name: "Secret does not contain the key",
+			annotations: map[string]string{
+				KonnectAPIAuthTokenSecretAnnotation:    "konnect-token",
+				KonnectAPIAuthTokenSecretKeyAnnotation: "other",
+			},
+			objects: []client.Object{
+				tokenSecret("konnect-token", "token", "spat_secret"),
+			},
+			expectedSecretErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cl := fakectrlruntimeclient.NewClientBuilder().
+				WithScheme(scheme.Get()).
+				WithObjects(tc.objects...).
+				Build()
+
+			token, err := getKonnectToken(context.Background(), cl, apiAuthWithAnnotations(tc.annotations))
+			if tc.expectedSecretErr {
+				require.Error(t, err)
+				assert.True(t, errors.As(err, &TokenSecretError{}))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedToken, token)
+		})
+	}
+}
+
+func TestSDKCache(t *testing.T) {
+	var (
+		factory = &fakeSDKFactory{}
+		cache   = NewSDKCache(factory)
+		nn      = types.NamespacedName{Namespace: "default", Name: "api-auth"}
+	)
+
+	cache.Get(nn, "us.api.konghq.tech", "spat_1")
+	cache.Get(nn, "us.api.konghq.tech", "spat_1")
+	assert.Equal(t, []SDKToken{"spat_1"}, factory.tokens, "SDK should be cached")
+
+	cache.Get(nn, "us.api.konghq.tech", "spat_2")
+	assert.Equal(t, []SDKToken{"spat_1", "spat_2"}, factory.tokens, "SDK should be re-created after token rotation")
+
+	cache.Delete(nn)
+	cache.Get(nn, "us.api.konghq.tech", "spat_2")
+	assert.Equal(t, []SDKToken{"spat_1", "spat_2", "spat_2"}, factory.tokens, "SDK should be re-created after deletion")
+}
+
+func TestKonnectAPIAuthConfigurationReconciler(t *testing.T) {
+	var (
+		ctx         = context.Background()
+		annotations = map[string]string{KonnectAPIAuthTokenSecretAnnotation: "konnect-token"}
+		req         = ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "api-auth"}}
+	)
+
+	requireCondition := func(
+		t *testing.T,
+		cl client.Client,
+		conditionType string,
+		status metav1.ConditionStatus,
+		reason string,
+	) {
+		t.Helper()
+
+		var apiAuth konnectv1alpha1.KonnectAPIAuthConfiguration
+		require.NoError(t, cl.Get(ctx, req.NamespacedName, &apiAuth))
+		c, ok := k8sutils.GetCondition(consts.ConditionType(conditionType), &apiAuth)
+		require.True(t, ok, "%s condition not set", conditionType)
+		assert.Equal(t, status, c.Status)
+		assert.Equal(t, reason, c.Reason)
+	}
+
+	t.Run("token from Secret rejected by Konnect is reported as expired", func(t *testing.T) {
+		cl := fakectrlruntimeclient.NewClientBuilder().
+			WithScheme(scheme.Get()).
+			WithObjects(
+				apiAuthWithAnnotations(annotations),
+				tokenSecret("konnect-token", "token", "spat_secret"),
+			).
+			WithStatusSubresource(&konnectv1alpha1.KonnectAPIAuthConfiguration{}).
+			Build()
+		sdk := newFakeSDK()
+		sdk.me.orgID = "org-id"
+		factory := &fakeSDKFactory{sdk: sdk}
+		r := NewKonnectAPIAuthConfigurationReconciler(NewSDKCache(factory), false, cl)
+
+		res, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+		assert.Equal(t, []SDKToken{"spat_secret"}, factory.tokens)
+		requireCondition(t, cl, KonnectEntityAPIAuthConfigurationValidConditionType, metav1.ConditionTrue, KonnectEntityAPIAuthConfigurationReasonValid)
+		requireCondition(t, cl, KonnectAPIAuthConfigurationTokenExpiredConditionType, metav1.ConditionFalse, KonnectAPIAuthConfigurationTokenExpiredReasonNotExpired)
+
+		var apiAuth konnectv1alpha1.KonnectAPIAuthConfiguration
+		require.NoError(t, cl.Get(ctx, req.NamespacedName, &apiAuth))
+		assert.Equal(t, "org-id", apiAuth.Status.OrganizationID)
+
+		t.Log("rotating the token to one which Konnect rejects as unauthorized")
+		var secret corev1.Secret
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Namespace: "default", Name: "konnect-token"}, &secret))
+		secret.Data["token"] = []byte("spat_rotated")
+		require.NoError(t, cl.Update(ctx, &secret))
+		sdk.me.get.err = sdkErrorWithStatus(http.StatusUnauthorized)
+
+		res, err = r.Reconcile(ctx, req)
+		require.NoError(t, err, "rejected token should not be retried until it changes")
+		assert.Equal(t, ctrl.Result{}, res)
+		assert.Equal(t, []SDKToken{"spat_secret", "spat_rotated"}, factory.tokens)
+		requireCondition(t, cl, KonnectEntityAPIAuthConfigurationValidConditionType, metav1.ConditionFalse, KonnectEntityAPIAuthConfigurationReasonInvalid)
+		requireCondition(t, cl, KonnectAPIAuthConfigurationTokenExpiredConditionType, metav1.ConditionTrue, KonnectAPIAuthConfigurationTokenExpiredReasonExpired)
+
+		t.Log("other Konnect errors don't change the TokenExpired condition and are retried")
+		sdk.me.get.err = sdkErrorWithStatus(http.StatusServiceUnavailable)
+		_, err = r.Reconcile(ctx, req)
+		require.Error(t, err)
+		requireCondition(t, cl, KonnectAPIAuthConfigurationTokenExpiredConditionType, metav1.ConditionTrue, KonnectAPIAuthConfigurationTokenExpiredReasonExpired)
+	})
+
+	t.Run("missing Secret", func(t *testing.T) {
+		cl := fakectrlruntimeclient.NewClientBuilder().
+			WithScheme(scheme.Get()).
+			WithObjects(apiAuthWithAnnotations(annotations)).
+			WithStatusSubresource(&konnectv1alpha1.KonnectAPIAuthConfiguration{}).
+			Build()
+		r := NewKonnectAPIAuthConfigurationReconciler(NewSDKCache(&fakeSDKFactory{}), false, cl)
+
+		res, err := r.Reconcile(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+		requireCondition(t, cl, KonnectEntityAPIAuthConfigurationValidConditionType, metav1.ConditionFalse, KonnectEntityAPIAuthConfigurationReasonInvalid)
+	})
+}
+
+func TestEnqueueKonnectAPIAuthConfigurationForSecret(t *testing.T) {
+	cl := fakectrlruntimeclient.NewClientBuilder().
+		WithScheme(scheme.Get()).
+		WithObjects(
+			apiAuthWithAnnotations(map[string]string{KonnectAPIAuthTokenSecretAnnotation: "konnect-token"}),
+			func() client.Object {
+				a := apiAuthWithAnnotations(nil)
+				a.Name = "api-auth-spec-token"
+				return a
+			}(),
+		).
+		WithIndex(&konnectv1alpha1.KonnectAPIAuthConfiguration{}, KonnectAPIAuthTokenSecretIndex, konnectAPIAuthTokenSecretIndexValues).
+		Build()
+
+	requests := enqueueKonnectAPIAuthConfigurationForSecret(cl)(
+		context.Background(),
+		tokenSecret("konnect-token", "token", "spat_secret"),
+	)
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "default", Name: "api-auth"}},
+	}, requests)
+
+	t.Log("Secrets with the same name in other namespaces don't enqueue anything")
+	otherNamespaceSecret := tokenSecret("konnect-token", "token", "spat_secret")
+	otherNamespaceSecret.Namespace = "other"
+	assert.Empty(t, enqueueKonnectAPIAuthConfigurationForSecret(cl)(context.Background(), otherNamespaceSecret))
+}
+
+func TestIsKonnectAPIAuthTokenSecret(t *testing.T) {
+	cl := fakectrlruntimeclient.NewClientBuilder().
+		WithScheme(scheme.Get()).
+		WithObjects(
+			apiAuthWithAnnotations(map[string]string{KonnectAPIAuthTokenSecretAnnotation: "konnect-token"}),
+		).
+		WithIndex(&konnectv1alpha1.KonnectAPIAuthConfiguration{}, KonnectAPIAuthTokenSecretIndex, konnectAPIAuthTokenSecretIndexValues).
+		Build()
+	isTokenSecret := isKonnectAPIAuthTokenSecret(cl)
+
+	assert.True(t, isTokenSecret(tokenSecret("konnect-token", "token", "spat_secret")))
+	assert.False(t, isTokenSecret(tokenSecret("unrelated", "token", "spat_secret")))
+
+	otherNamespaceSecret := tokenSecret("konnect-token", "token", "spat_secret")
+	otherNamespaceSecret.Namespace = "other"
+	assert.False(t, isTokenSecret(otherNamespaceSecret))
+}
@@ -0,0 +1,62 @@
+package konnect
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// SDKCache is a cache of Konnect SDKs shared by the Konnect reconcilers.
+// SDKs are cached per KonnectAPIAuthConfiguration so that they are not created
+// (and hence clients don't re-authenticate) on every reconciliation.
+// Cached SDK is re-created when the server URL or the token of the
+// KonnectAPIAuthConfiguration changes, e.g. when the token gets rotated.
+type SDKCache struct {
+	factory SDKFactory
+
+	lock    sync.Mutex
+	entries map[types.NamespacedName]sdkCacheEntry
+}
+
+type sdkCacheEntry struct {
+	serverURL string
+	token     SDKToken
+	sdk       SDKWrapper
+}
+
+// NewSDKCache creates a new SDKCache creating SDKs using the provided SDKFactory.
+func NewSDKCache(factory SDKFactory) *SDKCache {
+	return &SDKCache{
+		factory: factory,
+		entries: make(map[types.NamespacedName]sdkCacheEntry),
+	}
+}
+
+// Get returns the SDK for the KonnectAPIAuthConfiguration with the provided
+// name, creating it if it's not cached yet or if the cached SDK has been
+// created for a different server URL or token.
+func (c *SDKCache) Get(apiAuth types.NamespacedName, serverURL string, token SDKToken) SDKWrapper {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if e, ok := c.entries[apiAuth]; ok && e.serverURL == serverURL && e.token == token {
+		return e.sdk
+	}
+
+	sdk := c.factory.NewKonnectSDK(serverURL, token)
+	c.entries[apiAuth] = sdkCacheEntry{
+		serverURL: serverURL,
+		token:     token,
+		sdk:       sdk,
+	}
+	return sdk
+}
+
+// Delete removes the SDK cached for the KonnectAPIAuthConfiguration with
+// the provided name, e.g. when it gets deleted or its token becomes invalid.
+func (c *SDKCache) Delete(apiAuth types.NamespacedName) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.entries, apiAuth)
+}
@@ -1,16 +1,120 @@
 package konnect
 
 import (
+	"context"
+	"strings"
+
 	sdkkonnectgo "github.com/Kong/sdk-konnect-go"
 	sdkkonnectgocomp "github.com/Kong/sdk-konnect-go/models/components"
+	sdkkonnectgoops "github.com/Kong/sdk-konnect-go/models/operations"
 )
 
 // SDKToken is a token used to authenticate with the Konnect SDK.
 type SDKToken string
 
+// systemAccountTokenPrefix is the prefix of Konnect system account access tokens.
+const systemAccountTokenPrefix = "spat_"
+
+// IsSystemAccountToken returns true if the token is a Konnect system account
+// access token. Otherwise it's assumed to be a personal access token.
+func (t SDKToken) IsSystemAccountToken() bool {
+	return strings.HasPrefix(string(t), systemAccountTokenPrefix)
+}
+
 // SDKFactory is a factory for creating Konnect SDKs.
 type SDKFactory interface {
-	NewKonnectSDK(serverURL string, token SDKToken) *sdkkonnectgo.SDK
+	NewKonnectSDK(serverURL string, token SDKToken) SDKWrapper
+}
+
+// SDKWrapper is a wrapper of Konnect SDK to allow using mock SDKs in tests.
+// It exposes only the parts of the SDK used by the operator.
+type SDKWrapper interface {
+	GetServicesSDK() ServicesSDK
+	GetRoutesSDK() RoutesSDK
+	GetConsumersSDK() ConsumersSDK
+	GetMeSDK() MeSDK
+	GetDataPlaneCertificatesSDK() DataPlaneCertificatesSDK
+	GetControlPlanesSDK() ControlPlanesSDK
+}
+
+// ServicesSDK is the interface for the Konnect Services SDK.
+type ServicesSDK interface {
+	CreateService(ctx context.Context, controlPlaneID string, service sdkkonnectgocomp.ServiceInput, opts ...sdkkonnectgoops.Option) (*sdkkonnectgoops.CreateServiceResponse, error)
+	GetService(ctx context.Context, controlPlaneID string, serviceID string, opts ...sdkkonnectgoops.Option) (*sdkkonnectgoops.GetServiceResponse, error)
+	UpsertService(ctx context.Context, req sdkkonnectgoops.UpsertServiceRequest, opts ...sdkkonnectgoops.Option) (*sdkkonnectgoops.UpsertServiceResponse, error)
+	DeleteService(ctx context.Context, controlPlaneID string, serviceID string, opts ...sdkkonnectgoops.Option) (*sdkkonnectgoops.DeleteServiceResponse, error)
+	ListService(ctx context.Context, req sdkkonnectgoops.ListServiceRequest, opts ...sdkkonnectgoops.Option) (*sdkkonnectgoops.ListServiceResponse, error)
+}
+
+// RoutesSDK is the interface for the Konnect Routes SDK.
+type RoutesSDK interface {
+	CreateRoute(ctx context.Context, controlPlaneID string, route sdkkonnectgocomp.RouteInput, opts ...sdkkonnectgoops.Option) (*sdkkonnectgoops.CreateRouteResponse, error)
+	GetRoute(ctx context.Context, controlPlaneID string, routeID string, opts ...sdkkonnectgoops.Option) (*sdkkonnectgoops.GetRouteResponse, error)
+	UpsertRoute(ctx context.Context, req sdkkonnectgoops.UpsertRouteRequest, opts ...sdkkonnectgoops.Option) (*sdkkonnectgoops.UpsertRouteResponse, error)
+	DeleteRoute(ctx context.Context, controlPlaneID string, routeID string, opts ...sdkkonnectgoops.Option) (*sdkkonnectgoops.DeleteRouteResponse, error)
+	ListRoute(ctx context.Context, req sdkkonnectgoops.ListRouteRequest, opts ...sdkkonnectgoops.Option) (*sdkkonnectgoops.ListRouteResponse, error)
+}
+
+// ConsumersSDK is the interface for the Konnect Consumers SDK.
+type ConsumersSDK interface {
+	CreateConsumer(ctx context.Context, controlPlaneID string, consumer sdkkonnectgocomp.ConsumerInput, opts ...sdkkonnectgoops.Option) (*sdkkonnectgoops.CreateConsumerResponse, error)
+	GetConsumer(ctx context.Context, controlPlaneID string, consumerID string, opts ...sdkkonnectgoops.Option) (*sdkkonnectgoops.GetConsumerResponse, error)
+	UpsertConsumer(ctx context.Context, req sdkkonnectgoops.UpsertConsumerRequest, opts ...sdkkonnectgoops.Option) (*sdkkonnectgoops.UpsertConsumerResponse, error)
+	DeleteConsumer(ctx context.Context, controlPlaneID string, consumerID string, opts ...sdkkonnectgoops.Option) (*sdkkonnectgoops.DeleteConsumerResponse, error)
+	ListConsumer(ctx context.Context, req sdkkonnectgoops.ListConsumerRequest, opts ...sdkkonnectgoops.Option) (*sdkkonnectgoops.ListConsumerResponse, error)
+}
+
+// MeSDK is the interface for the Konnect Me SDK.
+type MeSDK interface {
+	GetOrganizationsMe(ctx context.Context, opts ...sdkkonnectgoops.Option) (*sdkkonnectgoops.GetOrganizationsMeResponse, error)
+}
+
+// DataPlaneCertificatesSDK is the interface for the Konnect DataPlane certificates SDK.
+type DataPlaneCertificatesSDK interface {
+	CreateDataplaneCertificate(ctx context.Context, controlPlaneID string, req *sdkkonnectgocomp.DataPlaneClientCertificateRequest, opts ...sdkkonnectgoops.Option) (*sdkkonnectgoops.CreateDataplaneCertificateResponse, error)
+	DeleteDataplaneCertificate(ctx context.Context, controlPlaneID string, certificateID string, opts ...sdkkonnectgoops.Option) (*sdkkonnectgoops.DeleteDataplaneCertificateResponse, error)
+	ListDpClientCertificates(ctx context.Context, req sdkkonnectgoops.ListDpClientCertificatesRequest, opts ...sdkkonnectgoops.Option) (*sdkkonnectgoops.ListDpClientCertificatesResponse, error)
+}
+
+// ControlPlanesSDK is the interface for the Konnect ControlPlanes SDK.
+type ControlPlanesSDK interface {
+	GetControlPlane(ctx context.Context, id string, opts ...sdkkonnectgoops.Option) (*sdkkonnectgoops.GetControlPlaneResponse, error)
+}
+
+type sdkWrapper struct {
+	sdk *sdkkonnectgo.SDK
+}
+
+var _ SDKWrapper = sdkWrapper{}
+
+// GetServicesSDK returns the SDK to operate Kong Services.
+func (w sdkWrapper) GetServicesSDK() ServicesSDK {
+	return w.sdk.Services
+}
+
+// GetRoutesSDK returns the SDK to operate Kong Routes.
+func (w sdkWrapper) GetRoutesSDK() RoutesSDK {
+	return w.sdk.Routes
+}
+
+// GetConsumersSDK returns the SDK to operate Kong Consumers.
+func (w sdkWrapper) GetConsumersSDK() ConsumersSDK {
+	return w.sdk.Consumers
+}
+
+// GetMeSDK returns the SDK to get current organization.
+func (w sdkWrapper) GetMeSDK() MeSDK {
+	return w.sdk.Me
+}
+
+// GetDataPlaneCertificatesSDK returns the SDK to operate DataPlane client certificates.
+func (w sdkWrapper) GetDataPlaneCertificatesSDK() DataPlaneCertificatesSDK {
+	return w.sdk.DPCertificates
+}
+
+// GetControlPlanesSDK returns the SDK to operate Konnect control planes.
+func (w sdkWrapper) GetControlPlanesSDK() ControlPlanesSDK {
+	return w.sdk.ControlPlanes
 }
 
 type sdkFactory struct{}
@@ -20,14 +124,20 @@ func NewSDKFactory() SDKFactory {
 	return sdkFactory{}
 }
 
-// NewKonnectSDK creates a new Konnect SDK.
-func (f sdkFactory) NewKonnectSDK(serverURL string, token SDKToken) *sdkkonnectgo.SDK {
-	return sdkkonnectgo.New(
-		sdkkonnectgo.WithSecurity(
-			sdkkonnectgocomp.Security{
-				PersonalAccessToken: sdkkonnectgo.String(string(token)),
-			},
+// NewKonnectSDK creates a new Konnect SDK authenticating with either a personal
+// or a system account access token.
+func (f sdkFactory) NewKonnectSDK(serverURL string, token SDKToken) SDKWrapper {
+	var security sdkkonnectgocomp.Security
+	if token.IsSystemAccountToken() {
+		security.SystemAccountAccessToken = sdkkonnectgo.String(string(token))
+	} else {
+		security.PersonalAccessToken = sdkkonnectgo.String(string(token))
+	}
+
+	return sdkWrapper{
+		sdk: sdkkonnectgo.New(
+			sdkkonnectgo.WithSecurity(security),
+			sdkkonnectgo.WithServerURL("https://"+serverURL),
 		),
-		sdkkonnectgo.WithServerURL("https://"+serverURL),
-	)
+	}
 }
@@ -0,0 +1,238 @@
+package konnect
+
+import (
+	"context"
+	"net/http"
+
+	sdkkonnectgo "github.com/Kong/sdk-konnect-go"
+	sdkkonnectgocomp "github.com/Kong/sdk-konnect-go/models/components"
+	sdkkonnectgoops "github.com/Kong/sdk-konnect-go/models/operations"
+	sdkkonnectgoerrs "github.com/Kong/sdk-konnect-go/models/sdkerrors"
+)
+
+// fakeSDKFactory is a SDKFactory returning the configured fake SDK.
+// It records the tokens SDKs are created with.
+type fakeSDKFactory struct {
+	sdk    *fakeSDK
+	tokens []SDKToken
+}
+
+func (f *fakeSDKFactory) NewKonnectSDK(_ string, token SDKToken) SDKWrapper {
+	f.tokens = append(f.tokens, token)
+	if f.sdk == nil {
+		return newFakeSDK()
+	}
+	return f.sdk
+}
+
+// fakeSDK is a SDKWrapper backed by fake SDKs recording the requests they receive.
+type fakeSDK struct {
+	services  *fakeServicesSDK
+	routes    *fakeRoutesSDK
+	consumers *fakeConsumersSDK
+	me        *fakeMeSDK
+}
+
+func newFakeSDK() *fakeSDK {
+	return &fakeSDK{
+		services:  &fakeServicesSDK{},
+		routes:    &fakeRoutesSDK{},
+		consumers: &fakeConsumersSDK{},
+		me:        &fakeMeSDK{},
+	}
+}
+
+func (f *fakeSDK) GetServicesSDK() ServicesSDK {
+	return f.services
+}
+
+func (f *fakeSDK) GetRoutesSDK() RoutesSDK {
+	return f.routes
+}
+
+func (f *fakeSDK) GetConsumersSDK() ConsumersSDK {
+	return f.consumers
+}
+
+func (f *fakeSDK) GetMeSDK() MeSDK {
+	return f.me
+}
+
+func (f *fakeSDK) GetDataPlaneCertificatesSDK() DataPlaneCertificatesSDK {
+	return nil
+}
+
+func (f *fakeSDK) GetControlPlanesSDK() ControlPlanesSDK {
+	return nil
+}
+
+// fakeOp holds the configured result of fake SDK operations and records
+// the Control Plane IDs passed to them.
+type fakeOp struct {
+	err            error
+	controlPlaneID string
+	called         bool
+}
+
+func (o *fakeOp) call(controlPlaneID string) error {
+	o.called = true
+	o.controlPlaneID = controlPlaneID
+	return o.err
+}
+
+type fakeServicesSDK struct {
+	create, get, upsert, delete, list fakeOp
+	createdID                         string
+	lastInput                         sdkkonnectgocomp.ServiceInput
+	// remote is returned by GetService and listed by ListService.
+	remote    *sdkkonnectgocomp.Service
+	listed    []sdkkonnectgocomp.Service
+	lastGetID string
+}
+
+func (f *fakeServicesSDK) CreateService(_ context.Context, cpID string, svc sdkkonnectgocomp.ServiceInput, _ ...sdkkonnectgoops.Option) (*sdkkonnectgoops.CreateServiceResponse, error) {
+	f.lastInput = svc
+	if err := f.create.call(cpID); err != nil {
+		return nil, err
+	}
+	return &sdkkonnectgoops.CreateServiceResponse{
+		Service: &sdkkonnectgocomp.Service{ID: sdkkonnectgo.String(f.createdID)},
+	}, nil
+}
+
+func (f *fakeServicesSDK) GetService(_ context.Context, cpID string, id string, _ ...sdkkonnectgoops.Option) (*sdkkonnectgoops.GetServiceResponse, error) {
+	f.lastGetID = id
+	if err := f.get.call(cpID); err != nil {
+		return nil, err
+	}
+	return &sdkkonnectgoops.GetServiceResponse{Service: f.remote}, nil
+}
+
+func (f *fakeServicesSDK) ListService(_ context.Context, req sdkkonnectgoops.ListServiceRequest, _ ...sdkkonnectgoops.Option) (*sdkkonnectgoops.ListServiceResponse, error) {
+	if err := f.list.call(req.ControlPlaneID); err != nil {
+		return nil, err
+	}
+	return &sdkkonnectgoops.ListServiceResponse{
+		Object: &sdkkonnectgoops.ListServiceResponseBody{Data: f.listed},
+	}, nil
+}
+
+func (f *fakeServicesSDK) UpsertService(_ context.Context, req sdkkonnectgoops.UpsertServiceRequest, _ ...sdkkonnectgoops.Option) (*sdkkonnectgoops.UpsertServiceResponse, error) {
+	f.lastInput = req.Service
+	return &sdkkonnectgoops.UpsertServiceResponse{}, f.upsert.call(req.ControlPlaneID)
+}
+
+func (f *fakeServicesSDK) DeleteService(_ context.Context, cpID string, _ string, _ ...sdkkonnectgoops.Option) (*sdkkonnectgoops.DeleteServiceResponse, error) {
+	return &sdkkonnectgoops.DeleteServiceResponse{}, f.delete.call(cpID)
+}
+
+type fakeRoutesSDK struct {
+	create, get, upsert, delete, list fakeOp
+	createdID                         string
+	lastInput                         sdkkonnectgocomp.RouteInput
+	// remote is returned by GetRoute and listed by ListRoute.
+	remote    *sdkkonnectgocomp.Route
+	listed    []sdkkonnectgocomp.Route
+	lastGetID string
+}
+
+func (f *fakeRoutesSDK) CreateRoute(_ context.Context, cpID string, route sdkkonnectgocomp.RouteInput, _ ...sdkkonnectgoops.Option) (*sdkkonnectgoops.CreateRouteResponse, error) {
+	f.lastInput = route
+	if err := f.create.call(cpID); err != nil {
+		return nil, err
+	}
+	return &sdkkonnectgoops.CreateRouteResponse{
+		Route: &sdkkonnectgocomp.Route{ID: sdkkonnectgo.String(f.createdID)},
+	}, nil
+}
+
+func (f *fakeRoutesSDK) GetRoute(_ context.Context, cpID string, id string, _ ...sdkkonnectgoops.Option) (*sdkkonnectgoops.GetRouteResponse, error) {
+	f.lastGetID = id
+	if err := f.get.call(cpID); err != nil {
+		return nil, err
+	}
+	return &sdkkonnectgoops.GetRouteResponse{Route: f.remote}, nil
+}
+
+func (f *fakeRoutesSDK) ListRoute(_ context.Context, req sdkkonnectgoops.ListRouteRequest, _ ...sdkkonnectgoops.Option) (*sdkkonnectgoops.ListRouteResponse, error) {
+	if err := f.list.call(req.ControlPlaneID); err != nil {
+		return nil, err
+	}
+	return &sdkkonnectgoops.ListRouteResponse{
+		Object: &sdkkonnectgoops.ListRouteResponseBody{Data: f.listed},
+	}, nil
+}
+
+func (f *fakeRoutesSDK) UpsertRoute(_ context.Context, req sdkkonnectgoops.UpsertRouteRequest, _ ...sdkkonnectgoops.Option) (*sdkkonnectgoops.UpsertRouteResponse, error) {
+	f.lastInput = req.Route
+	return &sdkkonnectgoops.UpsertRouteResponse{}, f.upsert.call(req.ControlPlaneID)
+}
+
+func (f *fakeRoutesSDK) DeleteRoute(_ context.Context, cpID string, _ string, _ ...sdkkonnectgoops.Option) (*sdkkonnectgoops.DeleteRouteResponse, error) {
+	return &sdkkonnectgoops.DeleteRouteResponse{}, f.delete.call(cpID)
+}
+
+type fakeConsumersSDK struct {
+	create, get, upsert, delete, list fakeOp
+	createdID                         string
+	lastInput                         sdkkonnectgocomp.ConsumerInput
+	// remote is returned by GetConsumer and listed by ListConsumer.
+	remote    *sdkkonnectgocomp.Consumer
+	listed    []sdkkonnectgocomp.Consumer
+	lastGetID string
+}
+
+func (f *fakeConsumersSDK) CreateConsumer(_ context.Context, cpID string, consumer sdkkonnectgocomp.ConsumerInput, _ ...sdkkonnectgoops.Option) (*sdkkonnectgoops.CreateConsumerResponse, error) {
+	f.lastInput = consumer
+	if err := f.create.call(cpID); err != nil {
+		return nil, err
+	}
+	return &sdkkonnectgoops.CreateConsumerResponse{
+		Consumer: &sdkkonnectgocomp.Consumer{ID: sdkkonnectgo.String(f.createdID)},
+	}, nil
+}
+
+func (f *fakeConsumersSDK) GetConsumer(_ context.Context, cpID string, id string, _ ...sdkkonnectgoops.Option) (*sdkkonnectgoops.GetConsumerResponse, error) {
+	f.lastGetID = id
+	if err := f.get.call(cpID); err != nil {
+		return nil, err
+	}
+	return &sdkkonnectgoops.GetConsumerResponse{Consumer: f.remote}, nil
+}
+
+func (f *fakeConsumersSDK) ListConsumer(_ context.Context, req sdkkonnectgoops.ListConsumerRequest, _ ...sdkkonnectgoops.Option) (*sdkkonnectgoops.ListConsumerResponse, error) {
+	if err := f.list.call(req.ControlPlaneID); err != nil {
+		return nil, err
+	}
+	return &sdkkonnectgoops.ListConsumerResponse{
+		Object: &sdkkonnectgoops.ListConsumerResponseBody{Data: f.listed},
+	}, nil
+}
+
+func (f *fakeConsumersSDK) UpsertConsumer(_ context.Context, req sdkkonnectgoops.UpsertConsumerRequest, _ ...sdkkonnectgoops.Option) (*sdkkonnectgoops.UpsertConsumerResponse, error) {
+	f.lastInput = req.Consumer
+	return &sdkkonnectgoops.UpsertConsumerResponse{}, f.upsert.call(req.ControlPlaneID)
+}
+
+func (f *fakeConsumersSDK) DeleteConsumer(_ context.Context, cpID string, _ string, _ ...sdkkonnectgoops.Option) (*sdkkonnectgoops.DeleteConsumerResponse, error) {
+	return &sdkkonnectgoops.DeleteConsumerResponse{}, f.delete.call(cpID)
+}
+
+type fakeMeSDK struct {
+	get   fakeOp
+	orgID string
+}
+
+func (f *fakeMeSDK) GetOrganizationsMe(_ context.Context, _ ...sdkkonnectgoops.Option) (*sdkkonnectgoops.GetOrganizationsMeResponse, error) {
+	if err := f.get.call(""); err != nil {
+		return nil, err
+	}
+	return &sdkkonnectgoops.GetOrganizationsMeResponse{
+		MeOrganization: &sdkkonnectgocomp.MeOrganization{ID: sdkkonnectgo.String(f.orgID)},
+	}, nil
+}
+
+// sdkErrorWithStatus returns a Konnect SDK error with the provided HTTP status code.
+func sdkErrorWithStatus(statusCode int) error {
+	return sdkkonnectgoerrs.NewSDKError(http.StatusText(statusCode), statusCode, "", nil)
+}
@@ -1,13 +1,22 @@
 package konnect
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 
+	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorerrors "github.com/kong/gateway-operator/internal/errors"
 
 	configurationv1 "github.com/kong/kubernetes-configuration/api/configuration/v1"
 	configurationv1alpha1 "github.com/kong/kubernetes-configuration/api/configuration/v1alpha1"
+	konnectv1alpha1 "github.com/kong/kubernetes-configuration/api/konnect/v1alpha1"
 )
 
 // ReconciliationWatchOptionsForEntity returns the watch options for the given
@@ -23,10 +32,121 @@ func ReconciliationWatchOptionsForEntity[
 	case *configurationv1alpha1.KongService:
 		return nil
 	case *configurationv1alpha1.KongRoute:
-		return nil
+		return KongRouteReconciliationWatchOptions(cl)
 	case *configurationv1.KongConsumer:
 		return nil
 	default:
 		panic(fmt.Sprintf("unsupported entity type %T", ent))
 	}
 }
+
+// KongRouteReconciliationWatchOptions returns the watch options for
+// the KongRoute.
+func KongRouteReconciliationWatchOptions(
+	cl client.Client,
+) []func(*ctrl.Builder) *ctrl.Builder {
+	return []func(*ctrl.Builder) *ctrl.Builder{
+		func(b *ctrl.Builder) *ctrl.Builder {
+			return b.Watches(
+				&configurationv1alpha1.KongService{},
+				handler.EnqueueRequestsFromMapFunc(
+					enqueueKongRouteForKongService(cl),
+				),
+			)
+		},
+	}
+}
+
+// enqueueKongRouteForKongService returns a map function enqueueing the KongRoutes
+// referencing the KongService, e.g. so that they can get programmed once
+// the KongService gets created in Konnect.
+func enqueueKongRouteForKongService(
+	cl client.Client,
+) func(ctx context.Context, obj client.Object) []reconcile.Request {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		svc, ok := obj.(*configurationv1alpha1.KongService)
+		if !ok {
+			ctrllog.FromContext(ctx).Error(
+				operatorerrors.ErrUnexpectedObject,
+				"failed to run map funcs",
+				"expected", "KongService", "found", reflect.TypeOf(obj),
+			)
+			return nil
+		}
+
+		var routes configurationv1alpha1.KongRouteList
+		if err := cl.List(ctx, &routes, client.InNamespace(svc.Namespace)); err != nil {
+			ctrllog.FromContext(ctx).Error(err, "failed to list KongRoutes", "namespace", svc.Namespace)
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, route := range routes.Items {
+			ref := route.Spec.ServiceRef
+			if ref.Type != configurationv1alpha1.ServiceRefNamespacedRef ||
+				ref.NamespacedRef == nil ||
+				ref.NamespacedRef.Name != svc.Name {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&route),
+			})
+		}
+		return requests
+	}
+}
+
+// enqueueKonnectAPIAuthConfigurationForSecret returns a map function enqueueing
+// the KonnectAPIAuthConfigurations using the Konnect token stored in the Secret,
+// e.g. so that they get revalidated when the token gets rotated.
+func enqueueKonnectAPIAuthConfigurationForSecret(
+	cl client.Client,
+) func(ctx context.Context, obj client.Object) []reconcile.Request {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		secret, ok := obj.(*corev1.Secret)
+		if !ok {
+			ctrllog.FromContext(ctx).Error(
+				operatorerrors.ErrUnexpectedObject,
+				"failed to run map funcs",
+				"expected", "Secret", "found", reflect.TypeOf(obj),
+			)
+			return nil
+		}
+
+		var apiAuths konnectv1alpha1.KonnectAPIAuthConfigurationList
+		if err := cl.List(ctx, &apiAuths,
+			client.MatchingFields{
+				KonnectAPIAuthTokenSecretIndex: client.ObjectKeyFromObject(secret).String(),
+			},
+		); err != nil {
+			ctrllog.FromContext(ctx).Error(err, "failed to list KonnectAPIAuthConfigurations", "secret", client.ObjectKeyFromObject(secret))
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, apiAuth := range apiAuths.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&apiAuth),
+			})
+		}
+		return requests
+	}
+}
+
+// isKonnectAPIAuthTokenSecret returns a predicate function filtering out the events
+// of Secrets which are not used as a Konnect token by any KonnectAPIAuthConfiguration.
+func isKonnectAPIAuthTokenSecret(cl client.Client) func(obj client.Object) bool {
+	return func(obj client.Object) bool {
+		var apiAuths konnectv1alpha1.KonnectAPIAuthConfigurationList
+		if err := cl.List(context.Background(), &apiAuths,
+			client.MatchingFields{
+				KonnectAPIAuthTokenSecretIndex: client.ObjectKeyFromObject(obj).String(),
+			},
+			client.Limit(1),
+		); err != nil {
+			// Let the event through, the map function logs the error.
+			return true
+		}
+		return len(apiAuths.Items) > 0
+	}
+}
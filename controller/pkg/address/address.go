@@ -8,6 +8,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
 )
 
 // AddressesFromService retrieves addresses from the provided service.
@@ -44,7 +45,7 @@ func AddressesFromService(service *corev1.Service) ([]operatorv1beta1.Address, e
 			// have limited utility today: they more or less indicate a need for special
 			// knowledge of the network to do anything useful. In the future we may expand
 			// private IP related functionality as needed.
-			if ip.IsPrivate() {
+			if ip.IsPrivate() || isPrivateIngressService(service) {
 				sourceType = operatorv1beta1.PrivateLoadBalancerAddressSourceType
 			} else {
 				sourceType = operatorv1beta1.PublicLoadBalancerAddressSourceType
@@ -92,6 +93,9 @@ const (
 // source type based on Service metadata like annotations.
 // It returns the deduced address source type.
 func deduceAddressSourceTypeFromService(s *corev1.Service) operatorv1beta1.AddressSourceType {
+	if isPrivateIngressService(s) {
+		return operatorv1beta1.PrivateLoadBalancerAddressSourceType
+	}
 	if v, ok := s.Annotations[serviceAnnotationAWSLoadBalancerSchemeKey]; ok {
 		switch v {
 		case serviceAnnotationAWSLoadBalancerSchemeInternal:
@@ -104,3 +108,9 @@ func deduceAddressSourceTypeFromService(s *corev1.Service) operatorv1beta1.Addre
 	// By default, assume that a load balancer is public.
 	return operatorv1beta1.PublicLoadBalancerAddressSourceType
 }
+
+// isPrivateIngressService returns true when the provided Service is a DataPlane
+// private ingress Service, whose load balancer is private by definition.
+func isPrivateIngressService(s *corev1.Service) bool {
+	return s.Labels[consts.DataPlaneServiceTypeLabel] == string(consts.DataPlanePrivateIngressServiceLabelValue)
+}
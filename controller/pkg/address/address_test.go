@@ -9,6 +9,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
 )
 
 func Test_AddressesFromService(t *testing.T) {
@@ -38,6 +39,40 @@ func Test_AddressesFromService(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "private ingress Service load balancer IP address and hostname",
+			service: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						consts.DataPlaneServiceTypeLabel: string(consts.DataPlanePrivateIngressServiceLabelValue),
+					},
+				},
+				Status: corev1.ServiceStatus{
+					LoadBalancer: corev1.LoadBalancerStatus{
+						Ingress: []corev1.LoadBalancerIngress{
+							{
+								IP: "1.1.1.1",
+							},
+							{
+								Hostname: "internal.myhostname.com",
+							},
+						},
+					},
+				},
+			},
+			want: []operatorv1beta1.Address{
+				{
+					Type:       lo.ToPtr(operatorv1beta1.IPAddressType),
+					Value:      "1.1.1.1",
+					SourceType: operatorv1beta1.PrivateLoadBalancerAddressSourceType,
+				},
+				{
+					Type:       lo.ToPtr(operatorv1beta1.HostnameAddressType),
+					Value:      "internal.myhostname.com",
+					SourceType: operatorv1beta1.PrivateLoadBalancerAddressSourceType,
+				},
+			},
+		},
 		{
 			name: "1 load balancer IP address and 1 hostname",
 			service: &corev1.Service{
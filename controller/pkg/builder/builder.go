@@ -86,3 +86,10 @@ func (b *testDataPlaneBuilder) WithPromotionStrategy(promotionStrategy operatorv
 	b.dataplane.Spec.Deployment.Rollout.Strategy.BlueGreen.Promotion.Strategy = promotionStrategy
 	return b
 }
+
+// WithDeploymentResourcePlan sets the Deployment rollout resource plan of the DataPlane object.
+func (b *testDataPlaneBuilder) WithDeploymentResourcePlan(plan operatorv1beta1.RolloutResourcePlanDeployment) *testDataPlaneBuilder {
+	b.initDeploymentRolloutBlueGreen()
+	b.dataplane.Spec.Deployment.Rollout.Strategy.BlueGreen.Resources.Plan.Deployment = plan
+	return b
+}
@@ -180,11 +180,21 @@ func SetDefaults(
 	}
 
 	if args.ControlPlaneName != "" {
-		const controllerElectionIDEnvVarName = "CONTROLLER_ELECTION_ID"
-		electionID := fmt.Sprintf("%s.konghq.com", args.ControlPlaneName)
-		if _, isOverrideDisabled := dontOverride[controllerElectionIDEnvVarName]; !isOverrideDisabled {
-			if k8sutils.EnvValueByName(container.Env, controllerElectionIDEnvVarName) != electionID {
-				container.Env = k8sutils.UpdateEnv(container.Env, controllerElectionIDEnvVarName, electionID)
+		electionID := LeaderElectionID(args.ControlPlaneName)
+		if _, isOverrideDisabled := dontOverride[consts.ControlPlaneElectionIDEnvVarName]; !isOverrideDisabled {
+			if k8sutils.EnvValueByName(container.Env, consts.ControlPlaneElectionIDEnvVarName) != electionID {
+				container.Env = k8sutils.UpdateEnv(container.Env, consts.ControlPlaneElectionIDEnvVarName, electionID)
+				changed = true
+			}
+		}
+	}
+
+	// The leader election Lease is kept in the ControlPlane's namespace so that
+	// the per-ControlPlane Role grants the pods access to it.
+	if args.Namespace != "" {
+		if _, isOverrideDisabled := dontOverride[consts.ControlPlaneElectionNamespaceEnvVarName]; !isOverrideDisabled {
+			if k8sutils.EnvValueByName(container.Env, consts.ControlPlaneElectionNamespaceEnvVarName) != args.Namespace {
+				container.Env = k8sutils.UpdateEnv(container.Env, consts.ControlPlaneElectionNamespaceEnvVarName, args.Namespace)
 				changed = true
 			}
 		}
@@ -203,6 +213,37 @@ func SetDefaults(
 	return changed
 }
 
+// LeaderElectionID returns the default leader election ID of the ControlPlane
+// with the provided name.
+func LeaderElectionID(controlPlaneName string) string {
+	return controlPlaneName + consts.ControlPlaneElectionIDSuffix
+}
+
+// LeaderElectionLease returns the namespaced name of the Lease used by the
+// ControlPlane's pods for leader election, honoring the election ID and
+// namespace overrides set in the ControlPlane's controller container.
+func LeaderElectionLease(cp *operatorv1beta1.ControlPlane) k8stypes.NamespacedName {
+	nn := k8stypes.NamespacedName{
+		Namespace: cp.Namespace,
+		Name:      LeaderElectionID(cp.Name),
+	}
+	pts := cp.Spec.Deployment.PodTemplateSpec
+	if pts == nil {
+		return nn
+	}
+	container := k8sutils.GetPodContainerByName(&pts.Spec, consts.ControlPlaneControllerContainerName)
+	if container == nil {
+		return nn
+	}
+	if id := k8sutils.EnvValueByName(container.Env, consts.ControlPlaneElectionIDEnvVarName); id != "" {
+		nn.Name = id
+	}
+	if ns := k8sutils.EnvValueByName(container.Env, consts.ControlPlaneElectionNamespaceEnvVarName); ns != "" {
+		nn.Namespace = ns
+	}
+	return nn
+}
+
 // GenerateImage returns the image to use for the control plane.
 func GenerateImage(opts *operatorv1beta1.ControlPlaneOptions, validators ...versions.VersionValidationOption) (string, error) {
 	container := k8sutils.GetPodContainerByName(&opts.Deployment.PodTemplateSpec.Spec, consts.ControlPlaneControllerContainerName)
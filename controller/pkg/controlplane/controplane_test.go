@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
 	"github.com/kong/gateway-operator/pkg/consts"
@@ -109,3 +111,61 @@ func TestDeduceAnonymousReportsEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestLeaderElectionLease(t *testing.T) {
+	newControlPlane := func(env ...corev1.EnvVar) *operatorv1beta1.ControlPlane {
+		return &operatorv1beta1.ControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cp",
+				Namespace: "ns",
+			},
+			Spec: operatorv1beta1.ControlPlaneSpec{
+				ControlPlaneOptions: operatorv1beta1.ControlPlaneOptions{
+					Deployment: operatorv1beta1.ControlPlaneDeploymentOptions{
+						PodTemplateSpec: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: consts.ControlPlaneControllerContainerName,
+										Env:  env,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		cp       *operatorv1beta1.ControlPlane
+		expected k8stypes.NamespacedName
+	}{
+		{
+			name:     "defaults without a PodTemplateSpec",
+			cp:       &operatorv1beta1.ControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "cp", Namespace: "ns"}},
+			expected: k8stypes.NamespacedName{Namespace: "ns", Name: "cp.konghq.com"},
+		},
+		{
+			name:     "defaults without env overrides",
+			cp:       newControlPlane(),
+			expected: k8stypes.NamespacedName{Namespace: "ns", Name: "cp.konghq.com"},
+		},
+		{
+			name: "env overrides",
+			cp: newControlPlane(
+				corev1.EnvVar{Name: consts.ControlPlaneElectionIDEnvVarName, Value: "custom"},
+				corev1.EnvVar{Name: consts.ControlPlaneElectionNamespaceEnvVarName, Value: "other"},
+			),
+			expected: k8stypes.NamespacedName{Namespace: "other", Name: "custom"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, LeaderElectionLease(tt.cp))
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package secrets
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
@@ -20,11 +21,13 @@ import (
 	"github.com/cloudflare/cfssl/signer"
 	"github.com/cloudflare/cfssl/signer/local"
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
 	certificatesv1 "k8s.io/api/certificates/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	ctrlruntimelog "sigs.k8s.io/controller-runtime/pkg/log"
@@ -170,6 +173,8 @@ func IsTLSSecretValid(secret *corev1.Secret) bool {
 // mtlsCASecretNamespace/mtlsCASecretName Secret, or does nothing if a namespace/name Secret is
 // already present. It returns a boolean indicating if it created a Secret and an error indicating
 // any failures it encountered.
+// The certificate in an existing Secret is renewed in place when it expires within
+// the renewal window (see WithRenewalWindow).
 func EnsureCertificate[
 	T interface {
 		*operatorv1beta1.ControlPlane | *operatorv1beta1.DataPlane
@@ -183,9 +188,17 @@ func EnsureCertificate[
 	usages []certificatesv1.KeyUsage,
 	cl client.Client,
 	additionalMatchingLabels client.MatchingLabels,
+	opts ...CertificateOpt,
 ) (op.Result, *corev1.Secret, error) {
 	setCALogger(ctrlruntimelog.Log)
 
+	certOpts := certificateOptions{
+		renewalWindow: consts.DefaultClusterCertificateRenewalWindow,
+	}
+	for _, opt := range opts {
+		opt(&certOpts)
+	}
+
 	// TODO: https://github.com/Kong/gateway-operator-archive/pull/156.
 	// Use only new labels after several minor version of soak time.
 
@@ -224,7 +237,8 @@ func EnsureCertificate[
 
 	count := len(secrets)
 	if count > 1 {
-		if err := k8sreduce.ReduceSecrets(ctx, cl, secrets, getPreDeleteHooks(owner)...); err != nil {
+		preDeleteHooks := append(getPreDeleteHooks(owner), deleteCertificateExpirationMetricPreDeleteHook)
+		if err := k8sreduce.ReduceSecrets(ctx, cl, secrets, preDeleteHooks...); err != nil {
 			return op.Noop, nil, err
 		}
 		return op.Noop, nil, errors.New("number of secrets reduced")
@@ -235,7 +249,7 @@ func EnsureCertificate[
 
 	// If there are no secrets yet, then create one.
 	if count == 0 {
-		return generateTLSDataSecret(ctx, generatedSecret, owner, subject, mtlsCASecretNN, usages, cl)
+		return createTLSDataSecret(ctx, generatedSecret, owner, subject, mtlsCASecretNN, usages, cl)
 	}
 
 	// Otherwise there is already 1 certificate matching specified selectors.
@@ -247,8 +261,9 @@ func EnsureCertificate[
 		if err := cl.Delete(ctx, existingSecret); err != nil {
 			return op.Noop, nil, err
 		}
+		deleteCertificateExpirationMetric(existingSecret)
 
-		return generateTLSDataSecret(ctx, generatedSecret, owner, subject, mtlsCASecretNN, usages, cl)
+		return createTLSDataSecret(ctx, generatedSecret, owner, subject, mtlsCASecretNN, usages, cl)
 	}
 
 	// Check if existing certificate is for a different subject.
@@ -261,9 +276,26 @@ func EnsureCertificate[
 		if err := cl.Delete(ctx, existingSecret); err != nil {
 			return op.Noop, nil, err
 		}
+		deleteCertificateExpirationMetric(existingSecret)
+
+		return createTLSDataSecret(ctx, generatedSecret, owner, subject, mtlsCASecretNN, usages, cl)
+	}
 
-		return generateTLSDataSecret(ctx, generatedSecret, owner, subject, mtlsCASecretNN, usages, cl)
+	// Renew the certificate in place if it expires within the renewal window or
+	// if the cluster CA has changed, e.g. during the cluster CA rotation.
+	// Keeping the Secret allows the pods using it to be rolled out gradually.
+	ca := &corev1.Secret{}
+	if err := cl.Get(ctx, mtlsCASecretNN, ca); err != nil {
+		return op.Noop, nil, fmt.Errorf("failed getting cluster CA secret %s: %w", mtlsCASecretNN, err)
+	}
+	caCert, err := parseCertificate(ca)
+	if err != nil {
+		return op.Noop, nil, fmt.Errorf("failed parsing cluster CA certificate: %w", err)
 	}
+	if reason := certificateRenewalReason(existingSecret, cert, ca, caCert, certOpts.renewalWindow); reason != "" {
+		return renewTLSDataSecret(ctx, existingSecret, generatedSecret, reason, owner, subject, mtlsCASecretNN, usages, cl, certOpts)
+	}
+	setCertificateExpirationMetric(existingSecret, owner, cert)
 
 	var updated bool
 	updated, existingSecret.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existingSecret.ObjectMeta, generatedSecret.ObjectMeta)
@@ -320,11 +352,11 @@ func getSecretOpts[T interface {
 	}
 }
 
-// generateTLSDataSecret generates a TLS certificate data, fills the provided secret with
+// createTLSDataSecret generates a TLS certificate data, fills the provided secret with
 // that data and creates it using the k8s client.
 // It returns a boolean indicating whether the secret has been created, the secret
 // itself and an error.
-func generateTLSDataSecret(
+func createTLSDataSecret(
 	ctx context.Context,
 	generatedSecret *corev1.Secret,
 	owner client.Object,
@@ -333,6 +365,114 @@ func generateTLSDataSecret(
 	usages []certificatesv1.KeyUsage,
 	k8sClient client.Client,
 ) (op.Result, *corev1.Secret, error) {
+	data, err := generateTLSData(ctx, owner, subject, mtlsCASecret, usages, k8sClient)
+	if err != nil {
+		return op.Noop, nil, err
+	}
+	generatedSecret.Data = data
+
+	err = k8sClient.Create(ctx, generatedSecret)
+	if err != nil {
+		return op.Noop, nil, err
+	}
+
+	if cert, err := parseCertificate(generatedSecret); err == nil {
+		setCertificateExpirationMetric(generatedSecret, owner, cert)
+	}
+	return op.Created, generatedSecret, nil
+}
+
+// certificateRenewalReason returns the reason why the certificate in the provided
+// secret has to be renewed or an empty string if it doesn't have to be renewed.
+func certificateRenewalReason(
+	secret *corev1.Secret,
+	cert *x509.Certificate,
+	ca *corev1.Secret,
+	caCert *x509.Certificate,
+	renewalWindow time.Duration,
+) string {
+	switch {
+	case cert.CheckSignatureFrom(caCert) != nil:
+		return "it has not been issued by the current cluster CA"
+	case !bytes.Equal(secret.Data["ca.crt"], caBundle(ca)):
+		return "the cluster CA bundle has changed"
+	// Certificates can't outlive the CA which issued them so there's no point in
+	// renewing a certificate when the CA itself expires within the renewal window.
+	// In that case the cluster CA has to be rotated first.
+	case time.Until(cert.NotAfter) <= renewalWindow && time.Until(caCert.NotAfter) > renewalWindow:
+		return fmt.Sprintf("it expires at %s", cert.NotAfter.Format(time.RFC3339))
+	default:
+		return ""
+	}
+}
+
+// caBundle returns the bundle of CA certificates which should be trusted by the
+// holders of the certificates issued by the provided cluster CA. During the cluster
+// CA rotation it holds both the old and the new CA certificate.
+func caBundle(ca *corev1.Secret) []byte {
+	if bundle, ok := ca.Data[consts.CACRT]; ok && len(bundle) > 0 {
+		return bundle
+	}
+	return ca.Data["tls.crt"]
+}
+
+// renewTLSDataSecret generates new TLS certificate data for the existing secret
+// and updates it using the k8s client. The time of the renewal is stored in the
+// secret's consts.CertificateRenewedAtAnnotation annotation.
+// Events about the renewal are emitted for the owner if an event recorder is configured.
+func renewTLSDataSecret(
+	ctx context.Context,
+	existingSecret *corev1.Secret,
+	generatedSecret *corev1.Secret,
+	reason string,
+	owner client.Object,
+	subject string,
+	mtlsCASecret types.NamespacedName,
+	usages []certificatesv1.KeyUsage,
+	k8sClient client.Client,
+	certOpts certificateOptions,
+) (op.Result, *corev1.Secret, error) {
+	data, err := generateTLSData(ctx, owner, subject, mtlsCASecret, usages, k8sClient)
+	if err != nil {
+		certOpts.recordEvent(owner, corev1.EventTypeWarning, CertificateRenewalFailedEventReason,
+			fmt.Sprintf("Failed to renew certificate in Secret %s because %s: %v",
+				existingSecret.Name, reason, err),
+		)
+		return op.Noop, nil, fmt.Errorf("failed renewing certificate in secret %s: %w", existingSecret.Name, err)
+	}
+
+	_, existingSecret.ObjectMeta = k8sutils.EnsureObjectMetaIsUpdated(existingSecret.ObjectMeta, generatedSecret.ObjectMeta)
+	if existingSecret.Annotations == nil {
+		existingSecret.Annotations = make(map[string]string)
+	}
+	existingSecret.Annotations[consts.CertificateRenewedAtAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	existingSecret.Data = data
+	if err := k8sClient.Update(ctx, existingSecret); err != nil {
+		return op.Noop, nil, fmt.Errorf("failed updating secret %s with renewed certificate: %w", existingSecret.Name, err)
+	}
+
+	renewed, err := parseCertificate(existingSecret)
+	if err != nil {
+		return op.Noop, nil, err
+	}
+	setCertificateExpirationMetric(existingSecret, owner, renewed)
+	certOpts.recordEvent(owner, corev1.EventTypeNormal, CertificateRenewedEventReason,
+		fmt.Sprintf("Certificate in Secret %s has been renewed because %s, the new certificate expires at %s",
+			existingSecret.Name, reason, renewed.NotAfter.Format(time.RFC3339)),
+	)
+	return op.Updated, existingSecret, nil
+}
+
+// generateTLSData generates a private key and a certificate for it signed by
+// the CA from the mtlsCASecret and returns them as TLS secret data.
+func generateTLSData(
+	ctx context.Context,
+	owner client.Object,
+	subject string,
+	mtlsCASecret types.NamespacedName,
+	usages []certificatesv1.KeyUsage,
+	k8sClient client.Client,
+) (map[string][]byte, error) {
 	template := x509.CertificateRequest{
 		Subject: pkix.Name{
 			CommonName:   subject,
@@ -345,23 +485,21 @@ func generateTLSDataSecret(
 
 	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	if err != nil {
-		return op.Noop, nil, err
+		return nil, err
 	}
 
 	der, err := x509.CreateCertificateRequest(rand.Reader, &template, priv)
 	if err != nil {
-		return op.Noop, nil, err
+		return nil, err
 	}
 
 	// This is effectively a placeholder so long as we handle signing internally. When actually creating CSR resources,
 	// this string is used by signers to filter which resources they pay attention to
 	signerName := "gateway-operator.konghq.com/mtls"
-	// TODO This creates certificates that last for 10 years as an arbitrarily long period for the alpha. A production-
-	// ready implementation should use a shorter lifetime and rotate certificates. Rotation requires some mechanism to
-	// recognize that certificates have expired (ideally without permissions to read Secrets across the cluster) and
-	// to get Deployments to acknowledge them. For Kong, this requires a restart, as there's no way to force a reload
-	// of updated files on disk.
-	expiration := int32(315400000)
+	// Certificates are renewed when they are about to expire (see EnsureCertificate and
+	// CertificateRenewalRequeueAfter) and the renewal triggers a restart of the pods using
+	// them, since Kong has no way to force a reload of updated files on disk.
+	expiration := int32(consts.ClusterCertificateLifetime.Seconds())
 
 	csr := certificatesv1.CertificateSigningRequest{
 		ObjectMeta: metav1.ObjectMeta{
@@ -382,33 +520,76 @@ func generateTLSDataSecret(
 	ca := &corev1.Secret{}
 	err = k8sClient.Get(ctx, mtlsCASecret, ca)
 	if err != nil {
-		return op.Noop, nil, err
+		return nil, err
 	}
 
 	signed, err := signCertificate(csr, ca)
 	if err != nil {
-		return op.Noop, nil, err
+		return nil, err
 	}
 	privDer, err := x509.MarshalECPrivateKey(priv)
 	if err != nil {
-		return op.Noop, nil, err
+		return nil, err
 	}
 
-	generatedSecret.Data = map[string][]byte{
-		"ca.crt":  ca.Data["tls.crt"],
+	return map[string][]byte{
+		"ca.crt":  caBundle(ca),
 		"tls.crt": signed,
 		"tls.key": pem.EncodeToMemory(&pem.Block{
 			Type:  "EC PRIVATE KEY",
 			Bytes: privDer,
 		}),
+	}, nil
+}
+
+// parseCertificate parses the certificate stored in the provided TLS secret.
+func parseCertificate(secret *corev1.Secret) (*x509.Certificate, error) {
+	block, _ := pem.Decode(secret.Data["tls.crt"])
+	if block == nil {
+		return nil, fmt.Errorf("failed decoding 'tls.crt' data from secret %s", secret.Name)
 	}
+	return x509.ParseCertificate(block.Bytes)
+}
 
-	err = k8sClient.Create(ctx, generatedSecret)
+// RequeueForCertificateRenewal returns the provided result updated to requeue the
+// owner of the certificate issued by the operator in the provided Secret once the
+// certificate enters the renewal window, so that it gets renewed by EnsureCertificate
+// even if nothing else triggers the owner's reconciliation in the meantime.
+// Results which requeue sooner are returned unchanged.
+func RequeueForCertificateRenewal(res ctrl.Result, secret *corev1.Secret, renewalWindow time.Duration) ctrl.Result {
+	cert, err := parseCertificate(secret)
 	if err != nil {
-		return op.Noop, nil, err
+		return res
+	}
+	requeueAfter := time.Until(cert.NotAfter.Add(-renewalWindow))
+	if requeueAfter <= 0 {
+		// The certificate is already due for renewal, which happens only when
+		// the cluster CA expires within the renewal window as well. The cluster
+		// CA rotation renews it then.
+		return res
 	}
+	if res.RequeueAfter == 0 || requeueAfter < res.RequeueAfter {
+		res.RequeueAfter = requeueAfter
+	}
+	return res
+}
 
-	return op.Created, generatedSecret, nil
+// CertificateRenewalDeploymentOpt returns a DeploymentOpt which propagates the time
+// of the last renewal of the certificate in the provided Secret to the Deployment's
+// pod template. This makes the Deployment roll out its pods, one by one according
+// to its rollout strategy, when the certificate gets renewed.
+// Deployments using certificates which have never been renewed are not modified.
+func CertificateRenewalDeploymentOpt(secret *corev1.Secret) k8sresources.DeploymentOpt {
+	return func(d *appsv1.Deployment) {
+		renewedAt, ok := secret.GetAnnotations()[consts.CertificateRenewedAtAnnotation]
+		if !ok {
+			return
+		}
+		if d.Spec.Template.Annotations == nil {
+			d.Spec.Template.Annotations = make(map[string]string)
+		}
+		d.Spec.Template.Annotations[consts.CertificateRenewedAtAnnotation] = renewedAt
+	}
 }
 
 // GetManagedLabelForServiceSecret returns a label selector for the ServiceSecret.
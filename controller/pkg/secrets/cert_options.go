@@ -0,0 +1,50 @@
+package secrets
+
+import (
+	"time"
+
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// CertificateRenewedEventReason is the reason of the event emitted for the owner
+	// of a certificate Secret when the certificate has been renewed.
+	CertificateRenewedEventReason = "CertificateRenewed"
+	// CertificateRenewalFailedEventReason is the reason of the warning event emitted
+	// for the owner of a certificate Secret when the certificate is about to expire
+	// and it could not be renewed.
+	CertificateRenewalFailedEventReason = "CertificateRenewalFailed"
+)
+
+// CertificateOpt is an option function for EnsureCertificate.
+type CertificateOpt func(*certificateOptions)
+
+type certificateOptions struct {
+	renewalWindow time.Duration
+	eventRecorder record.EventRecorder
+}
+
+// WithRenewalWindow configures the period before the certificate's expiration
+// during which the certificate gets renewed.
+// Defaults to consts.DefaultClusterCertificateRenewalWindow.
+func WithRenewalWindow(renewalWindow time.Duration) CertificateOpt {
+	return func(o *certificateOptions) {
+		o.renewalWindow = renewalWindow
+	}
+}
+
+// WithEventRecorder configures the event recorder used to emit events about
+// certificate renewals for the owner of the certificate.
+func WithEventRecorder(eventRecorder record.EventRecorder) CertificateOpt {
+	return func(o *certificateOptions) {
+		o.eventRecorder = eventRecorder
+	}
+}
+
+func (o certificateOptions) recordEvent(owner client.Object, eventType, reason, message string) {
+	if o.eventRecorder == nil {
+		return
+	}
+	o.eventRecorder.Event(owner, eventType, reason, message)
+}
@@ -17,13 +17,18 @@ import (
 	"time"
 
 	"github.com/kong/kubernetes-testing-framework/pkg/utils/kubernetes/generators"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	certificatesv1 "k8s.io/api/certificates/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	ctrlruntimelog "sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -32,6 +37,7 @@ import (
 	"github.com/kong/gateway-operator/controller/pkg/log"
 	"github.com/kong/gateway-operator/controller/pkg/op"
 	gwtypes "github.com/kong/gateway-operator/internal/types"
+	"github.com/kong/gateway-operator/pkg/consts"
 	k8sresources "github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
 )
 
@@ -396,7 +402,234 @@ func TestMaybeCreateCertificateSecret(t *testing.T) {
 	}
 }
 
+func TestEnsureCertificateRenewal(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, certificatesv1.AddToScheme(scheme))
+	require.NoError(t, operatorv1beta1.AddToScheme(scheme))
+
+	dp := &operatorv1beta1.DataPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dp-1",
+			Namespace: "ns",
+			UID:       types.UID("1234"),
+		},
+	}
+	fakeClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(dp).
+		Build()
+
+	// Use a CA outliving the certificates it issues so that they can be renewed.
+	caSecretNN := types.NamespacedName{Name: "test-mtls-secret", Namespace: "ns"}
+	caSecret, err := generateCACertExpiringAt(caSecretNN, time.Now().Add(20*365*24*time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, fakeClient.Create(ctx, caSecret))
+
+	ensure := func(opts ...CertificateOpt) (op.Result, *corev1.Secret) {
+		t.Helper()
+		res, secret, err := EnsureCertificate(
+			ctx,
+			dp,
+			"test-subject",
+			caSecretNN,
+			[]certificatesv1.KeyUsage{
+				certificatesv1.UsageServerAuth,
+			},
+			fakeClient,
+			nil,
+			opts...,
+		)
+		require.NoError(t, err)
+		return res, secret
+	}
+
+	res, created := ensure()
+	require.Equal(t, op.Created, res)
+	require.NotContains(t, created.Annotations, consts.CertificateRenewedAtAnnotation)
+	createdCert, err := parseCertificate(created)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(consts.ClusterCertificateLifetime), createdCert.NotAfter, time.Hour)
+
+	t.Log("owner is requeued once the certificate enters the renewal window")
+	requeued := RequeueForCertificateRenewal(ctrl.Result{}, created, consts.DefaultClusterCertificateRenewalWindow)
+	require.InDelta(t,
+		consts.ClusterCertificateLifetime-consts.DefaultClusterCertificateRenewalWindow, requeued.RequeueAfter, float64(time.Hour),
+	)
+	require.Equal(t, ctrl.Result{RequeueAfter: time.Second},
+		RequeueForCertificateRenewal(ctrl.Result{RequeueAfter: time.Second}, created, consts.DefaultClusterCertificateRenewalWindow),
+		"results requeued sooner are not changed",
+	)
+	require.Equal(t, ctrl.Result{},
+		RequeueForCertificateRenewal(ctrl.Result{}, created, 2*consts.ClusterCertificateLifetime),
+		"certificates already due for renewal don't requeue",
+	)
+
+	t.Log("certificate not expiring within the renewal window is not renewed")
+	res, secret := ensure()
+	require.Equal(t, op.Noop, res)
+	require.Equal(t, created.Data["tls.crt"], secret.Data["tls.crt"])
+
+	t.Log("certificate expiring within the renewal window is renewed in place")
+	recorder := record.NewFakeRecorder(1)
+	res, renewed := ensure(
+		WithRenewalWindow(15*365*24*time.Hour),
+		WithEventRecorder(recorder),
+	)
+	require.Equal(t, op.Updated, res)
+	require.Equal(t, created.Name, renewed.Name)
+	require.NotEqual(t, created.Data["tls.crt"], renewed.Data["tls.crt"])
+	require.NotEqual(t, created.Data["tls.key"], renewed.Data["tls.key"])
+	require.Equal(t, caSecret.Data["tls.crt"], renewed.Data["ca.crt"])
+	require.Contains(t, renewed.Annotations, consts.CertificateRenewedAtAnnotation)
+	require.Len(t, recorder.Events, 1)
+	require.Contains(t, <-recorder.Events, CertificateRenewedEventReason)
+
+	var secrets corev1.SecretList
+	require.NoError(t, fakeClient.List(ctx, &secrets, client.InNamespace("ns")))
+	require.Len(t, secrets.Items, 2, "renewal should not create new secrets")
+
+	t.Log("expiration metrics are deleted with the certificate's owner")
+	labels := prometheus.Labels{"namespace": "ns", "secret": renewed.Name, "owner_kind": "DataPlane", "owner_name": dp.Name}
+	renewedCert, err := parseCertificate(renewed)
+	require.NoError(t, err)
+	require.Equal(t, float64(renewedCert.NotAfter.Unix()), testutil.ToFloat64(certificateExpirationTimestamp.With(labels)))
+	DeleteCertificateExpirationMetrics(dp)
+	require.False(t, certificateExpirationTimestamp.Delete(labels), "the metric has already been deleted")
+}
+
+func TestEnsureCertificateClusterCARotation(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, certificatesv1.AddToScheme(scheme))
+	require.NoError(t, operatorv1beta1.AddToScheme(scheme))
+
+	dp := &operatorv1beta1.DataPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dp-1",
+			Namespace: "ns",
+			UID:       types.UID("1234"),
+		},
+	}
+	fakeClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(dp).
+		Build()
+
+	caSecretNN := types.NamespacedName{Name: "test-mtls-secret", Namespace: "ns"}
+	oldCA, err := generateCACert(caSecretNN)
+	require.NoError(t, err)
+	require.NoError(t, fakeClient.Create(ctx, oldCA))
+	newCA, err := generateCACert(caSecretNN)
+	require.NoError(t, err)
+
+	ensure := func() (op.Result, *corev1.Secret) {
+		t.Helper()
+		res, secret, err := EnsureCertificate(
+			ctx,
+			dp,
+			"test-subject",
+			caSecretNN,
+			[]certificatesv1.KeyUsage{
+				certificatesv1.UsageServerAuth,
+			},
+			fakeClient,
+			nil,
+		)
+		require.NoError(t, err)
+		return res, secret
+	}
+	updateCA := func(data map[string][]byte) {
+		t.Helper()
+		ca := &corev1.Secret{}
+		require.NoError(t, fakeClient.Get(ctx, caSecretNN, ca))
+		ca.Data = data
+		require.NoError(t, fakeClient.Update(ctx, ca))
+	}
+	requireIssuedBy := func(secret *corev1.Secret, ca *corev1.Secret) {
+		t.Helper()
+		cert, err := parseCertificate(secret)
+		require.NoError(t, err)
+		caCert, err := parseCertificate(ca)
+		require.NoError(t, err)
+		require.NoError(t, cert.CheckSignatureFrom(caCert))
+	}
+
+	res, secret := ensure()
+	require.Equal(t, op.Created, res)
+	require.Equal(t, oldCA.Data["tls.crt"], secret.Data["ca.crt"])
+	requireIssuedBy(secret, oldCA)
+
+	t.Log("new CA is added to the bundle, certificate is re-issued by the old CA")
+	bundle := append(bytes.Clone(oldCA.Data["tls.crt"]), newCA.Data["tls.crt"]...)
+	updateCA(map[string][]byte{
+		"tls.crt": oldCA.Data["tls.crt"],
+		"tls.key": oldCA.Data["tls.key"],
+		"ca.crt":  bundle,
+	})
+	res, secret = ensure()
+	require.Equal(t, op.Updated, res)
+	require.Equal(t, bundle, secret.Data["ca.crt"])
+	requireIssuedBy(secret, oldCA)
+
+	t.Log("new CA becomes the issuer, certificate is re-issued by the new CA")
+	updateCA(map[string][]byte{
+		"tls.crt": newCA.Data["tls.crt"],
+		"tls.key": newCA.Data["tls.key"],
+		"ca.crt":  bundle,
+	})
+	res, secret = ensure()
+	require.Equal(t, op.Updated, res)
+	require.Equal(t, bundle, secret.Data["ca.crt"])
+	requireIssuedBy(secret, newCA)
+
+	t.Log("old CA is removed from the bundle")
+	updateCA(map[string][]byte{
+		"tls.crt": newCA.Data["tls.crt"],
+		"tls.key": newCA.Data["tls.key"],
+	})
+	res, secret = ensure()
+	require.Equal(t, op.Updated, res)
+	require.Equal(t, newCA.Data["tls.crt"], secret.Data["ca.crt"])
+	requireIssuedBy(secret, newCA)
+
+	res, _ = ensure()
+	require.Equal(t, op.Noop, res)
+}
+
+func TestCertificateRenewalDeploymentOpt(t *testing.T) {
+	t.Run("deployment is not changed when certificate has never been renewed", func(t *testing.T) {
+		d := &appsv1.Deployment{}
+		CertificateRenewalDeploymentOpt(&corev1.Secret{})(d)
+		require.Nil(t, d.Spec.Template.Annotations)
+	})
+
+	t.Run("renewal time is propagated to pod template", func(t *testing.T) {
+		d := &appsv1.Deployment{}
+		CertificateRenewalDeploymentOpt(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					consts.CertificateRenewedAtAnnotation: "2024-08-01T00:00:00Z",
+				},
+			},
+		})(d)
+		require.Equal(t, map[string]string{
+			consts.CertificateRenewedAtAnnotation: "2024-08-01T00:00:00Z",
+		}, d.Spec.Template.Annotations)
+	})
+}
+
 func generateCACert(nn types.NamespacedName) (*corev1.Secret, error) {
+	return generateCACertExpiringAt(nn, time.Now().Add(time.Second*315400000))
+}
+
+func generateCACertExpiringAt(nn types.NamespacedName, notAfter time.Time) (*corev1.Secret, error) {
 	serial, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
 	if err != nil {
 		return nil, err
@@ -411,7 +644,7 @@ func generateCACert(nn types.NamespacedName) (*corev1.Secret, error) {
 		SerialNumber:          serial,
 		SignatureAlgorithm:    x509.ECDSAWithSHA256,
 		NotBefore:             time.Now(),
-		NotAfter:              time.Now().Add(time.Second * 315400000),
+		NotAfter:              notAfter,
 		KeyUsage:              x509.KeyUsageCertSign + x509.KeyUsageKeyEncipherment + x509.KeyUsageDigitalSignature,
 		BasicConstraintsValid: true,
 		IsCA:                  true,
@@ -0,0 +1,165 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/samber/lo"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/pkg/op"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+	k8sresources "github.com/kong/gateway-operator/pkg/utils/kubernetes/resources"
+)
+
+// ErrCertificateNotReady is returned by EnsureCertManagerCertificate when the
+// cert-manager Certificate has not been issued yet.
+var ErrCertificateNotReady = errors.New("certificate is not ready yet")
+
+// CertificateNotReadyRequeueAfter is the period after which the owner of a
+// cert-manager Certificate which is not ready yet should be reconciled again.
+// cert-manager Certificates are not watched as the cert-manager CRDs are not
+// required to be installed.
+const CertificateNotReadyRequeueAfter = 5 * time.Second
+
+// EnsureCertManagerCertificate ensures that a cert-manager Certificate with the
+// provided subject and usages is issued for the provided owner by the provided
+// Issuer or ClusterIssuer (see operatorv1beta1.ClusterCertificateOptions) and
+// returns the Secret holding the certificate.
+// ErrCertificateNotReady is returned until the Certificate is issued.
+// The Secret gets owned by the owner (without being its controller, since it's
+// managed by cert-manager) and its consts.CertificateRenewedAtAnnotation annotation
+// is set when cert-manager renews the certificate so that the Deployments
+// using it can be rolled out using CertificateRenewalDeploymentOpt.
+func EnsureCertManagerCertificate[
+	T interface {
+		*operatorv1beta1.ControlPlane | *operatorv1beta1.DataPlane
+		client.Object
+	},
+](
+	ctx context.Context,
+	owner T,
+	subject string,
+	issuer operatorv1beta1.CertManagerIssuerReference,
+	usages []certificatesv1.KeyUsage,
+	cl client.Client,
+	additionalMatchingLabels client.MatchingLabels,
+) (op.Result, *corev1.Secret, error) {
+	issuerRef, err := k8sresources.CertManagerIssuerRef(issuer)
+	if err != nil {
+		return op.Noop, nil, err
+	}
+
+	matchingLabels := k8sresources.GetManagedLabelForOwner(owner)
+	for k, v := range additionalMatchingLabels {
+		matchingLabels[k] = v
+	}
+
+	var certificateList certmanagerv1.CertificateList
+	if err := cl.List(ctx, &certificateList, client.InNamespace(owner.GetNamespace()), matchingLabels); err != nil {
+		return op.Noop, nil, fmt.Errorf("failed listing Certificates for %T %s/%s: %w", owner, owner.GetNamespace(), owner.GetName(), err)
+	}
+	certificates := lo.Filter(certificateList.Items, func(c certmanagerv1.Certificate, _ int) bool {
+		return lo.ContainsBy(c.GetOwnerReferences(), func(ref metav1.OwnerReference) bool {
+			return ref.UID == owner.GetUID()
+		})
+	})
+
+	switch len(certificates) {
+	case 0:
+		certificate := k8sresources.GenerateNewCertManagerCertificate(owner, subject, issuerRef, usages, matchingLabels)
+		if err := cl.Create(ctx, certificate); err != nil {
+			return op.Noop, nil, fmt.Errorf("failed creating Certificate for %T %s/%s: %w", owner, owner.GetNamespace(), owner.GetName(), err)
+		}
+		return op.Created, nil, ErrCertificateNotReady
+	case 1:
+	default:
+		for _, c := range certificates[1:] {
+			if err := cl.Delete(ctx, &c); client.IgnoreNotFound(err) != nil {
+				return op.Noop, nil, err
+			}
+		}
+		return op.Noop, nil, errors.New("number of certificates reduced")
+	}
+
+	certificate := certificates[0]
+	old := certificate.DeepCopy()
+	k8sresources.SetCertManagerCertificateSpec(&certificate, subject, issuerRef, usages)
+	if !equality.Semantic.DeepEqual(old.Spec, certificate.Spec) {
+		if err := cl.Update(ctx, &certificate); err != nil {
+			return op.Noop, nil, fmt.Errorf("failed updating Certificate %s: %w", certificate.Name, err)
+		}
+		return op.Updated, nil, ErrCertificateNotReady
+	}
+
+	if !isCertManagerCertificateReady(&certificate) {
+		return op.Noop, nil, ErrCertificateNotReady
+	}
+
+	secret := &corev1.Secret{}
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: certificate.Namespace, Name: certificate.Spec.SecretName}, secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return op.Noop, nil, ErrCertificateNotReady
+		}
+		return op.Noop, nil, err
+	}
+
+	if updated := ensureCertManagerSecretMetadata(secret, &certificate, owner); updated {
+		if err := cl.Update(ctx, secret); err != nil {
+			return op.Noop, nil, fmt.Errorf("failed updating secret %s: %w", secret.Name, err)
+		}
+	}
+	return op.Noop, secret, nil
+}
+
+// isCertManagerCertificateReady returns true if the Certificate has been issued
+// for its current spec.
+func isCertManagerCertificateReady(certificate *certmanagerv1.Certificate) bool {
+	return lo.ContainsBy(certificate.Status.Conditions, func(c certmanagerv1.CertificateCondition) bool {
+		return c.Type == certmanagerv1.CertificateConditionReady &&
+			c.Status == cmmeta.ConditionTrue &&
+			c.ObservedGeneration == certificate.Generation
+	})
+}
+
+// ensureCertManagerSecretMetadata ensures that the Secret holding the certificate
+// issued by cert-manager is owned by the provided owner and that the time of the
+// certificate's renewal is set in its consts.CertificateRenewedAtAnnotation annotation.
+// It returns true if the Secret has been modified.
+func ensureCertManagerSecretMetadata(secret *corev1.Secret, certificate *certmanagerv1.Certificate, owner client.Object) bool {
+	var updated bool
+	if !lo.ContainsBy(secret.GetOwnerReferences(), func(ref metav1.OwnerReference) bool {
+		return ref.UID == owner.GetUID()
+	}) {
+		ownerRef := k8sutils.GenerateOwnerReferenceForObject(owner)
+		// cert-manager might be the controller of the Secret.
+		ownerRef.Controller = nil
+		secret.OwnerReferences = append(secret.OwnerReferences, ownerRef)
+		updated = true
+	}
+
+	// The first revision of the certificate is the one the Deployments have been
+	// created with, only subsequent ones are renewals.
+	if lo.FromPtr(certificate.Status.Revision) > 1 && certificate.Status.NotBefore != nil {
+		renewedAt := certificate.Status.NotBefore.UTC().Format(time.RFC3339)
+		if secret.Annotations[consts.CertificateRenewedAtAnnotation] != renewedAt {
+			if secret.Annotations == nil {
+				secret.Annotations = make(map[string]string)
+			}
+			secret.Annotations[consts.CertificateRenewedAtAnnotation] = renewedAt
+			updated = true
+		}
+	}
+	return updated
+}
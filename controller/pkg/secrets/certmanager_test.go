@@ -0,0 +1,131 @@
+package secrets
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/controller/pkg/op"
+	"github.com/kong/gateway-operator/pkg/consts"
+)
+
+func TestEnsureCertManagerCertificate(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, operatorv1beta1.AddToScheme(scheme))
+	require.NoError(t, certmanagerv1.AddToScheme(scheme))
+
+	dp := &operatorv1beta1.DataPlane{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: operatorv1beta1.SchemeGroupVersion.String(),
+			Kind:       "DataPlane",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dp-1",
+			Namespace: "ns",
+			UID:       types.UID("1234"),
+		},
+	}
+	fakeClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(dp).
+		Build()
+
+	ensure := func(issuer operatorv1beta1.CertManagerIssuerReference) (op.Result, *corev1.Secret, error) {
+		return EnsureCertManagerCertificate(
+			ctx,
+			dp,
+			"test-subject",
+			issuer,
+			[]certificatesv1.KeyUsage{
+				certificatesv1.UsageServerAuth,
+			},
+			fakeClient,
+			nil,
+		)
+	}
+	getCertificate := func() *certmanagerv1.Certificate {
+		t.Helper()
+		var certificates certmanagerv1.CertificateList
+		require.NoError(t, fakeClient.List(ctx, &certificates, client.InNamespace("ns")))
+		require.Len(t, certificates.Items, 1)
+		return &certificates.Items[0]
+	}
+
+	t.Log("unsupported issuer kind is rejected")
+	_, _, err := ensure(operatorv1beta1.CertManagerIssuerReference{Kind: "Unknown", Name: "issuer"})
+	require.Error(t, err)
+
+	t.Log("Certificate is created")
+	res, _, err := ensure(operatorv1beta1.CertManagerIssuerReference{Kind: operatorv1beta1.CertManagerIssuerKindIssuer, Name: "issuer"})
+	require.ErrorIs(t, err, ErrCertificateNotReady)
+	require.Equal(t, op.Created, res)
+	certificate := getCertificate()
+	require.Equal(t, cmmeta.ObjectReference{
+		Name:  "issuer",
+		Kind:  certmanagerv1.IssuerKind,
+		Group: "cert-manager.io",
+	}, certificate.Spec.IssuerRef)
+	require.Equal(t, "test-subject", certificate.Spec.CommonName)
+	require.Equal(t, []certmanagerv1.KeyUsage{certmanagerv1.UsageServerAuth}, certificate.Spec.Usages)
+	require.Equal(t, certificate.Name, certificate.Spec.SecretName)
+
+	t.Log("changing the issuer updates the Certificate")
+	res, _, err = ensure(operatorv1beta1.CertManagerIssuerReference{Kind: operatorv1beta1.CertManagerIssuerKindClusterIssuer, Name: "cluster-issuer"})
+	require.ErrorIs(t, err, ErrCertificateNotReady)
+	require.Equal(t, op.Updated, res)
+	certificate = getCertificate()
+	require.Equal(t, certmanagerv1.ClusterIssuerKind, certificate.Spec.IssuerRef.Kind)
+
+	t.Log("Secret is returned once the Certificate is ready")
+	certificate.Status = certmanagerv1.CertificateStatus{
+		Conditions: []certmanagerv1.CertificateCondition{
+			{
+				Type:               certmanagerv1.CertificateConditionReady,
+				Status:             cmmeta.ConditionTrue,
+				ObservedGeneration: certificate.Generation,
+			},
+		},
+		Revision: lo.ToPtr(1),
+	}
+	require.NoError(t, fakeClient.Update(ctx, certificate))
+	require.NoError(t, fakeClient.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      certificate.Spec.SecretName,
+		},
+	}))
+	res, secret, err := ensure(operatorv1beta1.CertManagerIssuerReference{Kind: operatorv1beta1.CertManagerIssuerKindClusterIssuer, Name: "cluster-issuer"})
+	require.NoError(t, err)
+	require.Equal(t, op.Noop, res)
+	require.Equal(t, certificate.Spec.SecretName, secret.Name)
+	require.Len(t, secret.OwnerReferences, 1)
+	require.Equal(t, dp.UID, secret.OwnerReferences[0].UID)
+	require.NotContains(t, secret.Annotations, consts.CertificateRenewedAtAnnotation)
+
+	t.Log("renewal of the certificate is recorded in the Secret")
+	notBefore := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+	certificate = getCertificate()
+	certificate.Status.Revision = lo.ToPtr(2)
+	certificate.Status.NotBefore = &metav1.Time{Time: notBefore}
+	require.NoError(t, fakeClient.Update(ctx, certificate))
+	_, secret, err = ensure(operatorv1beta1.CertManagerIssuerReference{Kind: operatorv1beta1.CertManagerIssuerKindClusterIssuer, Name: "cluster-issuer"})
+	require.NoError(t, err)
+	require.Equal(t, notBefore.Format(time.RFC3339), secret.Annotations[consts.CertificateRenewedAtAnnotation])
+}
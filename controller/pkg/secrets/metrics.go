@@ -0,0 +1,63 @@
+package secrets
+
+import (
+	"context"
+	"crypto/x509"
+	"reflect"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// certificateExpirationTimestamp exposes the expiration time of the certificates
+// issued by the operator so that alerts can be set up for upcoming expirations,
+// e.g. when the certificates cannot be renewed.
+var certificateExpirationTimestamp = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "gateway_operator_certificate_expiration_timestamp_seconds",
+		Help: "Expiration time of the certificates issued by the operator, as a Unix timestamp.",
+	},
+	[]string{"namespace", "secret", "owner_kind", "owner_name"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(certificateExpirationTimestamp)
+}
+
+func setCertificateExpirationMetric(secret *corev1.Secret, owner client.Object, cert *x509.Certificate) {
+	certificateExpirationTimestamp.WithLabelValues(
+		secret.Namespace,
+		secret.Name,
+		reflect.TypeOf(owner).Elem().Name(),
+		owner.GetName(),
+	).Set(float64(cert.NotAfter.Unix()))
+}
+
+// deleteCertificateExpirationMetric deletes the expiration metric of the certificate
+// in the provided Secret, e.g. when the Secret is deleted.
+func deleteCertificateExpirationMetric(secret client.Object) {
+	certificateExpirationTimestamp.DeletePartialMatch(prometheus.Labels{
+		"namespace": secret.GetNamespace(),
+		"secret":    secret.GetName(),
+	})
+}
+
+// deleteCertificateExpirationMetricPreDeleteHook deletes the expiration metric of
+// the certificate in the Secret which is about to be deleted.
+func deleteCertificateExpirationMetricPreDeleteHook(_ context.Context, _ client.Client, secret client.Object) error {
+	deleteCertificateExpirationMetric(secret)
+	return nil
+}
+
+// DeleteCertificateExpirationMetrics deletes the expiration metrics of all the
+// certificates issued for the provided owner. It has to be called once the owner
+// is deleted, since its Secrets are then garbage collected by Kubernetes.
+func DeleteCertificateExpirationMetrics(owner client.Object) {
+	certificateExpirationTimestamp.DeletePartialMatch(prometheus.Labels{
+		"namespace":  owner.GetNamespace(),
+		"owner_kind": reflect.TypeOf(owner).Elem().Name(),
+		"owner_name": owner.GetName(),
+	})
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
 type (
@@ -13,6 +14,18 @@ type (
 	HTTPRoute            = gatewayv1.HTTPRoute
 	HTTPRouteSpec        = gatewayv1.HTTPRouteSpec
 	HTTPRouteList        = gatewayv1.HTTPRouteList
+	GRPCRoute            = gatewayv1.GRPCRoute
+	GRPCRouteSpec        = gatewayv1.GRPCRouteSpec
+	GRPCRouteList        = gatewayv1.GRPCRouteList
+	TCPRoute             = gatewayv1alpha2.TCPRoute
+	TCPRouteSpec         = gatewayv1alpha2.TCPRouteSpec
+	TCPRouteList         = gatewayv1alpha2.TCPRouteList
+	TLSRoute             = gatewayv1alpha2.TLSRoute
+	TLSRouteSpec         = gatewayv1alpha2.TLSRouteSpec
+	TLSRouteList         = gatewayv1alpha2.TLSRouteList
+	UDPRoute             = gatewayv1alpha2.UDPRoute
+	UDPRouteSpec         = gatewayv1alpha2.UDPRouteSpec
+	UDPRouteList         = gatewayv1alpha2.UDPRouteList
 	ParentReference      = gatewayv1.ParentReference
 	CommonRouteSpec      = gatewayv1.CommonRouteSpec
 	Kind                 = gatewayv1.Kind
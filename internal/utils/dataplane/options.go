@@ -0,0 +1,42 @@
+package dataplane
+
+import (
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+)
+
+// OptionsFromGatewayConfiguration returns the DataPlane options configured with
+// the provided GatewayConfiguration DataPlane options.
+func OptionsFromGatewayConfiguration(opts operatorv1beta1.GatewayConfigDataPlaneOptions) *operatorv1beta1.DataPlaneOptions {
+	dataPlaneOptions := &operatorv1beta1.DataPlaneOptions{
+		Deployment: opts.Deployment,
+	}
+
+	if opts.Network.Services != nil && opts.Network.Services.Ingress != nil {
+		dataPlaneOptions.Network = operatorv1beta1.DataPlaneNetworkOptions{
+			Services: &operatorv1beta1.DataPlaneServices{
+				Ingress: &operatorv1beta1.DataPlaneServiceOptions{
+					ServiceOptions: operatorv1beta1.ServiceOptions{
+						Type:                  opts.Network.Services.Ingress.Type,
+						Annotations:           opts.Network.Services.Ingress.Annotations,
+						ExternalTrafficPolicy: opts.Network.Services.Ingress.ExternalTrafficPolicy,
+					},
+				},
+			},
+		}
+	}
+	if opts.Network.Services != nil && opts.Network.Services.PrivateIngress != nil {
+		if dataPlaneOptions.Network.Services == nil {
+			dataPlaneOptions.Network.Services = &operatorv1beta1.DataPlaneServices{}
+		}
+		dataPlaneOptions.Network.Services.PrivateIngress = &operatorv1beta1.DataPlaneServiceOptions{
+			ServiceOptions: operatorv1beta1.ServiceOptions{
+				Type:                  opts.Network.Services.PrivateIngress.Type,
+				Annotations:           opts.Network.Services.PrivateIngress.Annotations,
+				ExternalTrafficPolicy: opts.Network.Services.PrivateIngress.ExternalTrafficPolicy,
+			},
+		}
+	}
+	dataPlaneOptions.Network.ClusterCertificateOptions = opts.Network.ClusterCertificateOptions
+
+	return dataPlaneOptions
+}
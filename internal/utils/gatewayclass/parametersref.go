@@ -0,0 +1,40 @@
+package gatewayclass
+
+import (
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+)
+
+// -----------------------------------------------------------------------------
+// GatewayClass - ParametersRef
+// -----------------------------------------------------------------------------
+
+// IsGatewayConfigurationParametersRef returns true when the provided group and
+// kind of a parametersRef refer to a GatewayConfiguration.
+func IsGatewayConfigurationParametersRef(group gatewayv1.Group, kind gatewayv1.Kind) bool {
+	return string(group) == operatorv1beta1.SchemeGroupVersion.Group &&
+		string(kind) == "GatewayConfiguration"
+}
+
+// ReferencesGatewayConfiguration returns true when the GatewayClass references
+// the provided GatewayConfiguration through its parametersRef.
+func ReferencesGatewayConfiguration(gwc *gatewayv1.GatewayClass, gatewayConfig *operatorv1beta1.GatewayConfiguration) bool {
+	ref := gwc.Spec.ParametersRef
+	return ref != nil &&
+		IsGatewayConfigurationParametersRef(ref.Group, ref.Kind) &&
+		ref.Namespace != nil && string(*ref.Namespace) == gatewayConfig.Namespace &&
+		ref.Name == gatewayConfig.Name
+}
+
+// GatewayReferencesGatewayConfiguration returns true when the Gateway references
+// the provided GatewayConfiguration through its spec.infrastructure.parametersRef.
+func GatewayReferencesGatewayConfiguration(gateway *gatewayv1.Gateway, gatewayConfig *operatorv1beta1.GatewayConfiguration) bool {
+	if gateway.Spec.Infrastructure == nil || gateway.Spec.Infrastructure.ParametersRef == nil {
+		return false
+	}
+	ref := gateway.Spec.Infrastructure.ParametersRef
+	return gateway.Namespace == gatewayConfig.Namespace &&
+		IsGatewayConfigurationParametersRef(ref.Group, ref.Kind) &&
+		ref.Name == gatewayConfig.Name
+}
@@ -8,6 +8,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	operatorv1alpha1 "github.com/kong/gateway-operator/api/v1alpha1"
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
 )
 
@@ -37,3 +38,100 @@ func DataPlaneNameOnControlPlane(ctx context.Context, c cache.Cache) error {
 		return []string{}
 	})
 }
+
+const (
+	// ImagePullSecretIndex is the key to be used to access the .spec.imagePullSecretRef indexed values,
+	// in a form of <namespace>/<name>.
+	ImagePullSecretIndex = "kongPluginInstallationImagePullSecret"
+
+	// KongPluginInstallationsIndex is the key to be used to access the .spec.pluginsToInstall indexed values,
+	// in a form of list of <namespace>/<name>.
+	KongPluginInstallationsIndex = "kongPluginInstallations"
+)
+
+// ImagePullSecretOnKongPluginInstallation indexes the KongPluginInstallation .spec.imagePullSecretRef field
+// on the "kongPluginInstallationImagePullSecret" key.
+func ImagePullSecretOnKongPluginInstallation(ctx context.Context, c cache.Cache) error {
+	if _, err := c.GetInformer(ctx, &operatorv1alpha1.KongPluginInstallation{}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get informer for v1alpha1 KongPluginInstallation: %w, disabling indexing KongPluginInstallations' .spec.imagePullSecretRef", err)
+	}
+
+	return c.IndexField(ctx, &operatorv1alpha1.KongPluginInstallation{}, ImagePullSecretIndex, func(o client.Object) []string {
+		kpi, ok := o.(*operatorv1alpha1.KongPluginInstallation)
+		if !ok || kpi.Spec.ImagePullSecretRef == nil {
+			return []string{}
+		}
+		namespace := kpi.Spec.ImagePullSecretRef.Namespace
+		if namespace == "" {
+			namespace = kpi.Namespace
+		}
+		return []string{namespace + "/" + kpi.Spec.ImagePullSecretRef.Name}
+	})
+}
+
+// KongPluginInstallationsOnDataPlane indexes the DataPlane .spec.pluginsToInstall field
+// on the "kongPluginInstallations" key.
+func KongPluginInstallationsOnDataPlane(ctx context.Context, c cache.Cache) error {
+	if _, err := c.GetInformer(ctx, &operatorv1beta1.DataPlane{}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get informer for v1beta1 DataPlane: %w, disabling indexing KongPluginInstallations for DataPlanes' .spec.pluginsToInstall", err)
+	}
+
+	return c.IndexField(ctx, &operatorv1beta1.DataPlane{}, KongPluginInstallationsIndex, func(o client.Object) []string {
+		dp, ok := o.(*operatorv1beta1.DataPlane)
+		if !ok {
+			return []string{}
+		}
+		result := make([]string, 0, len(dp.Spec.PluginsToInstall))
+		for _, kpi := range dp.Spec.PluginsToInstall {
+			namespace := kpi.Namespace
+			if namespace == "" {
+				namespace = dp.Namespace
+			}
+			result = append(result, namespace+"/"+kpi.Name)
+		}
+		return result
+	})
+}
+
+const (
+	// DataPlaneMetricsExtensionIndex is the key to be used to access the .spec.extensions indexed values
+	// pointing to DataPlaneMetricsExtensions, in a form of list of <namespace>/<name>.
+	DataPlaneMetricsExtensionIndex = "dataplaneMetricsExtension"
+)
+
+// DataPlaneMetricsExtensionOnControlPlane indexes the ControlPlane .spec.extensions field
+// on the "dataplaneMetricsExtension" key.
+func DataPlaneMetricsExtensionOnControlPlane(ctx context.Context, c cache.Cache) error {
+	if _, err := c.GetInformer(ctx, &operatorv1beta1.ControlPlane{}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get informer for v1beta1 ControlPlane: %w, disabling indexing DataPlaneMetricsExtensions for ControlPlanes' .spec.extensions", err)
+	}
+
+	return c.IndexField(ctx, &operatorv1beta1.ControlPlane{}, DataPlaneMetricsExtensionIndex, func(o client.Object) []string {
+		controlPlane, ok := o.(*operatorv1beta1.ControlPlane)
+		if !ok {
+			return []string{}
+		}
+		result := make([]string, 0, len(controlPlane.Spec.Extensions))
+		for _, ext := range controlPlane.Spec.Extensions {
+			if ext.Group != operatorv1alpha1.SchemeGroupVersion.Group ||
+				ext.Kind != operatorv1alpha1.DataPlaneMetricsExtensionKind {
+				continue
+			}
+			namespace := controlPlane.Namespace
+			if ext.Namespace != nil && *ext.Namespace != "" {
+				namespace = *ext.Namespace
+			}
+			result = append(result, namespace+"/"+ext.Name)
+		}
+		return result
+	})
+}
@@ -37,11 +37,6 @@ func (v *Validator) ValidateDeploymentOptions(opts *operatorv1beta1.ControlPlane
 		return errors.New("ControlPlane requires an image")
 	}
 
-	// Ref: https://github.com/Kong/gateway-operator/issues/736
-	if opts.Replicas != nil && *opts.Replicas != 1 {
-		return errors.New("ControlPlane only supports replicas of 1")
-	}
-
 	container := k8sutils.GetPodContainerByName(&opts.PodTemplateSpec.Spec, consts.ControlPlaneControllerContainerName)
 	if container == nil {
 		// We need the controller container for e.g. specifying an image which
@@ -57,3 +52,20 @@ func (v *Validator) ValidateDeploymentOptions(opts *operatorv1beta1.ControlPlane
 
 	return nil
 }
+
+// ValidateClusterCertificateIssuer validates that the ControlPlane's and the DataPlane's
+// certificates securing their communication are issued by the same CA, i.e. that both
+// reference the same cert-manager issuer or neither does, in which case both certificates
+// are issued by the operator's cluster CA.
+func ValidateClusterCertificateIssuer(
+	controlPlaneOpts *operatorv1beta1.ClusterCertificateOptions,
+	dataPlaneOpts *operatorv1beta1.ClusterCertificateOptions,
+) error {
+	if controlPlaneOpts == nil && dataPlaneOpts == nil {
+		return nil
+	}
+	if controlPlaneOpts == nil || dataPlaneOpts == nil || controlPlaneOpts.Issuer != dataPlaneOpts.Issuer {
+		return errors.New("ControlPlane and DataPlane cluster certificates have to be issued by the same cert-manager issuer")
+	}
+	return nil
+}
@@ -57,8 +57,7 @@ func TestValidator_ValidateDeploymentOptions(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			// TODO: https://github.com/Kong/gateway-operator/issues/736
-			name: "using more than 1 replica is an error",
+			name: "using more than 1 replica is allowed",
 			v:    &Validator{},
 			opts: &operatorv1beta1.ControlPlaneDeploymentOptions{
 				Replicas: lo.ToPtr(int32(2)),
@@ -66,13 +65,14 @@ func TestValidator_ValidateDeploymentOptions(t *testing.T) {
 					Spec: corev1.PodSpec{
 						Containers: []corev1.Container{
 							{
-								Name: "controller",
+								Name:  "controller",
+								Image: "kong/kubernetes-ingress-controller:2.12",
 							},
 						},
 					},
 				},
 			},
-			wantErr: true,
+			wantErr: false,
 		},
 		{
 			name: "volumes and volume mounts can be specified on ControlPlane deployment options",
@@ -119,3 +119,61 @@ func TestValidator_ValidateDeploymentOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateClusterCertificateIssuer(t *testing.T) {
+	issuer := &operatorv1beta1.ClusterCertificateOptions{
+		Issuer: operatorv1beta1.CertManagerIssuerReference{
+			Kind: operatorv1beta1.CertManagerIssuerKindIssuer,
+			Name: "issuer",
+		},
+	}
+	clusterIssuer := &operatorv1beta1.ClusterCertificateOptions{
+		Issuer: operatorv1beta1.CertManagerIssuerReference{
+			Kind: operatorv1beta1.CertManagerIssuerKindClusterIssuer,
+			Name: "issuer",
+		},
+	}
+
+	tests := []struct {
+		name             string
+		controlPlaneOpts *operatorv1beta1.ClusterCertificateOptions
+		dataPlaneOpts    *operatorv1beta1.ClusterCertificateOptions
+		wantErr          bool
+	}{
+		{
+			name: "both using the cluster CA is valid",
+		},
+		{
+			name:             "both using the same issuer is valid",
+			controlPlaneOpts: issuer,
+			dataPlaneOpts:    issuer.DeepCopy(),
+		},
+		{
+			name:             "issuers of different kinds are invalid",
+			controlPlaneOpts: issuer,
+			dataPlaneOpts:    clusterIssuer,
+			wantErr:          true,
+		},
+		{
+			name:             "only the ControlPlane using an issuer is invalid",
+			controlPlaneOpts: issuer,
+			wantErr:          true,
+		},
+		{
+			name:          "only the DataPlane using an issuer is invalid",
+			dataPlaneOpts: clusterIssuer,
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateClusterCertificateIssuer(tt.controlPlaneOpts, tt.dataPlaneOpts)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
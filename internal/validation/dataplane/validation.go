@@ -11,7 +11,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/samber/lo"
 	corev1 "k8s.io/api/core/v1"
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
@@ -41,14 +43,35 @@ func (v *Validator) Validate(dataplane *operatorv1beta1.DataPlane) error {
 		return err
 	}
 
-	if err := v.ValidateDataPlaneDeploymentRollout(dataplane.Spec.Deployment.Rollout); err != nil {
+	if err := v.ValidateDataPlaneDatabaseOptions(dataplane); err != nil {
 		return err
 	}
 
-	if dataplane.Spec.Network.Services != nil && dataplane.Spec.Network.Services.Ingress != nil &&
-		dataplane.Spec.Deployment.PodTemplateSpec != nil {
+	if err := v.ValidateDataPlaneDeploymentRollout(dataplane.Spec.Deployment); err != nil {
+		return err
+	}
+
+	if err := v.ValidateDataPlanePluginsToInstall(dataplane); err != nil {
+		return err
+	}
+
+	if dataplane.Spec.Network.Services != nil && dataplane.Spec.Deployment.PodTemplateSpec != nil {
 		proxyContainer := k8sutils.GetPodContainerByName(&dataplane.Spec.Deployment.PodTemplateSpec.Spec, consts.DataPlaneProxyContainerName)
-		if err := v.ValidateDataPlaneIngressServiceOptions(dataplane.Namespace, dataplane.Spec.Network.Services.Ingress, proxyContainer); err != nil {
+		for _, opts := range []*operatorv1beta1.DataPlaneServiceOptions{
+			dataplane.Spec.Network.Services.Ingress,
+			dataplane.Spec.Network.Services.PrivateIngress,
+		} {
+			if opts == nil {
+				continue
+			}
+			if err := v.ValidateDataPlaneIngressServiceOptions(dataplane.Namespace, opts, proxyContainer); err != nil {
+				return err
+			}
+		}
+	}
+
+	if dataplane.Spec.Network.Services != nil && dataplane.Spec.Network.Services.AdminAPI != nil {
+		if err := v.ValidateDataPlaneAdminAPIServiceOptions(dataplane.Spec.Network.Services.AdminAPI); err != nil {
 			return err
 		}
 	}
@@ -56,24 +79,86 @@ func (v *Validator) Validate(dataplane *operatorv1beta1.DataPlane) error {
 	return nil
 }
 
+// ValidateDataPlaneAdminAPIServiceOptions validates the Admin API Service options of DataPlane object.
+func (v *Validator) ValidateDataPlaneAdminAPIServiceOptions(opts *operatorv1beta1.DataPlaneAdminAPIServiceOptions) error {
+	for _, p := range opts.Ports {
+		// The Admin API port is always exposed by the Admin API Service.
+		if p.Port == consts.DataPlaneAdminAPIPort || p.Name == consts.DataPlaneAdminServicePortName {
+			return fmt.Errorf("Admin API Service port %d (%s) conflicts with the Admin API port %d (%s)",
+				p.Port, p.Name, consts.DataPlaneAdminAPIPort, consts.DataPlaneAdminServicePortName)
+		}
+	}
+	return nil
+}
+
 // ValidateDataPlaneDeploymentRollout validates the Rollout field of DataPlane object.
-func (v *Validator) ValidateDataPlaneDeploymentRollout(rollout *operatorv1beta1.Rollout) error {
+func (v *Validator) ValidateDataPlaneDeploymentRollout(deployment operatorv1beta1.DataPlaneDeploymentOptions) error {
+	rollout := deployment.Rollout
 	if rollout != nil && rollout.Strategy.BlueGreen != nil && rollout.Strategy.BlueGreen.Promotion.Strategy == operatorv1beta1.AutomaticPromotion {
-		// Can't use AutomaticPromotion just yet.
-		// Related: https://github.com/Kong/gateway-operator/issues/1006.
-		return errors.New("DataPlane AutomaticPromotion cannot be used yet")
+		// AutomaticPromotion promotes the preview resources only when they pass the analysis.
+		analysis := rollout.Strategy.BlueGreen.Promotion.Analysis
+		if analysis == nil {
+			return errors.New("DataPlane AutomaticPromotion requires promotion analysis to be configured")
+		}
+		if analysis.Interval.Duration <= 0 || analysis.Window.Duration < analysis.Interval.Duration {
+			return errors.New("DataPlane promotion analysis interval has to be positive and not longer than its window")
+		}
+	}
+
+	if rollout != nil && rollout.Strategy.BlueGreen != nil && rollout.Strategy.BlueGreen.PreviewRouting != nil {
+		// Preview routing configures Nginx through these includes and Kong
+		// accepts only a single file for each of them.
+		if podTemplateSpec := deployment.PodTemplateSpec; podTemplateSpec != nil {
+			if container := k8sutils.GetPodContainerByName(&podTemplateSpec.Spec, consts.DataPlaneProxyContainerName); container != nil {
+				for _, env := range []string{"KONG_NGINX_HTTP_INCLUDE", "KONG_NGINX_PROXY_INCLUDE"} {
+					if k8sutils.IsEnvVarPresent(corev1.EnvVar{Name: env}, container.Env) {
+						return fmt.Errorf("DataPlane preview routing cannot be used with %s set on the proxy container", env)
+					}
+				}
+			}
+		}
 	}
 
-	if rollout != nil && rollout.Strategy.BlueGreen != nil &&
-		rollout.Strategy.BlueGreen.Resources.Plan.Deployment == operatorv1beta1.RolloutResourcePlanDeploymentDeleteOnPromotionRecreateOnRollout {
-		// Can't use DeleteOnPromotionRecreateOnRollout just yet.
-		// Related: https://github.com/Kong/gateway-operator/issues/1010.
-		return errors.New("DataPlane Deployment resource plan DeleteOnPromotionRecreateOnRollout cannot be used yet")
+	if rollout != nil && rollout.Strategy.Canary != nil {
+		// The Canary strategy splits the traffic by scaling the live and preview
+		// Deployments which would conflict with the HorizontalPodAutoscaler.
+		if scaling := deployment.Scaling; scaling != nil && scaling.HorizontalScaling != nil {
+			return errors.New("DataPlane Canary rollout strategy cannot be used with horizontal scaling")
+		}
+		steps := rollout.Strategy.Canary.Steps
+		for i := 1; i < len(steps); i++ {
+			if steps[i].Weight <= steps[i-1].Weight {
+				return fmt.Errorf("DataPlane Canary rollout step %d has to have a weight greater than the previous step", i)
+			}
+		}
 	}
 
 	return nil
 }
 
+// ValidateDataPlanePluginsToInstall validates the PluginsToInstall field of DataPlane object.
+func (v *Validator) ValidateDataPlanePluginsToInstall(dataplane *operatorv1beta1.DataPlane) error {
+	seen := make(map[string]struct{}, len(dataplane.Spec.PluginsToInstall))
+	for _, kpi := range dataplane.Spec.PluginsToInstall {
+		if kpi.Namespace != "" && kpi.Namespace != dataplane.Namespace {
+			return fmt.Errorf(
+				"KongPluginInstallation %s/%s: cross namespace references are not supported, it has to be in the DataPlane's namespace %s",
+				kpi.Namespace, kpi.Name, dataplane.Namespace,
+			)
+		}
+		// The name of a KongPluginInstallation is used as the name of the plugin and
+		// Kong uses it as a part of Lua module names, where dots are separators.
+		if strings.Contains(kpi.Name, ".") {
+			return fmt.Errorf("KongPluginInstallation %s: name must not contain dots as it is used as the plugin name", kpi.Name)
+		}
+		if _, ok := seen[kpi.Name]; ok {
+			return fmt.Errorf("KongPluginInstallation %s is referenced more than once", kpi.Name)
+		}
+		seen[kpi.Name] = struct{}{}
+	}
+	return nil
+}
+
 func (v *Validator) ValidateIfRolloutInProgress(dataplane, oldDataPlane *operatorv1beta1.DataPlane) error {
 	if dataplane.Status.RolloutStatus == nil {
 		return nil
@@ -116,17 +201,39 @@ func (v *Validator) ValidateDataPlaneDeploymentOptions(namespace string, opts *o
 		return errors.New("DataPlane requires an image")
 	}
 
-	// validate db mode.
-	dbMode, _, err := k8sutils.GetEnvValueFromContainer(context.Background(), container, namespace, consts.EnvVarKongDatabase, v.c)
+	return nil
+}
+
+// ValidateDataPlaneDatabaseOptions validates the database mode of DataPlane object.
+// DataPlanes run in DB-less mode unless they are configured with a Postgres database.
+func (v *Validator) ValidateDataPlaneDatabaseOptions(dataplane *operatorv1beta1.DataPlane) error {
+	if dataplane.Spec.Deployment.PodTemplateSpec == nil {
+		return nil
+	}
+	container := k8sutils.GetPodContainerByName(&dataplane.Spec.Deployment.PodTemplateSpec.Spec, consts.DataPlaneProxyContainerName)
+	if container == nil {
+		return nil
+	}
+	dbMode, _, err := k8sutils.GetEnvValueFromContainer(context.Background(), container, dataplane.Namespace, consts.EnvVarKongDatabase, v.c)
 	if err != nil {
 		return err
 	}
 
-	// only support dbless mode.
-	if dbMode != "" && dbMode != "off" {
-		return fmt.Errorf("database backend %s of DataPlane not supported currently", dbMode)
+	database := dataplane.Spec.Database
+	if database == nil || database.Postgres == nil {
+		// only support dbless mode without a database configured.
+		if dbMode != "" && dbMode != "off" {
+			return fmt.Errorf("database backend %s of DataPlane not supported currently", dbMode)
+		}
+		return nil
 	}
 
+	if dbMode != "" && dbMode != "postgres" {
+		return fmt.Errorf("database backend %s of DataPlane conflicts with its Postgres database configuration", dbMode)
+	}
+	if dataplane.Spec.Konnect != nil {
+		return errors.New("DataPlane connected to Konnect cannot use a database")
+	}
 	return nil
 }
 
@@ -134,6 +241,10 @@ func (v *Validator) ValidateDataPlaneDeploymentOptions(namespace string, opts *o
 func (v *Validator) ValidateDataPlaneIngressServiceOptions(
 	namespace string, opts *operatorv1beta1.DataPlaneServiceOptions, proxyContainer *corev1.Container,
 ) error {
+	if errs := apimachineryvalidation.ValidateAnnotations(opts.Annotations, field.NewPath("annotations")); len(errs) > 0 {
+		return fmt.Errorf("invalid ingress service annotations: %w", errs.ToAggregate())
+	}
+
 	if len(opts.Ports) > 0 {
 		kongPortMaps, hasKongPortMaps, err := k8sutils.GetEnvValueFromContainer(context.Background(), proxyContainer, namespace, "KONG_PORT_MAPS", v.c)
 		if err != nil {
@@ -143,6 +254,10 @@ func (v *Validator) ValidateDataPlaneIngressServiceOptions(
 		if err != nil {
 			return err
 		}
+		kongStreamListen, hasStreamListen, err := k8sutils.GetEnvValueFromContainer(context.Background(), proxyContainer, namespace, "KONG_STREAM_LISTEN", v.c)
+		if err != nil {
+			return err
+		}
 
 		var portNumberMap map[int32]int32 = make(map[int32]int32, 0)
 		if hasKongPortMaps {
@@ -162,12 +277,25 @@ func (v *Validator) ValidateDataPlaneIngressServiceOptions(
 
 		}
 
+		var streamListenPortNumbers []int32
+		if hasStreamListen {
+			streamListenPortNumbers, err = parseKongProxyListenPortNumbers(kongStreamListen)
+			if err != nil {
+				return err
+			}
+		}
+
 		for _, port := range opts.Ports {
 			targetPortNumber, err := getTargetPortNumber(port.TargetPort, proxyContainer)
 			if err != nil {
 				return fmt.Errorf("failed to get target port of port %d (port name %s) of ingress service: %w",
 					port.Port, port.Name, err)
 			}
+			// Ports of stream listeners are not subject to KONG_PORT_MAPS
+			// and KONG_PROXY_LISTEN.
+			if lo.Contains(streamListenPortNumbers, targetPortNumber) {
+				continue
+			}
 			if hasKongPortMaps && portNumberMap[port.Port] != targetPortNumber {
 				return fmt.Errorf("KONG_PORT_MAPS specified but target port %s not properly set", port.TargetPort.String())
 			}
@@ -220,7 +348,7 @@ func parseKongPortMaps(kongPortMapEnv string) (map[int32]int32, error) {
 	return portNumberMap, nil
 }
 
-// parseKongProxyListenPortNumbers parses `proxy_listen` configuration to listening ports.
+// parseKongProxyListenPortNumbers parses `proxy_listen` (or `stream_listen`) configuration to listening ports.
 // It returns the list of listening port numbers.  For example,
 // `"0.0.0.0:8000 reuseport backlog=16384, 0.0.0.0:8443 http2 ssl reuseport backlog=16384`
 // will be parsed into []int32{8000,8443}.
@@ -3,7 +3,9 @@ package dataplane
 import (
 	"encoding/base64"
 	"testing"
+	"time"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -542,6 +544,90 @@ func TestDataPlaneIngressServiceOptions(t *testing.T) {
 			hasError: true,
 			errMsg:   "target port 8888 not included in KONG_PROXY_LISTEN",
 		},
+		{
+			msg: "dataplane with ingress service options having target port in KONG_STREAM_LISTEN should be valid",
+			dataplane: &operatorv1beta1.DataPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-db-off-in-secret",
+					Namespace: "default",
+				},
+				Spec: operatorv1beta1.DataPlaneSpec{
+					DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+						Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+							DeploymentOptions: operatorv1beta1.DeploymentOptions{
+								PodTemplateSpec: &corev1.PodTemplateSpec{
+									Spec: corev1.PodSpec{
+										Containers: []corev1.Container{
+											{
+												Name: consts.DataPlaneProxyContainerName,
+												Env: []corev1.EnvVar{
+													{Name: "KONG_PORT_MAPS", Value: "80:8080,443:8443"},
+													{Name: "KONG_PROXY_LISTEN", Value: "0.0.0.0:8080 reuseport backlog=16384, 0.0.0.0:8443 http2 ssl reuseport backlog=16384"},
+													{Name: "KONG_STREAM_LISTEN", Value: "0.0.0.0:8888 reuseport backlog=16384, 0.0.0.0:9999 udp reuseport"},
+												},
+												Image: consts.DefaultDataPlaneImage,
+											},
+										},
+									},
+								},
+							},
+						},
+						Network: operatorv1beta1.DataPlaneNetworkOptions{
+							Services: &operatorv1beta1.DataPlaneServices{
+								Ingress: &operatorv1beta1.DataPlaneServiceOptions{
+									Ports: []operatorv1beta1.DataPlaneServicePort{
+										{Name: "http", Port: int32(80), TargetPort: intstr.FromInt(8080)},
+										{Name: "tcp", Port: int32(8888), TargetPort: intstr.FromInt(8888)},
+										{Name: "udp", Port: int32(9999), TargetPort: intstr.FromInt(9999), Protocol: corev1.ProtocolUDP},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			hasError: false,
+		},
+		{
+			msg: "dataplane with ingress service options having invalid annotations should be invalid",
+			dataplane: &operatorv1beta1.DataPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-invalid-annotations",
+					Namespace: "default",
+				},
+				Spec: operatorv1beta1.DataPlaneSpec{
+					DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+						Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+							DeploymentOptions: operatorv1beta1.DeploymentOptions{
+								PodTemplateSpec: &corev1.PodTemplateSpec{
+									Spec: corev1.PodSpec{
+										Containers: []corev1.Container{
+											{
+												Name:  consts.DataPlaneProxyContainerName,
+												Image: consts.DefaultDataPlaneImage,
+											},
+										},
+									},
+								},
+							},
+						},
+						Network: operatorv1beta1.DataPlaneNetworkOptions{
+							Services: &operatorv1beta1.DataPlaneServices{
+								Ingress: &operatorv1beta1.DataPlaneServiceOptions{
+									ServiceOptions: operatorv1beta1.ServiceOptions{
+										Annotations: map[string]string{
+											"invalid key!": "value",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			hasError: true,
+			errMsg:   `invalid ingress service annotations: annotations: Invalid value: "invalid key!": name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -827,3 +913,334 @@ func TestValidateUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateDataPlanePluginsToInstall(t *testing.T) {
+	testCases := []struct {
+		name             string
+		pluginsToInstall []operatorv1beta1.NamespacedName
+		expectedErr      string
+	}{
+		{
+			name: "plugins in the same namespace",
+			pluginsToInstall: []operatorv1beta1.NamespacedName{
+				{Name: "plugin-a"},
+				{Namespace: "default", Name: "plugin-b"},
+			},
+		},
+		{
+			name: "cross namespace reference",
+			pluginsToInstall: []operatorv1beta1.NamespacedName{
+				{Namespace: "other", Name: "plugin-a"},
+			},
+			expectedErr: "cross namespace references are not supported",
+		},
+		{
+			name: "name with dots",
+			pluginsToInstall: []operatorv1beta1.NamespacedName{
+				{Name: "plugin.a"},
+			},
+			expectedErr: "name must not contain dots",
+		},
+		{
+			name: "duplicated reference",
+			pluginsToInstall: []operatorv1beta1.NamespacedName{
+				{Name: "plugin-a"},
+				{Namespace: "default", Name: "plugin-a"},
+			},
+			expectedErr: "is referenced more than once",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dataplane := &operatorv1beta1.DataPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "dp",
+				},
+				Spec: operatorv1beta1.DataPlaneSpec{
+					DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+						PluginsToInstall: tc.pluginsToInstall,
+					},
+				},
+			}
+			err := NewValidator(nil).ValidateDataPlanePluginsToInstall(dataplane)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateDataPlaneDeploymentRolloutCanary(t *testing.T) {
+	canary := func(weights ...int32) *operatorv1beta1.Rollout {
+		steps := make([]operatorv1beta1.CanaryStep, 0, len(weights))
+		for _, w := range weights {
+			steps = append(steps, operatorv1beta1.CanaryStep{Weight: w})
+		}
+		return &operatorv1beta1.Rollout{
+			Strategy: operatorv1beta1.RolloutStrategy{
+				Canary: &operatorv1beta1.CanaryStrategy{Steps: steps},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		deployment  operatorv1beta1.DataPlaneDeploymentOptions
+		expectedErr string
+	}{
+		{
+			name: "increasing weights are valid",
+			deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+				Rollout: canary(10, 50, 100),
+			},
+		},
+		{
+			name: "weights which do not increase are rejected",
+			deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+				Rollout: canary(10, 50, 50),
+			},
+			expectedErr: "DataPlane Canary rollout step 2 has to have a weight greater than the previous step",
+		},
+		{
+			name: "horizontal scaling is rejected",
+			deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+				Rollout: canary(10),
+				DeploymentOptions: operatorv1beta1.DeploymentOptions{
+					Scaling: &operatorv1beta1.Scaling{
+						HorizontalScaling: &operatorv1beta1.HorizontalScaling{
+							MaxReplicas: 5,
+						},
+					},
+				},
+			},
+			expectedErr: "DataPlane Canary rollout strategy cannot be used with horizontal scaling",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewValidator(nil).ValidateDataPlaneDeploymentRollout(tc.deployment)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateDataPlaneDeploymentRolloutAutomaticPromotion(t *testing.T) {
+	automaticPromotion := func(analysis *operatorv1beta1.PromotionAnalysis) operatorv1beta1.DataPlaneDeploymentOptions {
+		return operatorv1beta1.DataPlaneDeploymentOptions{
+			Rollout: &operatorv1beta1.Rollout{
+				Strategy: operatorv1beta1.RolloutStrategy{
+					BlueGreen: &operatorv1beta1.BlueGreenStrategy{
+						Promotion: operatorv1beta1.Promotion{
+							Strategy: operatorv1beta1.AutomaticPromotion,
+							Analysis: analysis,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		deployment  operatorv1beta1.DataPlaneDeploymentOptions
+		expectedErr string
+	}{
+		{
+			name: "analysis is valid",
+			deployment: automaticPromotion(&operatorv1beta1.PromotionAnalysis{
+				Window:       metav1.Duration{Duration: 5 * time.Minute},
+				Interval:     metav1.Duration{Duration: 30 * time.Second},
+				MaxErrorRate: lo.ToPtr(int32(1)),
+			}),
+		},
+		{
+			name:        "missing analysis is rejected",
+			deployment:  automaticPromotion(nil),
+			expectedErr: "DataPlane AutomaticPromotion requires promotion analysis to be configured",
+		},
+		{
+			name: "interval longer than window is rejected",
+			deployment: automaticPromotion(&operatorv1beta1.PromotionAnalysis{
+				Window:   metav1.Duration{Duration: time.Minute},
+				Interval: metav1.Duration{Duration: 2 * time.Minute},
+			}),
+			expectedErr: "DataPlane promotion analysis interval has to be positive and not longer than its window",
+		},
+		{
+			name: "preview routing with user set Nginx include is rejected",
+			deployment: func() operatorv1beta1.DataPlaneDeploymentOptions {
+				d := automaticPromotion(&operatorv1beta1.PromotionAnalysis{
+					Window:   metav1.Duration{Duration: 5 * time.Minute},
+					Interval: metav1.Duration{Duration: 30 * time.Second},
+				})
+				d.Rollout.Strategy.BlueGreen.PreviewRouting = &operatorv1beta1.PreviewRouting{
+					Header: &operatorv1beta1.PreviewRoutingMatch{Name: "X-Preview", Value: "true"},
+				}
+				d.PodTemplateSpec = &corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: consts.DataPlaneProxyContainerName,
+								Env: []corev1.EnvVar{
+									{Name: "KONG_NGINX_PROXY_INCLUDE", Value: "/etc/kong/custom.conf"},
+								},
+							},
+						},
+					},
+				}
+				return d
+			}(),
+			expectedErr: "DataPlane preview routing cannot be used with KONG_NGINX_PROXY_INCLUDE set on the proxy container",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewValidator(nil).ValidateDataPlaneDeploymentRollout(tc.deployment)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateDataPlaneAdminAPIServiceOptions(t *testing.T) {
+	testCases := []struct {
+		name        string
+		ports       []operatorv1beta1.DataPlaneServicePort
+		expectedErr string
+	}{
+		{
+			name: "additional port is valid",
+			ports: []operatorv1beta1.DataPlaneServicePort{
+				{Name: "status", Port: consts.DataPlaneStatusPort},
+			},
+		},
+		{
+			name: "Admin API port number is rejected",
+			ports: []operatorv1beta1.DataPlaneServicePort{
+				{Name: "other", Port: consts.DataPlaneAdminAPIPort},
+			},
+			expectedErr: "Admin API Service port 8444 (other) conflicts with the Admin API port 8444 (admin)",
+		},
+		{
+			name: "Admin API port name is rejected",
+			ports: []operatorv1beta1.DataPlaneServicePort{
+				{Name: consts.DataPlaneAdminServicePortName, Port: consts.DataPlaneStatusPort},
+			},
+			expectedErr: "Admin API Service port 8100 (admin) conflicts with the Admin API port 8444 (admin)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewValidator(nil).ValidateDataPlaneAdminAPIServiceOptions(&operatorv1beta1.DataPlaneAdminAPIServiceOptions{
+				Ports: tc.ports,
+			})
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateDataPlaneDatabaseOptions(t *testing.T) {
+	postgres := &operatorv1beta1.DataPlaneDatabaseOptions{
+		Postgres: &operatorv1beta1.DataPlanePostgresOptions{
+			SecretName: "postgres",
+		},
+	}
+	testCases := []struct {
+		name        string
+		database    *operatorv1beta1.DataPlaneDatabaseOptions
+		konnect     *operatorv1beta1.DataPlaneKonnectOptions
+		env         []corev1.EnvVar
+		expectedErr string
+	}{
+		{
+			name:     "postgres database",
+			database: postgres,
+		},
+		{
+			name:     "postgres database with postgres database backend",
+			database: postgres,
+			env: []corev1.EnvVar{
+				{Name: consts.EnvVarKongDatabase, Value: "postgres"},
+			},
+		},
+		{
+			name:     "postgres database with dbless mode",
+			database: postgres,
+			env: []corev1.EnvVar{
+				{Name: consts.EnvVarKongDatabase, Value: "off"},
+			},
+			expectedErr: "database backend off of DataPlane conflicts with its Postgres database configuration",
+		},
+		{
+			name:     "postgres database with Konnect",
+			database: postgres,
+			konnect: &operatorv1beta1.DataPlaneKonnectOptions{
+				ControlPlaneID: "cp",
+			},
+			expectedErr: "DataPlane connected to Konnect cannot use a database",
+		},
+		{
+			name: "postgres database backend without a database",
+			env: []corev1.EnvVar{
+				{Name: consts.EnvVarKongDatabase, Value: "postgres"},
+			},
+			expectedErr: "database backend postgres of DataPlane not supported currently",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dataplane := &operatorv1beta1.DataPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "dp",
+				},
+				Spec: operatorv1beta1.DataPlaneSpec{
+					DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+						Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+							DeploymentOptions: operatorv1beta1.DeploymentOptions{
+								PodTemplateSpec: &corev1.PodTemplateSpec{
+									Spec: corev1.PodSpec{
+										Containers: []corev1.Container{
+											{
+												Name:  consts.DataPlaneProxyContainerName,
+												Image: consts.DefaultDataPlaneImage,
+												Env:   tc.env,
+											},
+										},
+									},
+								},
+							},
+						},
+						Database: tc.database,
+						Konnect:  tc.konnect,
+					},
+				},
+			}
+			err := NewValidator(fakeclient.NewClientBuilder().Build()).Validate(dataplane)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package gatewayconfiguration
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	dputils "github.com/kong/gateway-operator/internal/utils/dataplane"
+	"github.com/kong/gateway-operator/internal/validation/controlplane"
+	"github.com/kong/gateway-operator/internal/validation/dataplane"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+// Validator validates GatewayConfiguration objects.
+type Validator struct {
+	dataplaneValidator    *dataplane.Validator
+	controlplaneValidator *controlplane.Validator
+}
+
+// NewValidator creates a GatewayConfiguration validator.
+func NewValidator(c client.Client) *Validator {
+	return &Validator{
+		dataplaneValidator:    dataplane.NewValidator(c),
+		controlplaneValidator: controlplane.NewValidator(c),
+	}
+}
+
+// Validate validates a GatewayConfiguration object and return the first validation error found.
+func (v *Validator) Validate(gatewayConfig *operatorv1beta1.GatewayConfiguration) error {
+	if opts := gatewayConfig.Spec.DataPlaneOptions; opts != nil {
+		if err := v.dataplaneValidator.Validate(dataPlaneForOptions(gatewayConfig.Namespace, opts)); err != nil {
+			return fmt.Errorf("invalid dataPlaneOptions: %w", err)
+		}
+	}
+
+	if opts := gatewayConfig.Spec.ControlPlaneOptions; opts != nil {
+		if err := v.controlplaneValidator.Validate(controlPlaneForOptions(gatewayConfig.Namespace, opts)); err != nil {
+			return fmt.Errorf("invalid controlPlaneOptions: %w", err)
+		}
+	}
+
+	if err := validateClusterCertificateOptions(gatewayConfig); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateClusterCertificateOptions validates that the ControlPlane's and the DataPlane's
+// cluster certificates are issued by the same cert-manager issuer when either is set.
+func validateClusterCertificateOptions(gatewayConfig *operatorv1beta1.GatewayConfiguration) error {
+	var controlPlaneOpts, dataPlaneOpts *operatorv1beta1.ClusterCertificateOptions
+	if opts := gatewayConfig.Spec.ControlPlaneOptions; opts != nil {
+		controlPlaneOpts = opts.ClusterCertificateOptions
+	}
+	if opts := gatewayConfig.Spec.DataPlaneOptions; opts != nil {
+		dataPlaneOpts = opts.Network.ClusterCertificateOptions
+	}
+	if err := controlplane.ValidateClusterCertificateIssuer(controlPlaneOpts, dataPlaneOpts); err != nil {
+		return fmt.Errorf("invalid clusterCertificate options: %w", err)
+	}
+	return nil
+}
+
+// dataPlaneForOptions returns a DataPlane with the provided GatewayConfiguration
+// DataPlane options. GatewayConfigurations don't have to specify the proxy
+// container image because it's defaulted for the DataPlanes provisioned for
+// Gateways, hence it's defaulted here too.
+func dataPlaneForOptions(namespace string, opts *operatorv1beta1.GatewayConfigDataPlaneOptions) *operatorv1beta1.DataPlane {
+	dataplane := &operatorv1beta1.DataPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+		},
+		Spec: operatorv1beta1.DataPlaneSpec{
+			DataPlaneOptions: *dputils.OptionsFromGatewayConfiguration(*opts.DeepCopy()),
+		},
+	}
+	dataplane.Spec.Deployment.PodTemplateSpec = podTemplateSpecWithImage(
+		dataplane.Spec.Deployment.PodTemplateSpec, consts.DataPlaneProxyContainerName, consts.DefaultDataPlaneImage,
+	)
+	return dataplane
+}
+
+// controlPlaneForOptions returns a ControlPlane with the provided GatewayConfiguration
+// ControlPlane options. GatewayConfigurations don't have to specify the controller
+// container image because it's defaulted for the ControlPlanes provisioned for
+// Gateways, hence it's defaulted here too.
+func controlPlaneForOptions(namespace string, opts *operatorv1beta1.ControlPlaneOptions) *operatorv1beta1.ControlPlane {
+	controlplane := &operatorv1beta1.ControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+		},
+		Spec: operatorv1beta1.ControlPlaneSpec{
+			ControlPlaneOptions: *opts.DeepCopy(),
+		},
+	}
+	controlplane.Spec.Deployment.PodTemplateSpec = podTemplateSpecWithImage(
+		controlplane.Spec.Deployment.PodTemplateSpec, consts.ControlPlaneControllerContainerName, consts.DefaultControlPlaneImage,
+	)
+	return controlplane
+}
+
+// podTemplateSpecWithImage sets the provided image on the container with the
+// provided name unless it's already set, adding the container when it's missing.
+func podTemplateSpecWithImage(pts *corev1.PodTemplateSpec, containerName, image string) *corev1.PodTemplateSpec {
+	if pts == nil {
+		pts = &corev1.PodTemplateSpec{}
+	}
+	container := k8sutils.GetPodContainerByName(&pts.Spec, containerName)
+	if container == nil {
+		pts.Spec.Containers = append(pts.Spec.Containers, corev1.Container{
+			Name:  containerName,
+			Image: image,
+		})
+		return pts
+	}
+	if container.Image == "" {
+		container.Image = image
+	}
+	return pts
+}
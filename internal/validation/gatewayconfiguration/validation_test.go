@@ -0,0 +1,247 @@
+package gatewayconfiguration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
+)
+
+func TestValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    operatorv1beta1.GatewayConfigurationSpec
+		wantErr string
+	}{
+		{
+			name: "empty options are valid",
+		},
+		{
+			name: "options without images are valid",
+			spec: operatorv1beta1.GatewayConfigurationSpec{
+				DataPlaneOptions: &operatorv1beta1.GatewayConfigDataPlaneOptions{
+					Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+						DeploymentOptions: operatorv1beta1.DeploymentOptions{
+							PodTemplateSpec: &corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{
+										{
+											Name: consts.DataPlaneProxyContainerName,
+											Env: []corev1.EnvVar{
+												{Name: "KONG_LOG_LEVEL", Value: "debug"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				ControlPlaneOptions: &operatorv1beta1.ControlPlaneOptions{
+					Deployment: operatorv1beta1.ControlPlaneDeploymentOptions{
+						PodTemplateSpec: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: consts.ControlPlaneControllerContainerName,
+										Env: []corev1.EnvVar{
+											{Name: "CONTROLLER_LOG_LEVEL", Value: "debug"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "unsupported DataPlane database mode is invalid",
+			spec: operatorv1beta1.GatewayConfigurationSpec{
+				DataPlaneOptions: &operatorv1beta1.GatewayConfigDataPlaneOptions{
+					Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+						DeploymentOptions: operatorv1beta1.DeploymentOptions{
+							PodTemplateSpec: &corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{
+										{
+											Name: consts.DataPlaneProxyContainerName,
+											Env: []corev1.EnvVar{
+												{Name: consts.EnvVarKongDatabase, Value: "cassandra"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: "invalid dataPlaneOptions: database backend cassandra of DataPlane not supported currently",
+		},
+		{
+			name: "DataPlane automatic promotion without analysis is invalid",
+			spec: operatorv1beta1.GatewayConfigurationSpec{
+				DataPlaneOptions: &operatorv1beta1.GatewayConfigDataPlaneOptions{
+					Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+						Rollout: &operatorv1beta1.Rollout{
+							Strategy: operatorv1beta1.RolloutStrategy{
+								BlueGreen: &operatorv1beta1.BlueGreenStrategy{
+									Promotion: operatorv1beta1.Promotion{
+										Strategy: operatorv1beta1.AutomaticPromotion,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: "invalid dataPlaneOptions: DataPlane AutomaticPromotion requires promotion analysis to be configured",
+		},
+		{
+			name: "valid ingress service options are valid",
+			spec: operatorv1beta1.GatewayConfigurationSpec{
+				DataPlaneOptions: &operatorv1beta1.GatewayConfigDataPlaneOptions{
+					Network: operatorv1beta1.GatewayConfigDataPlaneNetworkOptions{
+						Services: &operatorv1beta1.GatewayConfigDataPlaneServices{
+							Ingress: &operatorv1beta1.GatewayConfigServiceOptions{
+								ServiceOptions: operatorv1beta1.ServiceOptions{
+									Type: corev1.ServiceTypeLoadBalancer,
+									Annotations: map[string]string{
+										"service.beta.kubernetes.io/aws-load-balancer-type": "nlb",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid ingress service options are invalid",
+			spec: operatorv1beta1.GatewayConfigurationSpec{
+				DataPlaneOptions: &operatorv1beta1.GatewayConfigDataPlaneOptions{
+					Network: operatorv1beta1.GatewayConfigDataPlaneNetworkOptions{
+						Services: &operatorv1beta1.GatewayConfigDataPlaneServices{
+							Ingress: &operatorv1beta1.GatewayConfigServiceOptions{
+								ServiceOptions: operatorv1beta1.ServiceOptions{
+									Annotations: map[string]string{
+										"invalid key!": "value",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: "invalid dataPlaneOptions: invalid ingress service annotations",
+		},
+		{
+			name: "invalid private ingress service options are invalid",
+			spec: operatorv1beta1.GatewayConfigurationSpec{
+				DataPlaneOptions: &operatorv1beta1.GatewayConfigDataPlaneOptions{
+					Network: operatorv1beta1.GatewayConfigDataPlaneNetworkOptions{
+						Services: &operatorv1beta1.GatewayConfigDataPlaneServices{
+							PrivateIngress: &operatorv1beta1.GatewayConfigServiceOptions{
+								ServiceOptions: operatorv1beta1.ServiceOptions{
+									Annotations: map[string]string{
+										"invalid key!": "value",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: "invalid dataPlaneOptions: invalid ingress service annotations",
+		},
+		{
+			name: "same ControlPlane and DataPlane cluster certificate issuers are valid",
+			spec: operatorv1beta1.GatewayConfigurationSpec{
+				ControlPlaneOptions: &operatorv1beta1.ControlPlaneOptions{
+					ClusterCertificateOptions: &operatorv1beta1.ClusterCertificateOptions{
+						Issuer: operatorv1beta1.CertManagerIssuerReference{
+							Kind: operatorv1beta1.CertManagerIssuerKindIssuer,
+							Name: "issuer",
+						},
+					},
+				},
+				DataPlaneOptions: &operatorv1beta1.GatewayConfigDataPlaneOptions{
+					Network: operatorv1beta1.GatewayConfigDataPlaneNetworkOptions{
+						ClusterCertificateOptions: &operatorv1beta1.ClusterCertificateOptions{
+							Issuer: operatorv1beta1.CertManagerIssuerReference{
+								Kind: operatorv1beta1.CertManagerIssuerKindIssuer,
+								Name: "issuer",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "different ControlPlane and DataPlane cluster certificate issuer kinds are invalid",
+			spec: operatorv1beta1.GatewayConfigurationSpec{
+				ControlPlaneOptions: &operatorv1beta1.ControlPlaneOptions{
+					ClusterCertificateOptions: &operatorv1beta1.ClusterCertificateOptions{
+						Issuer: operatorv1beta1.CertManagerIssuerReference{
+							Kind: operatorv1beta1.CertManagerIssuerKindIssuer,
+							Name: "issuer",
+						},
+					},
+				},
+				DataPlaneOptions: &operatorv1beta1.GatewayConfigDataPlaneOptions{
+					Network: operatorv1beta1.GatewayConfigDataPlaneNetworkOptions{
+						ClusterCertificateOptions: &operatorv1beta1.ClusterCertificateOptions{
+							Issuer: operatorv1beta1.CertManagerIssuerReference{
+								Kind: operatorv1beta1.CertManagerIssuerKindClusterIssuer,
+								Name: "issuer",
+							},
+						},
+					},
+				},
+			},
+			wantErr: "invalid clusterCertificate options: ControlPlane and DataPlane cluster certificates have to be issued by the same cert-manager issuer",
+		},
+		{
+			name: "ControlPlane cluster certificate issuer without the DataPlane's is invalid",
+			spec: operatorv1beta1.GatewayConfigurationSpec{
+				ControlPlaneOptions: &operatorv1beta1.ControlPlaneOptions{
+					ClusterCertificateOptions: &operatorv1beta1.ClusterCertificateOptions{
+						Issuer: operatorv1beta1.CertManagerIssuerReference{
+							Kind: operatorv1beta1.CertManagerIssuerKindClusterIssuer,
+							Name: "issuer",
+						},
+					},
+				},
+			},
+			wantErr: "invalid clusterCertificate options",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewValidator(fakectrlruntimeclient.NewClientBuilder().Build())
+			gatewayConfig := &operatorv1beta1.GatewayConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "config",
+					Namespace: "default",
+				},
+				Spec: tt.spec,
+			}
+			specBefore := gatewayConfig.Spec.DeepCopy()
+
+			err := v.Validate(gatewayConfig)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, specBefore, &gatewayConfig.Spec, "the GatewayConfiguration must not be modified")
+		})
+	}
+}
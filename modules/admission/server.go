@@ -15,6 +15,7 @@ import (
 
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
 	"github.com/kong/gateway-operator/internal/validation/dataplane"
+	"github.com/kong/gateway-operator/internal/validation/gatewayconfiguration"
 )
 
 var (
@@ -26,6 +27,7 @@ var (
 type Validator interface {
 	ValidateControlPlane(ctx context.Context, controlplane operatorv1beta1.ControlPlane) error
 	ValidateDataPlane(ctx context.Context, dataplane operatorv1beta1.DataPlane, old operatorv1beta1.DataPlane, op admissionv1.Operation) error
+	ValidateGatewayConfiguration(ctx context.Context, gatewayConfiguration operatorv1beta1.GatewayConfiguration) error
 }
 
 // RequestHandler handles the requests of validating objects.
@@ -40,7 +42,8 @@ type RequestHandler struct {
 func NewRequestHandler(c client.Client, l logr.Logger) *RequestHandler {
 	return &RequestHandler{
 		Validator: &validator{
-			dataplaneValidator: dataplane.NewValidator(c),
+			dataplaneValidator:            dataplane.NewValidator(c),
+			gatewayConfigurationValidator: gatewayconfiguration.NewValidator(c),
 		},
 		Logger: l.WithValues("component", "validation-server"),
 	}
@@ -94,6 +97,11 @@ var (
 		Version:  operatorv1beta1.SchemeGroupVersion.Version,
 		Resource: "dataplanes",
 	}
+	gatewayConfigurationGVResource = metav1.GroupVersionResource{
+		Group:    operatorv1beta1.SchemeGroupVersion.Group,
+		Version:  operatorv1beta1.SchemeGroupVersion.Version,
+		Resource: "gatewayconfigurations",
+	}
 )
 
 func (h *RequestHandler) handleValidation(ctx context.Context, req *admissionv1.AdmissionRequest) (
@@ -149,6 +157,19 @@ func (h *RequestHandler) handleValidation(ctx context.Context, req *admissionv1.
 				msg = err.Error()
 			}
 		}
+	case gatewayConfigurationGVResource:
+		gatewayConfiguration := operatorv1beta1.GatewayConfiguration{}
+		if req.Operation == admissionv1.Create || req.Operation == admissionv1.Update {
+			_, _, err := deserializer.Decode(req.Object.Raw, nil, &gatewayConfiguration)
+			if err != nil {
+				return nil, err
+			}
+			err = h.Validator.ValidateGatewayConfiguration(ctx, gatewayConfiguration)
+			if err != nil {
+				ok = false
+				msg = err.Error()
+			}
+		}
 	}
 
 	response.UID = req.UID
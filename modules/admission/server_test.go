@@ -433,3 +433,106 @@ func TestHandleDataPlaneValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleGatewayConfigurationValidation(t *testing.T) {
+	handler := NewRequestHandler(fakeclient.NewClientBuilder().Build(), logr.Discard())
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	testCases := []struct {
+		name          string
+		gatewayConfig *operatorv1beta1.GatewayConfiguration
+		hasError      bool
+		errMsg        string
+	}{
+		{
+			name: "validate_ok:no_image",
+			gatewayConfig: &operatorv1beta1.GatewayConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-no-image",
+					Namespace: "default",
+				},
+				Spec: operatorv1beta1.GatewayConfigurationSpec{
+					DataPlaneOptions:    &operatorv1beta1.GatewayConfigDataPlaneOptions{},
+					ControlPlaneOptions: &operatorv1beta1.ControlPlaneOptions{},
+				},
+			},
+			hasError: false,
+		},
+		{
+			name: "validate_error:database=xxx",
+			gatewayConfig: &operatorv1beta1.GatewayConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-db-xxx",
+					Namespace: "default",
+				},
+				Spec: operatorv1beta1.GatewayConfigurationSpec{
+					DataPlaneOptions: &operatorv1beta1.GatewayConfigDataPlaneOptions{
+						Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+							DeploymentOptions: operatorv1beta1.DeploymentOptions{
+								PodTemplateSpec: &corev1.PodTemplateSpec{
+									Spec: corev1.PodSpec{
+										Containers: []corev1.Container{
+											{
+												Name: consts.DataPlaneProxyContainerName,
+												Env: []corev1.EnvVar{
+													{
+														Name:  consts.EnvVarKongDatabase,
+														Value: "xxx",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			hasError: true,
+			errMsg:   "invalid dataPlaneOptions: database backend xxx of DataPlane not supported currently",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			review := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   operatorv1beta1.SchemeGroupVersion.Group,
+						Version: operatorv1beta1.SchemeGroupVersion.Version,
+						Kind:    "gatewayconfigurations",
+					},
+					Resource:  gatewayConfigurationGVResource,
+					Name:      tc.gatewayConfig.Name,
+					Namespace: tc.gatewayConfig.Namespace,
+					Operation: admissionv1.Create,
+					Object: runtime.RawExtension{
+						Object: tc.gatewayConfig,
+					},
+				},
+			}
+
+			buf, err := json.Marshal(review)
+			require.NoError(t, err)
+			req, err := http.NewRequest("POST", server.URL, bytes.NewReader(buf))
+			require.NoError(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			resp.Body.Close()
+			respReview := &admissionv1.AdmissionReview{}
+			require.NoError(t, json.Unmarshal(body, respReview))
+			validationResp := respReview.Response
+
+			if !tc.hasError {
+				require.EqualValues(t, http.StatusOK, validationResp.Result.Code, "response code should be 200 OK")
+			} else {
+				require.EqualValues(t, http.StatusBadRequest, validationResp.Result.Code, "response code should be 400 Bad Request")
+				require.Equal(t, tc.errMsg, validationResp.Result.Message)
+			}
+		})
+	}
+}
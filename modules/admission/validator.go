@@ -8,11 +8,13 @@ import (
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
 	controlplanevalidation "github.com/kong/gateway-operator/internal/validation/controlplane"
 	dataplanevalidation "github.com/kong/gateway-operator/internal/validation/dataplane"
+	gatewayconfigurationvalidation "github.com/kong/gateway-operator/internal/validation/gatewayconfiguration"
 )
 
 type validator struct {
-	dataplaneValidator    *dataplanevalidation.Validator
-	controlplaneValidator *controlplanevalidation.Validator
+	dataplaneValidator            *dataplanevalidation.Validator
+	controlplaneValidator         *controlplanevalidation.Validator
+	gatewayConfigurationValidator *gatewayconfigurationvalidation.Validator
 }
 
 // ValidateControlPlane validates the ControlPlane resource.
@@ -37,3 +39,8 @@ func (v *validator) ValidateDataPlane(ctx context.Context, dataPlane, old operat
 		return nil
 	}
 }
+
+// ValidateGatewayConfiguration validates the GatewayConfiguration resource.
+func (v *validator) ValidateGatewayConfiguration(ctx context.Context, gatewayConfiguration operatorv1beta1.GatewayConfiguration) error {
+	return v.gatewayConfigurationValidator.Validate(&gatewayConfiguration)
+}
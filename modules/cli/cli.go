@@ -13,6 +13,7 @@ import (
 	"github.com/kong/gateway-operator/modules/manager"
 	"github.com/kong/gateway-operator/modules/manager/logging"
 	"github.com/kong/gateway-operator/modules/manager/metadata"
+	"github.com/kong/gateway-operator/pkg/consts"
 )
 
 // New returns a new CLI.
@@ -34,6 +35,10 @@ func New(m metadata.Info) *CLI {
 	flagSet.StringVar(&cfg.ControllerName, "controller-name", "", "Controller name to use if other than the default, only needed for multi-tenancy.")
 	flagSet.StringVar(&cfg.ClusterCASecretName, "cluster-ca-secret", "kong-operator-ca", "Name of the Secret containing the cluster CA certificate.")
 	flagSet.StringVar(&deferCfg.ClusterCASecretNamespace, "cluster-ca-secret-namespace", "", "Name of the namespace for Secret containing the cluster CA certificate.")
+	flagSet.DurationVar(&cfg.ClusterCertificateRenewalWindow, "cluster-certificate-renewal-window", consts.DefaultClusterCertificateRenewalWindow,
+		"Duration before expiration of the certificates issued by the operator (e.g. for DataPlane and ControlPlane mTLS) when they get renewed. Has to be shorter than their lifetime of 1 year.")
+	flagSet.DurationVar(&cfg.ClusterCARenewalWindow, "cluster-ca-renewal-window", consts.DefaultClusterCARenewalWindow,
+		"Duration before expiration of the cluster CA when it gets rotated. Should be longer than --cluster-certificate-renewal-window.")
 
 	// controllers for standard APIs and features
 	flagSet.BoolVar(&cfg.GatewayControllerEnabled, "enable-controller-gateway", true, "Enable the Gateway controller.")
@@ -43,6 +48,8 @@ func New(m metadata.Info) *CLI {
 
 	// controllers for specialized APIs and features
 	flagSet.BoolVar(&cfg.AIGatewayControllerEnabled, "enable-controller-aigateway", false, "Enable the AIGateway controller. (Experimental).")
+	flagSet.BoolVar(&cfg.KongPluginInstallationControllerEnabled, "enable-controller-kongplugininstallation", false, "Enable the KongPluginInstallation controller. (Experimental).")
+	flagSet.BoolVar(&cfg.DataPlaneMetricsExtensionControllerEnabled, "enable-controller-dataplanemetricsextension", false, "Enable the DataPlaneMetricsExtension controller. (Experimental).")
 
 	// controllers for Konnect APIs
 	flagSet.BoolVar(&cfg.KonnectControllersEnabled, "enable-controller-konnect", false, "Enable the Konnect controllers.")
@@ -11,6 +11,7 @@ import (
 	"github.com/kong/gateway-operator/modules/manager"
 	"github.com/kong/gateway-operator/modules/manager/logging"
 	"github.com/kong/gateway-operator/modules/manager/metadata"
+	"github.com/kong/gateway-operator/pkg/consts"
 )
 
 func TestParse(t *testing.T) {
@@ -130,26 +131,30 @@ func TestParseWithAdditionalFlags(t *testing.T) {
 
 func expectedDefaultCfg() manager.Config {
 	return manager.Config{
-		MetricsAddr:                         ":8080",
-		ProbeAddr:                           ":8081",
-		WebhookCertDir:                      "/tmp/k8s-webhook-server/serving-certs",
-		WebhookPort:                         9443,
-		LeaderElection:                      true,
-		LeaderElectionNamespace:             "kong-system",
-		DevelopmentMode:                     false,
-		ControllerName:                      "",
-		ControllerNamespace:                 "kong-system",
-		AnonymousReports:                    true,
-		APIServerPath:                       "",
-		KubeconfigPath:                      "",
-		ClusterCASecretName:                 "kong-operator-ca",
-		ClusterCASecretNamespace:            "kong-system",
-		GatewayControllerEnabled:            true,
-		ControlPlaneControllerEnabled:       true,
-		DataPlaneControllerEnabled:          true,
-		DataPlaneBlueGreenControllerEnabled: true,
-		KonnectControllersEnabled:           false,
-		ValidatingWebhookEnabled:            true,
-		LoggerOpts:                          &zap.Options{},
+		MetricsAddr:                                ":8080",
+		ProbeAddr:                                  ":8081",
+		WebhookCertDir:                             "/tmp/k8s-webhook-server/serving-certs",
+		WebhookPort:                                9443,
+		LeaderElection:                             true,
+		LeaderElectionNamespace:                    "kong-system",
+		DevelopmentMode:                            false,
+		ControllerName:                             "",
+		ControllerNamespace:                        "kong-system",
+		AnonymousReports:                           true,
+		APIServerPath:                              "",
+		KubeconfigPath:                             "",
+		ClusterCASecretName:                        "kong-operator-ca",
+		ClusterCASecretNamespace:                   "kong-system",
+		ClusterCertificateRenewalWindow:            consts.DefaultClusterCertificateRenewalWindow,
+		ClusterCARenewalWindow:                     consts.DefaultClusterCARenewalWindow,
+		GatewayControllerEnabled:                   true,
+		ControlPlaneControllerEnabled:              true,
+		DataPlaneControllerEnabled:                 true,
+		DataPlaneBlueGreenControllerEnabled:        true,
+		KongPluginInstallationControllerEnabled:    false,
+		DataPlaneMetricsExtensionControllerEnabled: false,
+		KonnectControllersEnabled:                  false,
+		ValidatingWebhookEnabled:                   true,
+		LoggerOpts:                                 &zap.Options{},
 	}
 }
@@ -0,0 +1,241 @@
+/*
+Copyright 2024 Kong Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
+)
+
+// caRotationCheckInterval is the interval in which the CA manager checks
+// whether the cluster CA should be rotated or the rotation can progress.
+const caRotationCheckInterval = 30 * time.Second
+
+// maybeRotateCACertificate drives the rotation of the cluster CA. The rotation
+// is started when the cluster CA Secret is annotated with consts.ClusterCARotateAnnotation
+// or when the CA certificate expires within the renewal window and consists of
+// the following phases:
+//
+//   - AddNewCA: the new CA is generated and added to the CA bundle trusted by
+//     ControlPlanes and DataPlanes, certificates are still issued by the old CA.
+//   - SignWithNewCA: the new CA becomes the issuer and certificates are re-issued.
+//   - RemoveOldCA: the old CA is removed from the CA bundle.
+//
+// The controllers issuing certificates re-issue them when the cluster CA Secret
+// changes, which rolls out ControlPlane and DataPlane pods. The rotation moves
+// on to the next phase only after all ControlPlane and DataPlane Deployments have
+// rolled out, so that the old CA is removed only when nothing relies on it anymore.
+// Deployments scaled to 0 are not waited for, see deploymentRolledOutSince.
+func (m *caManager) maybeRotateCACertificate(ctx context.Context) error {
+	ca := &corev1.Secret{}
+	if err := m.client.Get(ctx, client.ObjectKey{Namespace: m.secretNamespace, Name: m.secretName}, ca); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	phase := consts.ClusterCARotationPhase(ca.GetAnnotations()[consts.ClusterCARotationPhaseAnnotation])
+	if phase != "" {
+		startedAt, err := time.Parse(time.RFC3339, ca.GetAnnotations()[consts.ClusterCARotationPhaseStartedAtAnnotation])
+		if err != nil {
+			return fmt.Errorf("invalid %s annotation on CA Secret %s: %w",
+				consts.ClusterCARotationPhaseStartedAtAnnotation, m.secretName, err,
+			)
+		}
+		rolledOut, err := m.deploymentsRolledOutSince(ctx, startedAt)
+		if err != nil {
+			return err
+		}
+		if !rolledOut {
+			return nil
+		}
+	}
+
+	switch phase {
+	case "":
+		reason, err := m.caRotationReason(ca, now)
+		if err != nil || reason == "" {
+			return err
+		}
+		m.logger.Info(fmt.Sprintf("rotating CA certificate in Secret %s because %s", m.secretName, reason))
+
+		crt, key, err := generateCACertificate()
+		if err != nil {
+			return err
+		}
+		ca.Data[consts.ClusterCANextCertKey] = crt
+		ca.Data[consts.ClusterCANextKeyKey] = key
+		ca.Data[consts.CACRT] = append(bytes.Clone(ca.Data["tls.crt"]), crt...)
+		delete(ca.Annotations, consts.ClusterCARotateAnnotation)
+		setCARotationPhase(ca, consts.ClusterCARotationPhaseAddNewCA, now)
+
+	case consts.ClusterCARotationPhaseAddNewCA:
+		ca.Data["tls.crt"] = ca.Data[consts.ClusterCANextCertKey]
+		ca.Data["tls.key"] = ca.Data[consts.ClusterCANextKeyKey]
+		delete(ca.Data, consts.ClusterCANextCertKey)
+		delete(ca.Data, consts.ClusterCANextKeyKey)
+		setCARotationPhase(ca, consts.ClusterCARotationPhaseSignWithNewCA, now)
+
+	case consts.ClusterCARotationPhaseSignWithNewCA:
+		delete(ca.Data, consts.CACRT)
+		setCARotationPhase(ca, consts.ClusterCARotationPhaseRemoveOldCA, now)
+
+	case consts.ClusterCARotationPhaseRemoveOldCA:
+		delete(ca.Annotations, consts.ClusterCARotationPhaseAnnotation)
+		delete(ca.Annotations, consts.ClusterCARotationPhaseStartedAtAnnotation)
+		m.logger.Info(fmt.Sprintf("CA certificate rotation in Secret %s completed", m.secretName))
+
+	default:
+		return fmt.Errorf("unknown CA rotation phase %q in CA Secret %s", phase, m.secretName)
+	}
+
+	if phase != "" {
+		m.logger.Info(fmt.Sprintf("CA certificate rotation phase %s in Secret %s completed", phase, m.secretName))
+	}
+	return m.client.Update(ctx, ca)
+}
+
+// caRotationReason returns the reason why the cluster CA should be rotated or
+// an empty string if it shouldn't.
+func (m *caManager) caRotationReason(ca *corev1.Secret, now time.Time) (string, error) {
+	if _, ok := ca.GetAnnotations()[consts.ClusterCARotateAnnotation]; ok {
+		return fmt.Sprintf("it has been requested with the %s annotation", consts.ClusterCARotateAnnotation), nil
+	}
+
+	block, _ := pem.Decode(ca.Data["tls.crt"])
+	if block == nil {
+		return "", fmt.Errorf("failed decoding 'tls.crt' data from CA Secret %s", m.secretName)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", err
+	}
+	if cert.NotAfter.Sub(now) <= m.renewalWindow {
+		return fmt.Sprintf("it expires at %s", cert.NotAfter.Format(time.RFC3339)), nil
+	}
+	return "", nil
+}
+
+// deploymentsRolledOutSince returns true if all the ControlPlane and DataPlane
+// Deployments have rolled out their pods using certificates renewed after the
+// provided time.
+func (m *caManager) deploymentsRolledOutSince(ctx context.Context, t time.Time) (bool, error) {
+	req, err := labels.NewRequirement(
+		consts.GatewayOperatorManagedByLabel,
+		selection.In,
+		[]string{consts.ControlPlaneManagedLabelValue, consts.DataPlaneManagedLabelValue},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	var deployments appsv1.DeploymentList
+	if err := m.client.List(ctx, &deployments, &client.ListOptions{
+		LabelSelector: labels.NewSelector().Add(*req),
+	}); err != nil {
+		return false, fmt.Errorf("failed listing Deployments: %w", err)
+	}
+
+	for _, d := range deployments.Items {
+		usesClusterCA, err := m.deploymentUsesClusterCA(ctx, d)
+		if err != nil {
+			return false, err
+		}
+		if !usesClusterCA {
+			continue
+		}
+		if !deploymentRolledOutSince(d, t) {
+			m.logger.V(1).Info(fmt.Sprintf("waiting for Deployment %s/%s to roll out before CA rotation progresses", d.Namespace, d.Name))
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// deploymentUsesClusterCA returns false if the Deployment belongs to a ControlPlane
+// or a DataPlane which uses certificates issued by cert-manager instead of the
+// cluster CA, and hence is not affected by the cluster CA rotation.
+func (m *caManager) deploymentUsesClusterCA(ctx context.Context, d appsv1.Deployment) (bool, error) {
+	for _, ref := range d.GetOwnerReferences() {
+		if ref.APIVersion != operatorv1beta1.SchemeGroupVersion.String() {
+			continue
+		}
+		nn := client.ObjectKey{Namespace: d.Namespace, Name: ref.Name}
+		switch ref.Kind {
+		case "ControlPlane":
+			var cp operatorv1beta1.ControlPlane
+			if err := m.client.Get(ctx, nn, &cp); err != nil {
+				return false, client.IgnoreNotFound(err)
+			}
+			return cp.Spec.ClusterCertificateOptions == nil, nil
+		case "DataPlane":
+			var dp operatorv1beta1.DataPlane
+			if err := m.client.Get(ctx, nn, &dp); err != nil {
+				return false, client.IgnoreNotFound(err)
+			}
+			return dp.Spec.Network.ClusterCertificateOptions == nil, nil
+		}
+	}
+	return true, nil
+}
+
+// deploymentRolledOutSince returns true if the Deployment's pod template uses
+// certificates renewed after the provided time and all its pods have been
+// updated and are available.
+//
+// Deployments scaled to 0, e.g. BlueGreen preview Deployments waiting for a spec
+// change, have no pods relying on the old certificates, so they're considered
+// rolled out. Preview Deployments with replicas are waited for like any other
+// Deployment since their pods take part in the admin API mTLS and would stop
+// trusting the ControlPlane once the old CA is removed from the bundle.
+func deploymentRolledOutSince(d appsv1.Deployment, t time.Time) bool {
+	replicas := lo.FromPtrOr(d.Spec.Replicas, 1)
+	if replicas == 0 {
+		return true
+	}
+
+	renewedAt, err := time.Parse(time.RFC3339, d.Spec.Template.Annotations[consts.CertificateRenewedAtAnnotation])
+	if err != nil || renewedAt.Before(t) {
+		return false
+	}
+
+	return d.Status.ObservedGeneration >= d.Generation &&
+		d.Status.Replicas == replicas &&
+		d.Status.UpdatedReplicas == replicas &&
+		d.Status.AvailableReplicas == replicas
+}
+
+func setCARotationPhase(ca *corev1.Secret, phase consts.ClusterCARotationPhase, now time.Time) {
+	if ca.Annotations == nil {
+		ca.Annotations = make(map[string]string)
+	}
+	ca.Annotations[consts.ClusterCARotationPhaseAnnotation] = string(phase)
+	ca.Annotations[consts.ClusterCARotationPhaseStartedAtAnnotation] = now.UTC().Format(time.RFC3339)
+}
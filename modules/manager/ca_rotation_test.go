@@ -0,0 +1,194 @@
+/*
+Copyright 2024 Kong Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kong/gateway-operator/pkg/consts"
+)
+
+func TestCARotation(t *testing.T) {
+	ctx := context.Background()
+
+	testScheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(testScheme))
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "dataplane-deployment",
+			Labels: map[string]string{
+				consts.GatewayOperatorManagedByLabel: consts.DataPlaneManagedLabelValue,
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: lo.ToPtr(int32(1)),
+		},
+	}
+	// Preview Deployments have to roll out too unless they're scaled to 0.
+	previewDeployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "dataplane-preview-deployment",
+			Labels: map[string]string{
+				consts.GatewayOperatorManagedByLabel: consts.DataPlaneManagedLabelValue,
+				consts.DataPlaneDeploymentStateLabel: consts.DataPlaneStateLabelValuePreview,
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: lo.ToPtr(int32(1)),
+		},
+	}
+	// Deployments scaled to 0 never roll out, which mustn't stall the rotation.
+	scaledDownPreviewDeployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "dataplane-scaled-down-preview-deployment",
+			Labels: map[string]string{
+				consts.GatewayOperatorManagedByLabel: consts.DataPlaneManagedLabelValue,
+				consts.DataPlaneDeploymentStateLabel: consts.DataPlaneStateLabelValuePreview,
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: lo.ToPtr(int32(0)),
+		},
+	}
+	scaledDownDeployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "controlplane-deployment",
+			Labels: map[string]string{
+				consts.GatewayOperatorManagedByLabel: consts.ControlPlaneManagedLabelValue,
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: lo.ToPtr(int32(0)),
+		},
+	}
+	fakeClient := fakectrlruntimeclient.
+		NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(deployment, previewDeployment, scaledDownPreviewDeployment, scaledDownDeployment).
+		Build()
+
+	m := &caManager{
+		logger:          logr.Discard(),
+		client:          fakeClient,
+		secretName:      "kong-operator-ca",
+		secretNamespace: "test",
+		renewalWindow:   consts.DefaultClusterCARenewalWindow,
+	}
+	require.NoError(t, m.maybeCreateCACertificate(ctx))
+
+	getCA := func() *corev1.Secret {
+		t.Helper()
+		ca := &corev1.Secret{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "test", Name: "kong-operator-ca"}, ca))
+		return ca
+	}
+	rolloutDeployment := func(deployment *appsv1.Deployment) {
+		t.Helper()
+		d := &appsv1.Deployment{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(deployment), d))
+		d.Spec.Template.Annotations = map[string]string{
+			consts.CertificateRenewedAtAnnotation: time.Now().Add(time.Minute).UTC().Format(time.RFC3339),
+		}
+		require.NoError(t, fakeClient.Update(ctx, d))
+		d.Status = appsv1.DeploymentStatus{
+			ObservedGeneration: d.Generation,
+			Replicas:           1,
+			UpdatedReplicas:    1,
+			AvailableReplicas:  1,
+		}
+		require.NoError(t, fakeClient.Status().Update(ctx, d))
+	}
+	rolloutDeployments := func() {
+		t.Helper()
+		rolloutDeployment(deployment)
+		rolloutDeployment(previewDeployment)
+	}
+	requirePhase := func(ca *corev1.Secret, phase consts.ClusterCARotationPhase) {
+		t.Helper()
+		require.Equal(t, string(phase), ca.Annotations[consts.ClusterCARotationPhaseAnnotation])
+	}
+
+	t.Log("CA which doesn't expire soon is not rotated unless requested")
+	require.NoError(t, m.maybeRotateCACertificate(ctx))
+	oldCA := getCA()
+	require.NotContains(t, oldCA.Annotations, consts.ClusterCARotationPhaseAnnotation)
+
+	t.Log("requesting the rotation adds the new CA to the bundle")
+	oldCA.Annotations = map[string]string{consts.ClusterCARotateAnnotation: "true"}
+	require.NoError(t, fakeClient.Update(ctx, oldCA))
+	require.NoError(t, m.maybeRotateCACertificate(ctx))
+	ca := getCA()
+	requirePhase(ca, consts.ClusterCARotationPhaseAddNewCA)
+	require.NotContains(t, ca.Annotations, consts.ClusterCARotateAnnotation)
+	require.Equal(t, oldCA.Data["tls.crt"], ca.Data["tls.crt"])
+	newCACert := ca.Data[consts.ClusterCANextCertKey]
+	require.NotEmpty(t, newCACert)
+	require.NotEmpty(t, ca.Data[consts.ClusterCANextKeyKey])
+	require.Equal(t, append(oldCA.Data["tls.crt"], newCACert...), ca.Data[consts.CACRT])
+
+	t.Log("rotation doesn't progress until the Deployments roll out")
+	require.NoError(t, m.maybeRotateCACertificate(ctx))
+	requirePhase(getCA(), consts.ClusterCARotationPhaseAddNewCA)
+
+	t.Log("rotation doesn't progress until the preview Deployment rolls out")
+	rolloutDeployment(deployment)
+	require.NoError(t, m.maybeRotateCACertificate(ctx))
+	requirePhase(getCA(), consts.ClusterCARotationPhaseAddNewCA)
+
+	t.Log("the new CA becomes the issuer")
+	rolloutDeployment(previewDeployment)
+	require.NoError(t, m.maybeRotateCACertificate(ctx))
+	ca = getCA()
+	requirePhase(ca, consts.ClusterCARotationPhaseSignWithNewCA)
+	require.Equal(t, newCACert, ca.Data["tls.crt"])
+	require.NotContains(t, ca.Data, consts.ClusterCANextCertKey)
+	require.NotContains(t, ca.Data, consts.ClusterCANextKeyKey)
+	require.Equal(t, append(oldCA.Data["tls.crt"], newCACert...), ca.Data[consts.CACRT])
+
+	t.Log("the old CA is removed from the bundle")
+	rolloutDeployments()
+	require.NoError(t, m.maybeRotateCACertificate(ctx))
+	ca = getCA()
+	requirePhase(ca, consts.ClusterCARotationPhaseRemoveOldCA)
+	require.NotContains(t, ca.Data, consts.CACRT)
+
+	t.Log("rotation completes")
+	rolloutDeployments()
+	require.NoError(t, m.maybeRotateCACertificate(ctx))
+	ca = getCA()
+	require.NotContains(t, ca.Annotations, consts.ClusterCARotationPhaseAnnotation)
+	require.NotContains(t, ca.Annotations, consts.ClusterCARotationPhaseStartedAtAnnotation)
+	require.Equal(t, newCACert, ca.Data["tls.crt"])
+}
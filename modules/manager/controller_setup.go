@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"reflect"
 
+	configurationv1 "github.com/kong/kubernetes-configuration/api/configuration/v1"
+	configurationv1alpha1 "github.com/kong/kubernetes-configuration/api/configuration/v1alpha1"
 	"github.com/samber/lo"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -18,12 +20,16 @@ import (
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
 	"github.com/kong/gateway-operator/controller/controlplane"
 	"github.com/kong/gateway-operator/controller/dataplane"
+	"github.com/kong/gateway-operator/controller/dataplanemetricsextension"
 	"github.com/kong/gateway-operator/controller/gateway"
 	"github.com/kong/gateway-operator/controller/gatewayclass"
+	"github.com/kong/gateway-operator/controller/gatewayconfiguration"
+	"github.com/kong/gateway-operator/controller/kongplugininstallation"
 	"github.com/kong/gateway-operator/controller/konnect"
 	"github.com/kong/gateway-operator/controller/specialized"
 	"github.com/kong/gateway-operator/internal/utils/index"
 	dataplanevalidator "github.com/kong/gateway-operator/internal/validation/dataplane"
+	gatewayconfigurationvalidator "github.com/kong/gateway-operator/internal/validation/gatewayconfiguration"
 	"github.com/kong/gateway-operator/pkg/consts"
 	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
 )
@@ -33,6 +39,8 @@ const (
 	GatewayClassControllerName = "GatewayClass"
 	// GatewayControllerName is the name of the GatewayClass controller.
 	GatewayControllerName = "Gateway"
+	// GatewayConfigurationControllerName is the name of the GatewayConfiguration controller.
+	GatewayConfigurationControllerName = "GatewayConfiguration"
 	// ControlPlaneControllerName is the name of the GatewayClass controller.
 	ControlPlaneControllerName = "ControlPlane"
 	// DataPlaneControllerName is the name of the GatewayClass controller.
@@ -47,8 +55,20 @@ const (
 	DataPlaneOwnedDeploymentFinalizerControllerName = "DataPlaneOwnedDeploymentFinalizer"
 	// AIGatewayControllerName is the name of the GatewayClass controller.
 	AIGatewayControllerName = "AIGateway"
+	// KongPluginInstallationControllerName is the name of the KongPluginInstallation controller.
+	KongPluginInstallationControllerName = "KongPluginInstallation"
+	// DataPlaneMetricsExtensionControllerName is the name of the DataPlaneMetricsExtension controller.
+	DataPlaneMetricsExtensionControllerName = "DataPlaneMetricsExtension"
 	// KonnectAPIAuthConfigurationControllerName is the name of the KonnectAPIAuthConfiguration controller.
 	KonnectAPIAuthConfigurationControllerName = "KonnectAPIAuthConfiguration"
+	// KongServiceControllerName is the name of the KongService controller.
+	KongServiceControllerName = "KongService"
+	// KongRouteControllerName is the name of the KongRoute controller.
+	KongRouteControllerName = "KongRoute"
+	// KongConsumerControllerName is the name of the KongConsumer controller.
+	KongConsumerControllerName = "KongConsumer"
+	// KonnectDataPlaneControllerName is the name of the controller registering DataPlanes with Konnect control planes.
+	KonnectDataPlaneControllerName = "KonnectDataPlane"
 )
 
 // SetupControllersShim runs SetupControllers and returns its result as a slice of the map values.
@@ -100,6 +120,26 @@ func setupIndexes(ctx context.Context, mgr manager.Manager, cfg Config) error {
 			return fmt.Errorf("failed to setup index for DataPlane names on ControlPlane: %w", err)
 		}
 	}
+	if cfg.KongPluginInstallationControllerEnabled {
+		if err := index.ImagePullSecretOnKongPluginInstallation(ctx, mgr.GetCache()); err != nil {
+			return fmt.Errorf("failed to setup index for image pull Secrets on KongPluginInstallation: %w", err)
+		}
+		if cfg.DataPlaneControllerEnabled || cfg.DataPlaneBlueGreenControllerEnabled || cfg.GatewayControllerEnabled {
+			if err := index.KongPluginInstallationsOnDataPlane(ctx, mgr.GetCache()); err != nil {
+				return fmt.Errorf("failed to setup index for KongPluginInstallations on DataPlane: %w", err)
+			}
+		}
+	}
+	if cfg.DataPlaneMetricsExtensionControllerEnabled {
+		if err := index.DataPlaneMetricsExtensionOnControlPlane(ctx, mgr.GetCache()); err != nil {
+			return fmt.Errorf("failed to setup index for DataPlaneMetricsExtensions on ControlPlane: %w", err)
+		}
+	}
+	if cfg.KonnectControllersEnabled {
+		if err := konnect.TokenSecretOnKonnectAPIAuthConfiguration(ctx, mgr.GetCache()); err != nil {
+			return fmt.Errorf("failed to setup index for token Secrets on KonnectAPIAuthConfiguration: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -150,6 +190,24 @@ func SetupControllers(mgr manager.Manager, c *Config) (map[string]ControllerDef,
 				operatorv1alpha1.AIGatewayGVR(),
 			},
 		},
+		{
+			Condition: c.KongPluginInstallationControllerEnabled,
+			GVRs: []schema.GroupVersionResource{
+				operatorv1alpha1.KongPluginInstallationGVR(),
+			},
+		},
+		{
+			Condition: c.DataPlaneMetricsExtensionControllerEnabled,
+			GVRs: []schema.GroupVersionResource{
+				operatorv1alpha1.DataPlaneMetricsExtensionGVR(),
+				operatorv1beta1.ControlPlaneGVR(),
+				{
+					Group:    configurationv1.SchemeGroupVersion.Group,
+					Version:  configurationv1.SchemeGroupVersion.Version,
+					Resource: "kongplugins",
+				},
+			},
+		},
 	}
 	checker := k8sutils.CRDChecker{Client: mgr.GetClient()}
 	for _, check := range crdChecks {
@@ -186,54 +244,72 @@ func SetupControllers(mgr manager.Manager, c *Config) (map[string]ControllerDef,
 				DefaultDataPlaneImage: consts.DefaultDataPlaneImage,
 			},
 		},
+		// GatewayConfiguration controller
+		GatewayConfigurationControllerName: {
+			Enabled: c.GatewayControllerEnabled,
+			Controller: &gatewayconfiguration.Reconciler{
+				Client:          mgr.GetClient(),
+				Scheme:          mgr.GetScheme(),
+				DevelopmentMode: c.DevelopmentMode,
+				Validator:       gatewayconfigurationvalidator.NewValidator(mgr.GetClient()),
+			},
+		},
 		// ControlPlane controller
 		ControlPlaneControllerName: {
 			Enabled: c.GatewayControllerEnabled || c.ControlPlaneControllerEnabled,
 			Controller: &controlplane.Reconciler{
-				Client:                   mgr.GetClient(),
-				Scheme:                   mgr.GetScheme(),
-				ClusterCASecretName:      c.ClusterCASecretName,
-				ClusterCASecretNamespace: c.ClusterCASecretNamespace,
-				DevelopmentMode:          c.DevelopmentMode,
+				Client:                          mgr.GetClient(),
+				Scheme:                          mgr.GetScheme(),
+				ClusterCASecretName:             c.ClusterCASecretName,
+				ClusterCASecretNamespace:        c.ClusterCASecretNamespace,
+				ClusterCertificateRenewalWindow: c.ClusterCertificateRenewalWindow,
+				DevelopmentMode:                 c.DevelopmentMode,
 			},
 		},
 		// DataPlane controller
 		DataPlaneControllerName: {
 			Enabled: (c.DataPlaneControllerEnabled || c.GatewayControllerEnabled) && !c.DataPlaneBlueGreenControllerEnabled,
 			Controller: &dataplane.Reconciler{
-				Client:                   mgr.GetClient(),
-				Scheme:                   mgr.GetScheme(),
-				ClusterCASecretName:      c.ClusterCASecretName,
-				ClusterCASecretNamespace: c.ClusterCASecretNamespace,
-				DevelopmentMode:          c.DevelopmentMode,
-				Validator:                dataplanevalidator.NewValidator(mgr.GetClient()),
+				Client:                          mgr.GetClient(),
+				Scheme:                          mgr.GetScheme(),
+				ClusterCASecretName:             c.ClusterCASecretName,
+				ClusterCASecretNamespace:        c.ClusterCASecretNamespace,
+				ClusterCertificateRenewalWindow: c.ClusterCertificateRenewalWindow,
+				DevelopmentMode:                 c.DevelopmentMode,
+				Validator:                       dataplanevalidator.NewValidator(mgr.GetClient()),
 				Callbacks: dataplane.DataPlaneCallbacks{
 					BeforeDeployment: dataplane.CreateCallbackManager(),
 					AfterDeployment:  dataplane.CreateCallbackManager(),
 				},
-				DefaultImage: consts.DefaultDataPlaneImage,
+				DefaultImage:                            consts.DefaultDataPlaneImage,
+				KongPluginInstallationControllerEnabled: c.KongPluginInstallationControllerEnabled,
+				KonnectControllersEnabled:               c.KonnectControllersEnabled,
 			},
 		},
 		// DataPlaneBlueGreen controller
 		DataPlaneBlueGreenControllerName: {
 			Enabled: c.DataPlaneBlueGreenControllerEnabled,
 			Controller: &dataplane.BlueGreenReconciler{
-				Client:                   mgr.GetClient(),
-				DevelopmentMode:          c.DevelopmentMode,
-				ClusterCASecretName:      c.ClusterCASecretName,
-				ClusterCASecretNamespace: c.ClusterCASecretNamespace,
+				Client:                          mgr.GetClient(),
+				DevelopmentMode:                 c.DevelopmentMode,
+				ClusterCASecretName:             c.ClusterCASecretName,
+				ClusterCASecretNamespace:        c.ClusterCASecretNamespace,
+				ClusterCertificateRenewalWindow: c.ClusterCertificateRenewalWindow,
 				DataPlaneController: &dataplane.Reconciler{
-					Client:                   mgr.GetClient(),
-					Scheme:                   mgr.GetScheme(),
-					ClusterCASecretName:      c.ClusterCASecretName,
-					ClusterCASecretNamespace: c.ClusterCASecretNamespace,
-					DevelopmentMode:          c.DevelopmentMode,
-					Validator:                dataplanevalidator.NewValidator(mgr.GetClient()),
-					DefaultImage:             consts.DefaultDataPlaneImage,
+					Client:                          mgr.GetClient(),
+					Scheme:                          mgr.GetScheme(),
+					ClusterCASecretName:             c.ClusterCASecretName,
+					ClusterCASecretNamespace:        c.ClusterCASecretNamespace,
+					ClusterCertificateRenewalWindow: c.ClusterCertificateRenewalWindow,
+					DevelopmentMode:                 c.DevelopmentMode,
+					Validator:                       dataplanevalidator.NewValidator(mgr.GetClient()),
+					DefaultImage:                    consts.DefaultDataPlaneImage,
 					Callbacks: dataplane.DataPlaneCallbacks{
 						BeforeDeployment: dataplane.CreateCallbackManager(),
 						AfterDeployment:  dataplane.CreateCallbackManager(),
 					},
+					KongPluginInstallationControllerEnabled: c.KongPluginInstallationControllerEnabled,
+					KonnectControllersEnabled:               c.KonnectControllersEnabled,
 				},
 				Callbacks: dataplane.DataPlaneCallbacks{
 					BeforeDeployment: dataplane.CreateCallbackManager(),
@@ -263,6 +339,24 @@ func SetupControllers(mgr manager.Manager, c *Config) (map[string]ControllerDef,
 				c.DevelopmentMode,
 			),
 		},
+		// KongPluginInstallation controller
+		KongPluginInstallationControllerName: {
+			Enabled: c.KongPluginInstallationControllerEnabled,
+			Controller: &kongplugininstallation.Reconciler{
+				Client:          mgr.GetClient(),
+				Scheme:          mgr.GetScheme(),
+				DevelopmentMode: c.DevelopmentMode,
+			},
+		},
+		// DataPlaneMetricsExtension controller
+		DataPlaneMetricsExtensionControllerName: {
+			Enabled: c.DataPlaneMetricsExtensionControllerEnabled,
+			Controller: &dataplanemetricsextension.Reconciler{
+				Client:          mgr.GetClient(),
+				Scheme:          mgr.GetScheme(),
+				DevelopmentMode: c.DevelopmentMode,
+			},
+		},
 		// AIGateway Controller
 		AIGatewayControllerName: {
 			Enabled: c.AIGatewayControllerEnabled,
@@ -276,11 +370,46 @@ func SetupControllers(mgr manager.Manager, c *Config) (map[string]ControllerDef,
 
 	// Konnect controllers
 	if c.KonnectControllersEnabled {
-		sdkFactory := konnect.NewSDKFactory()
+		sdkCache := konnect.NewSDKCache(konnect.NewSDKFactory())
 		controllers[KonnectAPIAuthConfigurationControllerName] = ControllerDef{
 			Enabled: c.KonnectControllersEnabled,
 			Controller: konnect.NewKonnectAPIAuthConfigurationReconciler(
-				sdkFactory,
+				sdkCache,
+				c.DevelopmentMode,
+				mgr.GetClient(),
+			),
+		}
+		controllers[KongServiceControllerName] = ControllerDef{
+			Enabled: c.KonnectControllersEnabled,
+			Controller: konnect.NewKonnectEntityReconciler[configurationv1alpha1.KongService](
+				configurationv1alpha1.KongService{},
+				sdkCache,
+				c.DevelopmentMode,
+				mgr.GetClient(),
+			),
+		}
+		controllers[KongRouteControllerName] = ControllerDef{
+			Enabled: c.KonnectControllersEnabled,
+			Controller: konnect.NewKonnectEntityReconciler[configurationv1alpha1.KongRoute](
+				configurationv1alpha1.KongRoute{},
+				sdkCache,
+				c.DevelopmentMode,
+				mgr.GetClient(),
+			),
+		}
+		controllers[KongConsumerControllerName] = ControllerDef{
+			Enabled: c.KonnectControllersEnabled,
+			Controller: konnect.NewKonnectEntityReconciler[configurationv1.KongConsumer](
+				configurationv1.KongConsumer{},
+				sdkCache,
+				c.DevelopmentMode,
+				mgr.GetClient(),
+			),
+		}
+		controllers[KonnectDataPlaneControllerName] = ControllerDef{
+			Enabled: c.KonnectControllersEnabled && (c.DataPlaneControllerEnabled || c.DataPlaneBlueGreenControllerEnabled),
+			Controller: konnect.NewKonnectDataPlaneReconciler(
+				sdkCache,
 				c.DevelopmentMode,
 				mgr.GetClient(),
 			),
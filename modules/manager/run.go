@@ -32,11 +32,15 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -46,6 +50,7 @@ import (
 
 	"github.com/kong/gateway-operator/internal/telemetry"
 	"github.com/kong/gateway-operator/modules/manager/metadata"
+	"github.com/kong/gateway-operator/pkg/consts"
 	"github.com/kong/gateway-operator/pkg/vars"
 )
 
@@ -73,7 +78,13 @@ type Config struct {
 	KubeconfigPath           string
 	ClusterCASecretName      string
 	ClusterCASecretNamespace string
-	LoggerOpts               *zap.Options
+	// ClusterCertificateRenewalWindow is the duration before expiration of
+	// operator-issued certificates when they get renewed.
+	ClusterCertificateRenewalWindow time.Duration
+	// ClusterCARenewalWindow is the duration before expiration of the cluster
+	// CA when it gets rotated.
+	ClusterCARenewalWindow time.Duration
+	LoggerOpts             *zap.Options
 
 	// controllers for standard APIs and features
 	GatewayControllerEnabled            bool
@@ -82,7 +93,9 @@ type Config struct {
 	DataPlaneBlueGreenControllerEnabled bool
 
 	// Controllers for specialty APIs and experimental features.
-	AIGatewayControllerEnabled bool
+	AIGatewayControllerEnabled                 bool
+	KongPluginInstallationControllerEnabled    bool
+	DataPlaneMetricsExtensionControllerEnabled bool
 
 	// Controllers for Konnect APIs.
 	KonnectControllersEnabled bool
@@ -99,20 +112,22 @@ func DefaultConfig() Config {
 	)
 
 	return Config{
-		MetricsAddr:                   ":8080",
-		ProbeAddr:                     ":8081",
-		WebhookCertDir:                defaultWebhookCertDir,
-		WebhookPort:                   9443,
-		DevelopmentMode:               false,
-		LeaderElection:                true,
-		LeaderElectionNamespace:       defaultLeaderElectionNamespace,
-		ClusterCASecretName:           "kong-operator-ca",
-		ClusterCASecretNamespace:      defaultNamespace,
-		ControllerNamespace:           defaultNamespace,
-		LoggerOpts:                    &zap.Options{},
-		GatewayControllerEnabled:      true,
-		ControlPlaneControllerEnabled: true,
-		DataPlaneControllerEnabled:    true,
+		MetricsAddr:                     ":8080",
+		ProbeAddr:                       ":8081",
+		WebhookCertDir:                  defaultWebhookCertDir,
+		WebhookPort:                     9443,
+		DevelopmentMode:                 false,
+		LeaderElection:                  true,
+		LeaderElectionNamespace:         defaultLeaderElectionNamespace,
+		ClusterCASecretName:             "kong-operator-ca",
+		ClusterCASecretNamespace:        defaultNamespace,
+		ClusterCertificateRenewalWindow: consts.DefaultClusterCertificateRenewalWindow,
+		ClusterCARenewalWindow:          consts.DefaultClusterCARenewalWindow,
+		ControllerNamespace:             defaultNamespace,
+		LoggerOpts:                      &zap.Options{},
+		GatewayControllerEnabled:        true,
+		ControlPlaneControllerEnabled:   true,
+		DataPlaneControllerEnabled:      true,
 	}
 }
 
@@ -152,6 +167,15 @@ func Run(
 		setupLog.Info("development mode enabled")
 	}
 
+	if cfg.ClusterCertificateRenewalWindow >= consts.ClusterCertificateLifetime {
+		return fmt.Errorf("cluster certificate renewal window %s has to be shorter than the certificates lifetime %s",
+			cfg.ClusterCertificateRenewalWindow, consts.ClusterCertificateLifetime)
+	}
+	if cfg.ClusterCARenewalWindow >= consts.ClusterCALifetime {
+		return fmt.Errorf("cluster CA renewal window %s has to be shorter than the cluster CA lifetime %s",
+			cfg.ClusterCARenewalWindow, consts.ClusterCALifetime)
+	}
+
 	if cfg.LeaderElection {
 		setupLog.Info("leader election enabled", "namespace", cfg.LeaderElectionNamespace)
 	} else {
@@ -161,8 +185,22 @@ func Run(
 	restCfg := ctrl.GetConfigOrDie()
 	restCfg.UserAgent = metadata.UserAgent()
 
+	// EndpointSlices are only read for the Services managed by the operator,
+	// which propagate their labels to them, so the others are not cached.
+	managedBySelector, err := labels.Parse(consts.GatewayOperatorManagedByLabel)
+	if err != nil {
+		return fmt.Errorf("failed parsing managed-by label selector: %w", err)
+	}
+
 	mgr, err := ctrl.NewManager(restCfg, ctrl.Options{
 		Scheme: scheme,
+		Cache: cache.Options{
+			ByObject: map[client.Object]cache.ByObject{
+				&discoveryv1.EndpointSlice{}: {
+					Label: managedBySelector,
+				},
+			},
+		},
 		Metrics: server.Options{
 			BindAddress: cfg.MetricsAddr,
 		},
@@ -186,6 +224,7 @@ func Run(
 		client:          mgr.GetClient(),
 		secretName:      cfg.ClusterCASecretName,
 		secretNamespace: cfg.ClusterCASecretNamespace,
+		renewalWindow:   cfg.ClusterCARenewalWindow,
 	}
 	err = mgr.Add(caMgr)
 	if err != nil {
@@ -274,6 +313,9 @@ type caManager struct {
 	client          client.Client
 	secretName      string
 	secretNamespace string
+	// renewalWindow is the period before the CA certificate's expiration
+	// during which the CA gets rotated.
+	renewalWindow time.Duration
 }
 
 // Start starts the CA manager.
@@ -284,47 +326,27 @@ func (m *caManager) Start(ctx context.Context) error {
 	if m.secretNamespace == "" {
 		return fmt.Errorf("cannot use an empty secret namespace when creating a CA secret")
 	}
-	return m.maybeCreateCACertificate(ctx)
+	if err := m.maybeCreateCACertificate(ctx); err != nil {
+		return err
+	}
+
+	// Periodically check whether the CA should be rotated and drive the rotation.
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := m.maybeRotateCACertificate(ctx); err != nil {
+			m.logger.Error(err, "failed to rotate CA certificate")
+		}
+	}, caRotationCheckInterval)
+	return nil
 }
 
 func (m *caManager) maybeCreateCACertificate(ctx context.Context) error {
-	// TODO https://github.com/Kong/gateway-operator/issues/199 this also needs to check if the CA is expired and
-	// managed, and needs to reissue it (and all issued certificates) if so
 	ca := &corev1.Secret{}
 	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
 	defer cancel()
 	err := m.client.Get(ctx, client.ObjectKey{Namespace: m.secretNamespace, Name: m.secretName}, ca)
 	if k8serrors.IsNotFound(err) {
-		serial, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
-		if err != nil {
-			return err
-		}
 		m.logger.Info(fmt.Sprintf("no CA certificate Secret %s found, generating CA certificate", m.secretName))
-		template := x509.Certificate{
-			Subject: pkix.Name{
-				CommonName:   "Kong Gateway Operator CA",
-				Organization: []string{"Kong, Inc."},
-				Country:      []string{"US"},
-			},
-			SerialNumber:          serial,
-			SignatureAlgorithm:    x509.ECDSAWithSHA256,
-			NotBefore:             time.Now(),
-			NotAfter:              time.Now().Add(time.Second * 315400000),
-			KeyUsage:              x509.KeyUsageCertSign + x509.KeyUsageKeyEncipherment + x509.KeyUsageDigitalSignature,
-			BasicConstraintsValid: true,
-			IsCA:                  true,
-		}
-
-		priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
-		if err != nil {
-			return err
-		}
-		privDer, err := x509.MarshalECPrivateKey(priv)
-		if err != nil {
-			return err
-		}
-
-		der, err := x509.CreateCertificate(rand.Reader, &template, &template, priv.Public(), priv)
+		crt, key, err := generateCACertificate()
 		if err != nil {
 			return err
 		}
@@ -335,16 +357,9 @@ func (m *caManager) maybeCreateCACertificate(ctx context.Context) error {
 				Name:      m.secretName,
 			},
 			Type: corev1.SecretTypeTLS,
-			StringData: map[string]string{
-				"tls.crt": string(pem.EncodeToMemory(&pem.Block{
-					Type:  "CERTIFICATE",
-					Bytes: der,
-				})),
-
-				"tls.key": string(pem.EncodeToMemory(&pem.Block{
-					Type:  "EC PRIVATE KEY",
-					Bytes: privDer,
-				})),
+			Data: map[string][]byte{
+				"tls.crt": crt,
+				"tls.key": key,
 			},
 		}
 		err = m.client.Create(ctx, signedSecret)
@@ -358,6 +373,53 @@ func (m *caManager) maybeCreateCACertificate(ctx context.Context) error {
 	return nil
 }
 
+// generateCACertificate generates a new self-signed CA certificate and its
+// private key, both PEM encoded.
+func generateCACertificate() (crt []byte, key []byte, err error) {
+	serial, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+	if err != nil {
+		return nil, nil, err
+	}
+	template := x509.Certificate{
+		Subject: pkix.Name{
+			CommonName:   "Kong Gateway Operator CA",
+			Organization: []string{"Kong, Inc."},
+			Country:      []string{"US"},
+		},
+		SerialNumber:          serial,
+		SignatureAlgorithm:    x509.ECDSAWithSHA256,
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(consts.ClusterCALifetime),
+		KeyUsage:              x509.KeyUsageCertSign + x509.KeyUsageKeyEncipherment + x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	privDer, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, priv.Public(), priv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	crt = pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: der,
+	})
+	key = pem.EncodeToMemory(&pem.Block{
+		Type:  "EC PRIVATE KEY",
+		Bytes: privDer,
+	})
+	return crt, key, nil
+}
+
 // setupAnonymousReports sets up and starts the anonymous reporting and returns
 // a cleanup function and an error.
 // The caller is responsible to call the returned function - when the returned
@@ -0,0 +1,81 @@
+package consts
+
+import "time"
+
+// -----------------------------------------------------------------------------
+// Consts - Operator-issued certificates
+// -----------------------------------------------------------------------------
+
+const (
+	// DefaultClusterCertificateRenewalWindow is the default period before the
+	// expiration of the certificates issued by the operator (e.g. ControlPlane's
+	// and DataPlane's admin API mTLS certificates) during which they get renewed.
+	DefaultClusterCertificateRenewalWindow = 30 * 24 * time.Hour
+
+	// ClusterCertificateLifetime is the lifetime of the certificates issued by the
+	// operator, capped by the expiration of the cluster CA which issues them.
+	// The renewal window of the certificates has to be shorter than their lifetime.
+	ClusterCertificateLifetime = 365 * 24 * time.Hour
+
+	// ClusterCALifetime is the lifetime of the cluster CA certificate generated by
+	// the operator. The cluster CA gets rotated within its renewal window before
+	// it expires, which has to be shorter than this lifetime.
+	ClusterCALifetime = 315400000 * time.Second
+
+	// CertificateRenewedAtAnnotation is the annotation set on the Secrets holding
+	// certificates issued by the operator when the certificate gets renewed. It holds
+	// the time of the renewal and it's propagated to the pod templates of the
+	// Deployments using the certificate which triggers a rollout of their pods,
+	// since neither Kong nor the ingress controller reload certificates on their own.
+	CertificateRenewedAtAnnotation = OperatorAnnotationPrefix + "certificate-renewed-at"
+)
+
+// -----------------------------------------------------------------------------
+// Consts - Cluster CA rotation
+// -----------------------------------------------------------------------------
+
+const (
+	// DefaultClusterCARenewalWindow is the default period before the expiration
+	// of the cluster CA certificate during which the cluster CA gets rotated.
+	// It should be longer than the renewal window of the certificates issued by
+	// the cluster CA as these can't outlive the CA which issued them.
+	DefaultClusterCARenewalWindow = 90 * 24 * time.Hour
+
+	// ClusterCARotateAnnotation is the annotation which can be set on the cluster
+	// CA Secret to request the rotation of the cluster CA. It is removed by the
+	// operator once the rotation starts.
+	ClusterCARotateAnnotation = OperatorAnnotationPrefix + "rotate-ca"
+	// ClusterCARotationPhaseAnnotation is the annotation set on the cluster CA
+	// Secret holding the current phase of the cluster CA rotation.
+	ClusterCARotationPhaseAnnotation = OperatorAnnotationPrefix + "ca-rotation-phase"
+	// ClusterCARotationPhaseStartedAtAnnotation is the annotation set on the cluster
+	// CA Secret holding the time when the current phase of the cluster CA rotation
+	// has started. The rotation moves on to the next phase once all the ControlPlane
+	// and DataPlane Deployments have rolled out their pods after that time.
+	ClusterCARotationPhaseStartedAtAnnotation = OperatorAnnotationPrefix + "ca-rotation-phase-started-at"
+
+	// ClusterCANextCertKey is the key of the cluster CA Secret holding the
+	// certificate of the new CA during the AddNewCA phase of the rotation.
+	ClusterCANextCertKey = "next-tls.crt"
+	// ClusterCANextKeyKey is the key of the cluster CA Secret holding the
+	// private key of the new CA during the AddNewCA phase of the rotation.
+	ClusterCANextKeyKey = "next-tls.key"
+)
+
+// ClusterCARotationPhase is the phase of the cluster CA rotation.
+type ClusterCARotationPhase string
+
+const (
+	// ClusterCARotationPhaseAddNewCA is the first phase of the cluster CA rotation.
+	// The new CA is generated and added to the CA bundle (the cluster CA Secret's
+	// ca.crt) trusted by the ControlPlanes and DataPlanes while certificates are
+	// still issued by the old CA.
+	ClusterCARotationPhaseAddNewCA ClusterCARotationPhase = "AddNewCA"
+	// ClusterCARotationPhaseSignWithNewCA is the second phase of the cluster CA
+	// rotation. The new CA replaces the old one as the issuer and all the certificates
+	// are re-issued by it. The CA bundle still holds both the old and the new CA.
+	ClusterCARotationPhaseSignWithNewCA ClusterCARotationPhase = "SignWithNewCA"
+	// ClusterCARotationPhaseRemoveOldCA is the last phase of the cluster CA rotation.
+	// The old CA is removed from the CA bundle.
+	ClusterCARotationPhaseRemoveOldCA ClusterCARotationPhase = "RemoveOldCA"
+)
@@ -78,6 +78,25 @@ const (
 	CertPurposeLabel = OperatorLabelPrefix + "cert-purpose"
 )
 
+// -----------------------------------------------------------------------------
+// Consts - Gateway Infrastructure Metadata
+// -----------------------------------------------------------------------------
+
+const (
+	// AnnotationLastAppliedInfrastructureLabels is the annotation key to store the
+	// labels propagated from a Gateway's spec.infrastructure.labels to an object
+	// managed for that Gateway. It allows the controllers to propagate them further
+	// to the objects they manage and to remove the labels that were removed from
+	// the Gateway without interfering with labels from other sources.
+	AnnotationLastAppliedInfrastructureLabels = OperatorAnnotationPrefix + "last-applied-infrastructure-labels"
+
+	// AnnotationLastAppliedInfrastructureAnnotations is the annotation key to store
+	// the annotations propagated from a Gateway's spec.infrastructure.annotations
+	// to an object managed for that Gateway. It serves the same purpose as
+	// AnnotationLastAppliedInfrastructureLabels.
+	AnnotationLastAppliedInfrastructureAnnotations = OperatorAnnotationPrefix + "last-applied-infrastructure-annotations"
+)
+
 // -----------------------------------------------------------------------------
 // Consts - Names and Paths for Shared Resources
 // -----------------------------------------------------------------------------
@@ -42,6 +42,10 @@ const (
 	// to connect to the Kong Admin API. It needs to be customized to 5 seconds to avoid
 	// the ControlPlane crash due to DataPlane slow starts.
 	DataPlaneInitRetryDelay = "5s"
+
+	// ControlPlaneMetricsPort is the port on which the ingress controller
+	// exposes its Prometheus metrics.
+	ControlPlaneMetricsPort = 10255
 )
 
 // -----------------------------------------------------------------------------
@@ -64,3 +68,19 @@ const (
 
 // TODO: https://github.com/Kong/gateway-operator/issues/141
 // Extract as constants all the Env var Keys used to configure the ControlPlane.
+
+// -----------------------------------------------------------------------------
+// Consts - ControlPlane leader election parameters
+// -----------------------------------------------------------------------------
+
+const (
+	// ControlPlaneElectionIDEnvVarName is the name of the env var configuring
+	// the name of the Lease used by the ControlPlane's pods for leader election.
+	ControlPlaneElectionIDEnvVarName = "CONTROLLER_ELECTION_ID"
+	// ControlPlaneElectionNamespaceEnvVarName is the name of the env var configuring
+	// the namespace of the Lease used by the ControlPlane's pods for leader election.
+	ControlPlaneElectionNamespaceEnvVarName = "CONTROLLER_ELECTION_NAMESPACE"
+	// ControlPlaneElectionIDSuffix is the suffix appended to the ControlPlane's name
+	// to generate the default leader election ID.
+	ControlPlaneElectionIDSuffix = ".konghq.com"
+)
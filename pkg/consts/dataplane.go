@@ -66,9 +66,130 @@ const (
 	// DataPlane proxy.
 	DataPlaneIngressServiceLabelValue ServiceType = "ingress"
 
+	// DataPlanePrivateIngressServiceLabelValue indicates that the service is intended
+	// to expose the DataPlane proxy on a private network.
+	DataPlanePrivateIngressServiceLabelValue ServiceType = "private-ingress"
+
 	// DataPlaneProxyServiceLabelValue is the legacy label value which indicates
 	// that the service is inteded to expose the DataPlane proxy.
 	DataPlaneProxyServiceLabelValueLegacy ServiceType = "proxy"
+
+	// DataPlanePreviewRoutingServiceLabelValue indicates that the service is intended
+	// to expose the DataPlane proxy to the live pods which forward matching requests
+	// to the preview pods.
+	DataPlanePreviewRoutingServiceLabelValue ServiceType = "preview-routing"
+
+	// DataPlaneConfigMapTypeLabel is the label that is used for the ConfigMaps created
+	// by the DataPlane controller.
+	DataPlaneConfigMapTypeLabel = "gateway-operator.konghq.com/dataplane-configmap-type"
+
+	// DataPlanePreviewRoutingConfigMapLabelValue indicates that the ConfigMap holds
+	// the Nginx configuration forwarding matching requests to the preview pods.
+	DataPlanePreviewRoutingConfigMapLabelValue = "preview-routing"
+
+	// DataPlanePreviewRoutingChecksumAnnotation is the annotation set on the DataPlane's
+	// pod template holding a checksum of the preview routing configuration. It triggers
+	// a rollout of DataPlane's pods when the configuration changes.
+	DataPlanePreviewRoutingChecksumAnnotation = OperatorAnnotationPrefix + "preview-routing-checksum"
+
+	// DataPlanePreviewRoutingMountPath is the path under which the preview routing
+	// configuration is mounted in the DataPlane's proxy container.
+	DataPlanePreviewRoutingMountPath = "/etc/kong/preview-routing"
+)
+
+// -----------------------------------------------------------------------------
+// Consts - DataPlane Konnect
+// -----------------------------------------------------------------------------
+
+const (
+	// DataPlaneKonnectCertificatePurposeLabelValue is the value of the CertPurposeLabel
+	// set on the Secret holding the client certificate the DataPlane uses to connect
+	// to its Konnect control plane.
+	DataPlaneKonnectCertificatePurposeLabelValue = "konnect-dataplane"
+
+	// DataPlaneKonnectCertificateIDAnnotation is the annotation set on the DataPlane's
+	// Konnect certificate Secret holding the ID of the certificate registered with
+	// the Konnect control plane.
+	DataPlaneKonnectCertificateIDAnnotation = OperatorAnnotationPrefix + "konnect-certificate-id"
+
+	// DataPlaneKonnectControlPlaneIDAnnotation is the annotation set on the DataPlane's
+	// Konnect certificate Secret holding the ID of the Konnect control plane
+	// the certificate is registered with.
+	DataPlaneKonnectControlPlaneIDAnnotation = OperatorAnnotationPrefix + "konnect-control-plane-id"
+
+	// DataPlaneKonnectAPIAuthConfigurationAnnotation is the annotation set on the DataPlane's
+	// Konnect certificate Secret holding the name of the KonnectAPIAuthConfiguration
+	// used to register the certificate.
+	DataPlaneKonnectAPIAuthConfigurationAnnotation = OperatorAnnotationPrefix + "konnect-api-auth-configuration"
+
+	// DataPlaneKonnectControlPlaneEndpointAnnotation is the annotation set on the DataPlane's
+	// Konnect certificate Secret holding the cluster endpoint of the Konnect control plane.
+	DataPlaneKonnectControlPlaneEndpointAnnotation = OperatorAnnotationPrefix + "konnect-control-plane-endpoint"
+
+	// DataPlaneKonnectTelemetryEndpointAnnotation is the annotation set on the DataPlane's
+	// Konnect certificate Secret holding the telemetry endpoint of the Konnect control plane.
+	DataPlaneKonnectTelemetryEndpointAnnotation = OperatorAnnotationPrefix + "konnect-telemetry-endpoint"
+
+	// DataPlaneKonnectCertificateMountPath is the path under which the DataPlane's
+	// Konnect client certificate is mounted in the DataPlane's proxy container.
+	DataPlaneKonnectCertificateMountPath = "/etc/kong/konnect-certificate"
+)
+
+// -----------------------------------------------------------------------------
+// Consts - DataPlane Database
+// -----------------------------------------------------------------------------
+
+const (
+	// DataPlanePostgresSecretHostKey is the key of the DataPlane's Postgres
+	// Secret holding the database host.
+	DataPlanePostgresSecretHostKey = "host"
+
+	// DataPlanePostgresSecretPortKey is the optional key of the DataPlane's
+	// Postgres Secret holding the database port.
+	DataPlanePostgresSecretPortKey = "port"
+
+	// DataPlanePostgresSecretUserKey is the key of the DataPlane's Postgres
+	// Secret holding the database user.
+	DataPlanePostgresSecretUserKey = "user"
+
+	// DataPlanePostgresSecretPasswordKey is the key of the DataPlane's Postgres
+	// Secret holding the database user's password.
+	DataPlanePostgresSecretPasswordKey = "password"
+
+	// DataPlanePostgresSecretDatabaseKey is the optional key of the DataPlane's
+	// Postgres Secret holding the database name.
+	DataPlanePostgresSecretDatabaseKey = "database"
+
+	// DataPlanePostgresSecretSSLKey is the optional key of the DataPlane's
+	// Postgres Secret holding whether SSL is used to connect to the database.
+	DataPlanePostgresSecretSSLKey = "ssl"
+
+	// DataPlaneMigrationsJobPhaseLabel is the label set on the DataPlane's
+	// database migrations Jobs holding the migrations phase the Job runs.
+	DataPlaneMigrationsJobPhaseLabel = OperatorLabelPrefix + "dataplane-migrations-phase"
+
+	// DataPlaneMigrationsJobPhaseUpLabelValue is the value of the DataPlaneMigrationsJobPhaseLabel
+	// set on Jobs running kong migrations bootstrap and kong migrations up.
+	DataPlaneMigrationsJobPhaseUpLabelValue = "up"
+
+	// DataPlaneMigrationsJobPhaseFinishLabelValue is the value of the DataPlaneMigrationsJobPhaseLabel
+	// set on Jobs running kong migrations finish.
+	DataPlaneMigrationsJobPhaseFinishLabelValue = "finish"
+
+	// DataPlaneMigrationsJobImageAnnotation is the annotation set on the DataPlane's
+	// database migrations Jobs holding the DataPlane image whose migrations
+	// the Job runs.
+	DataPlaneMigrationsJobImageAnnotation = OperatorAnnotationPrefix + "dataplane-migrations-image"
+
+	// DataPlaneMigrationsJobChecksumAnnotation is the annotation set on the DataPlane's
+	// database migrations Jobs holding a checksum of the Job's pod template.
+	// It's used to recreate the Job when the DataPlane's spec it's generated
+	// from changes.
+	DataPlaneMigrationsJobChecksumAnnotation = OperatorAnnotationPrefix + "dataplane-migrations-checksum"
+
+	// DataPlaneMigrationsContainerName is the name of the container running
+	// the DataPlane's database migrations.
+	DataPlaneMigrationsContainerName = "kong-migrations"
 )
 
 // -----------------------------------------------------------------------------
@@ -164,8 +285,8 @@ const (
 
 const (
 	// EnvVarKongDatabase is the environment variable name to specify database
-	// backend used for dataplane(Kong gateway). Currently only DBLess mode
-	// (empty, or "off") is supported.
+	// backend used for dataplane(Kong gateway). DBLess mode (empty, or "off")
+	// is used unless the DataPlane is configured with a Postgres database.
 	EnvVarKongDatabase = "KONG_DATABASE"
 )
 
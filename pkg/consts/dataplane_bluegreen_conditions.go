@@ -19,6 +19,11 @@ const (
 	// a Service failing to get created during a rollout.
 	DataPlaneConditionReasonRolloutFailed ConditionReason = "Failed"
 
+	// DataPlaneConditionReasonRolloutTimedOut is a reason which indicates that
+	// the DataPlane's preview Deployment didn't become ready within the rollout
+	// timeout. The rollout is not retried until the DataPlane's spec changes.
+	DataPlaneConditionReasonRolloutTimedOut ConditionReason = "TimedOut"
+
 	// DataPlaneConditionReasonRolloutProgressing is a reason which indicates a DataPlane's
 	// new version is being rolled out.
 	DataPlaneConditionReasonRolloutProgressing ConditionReason = "Progressing"
@@ -27,6 +32,12 @@ const (
 	// is waiting for a change to trigger new version to be made available before promotion.
 	DataPlaneConditionReasonRolloutWaitingForChange ConditionReason = "WaitingForChange"
 
+	// DataPlaneConditionReasonRolloutAnalysisFailed is a reason which indicates
+	// that the preview pods didn't pass the promotion analysis configured for
+	// the AutomaticPromotion strategy. The rollout is not retried until
+	// the DataPlane's spec changes.
+	DataPlaneConditionReasonRolloutAnalysisFailed ConditionReason = "AnalysisFailed"
+
 	// DataPlaneConditionReasonRolloutPromotionInProgress is a reason which
 	// indicates that a promotion is in progress.
 	DataPlaneConditionReasonRolloutPromotionInProgress ConditionReason = "PromotionInProgress"
@@ -50,4 +61,8 @@ const (
 	// that is set for the RolledOut Condition when Reason is Progressing
 	// and the operator is waiting for preview Deployment to be ready.
 	DataPlaneConditionMessageRolledOutPreviewDeploymentNotYetReady = "Preview Deployment not yet ready"
+
+	// DataPlaneConditionMessageRolledOutRolloutTimedOut contains the message
+	// that is set for the RolledOut Condition when Reason is TimedOut.
+	DataPlaneConditionMessageRolledOutRolloutTimedOut = "Rollout timed out waiting for preview Deployment to become ready"
 )
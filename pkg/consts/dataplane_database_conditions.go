@@ -0,0 +1,29 @@
+package consts
+
+const (
+	// DataPlaneConditionTypeDatabaseMigrated is a condition type indicating whether
+	// the DataPlane's database has been migrated for the DataPlane's image.
+	DataPlaneConditionTypeDatabaseMigrated ConditionType = "DatabaseMigrated"
+)
+
+const (
+	// DataPlaneConditionReasonMigrationsRunning is a reason which indicates
+	// a database migrations Job of a DataPlane is running.
+	DataPlaneConditionReasonMigrationsRunning ConditionReason = "MigrationsRunning"
+
+	// DataPlaneConditionReasonMigrationsPending is a reason which indicates
+	// a DataPlane's database migrations are waiting for the previous migrations
+	// to be finished, which happens once all the DataPlane's pods run
+	// the previously migrated image.
+	DataPlaneConditionReasonMigrationsPending ConditionReason = "MigrationsPending"
+
+	// DataPlaneConditionReasonMigrationsFailed is a reason which indicates
+	// a database migrations Job of a DataPlane has failed. The condition's message
+	// carries the Job's failure. The failed Job is recreated after a backoff
+	// or as soon as the DataPlane's spec it's generated from changes.
+	DataPlaneConditionReasonMigrationsFailed ConditionReason = "MigrationsFailed"
+
+	// DataPlaneConditionReasonMigrationsComplete is a reason which indicates
+	// a DataPlane's database has been migrated for the DataPlane's image.
+	DataPlaneConditionReasonMigrationsComplete ConditionReason = "MigrationsComplete"
+)
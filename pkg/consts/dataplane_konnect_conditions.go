@@ -0,0 +1,21 @@
+package consts
+
+const (
+	// DataPlaneConditionTypeKonnectCertificateRegistered is a condition type
+	// indicating whether the client certificate of a DataPlane configured with
+	// spec.konnect has been registered with the Konnect control plane.
+	DataPlaneConditionTypeKonnectCertificateRegistered ConditionType = "KonnectCertificateRegistered"
+)
+
+const (
+	// DataPlaneConditionReasonKonnectCertificateRegistered is a reason which
+	// indicates that the DataPlane's client certificate has been registered
+	// with the Konnect control plane.
+	DataPlaneConditionReasonKonnectCertificateRegistered ConditionReason = "Registered"
+
+	// DataPlaneConditionReasonKonnectCertificateRegistrationFailed is a reason
+	// which indicates that the DataPlane's client certificate couldn't be
+	// registered with the Konnect control plane, e.g. because the referenced
+	// KonnectAPIAuthConfiguration is not valid or the Konnect API returned an error.
+	DataPlaneConditionReasonKonnectCertificateRegistrationFailed ConditionReason = "RegistrationFailed"
+)
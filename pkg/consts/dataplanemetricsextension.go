@@ -0,0 +1,22 @@
+package consts
+
+// -----------------------------------------------------------------------------
+// Consts - DataPlaneMetricsExtension Labels and Annotations
+// -----------------------------------------------------------------------------
+
+const (
+	// DataPlaneMetricsExtensionManagedLabelValue indicates that an object's lifecycle is managed
+	// by the DataPlaneMetricsExtension controller.
+	DataPlaneMetricsExtensionManagedLabelValue = "dataplanemetricsextension"
+
+	// DataPlaneMetricsExtensionServiceLabelPrefix is the prefix of the label set on
+	// Services which have a DataPlaneMetricsExtension's plugin attached. The label
+	// name is the extension's UID so that a Service can be selected by multiple
+	// extensions and each of them can list only the Services it has annotated.
+	DataPlaneMetricsExtensionServiceLabelPrefix = "dataplanemetricsextension." + OperatorLabelPrefix
+
+	// KongPluginsAnnotation is the annotation used by the ingress controller to
+	// attach KongPlugins to Kubernetes objects (e.g. Services). Its value is a comma
+	// separated list of KongPlugin names from the object's namespace.
+	KongPluginsAnnotation = "konghq.com/plugins"
+)
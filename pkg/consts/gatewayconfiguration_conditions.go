@@ -0,0 +1,19 @@
+package consts
+
+const (
+	// GatewayConfigurationConditionTypeAccepted is a condition type indicating
+	// whether the DataPlane and ControlPlane options of a GatewayConfiguration
+	// are valid.
+	GatewayConfigurationConditionTypeAccepted ConditionType = "Accepted"
+)
+
+const (
+	// GatewayConfigurationConditionReasonAccepted is a reason which indicates
+	// a GatewayConfiguration's options are valid.
+	GatewayConfigurationConditionReasonAccepted ConditionReason = "Accepted"
+
+	// GatewayConfigurationConditionReasonInvalid is a reason which indicates
+	// a GatewayConfiguration's options are invalid. The condition's message
+	// contains the validation error.
+	GatewayConfigurationConditionReasonInvalid ConditionReason = "Invalid"
+)
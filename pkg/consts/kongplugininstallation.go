@@ -0,0 +1,27 @@
+package consts
+
+// -----------------------------------------------------------------------------
+// Consts - KongPluginInstallation Labels and Annotations
+// -----------------------------------------------------------------------------
+
+const (
+	// KongPluginInstallationManagedLabelValue indicates that an object's lifecycle is managed
+	// by the KongPluginInstallation controller.
+	KongPluginInstallationManagedLabelValue = "kongplugininstallation"
+)
+
+// -----------------------------------------------------------------------------
+// Consts - DataPlane custom plugins
+// -----------------------------------------------------------------------------
+
+const (
+	// DataPlanePluginsMountPath is the path under which the custom plugins installed
+	// in a DataPlane are mounted. Every plugin is mounted in its own directory following
+	// the layout expected by Kong, i.e. <path>/kong/plugins/<plugin name>/handler.lua.
+	DataPlanePluginsMountPath = "/opt"
+
+	// DataPlanePluginsChecksumAnnotation is the annotation set on the DataPlane's pod template
+	// holding a checksum of the installed custom plugins. It triggers a rollout of DataPlane's
+	// pods when any of the plugins changes.
+	DataPlanePluginsChecksumAnnotation = OperatorAnnotationPrefix + "plugins-checksum"
+)
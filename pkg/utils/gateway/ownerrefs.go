@@ -112,9 +112,109 @@ func ListHTTPRoutesForGateway(
 		return nil, fmt.Errorf("can't list HTTPRoutes for gateway: %w", err)
 	}
 
-	var httpRoutes []gwtypes.HTTPRoute
-	for _, httpRoute := range httpRoutesList.Items {
-		if !lo.ContainsBy(httpRoute.Spec.ParentRefs, func(parentRef gwtypes.ParentReference) bool {
+	return filterRoutesForGateway(httpRoutesList.Items, gateway, func(r gwtypes.HTTPRoute) []gwtypes.ParentReference {
+		return r.Spec.ParentRefs
+	}), nil
+}
+
+// ListGRPCRoutesForGateway is a helper function which returns a list of GRPCRoutes
+// that have the provided Gateway set as parent in their spec.
+func ListGRPCRoutesForGateway(
+	ctx context.Context,
+	c client.Client,
+	gateway *gwtypes.Gateway,
+	opts ...client.ListOption,
+) ([]gwtypes.GRPCRoute, error) {
+	if gateway.Namespace == "" {
+		return nil, fmt.Errorf("can't list GRPCRoutes for gateway: Gateway %s was missing namespace", gateway.Name)
+	}
+
+	var grpcRoutesList gwtypes.GRPCRouteList
+	if err := c.List(ctx, &grpcRoutesList, opts...); err != nil {
+		return nil, fmt.Errorf("can't list GRPCRoutes for gateway: %w", err)
+	}
+
+	return filterRoutesForGateway(grpcRoutesList.Items, gateway, func(r gwtypes.GRPCRoute) []gwtypes.ParentReference {
+		return r.Spec.ParentRefs
+	}), nil
+}
+
+// ListTCPRoutesForGateway is a helper function which returns a list of TCPRoutes
+// that have the provided Gateway set as parent in their spec.
+func ListTCPRoutesForGateway(
+	ctx context.Context,
+	c client.Client,
+	gateway *gwtypes.Gateway,
+	opts ...client.ListOption,
+) ([]gwtypes.TCPRoute, error) {
+	if gateway.Namespace == "" {
+		return nil, fmt.Errorf("can't list TCPRoutes for gateway: Gateway %s was missing namespace", gateway.Name)
+	}
+
+	var tcpRoutesList gwtypes.TCPRouteList
+	if err := c.List(ctx, &tcpRoutesList, opts...); err != nil {
+		return nil, fmt.Errorf("can't list TCPRoutes for gateway: %w", err)
+	}
+
+	return filterRoutesForGateway(tcpRoutesList.Items, gateway, func(r gwtypes.TCPRoute) []gwtypes.ParentReference {
+		return r.Spec.ParentRefs
+	}), nil
+}
+
+// ListTLSRoutesForGateway is a helper function which returns a list of TLSRoutes
+// that have the provided Gateway set as parent in their spec.
+func ListTLSRoutesForGateway(
+	ctx context.Context,
+	c client.Client,
+	gateway *gwtypes.Gateway,
+	opts ...client.ListOption,
+) ([]gwtypes.TLSRoute, error) {
+	if gateway.Namespace == "" {
+		return nil, fmt.Errorf("can't list TLSRoutes for gateway: Gateway %s was missing namespace", gateway.Name)
+	}
+
+	var tlsRoutesList gwtypes.TLSRouteList
+	if err := c.List(ctx, &tlsRoutesList, opts...); err != nil {
+		return nil, fmt.Errorf("can't list TLSRoutes for gateway: %w", err)
+	}
+
+	return filterRoutesForGateway(tlsRoutesList.Items, gateway, func(r gwtypes.TLSRoute) []gwtypes.ParentReference {
+		return r.Spec.ParentRefs
+	}), nil
+}
+
+// ListUDPRoutesForGateway is a helper function which returns a list of UDPRoutes
+// that have the provided Gateway set as parent in their spec.
+func ListUDPRoutesForGateway(
+	ctx context.Context,
+	c client.Client,
+	gateway *gwtypes.Gateway,
+	opts ...client.ListOption,
+) ([]gwtypes.UDPRoute, error) {
+	if gateway.Namespace == "" {
+		return nil, fmt.Errorf("can't list UDPRoutes for gateway: Gateway %s was missing namespace", gateway.Name)
+	}
+
+	var udpRoutesList gwtypes.UDPRouteList
+	if err := c.List(ctx, &udpRoutesList, opts...); err != nil {
+		return nil, fmt.Errorf("can't list UDPRoutes for gateway: %w", err)
+	}
+
+	return filterRoutesForGateway(udpRoutesList.Items, gateway, func(r gwtypes.UDPRoute) []gwtypes.ParentReference {
+		return r.Spec.ParentRefs
+	}), nil
+}
+
+// filterRoutesForGateway returns the routes which reference the provided Gateway
+// (and one of its listeners, if the section name is set) in their parentRefs.
+func filterRoutesForGateway[T any](
+	routes []T,
+	gateway *gwtypes.Gateway,
+	parentRefsForRoute func(T) []gwtypes.ParentReference,
+) []T {
+	var filtered []T
+	for _, route := range routes {
+		if !lo.ContainsBy(parentRefsForRoute(route), func(parentRef gwtypes.ParentReference) bool {
 			gwGVK := gateway.GroupVersionKind()
 			if parentRef.Group != nil && string(*parentRef.Group) != gwGVK.Group {
 				return false
@@ -145,10 +245,10 @@ func ListHTTPRoutesForGateway(
 			continue
 		}
 
-		httpRoutes = append(httpRoutes, httpRoute)
+		filtered = append(filtered, route)
 	}
 
-	return httpRoutes, nil
+	return filtered
 }
 
 // GetDataPlaneForControlPlane retrieves the DataPlane object referenced by a ControlPlane
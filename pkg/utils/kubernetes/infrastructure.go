@@ -0,0 +1,140 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/gateway-operator/pkg/consts"
+)
+
+// -----------------------------------------------------------------------------
+// Kubernetes Utils - Gateway Infrastructure Metadata
+// -----------------------------------------------------------------------------
+
+// InfrastructureMetadata holds the labels and annotations which are propagated
+// from a Gateway's spec.infrastructure to the objects managed for that Gateway.
+type InfrastructureMetadata struct {
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// IsEmpty returns true when there are no labels and no annotations to propagate.
+func (m InfrastructureMetadata) IsEmpty() bool {
+	return len(m.Labels) == 0 && len(m.Annotations) == 0
+}
+
+// GetInfrastructureMetadata returns the infrastructure labels and annotations
+// which were applied to an object, as recorded in its annotations.
+// Malformed records are ignored.
+func GetInfrastructureMetadata(meta metav1.ObjectMeta) InfrastructureMetadata {
+	return InfrastructureMetadata{
+		Labels:      decodeInfrastructureRecord(meta.Annotations[consts.AnnotationLastAppliedInfrastructureLabels]),
+		Annotations: decodeInfrastructureRecord(meta.Annotations[consts.AnnotationLastAppliedInfrastructureAnnotations]),
+	}
+}
+
+// SetInfrastructureMetadata sets the infrastructure labels and annotations on
+// the metadata of a generated object and records the applied ones in its
+// annotations, so that they can be propagated further and removed once they
+// are removed from the Gateway.
+// Labels and annotations already set on the object are not overridden.
+func SetInfrastructureMetadata(meta *metav1.ObjectMeta, infra InfrastructureMetadata) {
+	var applied InfrastructureMetadata
+	meta.Labels, applied.Labels = setMissing(meta.Labels, infra.Labels)
+	meta.Annotations, applied.Annotations = setMissing(meta.Annotations, infra.Annotations)
+
+	if record := encodeInfrastructureRecord(applied.Labels); record != "" {
+		meta.Annotations = setKey(meta.Annotations, consts.AnnotationLastAppliedInfrastructureLabels, record)
+	}
+	if record := encodeInfrastructureRecord(applied.Annotations); record != "" {
+		meta.Annotations = setKey(meta.Annotations, consts.AnnotationLastAppliedInfrastructureAnnotations, record)
+	}
+}
+
+// SetInfrastructurePodTemplateMetadata sets the infrastructure labels and
+// annotations on the provided pod template. The applied ones are not recorded
+// because pod templates are always regenerated as a whole.
+// Labels and annotations already set on the pod template are not overridden.
+func SetInfrastructurePodTemplateMetadata(template *corev1.PodTemplateSpec, infra InfrastructureMetadata) {
+	template.Labels, _ = setMissing(template.Labels, infra.Labels)
+	template.Annotations, _ = setMissing(template.Annotations, infra.Annotations)
+}
+
+// UpdateInfrastructureMetadata replaces the infrastructure labels and annotations
+// which were previously applied to an object with the provided ones. It returns
+// the updated object metadata and whether it changed.
+// Labels and annotations from other sources are neither overridden nor removed.
+func UpdateInfrastructureMetadata(meta metav1.ObjectMeta, infra InfrastructureMetadata) (bool, metav1.ObjectMeta) {
+	var (
+		applied     = GetInfrastructureMetadata(meta)
+		labels      = maps.Clone(meta.Labels)
+		annotations = maps.Clone(meta.Annotations)
+	)
+	if applied.IsEmpty() && infra.IsEmpty() {
+		return false, meta
+	}
+
+	for k := range applied.Labels {
+		delete(labels, k)
+	}
+	for k := range applied.Annotations {
+		delete(annotations, k)
+	}
+	delete(annotations, consts.AnnotationLastAppliedInfrastructureLabels)
+	delete(annotations, consts.AnnotationLastAppliedInfrastructureAnnotations)
+
+	updated := metav1.ObjectMeta{Labels: labels, Annotations: annotations}
+	SetInfrastructureMetadata(&updated, infra)
+	if maps.Equal(updated.Labels, meta.Labels) && maps.Equal(updated.Annotations, meta.Annotations) {
+		return false, meta
+	}
+	meta.Labels = updated.Labels
+	meta.Annotations = updated.Annotations
+	return true, meta
+}
+
+// setMissing sets the values of src which are missing from dst and returns
+// the updated dst along with the values that were set.
+func setMissing(dst, src map[string]string) (map[string]string, map[string]string) {
+	var set map[string]string
+	for k, v := range src {
+		if _, ok := dst[k]; ok {
+			continue
+		}
+		dst = setKey(dst, k, v)
+		set = setKey(set, k, v)
+	}
+	return dst, set
+}
+
+func setKey(m map[string]string, k, v string) map[string]string {
+	if m == nil {
+		m = make(map[string]string)
+	}
+	m[k] = v
+	return m
+}
+
+func encodeInfrastructureRecord(m map[string]string) string {
+	if len(m) == 0 {
+		return ""
+	}
+	// Marshalling a map of strings can't fail and the keys are sorted, which
+	// keeps the record stable across reconciliations.
+	b, _ := json.Marshal(m)
+	return string(b)
+}
+
+func decodeInfrastructureRecord(record string) map[string]string {
+	if record == "" {
+		return nil
+	}
+	var m map[string]string
+	if err := json.Unmarshal([]byte(record), &m); err != nil {
+		return nil
+	}
+	return m
+}
@@ -0,0 +1,187 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/gateway-operator/pkg/consts"
+)
+
+func TestSetInfrastructureMetadata(t *testing.T) {
+	infra := InfrastructureMetadata{
+		Labels:      map[string]string{"cost-center": "1234", "app": "infra"},
+		Annotations: map[string]string{"sidecar.istio.io/inject": "true"},
+	}
+	meta := metav1.ObjectMeta{
+		Labels: map[string]string{"app": "dp"},
+	}
+
+	SetInfrastructureMetadata(&meta, infra)
+	require.Equal(t, metav1.ObjectMeta{
+		Labels: map[string]string{
+			"app":         "dp",
+			"cost-center": "1234",
+		},
+		Annotations: map[string]string{
+			"sidecar.istio.io/inject":                             "true",
+			consts.AnnotationLastAppliedInfrastructureLabels:      `{"cost-center":"1234"}`,
+			consts.AnnotationLastAppliedInfrastructureAnnotations: `{"sidecar.istio.io/inject":"true"}`,
+		},
+	}, meta)
+
+	t.Log("verifying that the applied labels and annotations can be read back")
+	require.Equal(t, InfrastructureMetadata{
+		Labels:      map[string]string{"cost-center": "1234"},
+		Annotations: map[string]string{"sidecar.istio.io/inject": "true"},
+	}, GetInfrastructureMetadata(meta))
+
+	t.Log("verifying that pod templates don't record the applied labels and annotations")
+	template := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{"app": "dp"},
+		},
+	}
+	SetInfrastructurePodTemplateMetadata(&template, infra)
+	require.Equal(t, metav1.ObjectMeta{
+		Labels: map[string]string{
+			"app":         "dp",
+			"cost-center": "1234",
+		},
+		Annotations: map[string]string{
+			"sidecar.istio.io/inject": "true",
+		},
+	}, template.ObjectMeta)
+
+	t.Log("verifying that malformed records are ignored")
+	require.Equal(t, InfrastructureMetadata{}, GetInfrastructureMetadata(metav1.ObjectMeta{
+		Annotations: map[string]string{consts.AnnotationLastAppliedInfrastructureLabels: "{"},
+	}))
+}
+
+func TestUpdateInfrastructureMetadata(t *testing.T) {
+	newMeta := func(infra InfrastructureMetadata) metav1.ObjectMeta {
+		meta := metav1.ObjectMeta{
+			Labels:      map[string]string{"app": "dp"},
+			Annotations: map[string]string{"user": "annotation"},
+		}
+		SetInfrastructureMetadata(&meta, infra)
+		return meta
+	}
+
+	testCases := []struct {
+		name     string
+		existing metav1.ObjectMeta
+		infra    InfrastructureMetadata
+		toUpdate bool
+		expected metav1.ObjectMeta
+	}{
+		{
+			name:     "no infrastructure metadata",
+			existing: newMeta(InfrastructureMetadata{}),
+			expected: newMeta(InfrastructureMetadata{}),
+		},
+		{
+			name:     "infrastructure metadata is added",
+			existing: newMeta(InfrastructureMetadata{}),
+			infra: InfrastructureMetadata{
+				Labels:      map[string]string{"cost-center": "1234"},
+				Annotations: map[string]string{"sidecar.istio.io/inject": "true"},
+			},
+			toUpdate: true,
+			expected: newMeta(InfrastructureMetadata{
+				Labels:      map[string]string{"cost-center": "1234"},
+				Annotations: map[string]string{"sidecar.istio.io/inject": "true"},
+			}),
+		},
+		{
+			name: "infrastructure metadata is up to date",
+			existing: newMeta(InfrastructureMetadata{
+				Labels: map[string]string{"cost-center": "1234"},
+			}),
+			infra: InfrastructureMetadata{
+				Labels: map[string]string{"cost-center": "1234"},
+			},
+			expected: newMeta(InfrastructureMetadata{
+				Labels: map[string]string{"cost-center": "1234"},
+			}),
+		},
+		{
+			name: "infrastructure metadata is changed and removed",
+			existing: newMeta(InfrastructureMetadata{
+				Labels:      map[string]string{"cost-center": "1234", "team": "a"},
+				Annotations: map[string]string{"sidecar.istio.io/inject": "true"},
+			}),
+			infra: InfrastructureMetadata{
+				Labels: map[string]string{"cost-center": "5678"},
+			},
+			toUpdate: true,
+			expected: newMeta(InfrastructureMetadata{
+				Labels: map[string]string{"cost-center": "5678"},
+			}),
+		},
+		{
+			name: "labels and annotations from other sources are kept",
+			existing: newMeta(InfrastructureMetadata{
+				Labels:      map[string]string{"cost-center": "1234"},
+				Annotations: map[string]string{"sidecar.istio.io/inject": "true"},
+			}),
+			infra: InfrastructureMetadata{
+				Labels:      map[string]string{"app": "infra"},
+				Annotations: map[string]string{"user": "infra"},
+			},
+			toUpdate: true,
+			expected: newMeta(InfrastructureMetadata{}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			existing := *tc.existing.DeepCopy()
+			toUpdate, updated := UpdateInfrastructureMetadata(existing, tc.infra)
+			require.Equal(t, tc.toUpdate, toUpdate)
+			require.Equal(t, tc.expected, updated)
+			require.Equal(t, tc.existing, existing, "existing metadata must not be modified")
+		})
+	}
+}
+
+func TestEnsureObjectMetaIsUpdatedWithInfrastructureMetadata(t *testing.T) {
+	generate := func(infra InfrastructureMetadata) metav1.ObjectMeta {
+		meta := metav1.ObjectMeta{
+			Labels: map[string]string{"app": "dp"},
+		}
+		SetInfrastructureMetadata(&meta, infra)
+		return meta
+	}
+
+	existing := generate(InfrastructureMetadata{
+		Labels:      map[string]string{"cost-center": "1234"},
+		Annotations: map[string]string{"sidecar.istio.io/inject": "true"},
+	})
+	existing.Annotations["deployment.kubernetes.io/revision"] = "3"
+
+	t.Log("verifying that removed infrastructure annotations are removed from the existing object")
+	toUpdate, updated := EnsureObjectMetaIsUpdated(existing, generate(InfrastructureMetadata{
+		Labels: map[string]string{"cost-center": "1234"},
+	}))
+	require.True(t, toUpdate)
+	require.Equal(t, metav1.ObjectMeta{
+		Labels: map[string]string{
+			"app":         "dp",
+			"cost-center": "1234",
+		},
+		Annotations: map[string]string{
+			"deployment.kubernetes.io/revision":              "3",
+			consts.AnnotationLastAppliedInfrastructureLabels: `{"cost-center":"1234"}`,
+		},
+	}, updated)
+
+	t.Log("verifying that the existing object isn't updated when the infrastructure metadata is up to date")
+	toUpdate, _ = EnsureObjectMetaIsUpdated(updated, generate(InfrastructureMetadata{
+		Labels: map[string]string{"cost-center": "1234"},
+	}))
+	require.False(t, toUpdate)
+}
@@ -8,6 +8,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -83,6 +84,41 @@ func ListHPAsForOwner(
 	return hpas, nil
 }
 
+// ListPodDisruptionBudgetsForOwner is a helper function which gets a list of PodDisruptionBudgets
+// using the provided list options and reduce by OwnerReference UID and namespace to efficiently
+// list only the objects owned by the provided UID.
+func ListPodDisruptionBudgetsForOwner(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	uid types.UID,
+	listOpts ...client.ListOption,
+) ([]policyv1.PodDisruptionBudget, error) {
+	pdbList := &policyv1.PodDisruptionBudgetList{}
+
+	err := c.List(
+		ctx,
+		pdbList,
+		append(
+			[]client.ListOption{client.InNamespace(namespace)},
+			listOpts...,
+		)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pdbs := make([]policyv1.PodDisruptionBudget, 0)
+	for _, pdb := range pdbList.Items {
+		pdb := pdb
+		if IsOwnedByRefUID(&pdb, uid) {
+			pdbs = append(pdbs, pdb)
+		}
+	}
+
+	return pdbs, nil
+}
+
 // ListServicesForOwner is a helper function which gets a list of Services
 // using the provided list options and reduce by OwnerReference UID and namespace to efficiently
 // list only the objects owned by the provided UID.
@@ -153,6 +189,76 @@ func ListServiceAccountsForOwner(
 	return serviceAccounts, nil
 }
 
+// ListRolesForOwner is a helper function which gets a list of Roles
+// using the provided list options and reduce by OwnerReference UID and namespace to efficiently
+// list only the objects owned by the provided UID.
+func ListRolesForOwner(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	uid types.UID,
+	listOpts ...client.ListOption,
+) ([]rbacv1.Role, error) {
+	roleList := &rbacv1.RoleList{}
+
+	err := c.List(
+		ctx,
+		roleList,
+		append(
+			[]client.ListOption{client.InNamespace(namespace)},
+			listOpts...,
+		)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]rbacv1.Role, 0)
+	for _, role := range roleList.Items {
+		role := role
+		if IsOwnedByRefUID(&role, uid) {
+			roles = append(roles, role)
+		}
+	}
+
+	return roles, nil
+}
+
+// ListRoleBindingsForOwner is a helper function which gets a list of RoleBindings
+// using the provided list options and reduce by OwnerReference UID and namespace to efficiently
+// list only the objects owned by the provided UID.
+func ListRoleBindingsForOwner(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	uid types.UID,
+	listOpts ...client.ListOption,
+) ([]rbacv1.RoleBinding, error) {
+	roleBindingList := &rbacv1.RoleBindingList{}
+
+	err := c.List(
+		ctx,
+		roleBindingList,
+		append(
+			[]client.ListOption{client.InNamespace(namespace)},
+			listOpts...,
+		)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	roleBindings := make([]rbacv1.RoleBinding, 0)
+	for _, roleBinding := range roleBindingList.Items {
+		roleBinding := roleBinding
+		if IsOwnedByRefUID(&roleBinding, uid) {
+			roleBindings = append(roleBindings, roleBinding)
+		}
+	}
+
+	return roleBindings, nil
+}
+
 // ListClusterRoles is a helper function which gets a list of ClusterRoles
 // using the provided list options.
 func ListClusterRoles(
@@ -225,6 +331,41 @@ func ListSecretsForOwner(ctx context.Context,
 	return secrets, nil
 }
 
+// ListConfigMapsForOwner is a helper function which gets a list of ConfigMaps
+// using the provided list options and reduce by OwnerReference UID and namespace to efficiently
+// list only the objects owned by the provided UID.
+func ListConfigMapsForOwner(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	uid types.UID,
+	listOpts ...client.ListOption,
+) ([]corev1.ConfigMap, error) {
+	configMapList := &corev1.ConfigMapList{}
+
+	err := c.List(
+		ctx,
+		configMapList,
+		append(
+			[]client.ListOption{client.InNamespace(namespace)},
+			listOpts...,
+		)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	configMaps := make([]corev1.ConfigMap, 0)
+	for _, configMap := range configMapList.Items {
+		configMap := configMap
+		if IsOwnedByRefUID(&configMap, uid) {
+			configMaps = append(configMaps, configMap)
+		}
+	}
+
+	return configMaps, nil
+}
+
 // ListValidatingWebhookConfigurations is a helper function that gets a list of ValidatingWebhookConfiguration
 // using the provided list options.
 func ListValidatingWebhookConfigurations(
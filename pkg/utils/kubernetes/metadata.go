@@ -53,6 +53,13 @@ func EnsureObjectMetaIsUpdated(
 		metaToUpdate = true
 	}
 
+	// compare and enforce the labels and annotations propagated from a Gateway's infrastructure
+	var infrastructureUpdated bool
+	infrastructureUpdated, existingMeta = UpdateInfrastructureMetadata(existingMeta, GetInfrastructureMetadata(generatedMeta))
+	if infrastructureUpdated {
+		metaToUpdate = true
+	}
+
 	// apply all the passed options
 	for _, opt := range options {
 		var changed bool
@@ -55,3 +55,13 @@ func GetContainerVolumeMountByMountPath(container *corev1.Container, mountPath s
 
 	return nil
 }
+
+// IsPodReady returns true if the pod has the Ready condition set to true.
+func IsPodReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -8,6 +8,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
@@ -79,6 +80,29 @@ func filterServiceAccounts(serviceAccounts []corev1.ServiceAccount) []corev1.Ser
 	return append(serviceAccounts[:toFilter], serviceAccounts[toFilter+1:]...)
 }
 
+// -----------------------------------------------------------------------------
+// Filter functions - ConfigMaps
+// -----------------------------------------------------------------------------
+
+// filterConfigMaps filters out the ConfigMap to be kept and returns
+// all the ConfigMaps to be deleted.
+// The filtered-out ConfigMap is decided as follows:
+// 1. creationTimestamp (older is better)
+func filterConfigMaps(configMaps []corev1.ConfigMap) []corev1.ConfigMap {
+	if len(configMaps) < 2 {
+		return []corev1.ConfigMap{}
+	}
+
+	toFilter := 0
+	for i, configMap := range configMaps {
+		if configMap.CreationTimestamp.Before(&configMaps[toFilter].CreationTimestamp) {
+			toFilter = i
+		}
+	}
+
+	return append(configMaps[:toFilter], configMaps[toFilter+1:]...)
+}
+
 // -----------------------------------------------------------------------------
 // Filter functions - ClusterRoles
 // -----------------------------------------------------------------------------
@@ -181,6 +205,52 @@ func filterClusterRoleBindings(clusterRoleBindings []rbacv1.ClusterRoleBinding)
 	return append(clusterRoleBindings[:oldestLegacy], clusterRoleBindings[oldestLegacy+1:]...)
 }
 
+// -----------------------------------------------------------------------------
+// Filter functions - Roles
+// -----------------------------------------------------------------------------
+
+// filterRoles filters out the Role to be kept and returns
+// all the Roles to be deleted.
+// The filtered-out Role is decided as follows:
+// 1. creationTimestamp (older is better)
+func filterRoles(roles []rbacv1.Role) []rbacv1.Role {
+	if len(roles) < 2 {
+		return []rbacv1.Role{}
+	}
+
+	toFilter := 0
+	for i, role := range roles {
+		if role.CreationTimestamp.Before(&roles[toFilter].CreationTimestamp) {
+			toFilter = i
+		}
+	}
+
+	return append(roles[:toFilter], roles[toFilter+1:]...)
+}
+
+// -----------------------------------------------------------------------------
+// Filter functions - RoleBindings
+// -----------------------------------------------------------------------------
+
+// filterRoleBindings filters out the RoleBinding to be kept and returns
+// all the RoleBindings to be deleted.
+// The filtered-out RoleBinding is decided as follows:
+// 1. creationTimestamp (older is better)
+func filterRoleBindings(roleBindings []rbacv1.RoleBinding) []rbacv1.RoleBinding {
+	if len(roleBindings) < 2 {
+		return []rbacv1.RoleBinding{}
+	}
+
+	toFilter := 0
+	for i, roleBinding := range roleBindings {
+		if roleBinding.CreationTimestamp.Before(&roleBindings[toFilter].CreationTimestamp) {
+			toFilter = i
+		}
+	}
+
+	return append(roleBindings[:toFilter], roleBindings[toFilter+1:]...)
+}
+
 // -----------------------------------------------------------------------------
 // Filter functions - Deployments
 // -----------------------------------------------------------------------------
@@ -367,6 +437,29 @@ func FilterHPAs(hpas []autoscalingv2.HorizontalPodAutoscaler) []autoscalingv2.Ho
 	return append(hpas[:best], hpas[best+1:]...)
 }
 
+// -----------------------------------------------------------------------------
+// Filter functions - PodDisruptionBudgets
+// -----------------------------------------------------------------------------
+
+// FilterPodDisruptionBudgets filters out the PodDisruptionBudgets to be kept and
+// returns all the PodDisruptionBudgets to be deleted.
+// The filtered-out PodDisruptionBudget is decided as follows:
+// 1. creationTimestamp (older is better)
+func FilterPodDisruptionBudgets(pdbs []policyv1.PodDisruptionBudget) []policyv1.PodDisruptionBudget {
+	if len(pdbs) < 2 {
+		return []policyv1.PodDisruptionBudget{}
+	}
+
+	best := 0
+	for i, pdb := range pdbs {
+		if pdb.CreationTimestamp.Before(&pdbs[best].CreationTimestamp) {
+			best = i
+		}
+	}
+
+	return append(pdbs[:best], pdbs[best+1:]...)
+}
+
 // -----------------------------------------------------------------------------
 // Filter functions - ValidatingWebhookConfigurations
 // -----------------------------------------------------------------------------
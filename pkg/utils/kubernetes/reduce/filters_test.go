@@ -114,6 +114,59 @@ func TestFilterServiceAccounts(t *testing.T) {
 	}
 }
 
+func TestFilterConfigMaps(t *testing.T) {
+	testCases := []struct {
+		name               string
+		configMaps         []corev1.ConfigMap
+		filteredConfigMaps []corev1.ConfigMap
+	}{
+		{
+			name: "the older configMap must be filtered out",
+			configMaps: []corev1.ConfigMap{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "12/31/1995",
+						CreationTimestamp: metav1.Date(1995, time.December, 31, 0, 0, 0, 0, time.UTC),
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "6/30/1990",
+						CreationTimestamp: metav1.Date(1990, time.June, 30, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+			filteredConfigMaps: []corev1.ConfigMap{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "12/31/1995",
+						CreationTimestamp: metav1.Date(1995, time.December, 31, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		{
+			name: "a single configMap is not filtered out",
+			configMaps: []corev1.ConfigMap{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "only",
+					},
+				},
+			},
+			filteredConfigMaps: []corev1.ConfigMap{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			filteredConfigMaps := filterConfigMaps(tc.configMaps)
+			require.Equal(t, tc.filteredConfigMaps, filteredConfigMaps)
+		})
+	}
+}
+
 func TestFilterDeployments(t *testing.T) {
 	testCases := []struct {
 		name                string
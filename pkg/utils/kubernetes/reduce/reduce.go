@@ -10,6 +10,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -53,6 +54,20 @@ func ReduceServiceAccounts(ctx context.Context, k8sClient client.Client, service
 	return nil
 }
 
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=delete
+
+// ReduceConfigMaps detects the best ConfigMap in the set and deletes all the others.
+func ReduceConfigMaps(ctx context.Context, k8sClient client.Client, configMaps []corev1.ConfigMap) error {
+	filteredConfigMaps := filterConfigMaps(configMaps)
+	for _, configMap := range filteredConfigMaps {
+		configMap := configMap
+		if err := k8sClient.Delete(ctx, &configMap); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=delete
 
 // ReduceClusterRoles detects the best ClusterRole in the set and deletes all the others.
@@ -81,6 +96,34 @@ func ReduceClusterRoleBindings(ctx context.Context, k8sClient client.Client, clu
 	return nil
 }
 
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=delete
+
+// ReduceRoles detects the best Role in the set and deletes all the others.
+func ReduceRoles(ctx context.Context, k8sClient client.Client, roles []rbacv1.Role) error {
+	filteredRoles := filterRoles(roles)
+	for _, role := range filteredRoles {
+		role := role
+		if err := k8sClient.Delete(ctx, &role); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=delete
+
+// ReduceRoleBindings detects the best RoleBinding in the set and deletes all the others.
+func ReduceRoleBindings(ctx context.Context, k8sClient client.Client, roleBindings []rbacv1.RoleBinding) error {
+	filteredRoleBindings := filterRoleBindings(roleBindings)
+	for _, roleBinding := range filteredRoleBindings {
+		roleBinding := roleBinding
+		if err := k8sClient.Delete(ctx, &roleBinding); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=delete
 
 // ReduceDeployments detects the best Deployment in the set and deletes all the others.
@@ -165,6 +208,22 @@ func ReduceHPAs(ctx context.Context, k8sClient client.Client, hpas []autoscaling
 	return nil
 }
 
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=delete
+
+// PDBFilterFunc filters a list of PodDisruptionBudgets.
+type PDBFilterFunc func(pdbs []policyv1.PodDisruptionBudget) []policyv1.PodDisruptionBudget
+
+// ReducePodDisruptionBudgets detects the best PodDisruptionBudget in the set and deletes all the others.
+func ReducePodDisruptionBudgets(ctx context.Context, k8sClient client.Client, pdbs []policyv1.PodDisruptionBudget, filter PDBFilterFunc) error {
+	for _, pdb := range filter(pdbs) {
+		pdb := pdb
+		if err := k8sClient.Delete(ctx, &pdb); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=delete
 
 // ReduceValidatingWebhookConfigurations detects the best ValidatingWebhookConfiguration in the set and deletes all the others.
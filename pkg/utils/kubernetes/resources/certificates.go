@@ -0,0 +1,96 @@
+package resources
+
+import (
+	"fmt"
+
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+// -----------------------------------------------------------------------------
+// cert-manager Certificate generators
+// -----------------------------------------------------------------------------
+
+// CertManagerIssuerRef returns the reference to the cert-manager Issuer or
+// ClusterIssuer which should issue certificates. Issuers are looked up in the
+// namespace of the Certificate, i.e. of the certificate's owner.
+func CertManagerIssuerRef(issuer operatorv1beta1.CertManagerIssuerReference) (cmmeta.ObjectReference, error) {
+	var kind string
+	switch issuer.Kind {
+	case operatorv1beta1.CertManagerIssuerKindIssuer:
+		kind = certmanagerv1.IssuerKind
+	case operatorv1beta1.CertManagerIssuerKindClusterIssuer:
+		kind = certmanagerv1.ClusterIssuerKind
+	default:
+		return cmmeta.ObjectReference{}, fmt.Errorf("unsupported cert-manager issuer kind %q", issuer.Kind)
+	}
+	return cmmeta.ObjectReference{
+		Name:  issuer.Name,
+		Kind:  kind,
+		Group: certmanagerv1.SchemeGroupVersion.Group,
+	}, nil
+}
+
+// GenerateNewCertManagerCertificate generates a cert-manager Certificate for
+// the provided owner with the provided subject, issued by the referenced issuer.
+// The certificate is stored in a Secret with the same name as the Certificate.
+func GenerateNewCertManagerCertificate[
+	T interface {
+		controlPlaneOrDataPlane
+		client.Object
+	},
+](
+	owner T,
+	subject string,
+	issuerRef cmmeta.ObjectReference,
+	usages []certificatesv1.KeyUsage,
+	labels client.MatchingLabels,
+) *certmanagerv1.Certificate {
+	name := k8sutils.TrimGenerateName(fmt.Sprintf("%s-%s-", getPrefixForOwner(owner), owner.GetName())) +
+		utilrand.String(5)
+	certificate := &certmanagerv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: owner.GetNamespace(),
+			Name:      name,
+			Labels:    make(map[string]string),
+		},
+		Spec: certmanagerv1.CertificateSpec{
+			SecretName: name,
+		},
+	}
+	k8sutils.SetOwnerForObject(certificate, owner)
+	addLabelForOwner(certificate, owner)
+	for k, v := range labels {
+		certificate.Labels[k] = v
+	}
+	SetCertManagerCertificateSpec(certificate, subject, issuerRef, usages)
+	return certificate
+}
+
+// SetCertManagerCertificateSpec sets the provided subject, issuer and usages
+// in the spec of the provided cert-manager Certificate.
+func SetCertManagerCertificateSpec(
+	certificate *certmanagerv1.Certificate,
+	subject string,
+	issuerRef cmmeta.ObjectReference,
+	usages []certificatesv1.KeyUsage,
+) {
+	certificate.Spec.CommonName = subject
+	certificate.Spec.DNSNames = []string{subject}
+	certificate.Spec.IssuerRef = issuerRef
+	certificate.Spec.Usages = make([]certmanagerv1.KeyUsage, 0, len(usages))
+	for _, u := range usages {
+		certificate.Spec.Usages = append(certificate.Spec.Usages, certmanagerv1.KeyUsage(u))
+	}
+	certificate.Spec.PrivateKey = &certmanagerv1.CertificatePrivateKey{
+		Algorithm: certmanagerv1.ECDSAKeyAlgorithm,
+		Size:      256,
+	}
+}
@@ -0,0 +1,129 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+// -----------------------------------------------------------------------------
+// ConfigMap generators
+// -----------------------------------------------------------------------------
+
+const (
+	// PreviewRoutingHTTPIncludeFile is the name of the file in the preview routing
+	// ConfigMap which is included in the Nginx http block.
+	PreviewRoutingHTTPIncludeFile = "http.conf"
+	// PreviewRoutingProxyIncludeFile is the name of the file in the preview routing
+	// ConfigMap which is included in the Nginx proxy server block.
+	PreviewRoutingProxyIncludeFile = "proxy.conf"
+
+	// previewRoutingForwardedHeader is set on the requests forwarded to the preview
+	// pods, so that they don't forward them again.
+	previewRoutingForwardedHeader = "X-Gateway-Operator-Preview-Forwarded"
+)
+
+// GenerateNewPreviewRoutingConfigMapForDataPlane is a helper to generate the ConfigMap holding
+// the Nginx configuration which forwards the requests matching the DataPlane's preview routing
+// to the provided preview routing Service.
+func GenerateNewPreviewRoutingConfigMapForDataPlane(
+	dataplane *operatorv1beta1.DataPlane,
+	service *corev1.Service,
+) (*corev1.ConfigMap, error) {
+	rollout := dataplane.Spec.Deployment.Rollout
+	if rollout == nil || rollout.Strategy.BlueGreen == nil || rollout.Strategy.BlueGreen.PreviewRouting == nil {
+		return nil, fmt.Errorf("cannot generate preview routing ConfigMap for DataPlane %s which doesn't have it configured", dataplane.Name)
+	}
+
+	var (
+		routing  = rollout.Strategy.BlueGreen.PreviewRouting
+		variable string
+		value    string
+	)
+	switch {
+	case routing.Header != nil:
+		variable = headerToNginxVariable(routing.Header.Name)
+		value = routing.Header.Value
+	case routing.Cookie != nil:
+		variable = "$cookie_" + routing.Cookie.Name
+		value = routing.Cookie.Value
+	default:
+		return nil, fmt.Errorf("preview routing of DataPlane %s has neither header nor cookie configured", dataplane.Name)
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    dataplane.Namespace,
+			GenerateName: k8sutils.TrimGenerateName(fmt.Sprintf("%s-preview-routing-%s-", consts.DataPlanePrefix, dataplane.Name)),
+			Labels: map[string]string{
+				"app":                              dataplane.Name,
+				consts.DataPlaneConfigMapTypeLabel: consts.DataPlanePreviewRoutingConfigMapLabelValue,
+			},
+		},
+		Data: map[string]string{
+			PreviewRoutingHTTPIncludeFile: fmt.Sprintf(previewRoutingHTTPIncludeTemplate,
+				service.Name, service.Namespace, consts.DefaultHTTPPort,
+				service.Name, service.Namespace, consts.DefaultHTTPSPort),
+			PreviewRoutingProxyIncludeFile: fmt.Sprintf(previewRoutingProxyIncludeTemplate,
+				variable, value, headerToNginxVariable(previewRoutingForwardedHeader), previewRoutingForwardedHeader),
+		},
+	}
+	LabelObjectAsDataPlaneManaged(cm)
+	k8sutils.SetOwnerForObject(cm, dataplane)
+
+	return cm, nil
+}
+
+// headerToNginxVariable returns the name of the Nginx variable holding the value
+// of the provided request header.
+func headerToNginxVariable(header string) string {
+	return "$http_" + strings.ReplaceAll(strings.ToLower(header), "-", "_")
+}
+
+// The requests are forwarded to the preview pods' port matching the scheme
+// they were received with, so that TLS terminated requests aren't sent over
+// plain HTTP.
+const previewRoutingHTTPIncludeTemplate = `upstream gateway_operator_preview {
+    server %s.%s.svc:%d;
+    keepalive 16;
+}
+upstream gateway_operator_preview_tls {
+    server %s.%s.svc:%d;
+    keepalive 16;
+}
+`
+
+// The requests are rewritten to an internal location in the server's rewrite
+// phase, so that they're proxied before Kong's router handles them.
+const previewRoutingProxyIncludeTemplate = `set $gateway_operator_preview "";
+if (%s = "%s") {
+    set $gateway_operator_preview "1";
+}
+if (%s) {
+    set $gateway_operator_preview "";
+}
+set $gateway_operator_preview_upstream "http://gateway_operator_preview";
+if ($scheme = "https") {
+    set $gateway_operator_preview_upstream "https://gateway_operator_preview_tls";
+}
+if ($gateway_operator_preview) {
+    rewrite ^ /__gateway_operator_preview__ last;
+}
+location = /__gateway_operator_preview__ {
+    internal;
+    proxy_http_version 1.1;
+    proxy_set_header Connection "";
+    proxy_set_header Host $host;
+    proxy_set_header X-Forwarded-For $proxy_add_x_forwarded_for;
+    proxy_set_header %s "true";
+    proxy_ssl_server_name on;
+    proxy_ssl_name $host;
+    proxy_pass $gateway_operator_preview_upstream$request_uri;
+}
+`
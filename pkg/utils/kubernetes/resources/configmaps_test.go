@@ -0,0 +1,87 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+)
+
+func TestGenerateNewPreviewRoutingConfigMapForDataPlane(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dataplane-preview-routing-dp-1-abcde",
+			Namespace: "default",
+		},
+	}
+	dataplane := func(routing *operatorv1beta1.PreviewRouting) *operatorv1beta1.DataPlane {
+		return &operatorv1beta1.DataPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dp-1",
+				Namespace: "default",
+			},
+			Spec: operatorv1beta1.DataPlaneSpec{
+				DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+					Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+						Rollout: &operatorv1beta1.Rollout{
+							Strategy: operatorv1beta1.RolloutStrategy{
+								BlueGreen: &operatorv1beta1.BlueGreenStrategy{
+									PreviewRouting: routing,
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		routing       *operatorv1beta1.PreviewRouting
+		expectedMatch string
+		expectedErr   bool
+	}{
+		{
+			name: "header",
+			routing: &operatorv1beta1.PreviewRouting{
+				Header: &operatorv1beta1.PreviewRoutingMatch{Name: "X-Kong-Preview", Value: "true"},
+			},
+			expectedMatch: `if ($http_x_kong_preview = "true") {`,
+		},
+		{
+			name: "cookie",
+			routing: &operatorv1beta1.PreviewRouting{
+				Cookie: &operatorv1beta1.PreviewRoutingMatch{Name: "preview_version", Value: "v2.1"},
+			},
+			expectedMatch: `if ($cookie_preview_version = "v2.1") {`,
+		},
+		{
+			name:        "not configured",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cm, err := GenerateNewPreviewRoutingConfigMapForDataPlane(dataplane(tc.routing), svc)
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "dataplane-preview-routing-dp-1-", cm.GenerateName)
+			require.Equal(t, "preview-routing", cm.Labels["gateway-operator.konghq.com/dataplane-configmap-type"])
+			require.Contains(t, cm.Data[PreviewRoutingHTTPIncludeFile], "server dataplane-preview-routing-dp-1-abcde.default.svc:80;")
+			require.Contains(t, cm.Data[PreviewRoutingHTTPIncludeFile], "server dataplane-preview-routing-dp-1-abcde.default.svc:443;")
+			require.Contains(t, cm.Data[PreviewRoutingProxyIncludeFile], `set $gateway_operator_preview_upstream "https://gateway_operator_preview_tls";`,
+				"TLS terminated requests are forwarded over TLS")
+			require.Contains(t, cm.Data[PreviewRoutingProxyIncludeFile], tc.expectedMatch)
+			require.Contains(t, cm.Data[PreviewRoutingProxyIncludeFile], "if ($http_x_gateway_operator_preview_forwarded) {",
+				"forwarded requests are not forwarded again")
+		})
+	}
+}
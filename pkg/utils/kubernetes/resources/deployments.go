@@ -112,6 +112,13 @@ func GenerateNewDeploymentForControlPlane(params GenerateNewDeploymentForControl
 	SetDefaultsPodTemplateSpec(&deployment.Spec.Template)
 	LabelObjectAsControlPlaneManaged(deployment)
 
+	// Spread the pods of multi-replica ControlPlanes across nodes so that a single
+	// node failure doesn't take down both the leader and the standby pods.
+	// This can be overridden through the ControlPlane's PodTemplateSpec.
+	if replicas := params.ControlPlane.Spec.Deployment.Replicas; replicas != nil && *replicas > 1 {
+		deployment.Spec.Template.Spec.Affinity = generateControlPlanePodAntiAffinity(params.ControlPlane.Name)
+	}
+
 	if params.ControlPlane.Spec.Deployment.PodTemplateSpec != nil {
 		patchedPodTemplateSpec, err := StrategicMergePatchPodTemplateSpec(&deployment.Spec.Template, params.ControlPlane.Spec.Deployment.PodTemplateSpec)
 		if err != nil {
@@ -120,6 +127,11 @@ func GenerateNewDeploymentForControlPlane(params GenerateNewDeploymentForControl
 		deployment.Spec.Template = *patchedPodTemplateSpec
 	}
 
+	// propagate the labels and annotations of the Gateway's infrastructure
+	infra := k8sutils.GetInfrastructureMetadata(params.ControlPlane.ObjectMeta)
+	k8sutils.SetInfrastructureMetadata(&deployment.ObjectMeta, infra)
+	k8sutils.SetInfrastructurePodTemplateMetadata(&deployment.Spec.Template, infra)
+
 	k8sutils.SetOwnerForObject(deployment, params.ControlPlane)
 
 	// Set defaults for the deployment so that we don't get a diff when we compare
@@ -129,6 +141,28 @@ func GenerateNewDeploymentForControlPlane(params GenerateNewDeploymentForControl
 	return deployment, nil
 }
 
+// generateControlPlanePodAntiAffinity generates the default pod anti-affinity
+// of the ControlPlane's pods which prefers scheduling them on different nodes.
+func generateControlPlanePodAntiAffinity(controlplaneName string) *corev1.Affinity {
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"app": controlplaneName,
+							},
+						},
+						TopologyKey: corev1.LabelHostname,
+					},
+				},
+			},
+		},
+	}
+}
+
 // GenerateContainerForControlPlaneParams is a parameter struct for GenerateControlPlaneContainer function.
 type GenerateContainerForControlPlaneParams struct {
 	Image string
@@ -281,6 +315,11 @@ func GenerateNewDeploymentForDataPlane(
 		opt(deployment)
 	}
 
+	// propagate the labels and annotations of the Gateway's infrastructure
+	infra := k8sutils.GetInfrastructureMetadata(dataplane.ObjectMeta)
+	k8sutils.SetInfrastructureMetadata(&deployment.ObjectMeta, infra)
+	k8sutils.SetInfrastructurePodTemplateMetadata(&deployment.Spec.Template, infra)
+
 	k8sutils.SetOwnerForObject(deployment, dataplane)
 	controllerutil.AddFinalizer(deployment, consts.DataPlaneOwnedWaitForOwnerFinalizer)
 
@@ -339,6 +378,32 @@ func GenerateDataPlaneContainer(image string) corev1.Container {
 	}
 }
 
+// GenerateDataPlanePostgresEnvVars generates the environment variables configuring
+// a DataPlane container to use the Postgres database whose connection details
+// are held by the provided Secret.
+func GenerateDataPlanePostgresEnvVars(secretName string) []corev1.EnvVar {
+	secretKeyRef := func(key string, optional bool) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secretName,
+				},
+				Key:      key,
+				Optional: lo.ToPtr(optional),
+			},
+		}
+	}
+	return []corev1.EnvVar{
+		{Name: consts.EnvVarKongDatabase, Value: "postgres"},
+		{Name: "KONG_PG_DATABASE", ValueFrom: secretKeyRef(consts.DataPlanePostgresSecretDatabaseKey, true)},
+		{Name: "KONG_PG_HOST", ValueFrom: secretKeyRef(consts.DataPlanePostgresSecretHostKey, false)},
+		{Name: "KONG_PG_PASSWORD", ValueFrom: secretKeyRef(consts.DataPlanePostgresSecretPasswordKey, false)},
+		{Name: "KONG_PG_PORT", ValueFrom: secretKeyRef(consts.DataPlanePostgresSecretPortKey, true)},
+		{Name: "KONG_PG_SSL", ValueFrom: secretKeyRef(consts.DataPlanePostgresSecretSSLKey, true)},
+		{Name: "KONG_PG_USER", ValueFrom: secretKeyRef(consts.DataPlanePostgresSecretUserKey, false)},
+	}
+}
+
 // GenerateDataPlaneReadinessProbe generates a dataplane probe that uses the specified endpoint.
 func GenerateDataPlaneReadinessProbe(endpoint string) *corev1.Probe {
 	return &corev1.Probe{
@@ -14,6 +14,7 @@ import (
 
 	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
 	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
 )
 
 func TestGenerateNewDeploymentForDataPlane(t *testing.T) {
@@ -582,3 +583,95 @@ func TestGenerateNewDeploymentForControlPlane(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateNewDeploymentForControlPlaneAntiAffinity(t *testing.T) {
+	newControlPlane := func(replicas *int32, podTemplateSpec *corev1.PodTemplateSpec) *operatorv1beta1.ControlPlane {
+		return &operatorv1beta1.ControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cp-1",
+				Namespace: "test-namespace",
+			},
+			Spec: operatorv1beta1.ControlPlaneSpec{
+				ControlPlaneOptions: operatorv1beta1.ControlPlaneOptions{
+					Deployment: operatorv1beta1.ControlPlaneDeploymentOptions{
+						Replicas:        replicas,
+						PodTemplateSpec: podTemplateSpec,
+					},
+				},
+			},
+		}
+	}
+	generate := func(cp *operatorv1beta1.ControlPlane) *corev1.Affinity {
+		deployment, err := GenerateNewDeploymentForControlPlane(GenerateNewDeploymentForControlPlaneParams{
+			ControlPlane:            cp,
+			ControlPlaneImage:       "kong/kubernetes-ingress-controller:3.1.5",
+			AdminMTLSCertSecretName: "cluster-certificate-secret-name",
+		})
+		require.NoError(t, err)
+		return deployment.Spec.Template.Spec.Affinity
+	}
+
+	t.Log("single replica ControlPlanes don't get a default anti-affinity")
+	require.Nil(t, generate(newControlPlane(nil, nil)))
+	require.Nil(t, generate(newControlPlane(lo.ToPtr(int32(1)), nil)))
+
+	t.Log("multi replica ControlPlanes prefer spreading their pods across nodes")
+	require.Equal(t, generateControlPlanePodAntiAffinity("cp-1"), generate(newControlPlane(lo.ToPtr(int32(3)), nil)))
+
+	t.Log("the default anti-affinity can be overridden")
+	zoneAntiAffinity := &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+				{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "cp-1"},
+					},
+					TopologyKey: corev1.LabelTopologyZone,
+				},
+			},
+		},
+	}
+	affinity := generate(newControlPlane(lo.ToPtr(int32(3)), &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Affinity: zoneAntiAffinity,
+		},
+	}))
+	require.Equal(t, zoneAntiAffinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+}
+
+func TestGenerateNewDeploymentForDataPlaneInfrastructureMetadata(t *testing.T) {
+	dataplane := &operatorv1beta1.DataPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dp",
+			Namespace: "test-namespace",
+		},
+	}
+	k8sutils.SetInfrastructureMetadata(&dataplane.ObjectMeta, k8sutils.InfrastructureMetadata{
+		Labels:      map[string]string{"cost-center": "1234", "app": "infra"},
+		Annotations: map[string]string{"sidecar.istio.io/inject": "true"},
+	})
+
+	deployment, err := GenerateNewDeploymentForDataPlane(dataplane, "kong:3.0")
+	require.NoError(t, err)
+
+	t.Log("the Deployment and its pods get the infrastructure labels and annotations")
+	require.Equal(t, "1234", deployment.Labels["cost-center"])
+	require.Equal(t, "true", deployment.Annotations["sidecar.istio.io/inject"])
+	require.Equal(t, "1234", deployment.Spec.Template.Labels["cost-center"])
+	require.Equal(t, "true", deployment.Spec.Template.Annotations["sidecar.istio.io/inject"])
+	require.Equal(t, k8sutils.InfrastructureMetadata{
+		Labels:      map[string]string{"cost-center": "1234"},
+		Annotations: map[string]string{"sidecar.istio.io/inject": "true"},
+	}, k8sutils.GetInfrastructureMetadata(deployment.ObjectMeta))
+
+	t.Log("the infrastructure labels don't override the ones used by the selector")
+	require.Equal(t, "dp", deployment.Spec.Template.Labels["app"])
+	require.NotContains(t, deployment.Spec.Template.Annotations, consts.AnnotationLastAppliedInfrastructureLabels)
+
+	t.Log("the DataPlane's Services get the infrastructure labels and annotations")
+	svc, err := GenerateNewIngressServiceForDataPlane(dataplane)
+	require.NoError(t, err)
+	require.Equal(t, "1234", svc.Labels["cost-center"])
+	require.Equal(t, "true", svc.Annotations["sidecar.istio.io/inject"])
+	require.Equal(t, "dp", svc.Spec.Selector["app"])
+}
@@ -1,14 +1,20 @@
 package resources
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/samber/lo"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
 	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
 )
 
 // -----------------------------------------------------------------------------
@@ -93,3 +99,83 @@ func newWebhookCertificateConfigJobCommon(namespace, serviceAccountName string,
 	}
 	return job
 }
+
+// GenerateNewMigrationsJobForDataPlane generates a Job running the database
+// migrations phase, i.e. DataPlaneMigrationsJobPhaseUpLabelValue or
+// DataPlaneMigrationsJobPhaseFinishLabelValue, of the provided DataPlane image.
+// The Job's container uses the environment and the image pull secrets
+// of the DataPlane's proxy container and is configured to use the DataPlane's
+// Postgres database.
+func GenerateNewMigrationsJobForDataPlane(
+	dataplane *operatorv1beta1.DataPlane,
+	image string,
+	phase string,
+) *batchv1.Job {
+	// Bootstrapping an already bootstrapped database is a noop so the up
+	// phase bootstraps the database of new DataPlanes as well.
+	command := "kong migrations bootstrap && kong migrations up"
+	if phase == consts.DataPlaneMigrationsJobPhaseFinishLabelValue {
+		command = "kong migrations finish"
+	}
+
+	container := corev1.Container{
+		Name:            consts.DataPlaneMigrationsContainerName,
+		Image:           image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"/bin/sh", "-c", command},
+	}
+	var imagePullSecrets []corev1.LocalObjectReference
+	if podTemplateSpec := dataplane.Spec.Deployment.PodTemplateSpec; podTemplateSpec != nil {
+		imagePullSecrets = podTemplateSpec.Spec.ImagePullSecrets
+		if proxy := k8sutils.GetPodContainerByName(&podTemplateSpec.Spec, consts.DataPlaneProxyContainerName); proxy != nil {
+			container.Env = append(container.Env, proxy.Env...)
+			container.EnvFrom = proxy.EnvFrom
+		}
+	}
+	if dataplane.Spec.Database != nil && dataplane.Spec.Database.Postgres != nil {
+		for _, env := range GenerateDataPlanePostgresEnvVars(dataplane.Spec.Database.Postgres.SecretName) {
+			container.Env = lo.Reject(container.Env, func(e corev1.EnvVar, _ int) bool {
+				return e.Name == env.Name
+			})
+			container.Env = append(container.Env, env)
+		}
+	}
+	sort.Sort(k8sutils.SortableEnvVars(container.Env))
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: dataplane.Namespace,
+			GenerateName: k8sutils.TrimGenerateName(
+				fmt.Sprintf("%s-%s-migrations-%s-", consts.DataPlanePrefix, dataplane.Name, phase),
+			),
+			Labels: map[string]string{
+				consts.DataPlaneMigrationsJobPhaseLabel: phase,
+			},
+			Annotations: map[string]string{
+				consts.DataPlaneMigrationsJobImageAnnotation: image,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: lo.ToPtr(int32(3)),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:    corev1.RestartPolicyNever,
+					ImagePullSecrets: imagePullSecrets,
+					Containers:       []corev1.Container{container},
+				},
+			},
+		},
+	}
+	job.Annotations[consts.DataPlaneMigrationsJobChecksumAnnotation] = podTemplateSpecChecksum(&job.Spec.Template)
+	LabelObjectAsDataPlaneManaged(job)
+	k8sutils.SetOwnerForObject(job, dataplane)
+	return job
+}
+
+// podTemplateSpecChecksum returns a checksum of the provided pod template.
+func podTemplateSpecChecksum(template *corev1.PodTemplateSpec) string {
+	// Marshaling a PodTemplateSpec can't fail.
+	b, _ := json.Marshal(template)
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
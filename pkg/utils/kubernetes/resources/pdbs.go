@@ -0,0 +1,99 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/samber/lo"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	operatorv1beta1 "github.com/kong/gateway-operator/api/v1beta1"
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
+)
+
+// GeneratePodDisruptionBudgetForDataPlane generates a PodDisruptionBudget for
+// the given DataPlane.
+// The PodDisruptionBudget selects the DataPlane's pods using the selector from
+// its status and, when a BlueGreen rollout is in progress, the selector of the
+// preview Deployment from its rollout status so that preview pods are covered too.
+func GeneratePodDisruptionBudgetForDataPlane(dataplane *operatorv1beta1.DataPlane) (*policyv1.PodDisruptionBudget, error) {
+	if dataplane.Spec.Resources.PodDisruptionBudget == nil {
+		return nil, fmt.Errorf("cannot generate PodDisruptionBudget for DataPlane %s which doesn't have it configured", dataplane.Name)
+	}
+
+	labels := GetManagedLabelForOwner(dataplane)
+	labels["app"] = dataplane.Name
+
+	selector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			"app": dataplane.Name,
+		},
+	}
+	var selectorValues []string
+	if dataplane.Status.Selector != "" {
+		selectorValues = append(selectorValues, dataplane.Status.Selector)
+	}
+	if rs := dataplane.Status.RolloutStatus; rs != nil && rs.Deployment != nil &&
+		rs.Deployment.Selector != "" && rs.Deployment.Selector != dataplane.Status.Selector {
+		selectorValues = append(selectorValues, rs.Deployment.Selector)
+	}
+	if len(selectorValues) > 0 {
+		selector.MatchExpressions = []metav1.LabelSelectorRequirement{
+			{
+				Key:      consts.OperatorLabelSelector,
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   selectorValues,
+			},
+		}
+	}
+
+	spec := dataplane.Spec.Resources.PodDisruptionBudget.Spec
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dataplane.Name,
+			Namespace: dataplane.Namespace,
+			Labels:    labels,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector:                   selector,
+			MinAvailable:               spec.MinAvailable,
+			MaxUnavailable:             spec.MaxUnavailable,
+			UnhealthyPodEvictionPolicy: spec.UnhealthyPodEvictionPolicy,
+		},
+	}
+
+	k8sutils.SetOwnerForObject(pdb, dataplane)
+
+	return pdb, nil
+}
+
+// GeneratePodDisruptionBudgetForControlPlane generates a PodDisruptionBudget for
+// the given ControlPlane.
+// The PodDisruptionBudget allows only one of the ControlPlane's pods to be
+// voluntarily disrupted at a time so that a standby pod is always available
+// to take over the leader election Lease.
+func GeneratePodDisruptionBudgetForControlPlane(controlplane *operatorv1beta1.ControlPlane) *policyv1.PodDisruptionBudget {
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: k8sutils.TrimGenerateName(fmt.Sprintf("%s-%s-", consts.ControlPlanePrefix, controlplane.Name)),
+			Namespace:    controlplane.Namespace,
+			Labels: map[string]string{
+				"app": controlplane.Name,
+			},
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app": controlplane.Name,
+				},
+			},
+			MaxUnavailable: lo.ToPtr(intstr.FromInt32(1)),
+		},
+	}
+	LabelObjectAsControlPlaneManaged(pdb)
+	k8sutils.SetOwnerForObject(pdb, controlplane)
+
+	return pdb
+}
@@ -1,8 +1,13 @@
 package resources
 
 import (
+	"fmt"
+
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
 )
 
 // -----------------------------------------------------------------------------
@@ -34,3 +39,32 @@ func GenerateNewRoleBindingForCertificateConfig(namespace, name, labelValue stri
 		},
 	}
 }
+
+// GenerateNewRoleBindingForControlPlane is a helper to generate a RoleBinding
+// binding the controlplane leader election Role to its ServiceAccount.
+func GenerateNewRoleBindingForControlPlane(namespace, controlplaneName, serviceAccountName, roleName string) *rbacv1.RoleBinding {
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: k8sutils.TrimGenerateName(fmt.Sprintf("%s-%s-", consts.ControlPlanePrefix, controlplaneName)),
+			Namespace:    namespace,
+			Labels: map[string]string{
+				"app": controlplaneName,
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     roleName,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      serviceAccountName,
+				Namespace: namespace,
+			},
+		},
+	}
+	LabelObjectAsControlPlaneManaged(roleBinding)
+
+	return roleBinding
+}
@@ -1,8 +1,13 @@
 package resources
 
 import (
+	"fmt"
+
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kong/gateway-operator/pkg/consts"
+	k8sutils "github.com/kong/gateway-operator/pkg/utils/kubernetes"
 )
 
 // -----------------------------------------------------------------------------
@@ -35,3 +40,44 @@ func GenerateNewRoleForCertificateConfig(namespace, name, labelValue string) *rb
 		},
 	}
 }
+
+// GenerateNewRoleForControlPlane is a helper to generate a Role granting
+// the controlplane deployment access to its leader election Lease.
+func GenerateNewRoleForControlPlane(namespace, controlplaneName string) *rbacv1.Role {
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: k8sutils.TrimGenerateName(fmt.Sprintf("%s-%s-", consts.ControlPlanePrefix, controlplaneName)),
+			Namespace:    namespace,
+			Labels: map[string]string{
+				"app": controlplaneName,
+			},
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{
+					"coordination.k8s.io",
+				},
+				Resources: []string{
+					"leases",
+				},
+				Verbs: []string{
+					"get", "list", "watch", "create", "update", "patch", "delete",
+				},
+			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"events",
+				},
+				Verbs: []string{
+					"create", "patch",
+				},
+			},
+		},
+	}
+	LabelObjectAsControlPlaneManaged(role)
+
+	return role
+}
@@ -3,6 +3,7 @@ package resources
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
@@ -79,12 +80,25 @@ func GenerateNewIngressServiceForDataPlane(dataplane *operatorv1beta1.DataPlane,
 		svc.Spec.Selector = newSelector
 	}
 
+	k8sutils.SetInfrastructureMetadata(&svc.ObjectMeta, k8sutils.GetInfrastructureMetadata(dataplane.ObjectMeta))
 	k8sutils.SetOwnerForObject(svc, dataplane)
 	controllerutil.AddFinalizer(svc, consts.DataPlaneOwnedWaitForOwnerFinalizer)
 
 	return svc, nil
 }
 
+// PrivateIngressServiceOpt is a helper to turn a Service generated with
+// GenerateNewIngressServiceForDataPlane into the DataPlane private ingress service.
+func PrivateIngressServiceOpt(dataplane *operatorv1beta1.DataPlane) ServiceOpt {
+	return func(s *corev1.Service) {
+		s.GenerateName = k8sutils.TrimGenerateName(fmt.Sprintf("%s-private-ingress-%s-", consts.DataPlanePrefix, dataplane.Name))
+		if s.Labels == nil {
+			s.Labels = make(map[string]string)
+		}
+		s.Labels[consts.DataPlaneServiceTypeLabel] = string(consts.DataPlanePrivateIngressServiceLabelValue)
+	}
+}
+
 // DefaultDataPlaneIngressServiceType is the default Service type for a DataPlane.
 const DefaultDataPlaneIngressServiceType = corev1.ServiceTypeLoadBalancer
 
@@ -153,29 +167,43 @@ func ServicePortsFromDataPlaneIngressOpt(dataplane *operatorv1beta1.DataPlane) S
 			len(dataplane.Spec.Network.Services.Ingress.Ports) == 0 {
 			return
 		}
+		type protocolPort struct {
+			protocol corev1.Protocol
+			port     int32
+		}
 		newPorts := make([]corev1.ServicePort, 0)
-		alreadyUsedPorts := make(map[int32]struct{})
+		alreadyUsedPorts := make(map[protocolPort]struct{})
 		for _, p := range dataplane.Spec.Network.Services.Ingress.Ports {
 			targetPort := intstr.FromInt(consts.DataPlaneProxyPort)
 			if !cmp.Equal(p.TargetPort, intstr.IntOrString{}) {
 				targetPort = p.TargetPort
 			}
-			if _, ok := alreadyUsedPorts[p.Port]; !ok {
+			protocol := corev1.ProtocolTCP
+			if p.Protocol != "" {
+				protocol = p.Protocol
+			}
+			name := fmt.Sprintf("port-%d", p.Port)
+			// TCP and UDP ports can share the same port number, hence the name
+			// of the UDP ones is suffixed to keep the names unique.
+			if protocol == corev1.ProtocolUDP {
+				name = fmt.Sprintf("port-%d-udp", p.Port)
+			}
+			if _, ok := alreadyUsedPorts[protocolPort{protocol, p.Port}]; !ok {
 				newPorts = append(newPorts, corev1.ServicePort{
-					// Currently, only TCP protocol supported.
-					Name:       fmt.Sprintf("port-%d", p.Port),
-					Protocol:   corev1.ProtocolTCP,
+					Name:       name,
+					Protocol:   protocol,
 					Port:       p.Port,
 					TargetPort: targetPort,
 				})
-				alreadyUsedPorts[p.Port] = struct{}{}
+				alreadyUsedPorts[protocolPort{protocol, p.Port}] = struct{}{}
 			}
 		}
 		service.Spec.Ports = newPorts
 	}
 }
 
-// GenerateNewAdminServiceForDataPlane is a helper to generate the headless dataplane admin service
+// GenerateNewAdminServiceForDataPlane is a helper to generate the dataplane admin service.
+// It is headless unless a different type is configured in the DataPlane spec.
 func GenerateNewAdminServiceForDataPlane(dataplane *operatorv1beta1.DataPlane, opts ...ServiceOpt) (*corev1.Service, error) {
 	adminService := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
@@ -211,6 +239,7 @@ func GenerateNewAdminServiceForDataPlane(dataplane *operatorv1beta1.DataPlane, o
 		},
 	}
 	LabelObjectAsDataPlaneManaged(adminService)
+	applyDataPlaneAdminAPIServiceOptions(adminService, dataplane)
 
 	for _, opt := range opts {
 		opt(adminService)
@@ -225,11 +254,109 @@ func GenerateNewAdminServiceForDataPlane(dataplane *operatorv1beta1.DataPlane, o
 		adminService.Spec.Selector = newSelector
 	}
 
+	k8sutils.SetInfrastructureMetadata(&adminService.ObjectMeta, k8sutils.GetInfrastructureMetadata(dataplane.ObjectMeta))
 	k8sutils.SetOwnerForObject(adminService, dataplane)
 	controllerutil.AddFinalizer(adminService, consts.DataPlaneOwnedWaitForOwnerFinalizer)
 	return adminService, nil
 }
 
+// applyDataPlaneAdminAPIServiceOptions applies the Admin API Service options
+// from the DataPlane spec to the provided admin Service.
+func applyDataPlaneAdminAPIServiceOptions(service *corev1.Service, dataplane *operatorv1beta1.DataPlane) {
+	if dataplane.Spec.Network.Services == nil || dataplane.Spec.Network.Services.AdminAPI == nil {
+		return
+	}
+	adminOpts := dataplane.Spec.Network.Services.AdminAPI
+
+	// Labels used by the operator take precedence over the user provided ones.
+	labels := maps.Clone(adminOpts.Labels)
+	if labels == nil {
+		labels = make(map[string]string, len(service.Labels))
+	}
+	maps.Copy(labels, service.Labels)
+	service.Labels = labels
+
+	if adminOpts.Type != "" && adminOpts.Type != corev1.ServiceTypeClusterIP {
+		service.Spec.Type = adminOpts.Type
+		service.Spec.ClusterIP = ""
+		service.Spec.ExternalTrafficPolicy = adminOpts.ExternalTrafficPolicy
+		if service.Spec.ExternalTrafficPolicy == "" {
+			service.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyCluster
+		}
+	}
+
+	for _, p := range adminOpts.Ports {
+		protocol := corev1.ProtocolTCP
+		if p.Protocol != "" {
+			protocol = p.Protocol
+		}
+		targetPort := intstr.FromInt32(p.Port)
+		if !cmp.Equal(p.TargetPort, intstr.IntOrString{}) {
+			targetPort = p.TargetPort
+		}
+		name := p.Name
+		if name == "" {
+			name = fmt.Sprintf("port-%d", p.Port)
+			if protocol == corev1.ProtocolUDP {
+				name = fmt.Sprintf("port-%d-udp", p.Port)
+			}
+		}
+		service.Spec.Ports = append(service.Spec.Ports, corev1.ServicePort{
+			Name:       name,
+			Protocol:   protocol,
+			Port:       p.Port,
+			TargetPort: targetPort,
+		})
+	}
+}
+
+// GenerateNewPreviewRoutingServiceForDataPlane is a helper to generate the ClusterIP Service
+// to which the live DataPlane pods forward the requests matching the DataPlane's preview routing.
+// It selects the preview pods during a rollout and the live pods otherwise.
+func GenerateNewPreviewRoutingServiceForDataPlane(dataplane *operatorv1beta1.DataPlane) *corev1.Service {
+	selector := dataplane.Status.Selector
+	if rs := dataplane.Status.RolloutStatus; rs != nil && rs.Deployment != nil && rs.Deployment.Selector != "" {
+		selector = rs.Deployment.Selector
+	}
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    dataplane.Namespace,
+			GenerateName: k8sutils.TrimGenerateName(fmt.Sprintf("%s-preview-routing-%s-", consts.DataPlanePrefix, dataplane.Name)),
+			Labels: map[string]string{
+				"app":                            dataplane.Name,
+				consts.DataPlaneServiceTypeLabel: string(consts.DataPlanePreviewRoutingServiceLabelValue),
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Selector: map[string]string{
+				"app":                        dataplane.Name,
+				consts.OperatorLabelSelector: selector,
+			},
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       consts.DefaultHTTPPort,
+					TargetPort: intstr.FromInt(consts.DataPlaneProxyPort),
+				},
+				{
+					Name:       "https",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       consts.DefaultHTTPSPort,
+					TargetPort: intstr.FromInt(consts.DataPlaneProxySSLPort),
+				},
+			},
+		},
+	}
+	LabelObjectAsDataPlaneManaged(svc)
+	k8sutils.SetInfrastructureMetadata(&svc.ObjectMeta, k8sutils.GetInfrastructureMetadata(dataplane.ObjectMeta))
+	k8sutils.SetOwnerForObject(svc, dataplane)
+
+	return svc
+}
+
 func getSelectorOverrides(overrideAnnotation string) (map[string]string, error) {
 	if overrideAnnotation == "" {
 		return nil, errors.New("selector override empty - expected format: key1=value,key2=value2")
@@ -277,6 +404,7 @@ func GenerateNewAdmissionWebhookServiceForControlPlane(cp *operatorv1beta1.Contr
 	}
 	pkgapiscorev1.SetDefaults_Service(svc)
 	LabelObjectAsControlPlaneManaged(svc)
+	k8sutils.SetInfrastructureMetadata(&svc.ObjectMeta, k8sutils.GetInfrastructureMetadata(cp.ObjectMeta))
 	k8sutils.SetOwnerForObject(svc, cp)
 
 	return svc, nil
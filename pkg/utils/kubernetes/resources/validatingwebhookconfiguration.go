@@ -53,6 +53,18 @@ func NewValidatingWebhookConfigurationBuilder(webhookName string) *ValidatingWeb
 								admissionregistrationv1.Update,
 							},
 						},
+						{
+							Rule: admissionregistrationv1.Rule{
+								APIGroups:   []string{"gateway-operator.konghq.com"},
+								APIVersions: []string{"v1beta1"},
+								Resources:   []string{"gatewayconfigurations"},
+								Scope:       &namespacedScope,
+							},
+							Operations: []admissionregistrationv1.OperationType{
+								admissionregistrationv1.Create,
+								admissionregistrationv1.Update,
+							},
+						},
 					},
 					AdmissionReviewVersions: []string{"v1", "v1beta1"},
 					SideEffects:             lo.ToPtr(admissionregistrationv1.SideEffectClassNone),
@@ -46,6 +46,7 @@ var (
 	commonSupportedFeatures = sets.New(
 		// core features
 		features.SupportHTTPRoute,
+		features.SupportGRPCRoute,
 		features.SupportGateway,
 		features.SupportReferenceGrant,
 
@@ -121,6 +122,7 @@ func TestGatewayConformance(t *testing.T) {
 	opts.Mode = mode
 	opts.ConformanceProfiles = sets.New(
 		suite.GatewayHTTPConformanceProfileName,
+		suite.GatewayGRPCConformanceProfileName,
 	)
 	opts.SupportedFeatures = supportedFeatures
 	opts.Implementation = conformancev1.Implementation{
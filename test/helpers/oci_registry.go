@@ -0,0 +1,158 @@
+package helpers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// -----------------------------------------------------------------------------
+// OCI registry test helper functions
+// -----------------------------------------------------------------------------
+
+// OCIRegistryOption is an option for an in-process OCI registry.
+type OCIRegistryOption func(*ociRegistryConfig)
+
+type ociRegistryConfig struct {
+	username, password string
+}
+
+// WithOCIRegistryBasicAuth makes the OCI registry require basic authentication
+// with the provided credentials.
+func WithOCIRegistryBasicAuth(username, password string) OCIRegistryOption {
+	return func(c *ociRegistryConfig) {
+		c.username, c.password = username, password
+	}
+}
+
+// StartOCIRegistry starts an in-process OCI registry listening on a loopback
+// address and returns its host (with port) that can be used in image references.
+// The registry is stopped when the test finishes.
+func StartOCIRegistry(t *testing.T, opts ...OCIRegistryOption) string {
+	t.Helper()
+
+	var cfg ociRegistryConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var handler http.Handler = registry.New(registry.Logger(log.New(io.Discard, "", 0)))
+	if cfg.username != "" {
+		next := handler
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			username, password, ok := r.BasicAuth()
+			if !ok || username != cfg.username || password != cfg.password {
+				w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return strings.TrimPrefix(server.URL, "http://")
+}
+
+// PushPluginImage pushes a container image with a single gzipped tarball layer
+// containing the provided files to the registry under the provided reference.
+// When credential is not empty, it's used to authenticate to the registry.
+func PushPluginImage(t *testing.T, ctx context.Context, reference string, files map[string]string, credential auth.Credential) {
+	t.Helper()
+
+	repo := ociRepository(t, reference, credential)
+	layer := pushBlob(t, ctx, repo, ocispec.MediaTypeImageLayerGzip, gzippedTarball(t, files))
+	manifest, err := oras.PackManifest(ctx, repo, oras.PackManifestVersion1_0, "", oras.PackManifestOptions{
+		Layers: []ocispec.Descriptor{layer},
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.Tag(ctx, manifest, repo.Reference.Reference))
+}
+
+// PushPluginArtifact pushes an OCI artifact with a layer per provided file (as done
+// e.g. by the ORAS CLI) to the registry under the provided reference.
+func PushPluginArtifact(t *testing.T, ctx context.Context, reference string, files map[string]string) {
+	t.Helper()
+
+	repo := ociRepository(t, reference, auth.EmptyCredential)
+	layers := make([]ocispec.Descriptor, 0, len(files))
+	for _, name := range sortedKeys(files) {
+		layer := pushBlob(t, ctx, repo, "application/vnd.kong.plugin.file", []byte(files[name]))
+		layer.Annotations = map[string]string{ocispec.AnnotationTitle: name}
+		layers = append(layers, layer)
+	}
+	manifest, err := oras.PackManifest(ctx, repo, oras.PackManifestVersion1_1, "application/vnd.kong.plugin", oras.PackManifestOptions{
+		Layers: layers,
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.Tag(ctx, manifest, repo.Reference.Reference))
+}
+
+func ociRepository(t *testing.T, reference string, credential auth.Credential) *remote.Repository {
+	t.Helper()
+
+	repo, err := remote.NewRepository(reference)
+	require.NoError(t, err)
+	repo.PlainHTTP = true
+	if credential != auth.EmptyCredential {
+		repo.Client = &auth.Client{
+			Credential: auth.StaticCredential(repo.Reference.Host(), credential),
+		}
+	}
+	return repo
+}
+
+func pushBlob(t *testing.T, ctx context.Context, repo *remote.Repository, mediaType string, b []byte) ocispec.Descriptor {
+	t.Helper()
+
+	desc := content.NewDescriptorFromBytes(mediaType, b)
+	require.NoError(t, repo.Push(ctx, desc, bytes.NewReader(b)))
+	return desc
+}
+
+func gzippedTarball(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for _, name := range sortedKeys(files) {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0o644,
+			Size:     int64(len(files[name])),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(files[name]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
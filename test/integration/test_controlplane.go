@@ -678,3 +678,107 @@ func TestControlPlaneUpdate(t *testing.T) {
 		)
 	})
 }
+
+func TestControlPlaneHighAvailability(t *testing.T) {
+	t.Parallel()
+	namespace, cleaner := helpers.SetupTestEnv(t, GetCtx(), GetEnv())
+
+	dataplaneClient := GetClients().OperatorClient.ApisV1beta1().DataPlanes(namespace.Name)
+	controlplaneClient := GetClients().OperatorClient.ApisV1beta1().ControlPlanes(namespace.Name)
+
+	dataplane := &operatorv1beta1.DataPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace.Name,
+			Name:      uuid.NewString(),
+		},
+		Spec: operatorv1beta1.DataPlaneSpec{
+			DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+				Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+					DeploymentOptions: operatorv1beta1.DeploymentOptions{
+						PodTemplateSpec: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:  consts.DataPlaneProxyContainerName,
+										Image: helpers.GetDefaultDataPlaneImage(),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	controlplaneName := types.NamespacedName{
+		Namespace: namespace.Name,
+		Name:      uuid.NewString(),
+	}
+	controlplane := &operatorv1beta1.ControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: controlplaneName.Namespace,
+			Name:      controlplaneName.Name,
+		},
+		Spec: operatorv1beta1.ControlPlaneSpec{
+			ControlPlaneOptions: operatorv1beta1.ControlPlaneOptions{
+				Deployment: operatorv1beta1.ControlPlaneDeploymentOptions{
+					Replicas: lo.ToPtr(int32(2)),
+					PodTemplateSpec: &corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  consts.ControlPlaneControllerContainerName,
+									Image: consts.DefaultControlPlaneImage,
+								},
+							},
+						},
+					},
+				},
+				DataPlane: &dataplane.Name,
+			},
+		},
+	}
+
+	t.Log("deploying dataplane resource")
+	dataplane, err := dataplaneClient.Create(GetCtx(), dataplane, metav1.CreateOptions{})
+	require.NoError(t, err)
+	cleaner.Add(dataplane)
+
+	t.Log("deploying controlplane resource with 2 replicas")
+	controlplane, err = controlplaneClient.Create(GetCtx(), controlplane, metav1.CreateOptions{})
+	require.NoError(t, err)
+	cleaner.Add(controlplane)
+
+	t.Log("verifying that the controlplane gets marked as provisioned")
+	require.Eventually(t, testutils.ControlPlaneIsProvisioned(t, GetCtx(), controlplaneName, clients), testutils.ControlPlaneCondDeadline, testutils.ControlPlaneCondTick)
+
+	t.Log("verifying that the controlplane reports a leader and a standby pod")
+	require.Eventually(t, func() bool {
+		cp, err := controlplaneClient.Get(GetCtx(), controlplaneName.Name, metav1.GetOptions{})
+		if err != nil {
+			return false
+		}
+		return cp.Status.ReadyReplicas == 2 && cp.Status.StandbyReplicas == 1 && cp.Status.Leader != ""
+	}, testutils.ControlPlaneCondDeadline, testutils.ControlPlaneCondTick)
+
+	t.Log("verifying that the controlplane owns a leader election role binding and a pod disruption budget")
+	require.Eventually(t, func() bool {
+		roleBindings, err := k8sutils.ListRoleBindingsForOwner(GetCtx(), GetClients().MgrClient, namespace.Name, controlplane.UID)
+		if err != nil || len(roleBindings) != 1 {
+			return false
+		}
+		pdbs, err := k8sutils.ListPodDisruptionBudgetsForOwner(GetCtx(), GetClients().MgrClient, namespace.Name, controlplane.UID)
+		return err == nil && len(pdbs) == 1
+	}, testutils.ControlPlaneCondDeadline, testutils.ControlPlaneCondTick)
+
+	t.Log("verifying that the standby pod takes over when the leader is deleted")
+	cp, err := controlplaneClient.Get(GetCtx(), controlplaneName.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	oldLeader := cp.Status.Leader
+	require.NoError(t, GetClients().K8sClient.CoreV1().Pods(namespace.Name).Delete(GetCtx(), oldLeader, metav1.DeleteOptions{}))
+	require.Eventually(t, func() bool {
+		cp, err := controlplaneClient.Get(GetCtx(), controlplaneName.Name, metav1.GetOptions{})
+		return err == nil && cp.Status.Leader != "" && cp.Status.Leader != oldLeader
+	}, testutils.ControlPlaneCondDeadline, testutils.ControlPlaneCondTick)
+}
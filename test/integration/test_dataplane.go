@@ -818,3 +818,132 @@ func TestDataPlaneVolumeMounts(t *testing.T) {
 		return true
 	}, time.Minute, time.Second)
 }
+
+func TestDataPlanePostgres(t *testing.T) {
+	t.Parallel()
+	namespace, cleaner := helpers.SetupTestEnv(t, GetCtx(), GetEnv())
+
+	t.Log("deploying a local Postgres database")
+	const (
+		postgresUser     = "kong"
+		postgresPassword = "kong"
+	)
+	postgresLabels := map[string]string{"app": "postgres"}
+	postgresDeployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace.Name,
+			Name:      "postgres",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: postgresLabels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: postgresLabels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "postgres",
+							Image: "postgres:16",
+							Env: []corev1.EnvVar{
+								{Name: "POSTGRES_USER", Value: postgresUser},
+								{Name: "POSTGRES_PASSWORD", Value: postgresPassword},
+								{Name: "POSTGRES_DB", Value: "kong"},
+							},
+							Ports: []corev1.ContainerPort{
+								{ContainerPort: 5432},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, GetClients().MgrClient.Create(GetCtx(), postgresDeployment))
+	cleaner.Add(postgresDeployment)
+	postgresService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace.Name,
+			Name:      "postgres",
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: postgresLabels,
+			Ports: []corev1.ServicePort{
+				{Port: 5432, TargetPort: intstr.FromInt(5432)},
+			},
+		},
+	}
+	require.NoError(t, GetClients().MgrClient.Create(GetCtx(), postgresService))
+	cleaner.Add(postgresService)
+	postgresSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace.Name,
+			Name:      "postgres",
+		},
+		StringData: map[string]string{
+			consts.DataPlanePostgresSecretHostKey:     postgresService.Name,
+			consts.DataPlanePostgresSecretUserKey:     postgresUser,
+			consts.DataPlanePostgresSecretPasswordKey: postgresPassword,
+			consts.DataPlanePostgresSecretDatabaseKey: "kong",
+		},
+	}
+	require.NoError(t, GetClients().MgrClient.Create(GetCtx(), postgresSecret))
+	cleaner.Add(postgresSecret)
+
+	t.Log("deploying dataplane resource using the Postgres database")
+	dataplaneName := types.NamespacedName{
+		Namespace: namespace.Name,
+		Name:      uuid.NewString(),
+	}
+	dataplane := &operatorv1beta1.DataPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: dataplaneName.Namespace,
+			Name:      dataplaneName.Name,
+		},
+		Spec: operatorv1beta1.DataPlaneSpec{
+			DataPlaneOptions: operatorv1beta1.DataPlaneOptions{
+				Deployment: operatorv1beta1.DataPlaneDeploymentOptions{
+					DeploymentOptions: operatorv1beta1.DeploymentOptions{
+						PodTemplateSpec: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:  consts.DataPlaneProxyContainerName,
+										Image: helpers.GetDefaultDataPlaneImage(),
+									},
+								},
+							},
+						},
+					},
+				},
+				Database: &operatorv1beta1.DataPlaneDatabaseOptions{
+					Postgres: &operatorv1beta1.DataPlanePostgresOptions{
+						SecretName: postgresSecret.Name,
+					},
+				},
+			},
+		},
+	}
+	dataplaneClient := GetClients().OperatorClient.ApisV1beta1().DataPlanes(namespace.Name)
+	dataplane, err := dataplaneClient.Create(GetCtx(), dataplane, metav1.CreateOptions{})
+	require.NoError(t, err)
+	cleaner.Add(dataplane)
+
+	t.Log("verifying dataplane gets marked provisioned")
+	require.Eventually(t, testutils.DataPlaneIsReady(t, GetCtx(), dataplaneName, GetClients().OperatorClient), 3*time.Minute, time.Second)
+
+	t.Log("verifying the database migrations are finished")
+	require.Eventually(t, testutils.DataPlanePredicate(t, GetCtx(), dataplaneName, func(dp *operatorv1beta1.DataPlane) bool {
+		c, ok := k8sutils.GetCondition(consts.DataPlaneConditionTypeDatabaseMigrated, dp)
+		return ok && c.Status == metav1.ConditionTrue &&
+			dp.Status.DatabaseStatus != nil &&
+			dp.Status.DatabaseStatus.FinishedImage == helpers.GetDefaultDataPlaneImage()
+	}, GetClients().OperatorClient), 3*time.Minute, time.Second)
+
+	t.Log("verifying the dataplane uses the Postgres database")
+	deployments := testutils.MustListDataPlaneDeployments(t, GetCtx(), dataplane, clients, client.MatchingLabels{
+		consts.GatewayOperatorManagedByLabel: consts.DataPlaneManagedLabelValue,
+	})
+	require.Len(t, deployments, 1, "There must be only one DataPlane deployment")
+	proxyContainer := k8sutils.GetPodContainerByName(&deployments[0].Spec.Template.Spec, consts.DataPlaneProxyContainerName)
+	require.NotNil(t, proxyContainer)
+	require.Equal(t, "postgres", GetEnvValueByName(proxyContainer.Env, consts.EnvVarKongDatabase))
+}
@@ -996,3 +996,84 @@ func (d *networkPolicyIngressRuleDecorator) withPeerMatchLabels(
 		},
 	})
 }
+
+func TestGatewayInfrastructureMetadataPropagation(t *testing.T) {
+	t.Parallel()
+	namespace, cleaner := helpers.SetupTestEnv(t, GetCtx(), GetEnv())
+
+	t.Log("deploying a GatewayClass resource")
+	gatewayClass := helpers.MustGenerateGatewayClass(t)
+	gatewayClass, err := GetClients().GatewayClient.GatewayV1().GatewayClasses().Create(GetCtx(), gatewayClass, metav1.CreateOptions{})
+	require.NoError(t, err)
+	cleaner.Add(gatewayClass)
+
+	t.Log("deploying Gateway resource with infrastructure labels and annotations")
+	gatewayNN := types.NamespacedName{
+		Name:      uuid.NewString(),
+		Namespace: namespace.Name,
+	}
+	gateway := helpers.GenerateGateway(gatewayNN, gatewayClass)
+	gateway.Spec.Infrastructure = &gatewayv1.GatewayInfrastructure{
+		Labels: map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue{
+			"example.com/cost-center": "1234",
+		},
+		Annotations: map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue{
+			"example.com/mesh-injection": "enabled",
+		},
+	}
+	gateway, err = GetClients().GatewayClient.GatewayV1().Gateways(namespace.Name).Create(GetCtx(), gateway, metav1.CreateOptions{})
+	require.NoError(t, err)
+	cleaner.Add(gateway)
+
+	t.Log("verifying Gateway gets marked as Programmed")
+	require.Eventually(t, testutils.GatewayIsProgrammed(t, GetCtx(), gatewayNN, clients), testutils.GatewayReadyTimeLimit, time.Second)
+
+	hasMetadata := func(obj metav1.Object, label, annotation bool) bool {
+		_, hasLabel := obj.GetLabels()["example.com/cost-center"]
+		_, hasAnnotation := obj.GetAnnotations()["example.com/mesh-injection"]
+		return hasLabel == label && hasAnnotation == annotation
+	}
+	infrastructureMetadataIsPropagated := func(label, annotation bool) func() bool {
+		return func() bool {
+			dataplanes := testutils.MustListDataPlanesForGateway(t, GetCtx(), gateway, clients)
+			controlplanes := testutils.MustListControlPlanesForGateway(t, GetCtx(), gateway, clients)
+			networkPolicies := testutils.MustListNetworkPoliciesForGateway(t, GetCtx(), gateway, clients)
+			if len(dataplanes) != 1 || len(controlplanes) != 1 || len(networkPolicies) != 1 {
+				return false
+			}
+			objs := []metav1.Object{&dataplanes[0], &controlplanes[0], &networkPolicies[0]}
+			for _, d := range testutils.MustListDataPlaneDeployments(t, GetCtx(), &dataplanes[0], clients, client.MatchingLabels{}) {
+				objs = append(objs, &d, &d.Spec.Template)
+			}
+			for _, s := range testutils.MustListDataPlaneServices(t, GetCtx(), &dataplanes[0], GetClients().MgrClient, client.MatchingLabels{}) {
+				objs = append(objs, &s)
+			}
+			for _, d := range testutils.MustListControlPlaneDeployments(t, GetCtx(), &controlplanes[0], clients) {
+				objs = append(objs, &d, &d.Spec.Template)
+			}
+			for _, obj := range objs {
+				if !hasMetadata(obj, label, annotation) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+
+	t.Log("verifying the infrastructure labels and annotations are propagated to the Gateway's resources")
+	require.Eventually(t, infrastructureMetadataIsPropagated(true, true), testutils.SubresourceReadinessWait, time.Second)
+
+	t.Log("removing the infrastructure annotations from the Gateway")
+	require.Eventually(t, func() bool {
+		gateway, err = GetClients().GatewayClient.GatewayV1().Gateways(namespace.Name).Get(GetCtx(), gatewayNN.Name, metav1.GetOptions{})
+		if err != nil {
+			return false
+		}
+		gateway.Spec.Infrastructure.Annotations = nil
+		_, err = GetClients().GatewayClient.GatewayV1().Gateways(namespace.Name).Update(GetCtx(), gateway, metav1.UpdateOptions{})
+		return err == nil
+	}, time.Minute, time.Second)
+
+	t.Log("verifying the infrastructure annotations are removed from the Gateway's resources")
+	require.Eventually(t, infrastructureMetadataIsPropagated(true, false), testutils.SubresourceReadinessWait, time.Second)
+}
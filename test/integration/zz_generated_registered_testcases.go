@@ -5,6 +5,7 @@ func init() {
 	addTestsToTestSuite(
 		TestAIGatewayCreation,
 		TestControlPlaneEssentials,
+		TestControlPlaneHighAvailability,
 		TestControlPlaneUpdate,
 		TestControlPlaneWhenNoDataPlane,
 		TestDataPlaneBlueGreenHorizontalScaling,
@@ -12,6 +13,7 @@ func init() {
 		TestDataPlaneBlueGreen_ResourcesNotDeletedUntilOwnerIsRemoved,
 		TestDataPlaneEssentials,
 		TestDataPlaneHorizontalScaling,
+		TestDataPlanePostgres,
 		TestDataPlaneUpdate,
 		TestDataPlaneValidation,
 		TestDataPlaneVolumeMounts,
@@ -20,6 +22,7 @@ func init() {
 		TestGatewayConfigurationEssentials,
 		TestGatewayDataPlaneNetworkPolicy,
 		TestGatewayEssentials,
+		TestGatewayInfrastructureMetadataPropagation,
 		TestGatewayMultiple,
 		TestGatewayProvisionDataPlaneFail,
 		TestGatewayWithMultipleListeners,